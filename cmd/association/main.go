@@ -0,0 +1,46 @@
+// Command association creates an association, or renames one when the slug
+// already exists. The migration seeds a "default" association that holds
+// any data created before multi-tenancy; rename it with -slug default.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/config"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/db"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+func main() {
+	slug := flag.String("slug", "", "subdomain-safe identifier, e.g. acacia-heights")
+	name := flag.String("name", "", "display name shown on statements and calendars")
+	timezone := flag.String("timezone", "Asia/Manila", "IANA time zone of the association")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	db, err := db.NewPostgresDB(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer db.Close()
+
+	associationService := service.NewAssociationService(repository.NewAssociationRepository(repository.NewTenantDB(db)))
+	association, err := associationService.SaveAssociation(context.Background(), &service.AssociationRequest{
+		Slug:     *slug,
+		Name:     *name,
+		Timezone: *timezone,
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	fmt.Printf("saved association %s (%s) with id %s\n", association.Slug, association.Name, association.ID)
+}
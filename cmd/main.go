@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/config"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/db"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/server"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
 )
 
 func main() {
@@ -26,15 +35,92 @@ func main() {
 	// initialize repo
 	repos := repository.NewRepository(db)
 
-	jwt := auth.NewJWTAuth(cfg.JWTSecret, cfg.JWTIssuer, cfg.JWTAudience, cfg.JWTCookieDomain)
+	jwt := auth.NewJWTAuth(cfg.JWTSecret, cfg.JWTIssuer, cfg.JWTAudience, cfg.JWTCookieDomain, repos.RefreshTokenRepository)
+
+	var store storage.Storage
+	switch cfg.StorageDriver {
+	case "s3":
+		store, err = storage.NewS3Storage(context.Background(), storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			UseSSL:    cfg.S3UseSSL,
+		})
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	default:
+		store = storage.NewLocalStorage(cfg.StorageDir, cfg.PublicURL, []byte(cfg.JWTSecret))
+	}
+
+	var mail mailer.Mailer
+	switch cfg.MailDriver {
+	case "smtp":
+		mail = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "file":
+		mail = mailer.NewFileMailer(cfg.MailDir, cfg.MailFrom)
+	default:
+		mail = mailer.NewLogMailer()
+	}
 
 	// initialize service
-	services := service.NewService(repos)
+	background := job.NewBackground()
+	services := service.NewService(repos, jwt, store, mail, background, cfg)
+
+	// stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// start background jobs
+
+	// jobs run once per association since every query is tenant scoped
+	scheduler := job.NewScheduler()
+	scheduler.Add("monthly-billing", time.Hour, func(ctx context.Context) error {
+		return services.AssociationService.ForEachAssociation(ctx, func(ctx context.Context) error {
+			return services.BillingService.RunScheduledBilling(ctx, time.Now())
+		})
+	})
+	scheduler.Add("daily-penalties", 24*time.Hour, func(ctx context.Context) error {
+		return services.AssociationService.ForEachAssociation(ctx, func(ctx context.Context) error {
+			return services.PenaltyService.ApplyPenalties(ctx, time.Now())
+		})
+	})
+	scheduler.Add("expire-unverified-accounts", time.Hour, func(ctx context.Context) error {
+		return services.AssociationService.ForEachAssociation(ctx, func(ctx context.Context) error {
+			return services.UserService.ExpireUnverifiedUsers(ctx, time.Now())
+		})
+	})
+	scheduler.Add("purge-revoked-sessions", 24*time.Hour, func(ctx context.Context) error {
+		return services.AssociationService.ForEachAssociation(ctx, func(ctx context.Context) error {
+			return services.AuthService.PurgeRevokedSessions(ctx, time.Now())
+		})
+	})
+	scheduler.Start(ctx)
 
 	// start server
 	s := server.New(services, cfg, jwt)
-	if err := s.Run(); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- s.Run()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			log.Fatalf("Server failed to start: %v", err)
+		}
+	case <-ctx.Done():
 	}
 
+	// finish requests in flight, then the emails they queued, before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), constants.ShutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to shut down server: %v\n", err)
+	}
+	if err := background.Wait(shutdownCtx); err != nil {
+		log.Printf("failed to finish background tasks: %v\n", err)
+	}
 }
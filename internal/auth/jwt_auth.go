@@ -1,24 +1,40 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
 )
 
 type IJWTAuth interface {
-	GenerateToken(user *model.User) (TokenPairs, error)
-	ParseAccessToken(tokenStr string) (*Claims, error)
+	GenerateToken(user *model.User, familyID uuid.UUID) (TokenPairs, error)
+	ParseAccessToken(ctx context.Context, tokenStr string) (*Claims, error)
+	ParseRefreshToken(tokenStr string) (*RefreshClaims, error)
+	GenerateGatePass(passID uuid.UUID, expiresAt time.Time) (string, error)
+	ParseGatePass(tokenStr string) (*GatePassClaims, error)
+	GenerateMFAChallenge(user *model.User, expiresAt time.Time) (string, error)
+	ParseMFAChallenge(tokenStr string) (*MFAChallengeClaims, error)
 	GetRefreshCookie(refreshToken string) *http.Cookie
 	GetExpiredRefreshCookie() *http.Cookie
+	RefreshCookieName() string
+}
+
+// SessionDenylist reports whether a session (refresh token family) was
+// revoked before its access tokens expired.
+type SessionDenylist interface {
+	IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
 }
 
 type JWTAuth struct {
+	Denylist      SessionDenylist
 	Issuer        string
 	Audience      string
 	Secret        string
@@ -32,16 +48,62 @@ type JWTAuth struct {
 type TokenPairs struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
+
+	RefreshTokenID   uuid.UUID `json:"-"`
+	FamilyID         uuid.UUID `json:"-"`
+	RefreshExpiresAt time.Time `json:"-"`
 }
 
+// Claims ties the token to the association the user belongs to, so a token
+// cannot be replayed against another association.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Name   string `json:"name"`
+	UserID        string `json:"user_id"`
+	AssociationID string `json:"association_id"`
+	Name          string `json:"name"`
+	SessionID     string `json:"sid"`
+	TokenUse      string `json:"token_use"`
+	jwt.RegisteredClaims
+}
+
+// RefreshClaims carries the token's jti in RegisteredClaims.ID and the family
+// it was rotated from, so the server can detect replays of old tokens. Its
+// token_use keeps it from being accepted as an access token, which shares
+// the secret, issuer and audience.
+type RefreshClaims struct {
+	UserID        string `json:"user_id"`
+	AssociationID string `json:"association_id"`
+	FamilyID      string `json:"family_id"`
+	TokenUse      string `json:"token_use"`
+	jwt.RegisteredClaims
+}
+
+// GatePassClaims identifies a visitor pass by its jti. Passes are signed
+// with the same secret as session tokens but for a separate audience, so a
+// pass can never be replayed as an access token or vice versa.
+type GatePassClaims struct {
+	jwt.RegisteredClaims
+}
+
+// MFAChallengeClaims stand in for the session between a correct password
+// and a confirmed second factor. They have their own audience, so a
+// challenge cannot be used as an access token.
+type MFAChallengeClaims struct {
+	UserID        string `json:"user_id"`
+	AssociationID string `json:"association_id"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTAuth(secret, issuer, audience, cookieDomain string) IJWTAuth {
+const (
+	tokenUseAccess  = "access"
+	tokenUseRefresh = "refresh"
+
+	gatePassAudience     = "gate-pass"
+	mfaChallengeAudience = "mfa-challenge"
+)
+
+func NewJWTAuth(secret, issuer, audience, cookieDomain string, denylist SessionDenylist) IJWTAuth {
 	return &JWTAuth{
+		Denylist:      denylist,
 		Issuer:        issuer,
 		Audience:      audience,
 		Secret:        secret,
@@ -53,15 +115,19 @@ func NewJWTAuth(secret, issuer, audience, cookieDomain string) IJWTAuth {
 	}
 }
 
-func (j *JWTAuth) GenerateToken(user *model.User) (TokenPairs, error) {
+func (j *JWTAuth) GenerateToken(user *model.User, familyID uuid.UUID) (TokenPairs, error) {
+	now := time.Now()
 	accessClaims := &Claims{
-		UserID: user.ID.String(),
-		Name:   fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		UserID:        user.ID.String(),
+		AssociationID: user.AssociationID.String(),
+		Name:          fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		SessionID:     familyID.String(),
+		TokenUse:      tokenUseAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    j.Issuer,
 			Audience:  []string{j.Audience},
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.TokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.TokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.ID.String(),
 		},
 	}
@@ -69,31 +135,45 @@ func (j *JWTAuth) GenerateToken(user *model.User) (TokenPairs, error) {
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
 	signedAccessToken, err := accessToken.SignedString([]byte(j.Secret))
 	if err != nil {
-		return TokenPairs{}, nil
+		return TokenPairs{}, fmt.Errorf("failed to sign access token: %w", err)
 	}
 
-	refreshClaims := jwt.MapClaims{
-		"sub": user.ID.String(),
-		"exp": time.Now().Add(j.RefreshExpiry).Unix(),
-		"iat": time.Now().Unix(),
+	refreshTokenID := uuid.New()
+	refreshExpiresAt := now.Add(j.RefreshExpiry)
+	refreshClaims := &RefreshClaims{
+		UserID:        user.ID.String(),
+		AssociationID: user.AssociationID.String(),
+		FamilyID:      familyID.String(),
+		TokenUse:      tokenUseRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        refreshTokenID.String(),
+			Issuer:    j.Issuer,
+			Audience:  []string{j.Audience},
+			ExpiresAt: jwt.NewNumericDate(refreshExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   user.ID.String(),
+		},
 	}
 
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
 	signedRefreshToken, err := refreshToken.SignedString([]byte(j.Secret))
 	if err != nil {
-		return TokenPairs{}, nil
+		return TokenPairs{}, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
 	return TokenPairs{
-		AccessToken:  signedAccessToken,
-		RefreshToken: signedRefreshToken,
+		AccessToken:      signedAccessToken,
+		RefreshToken:     signedRefreshToken,
+		RefreshTokenID:   refreshTokenID,
+		FamilyID:         familyID,
+		RefreshExpiresAt: refreshExpiresAt,
 	}, nil
 }
 
-func (j *JWTAuth) ParseAccessToken(tokenStr string) (*Claims, error) {
+func (j *JWTAuth) ParseAccessToken(ctx context.Context, tokenStr string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		return []byte(j.Secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(j.Issuer), jwt.WithAudience(j.Audience))
 	if err != nil || !token.Valid {
 		return nil, constants.ErrInvalidToken
 	}
@@ -102,14 +182,129 @@ func (j *JWTAuth) ParseAccessToken(tokenStr string) (*Claims, error) {
 	if !ok {
 		return nil, errors.New("could not parse claims")
 	}
+	if claims.TokenUse != tokenUseAccess {
+		return nil, constants.ErrInvalidToken
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+	associationID, err := uuid.Parse(claims.AssociationID)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+
+	if j.Denylist != nil {
+		// sessions are stored per association
+		ctx = tenant.WithAssociation(ctx, &model.Association{ID: associationID})
+		revoked, err := j.Denylist.IsSessionRevoked(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, constants.ErrSessionRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+func (j *JWTAuth) ParseRefreshToken(tokenStr string) (*RefreshClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &RefreshClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(j.Issuer), jwt.WithAudience(j.Audience))
+	if err != nil || !token.Valid {
+		return nil, constants.ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*RefreshClaims)
+	if !ok || claims.TokenUse != tokenUseRefresh || claims.ID == "" || claims.FamilyID == "" || claims.AssociationID == "" {
+		return nil, constants.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (j *JWTAuth) GenerateGatePass(passID uuid.UUID, expiresAt time.Time) (string, error) {
+	claims := &GatePassClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        passID.String(),
+			Issuer:    j.Issuer,
+			Audience:  []string{gatePassAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(j.Secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign gate pass: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (j *JWTAuth) ParseGatePass(tokenStr string) (*GatePassClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &GatePassClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(j.Issuer), jwt.WithAudience(gatePassAudience))
+	if err != nil || !token.Valid {
+		return nil, constants.ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*GatePassClaims)
+	if !ok || claims.ID == "" {
+		return nil, constants.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (j *JWTAuth) GenerateMFAChallenge(user *model.User, expiresAt time.Time) (string, error) {
+	claims := &MFAChallengeClaims{
+		UserID:        user.ID.String(),
+		AssociationID: user.AssociationID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    j.Issuer,
+			Audience:  []string{mfaChallengeAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   user.ID.String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(j.Secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign mfa challenge: %w", err)
+	}
+
+	return signed, nil
+}
 
-	if claims.Issuer != j.Issuer {
-		return nil, errors.New("invalid issuer")
+func (j *JWTAuth) ParseMFAChallenge(tokenStr string) (*MFAChallengeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &MFAChallengeClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(j.Issuer), jwt.WithAudience(mfaChallengeAudience))
+	if err != nil || !token.Valid {
+		return nil, constants.ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*MFAChallengeClaims)
+	if !ok || claims.UserID == "" || claims.AssociationID == "" {
+		return nil, constants.ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
+func (j *JWTAuth) RefreshCookieName() string {
+	return j.CookieName
+}
+
 func (j *JWTAuth) GetRefreshCookie(refreshToken string) *http.Cookie {
 	return &http.Cookie{
 		Name:     j.CookieName,
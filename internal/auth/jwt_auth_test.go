@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+func newTestJWTAuth() *JWTAuth {
+	return NewJWTAuth("test-secret", "hoa-hub", "hoa-hub-api", "", nil).(*JWTAuth)
+}
+
+func TestJWTAuth_ParseAccessToken(t *testing.T) {
+	j := newTestJWTAuth()
+	user := &model.User{ID: uuid.New(), AssociationID: uuid.New(), FirstName: "Ana", LastName: "Cruz"}
+
+	pair, err := j.GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gatePass, err := j.GenerateGatePass(uuid.New(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	challenge, err := j.GenerateMFAChallenge(user, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherIssuer := *j
+	otherIssuer.Issuer = "someone-else"
+	foreign, err := otherIssuer.GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherAudience := *j
+	otherAudience.Audience = "another-api"
+	elsewhere, err := otherAudience.GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		expectErr bool
+	}{
+		{name: "access token", token: pair.AccessToken},
+		{name: "refresh token", token: pair.RefreshToken, expectErr: true},
+		{name: "gate pass", token: gatePass, expectErr: true},
+		{name: "mfa challenge", token: challenge, expectErr: true},
+		{name: "other issuer", token: foreign.AccessToken, expectErr: true},
+		{name: "other audience", token: elsewhere.AccessToken, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := j.ParseAccessToken(context.Background(), tc.token)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected the token to be rejected, got claims %+v", claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.UserID != user.ID.String() {
+				t.Errorf("expected user %s, got %s", user.ID, claims.UserID)
+			}
+		})
+	}
+}
+
+func TestJWTAuth_ParseRefreshToken(t *testing.T) {
+	j := newTestJWTAuth()
+	user := &model.User{ID: uuid.New(), AssociationID: uuid.New()}
+
+	pair, err := j.GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := j.ParseRefreshToken(pair.RefreshToken); err != nil {
+		t.Errorf("expected the refresh token to parse, got %v", err)
+	}
+	if _, err := j.ParseRefreshToken(pair.AccessToken); err == nil {
+		t.Error("expected an access token to be rejected as a refresh token")
+	}
+}
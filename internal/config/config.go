@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +16,31 @@ type Config struct {
 	JWTAudience     string
 	JWTSecret       string
 	JWTCookieDomain string
+	BillingDay      int
+	BillingDueDay   int
+	MaxStickers     int
+	StorageDriver   string
+	StorageDir      string
+	PublicURL       string
+	S3Endpoint      string
+	S3AccessKey     string
+	S3SecretKey     string
+	S3Bucket        string
+	S3Region        string
+	S3UseSSL        bool
+	// TenantBaseDomain lets associations be resolved from subdomains of it
+	TenantBaseDomain string
+	// AppURL is the web app that emailed links open
+	AppURL       string
+	MailDriver   string
+	MailDir      string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	// MFARequiredRoles must confirm a TOTP code at every sign in
+	MFARequiredRoles []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -69,16 +96,160 @@ func LoadConfig() (*Config, error) {
 		return nil, envErrorMsg("JWT_COOKIE_DOMAIN")
 	}
 
+	billingDay, err := getEnvInt("BILLING_DAY", 1)
+	if err != nil {
+		return nil, err
+	}
+	if billingDay < 1 || billingDay > 28 {
+		return nil, fmt.Errorf("BILLING_DAY must be between 1 and 28")
+	}
+
+	billingDueDay, err := getEnvInt("BILLING_DUE_DAY", 15)
+	if err != nil {
+		return nil, err
+	}
+	if billingDueDay < 1 || billingDueDay > 28 {
+		return nil, fmt.Errorf("BILLING_DUE_DAY must be between 1 and 28")
+	}
+
+	maxStickers, err := getEnvInt("MAX_STICKERS_PER_PROPERTY", 4)
+	if err != nil {
+		return nil, err
+	}
+	if maxStickers < 1 {
+		return nil, fmt.Errorf("MAX_STICKERS_PER_PROPERTY must be at least 1")
+	}
+
+	storageDriver := os.Getenv("STORAGE_DRIVER")
+	if storageDriver == "" {
+		storageDriver = "local"
+	}
+	if storageDriver != "local" && storageDriver != "s3" {
+		return nil, fmt.Errorf("STORAGE_DRIVER must be local or s3")
+	}
+
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "uploads"
+	}
+
+	// signed links to locally stored files and calendar feed URLs point back
+	// at this server
+	publicURL := os.Getenv("PUBLIC_URL")
+	if publicURL == "" {
+		publicURL = "http://localhost:" + port
+	}
+
+	appURL := os.Getenv("APP_URL")
+	if appURL == "" {
+		appURL = publicURL
+	}
+
+	mailDriver := os.Getenv("MAIL_DRIVER")
+	if mailDriver == "" {
+		mailDriver = "log"
+	}
+	if mailDriver != "log" && mailDriver != "file" && mailDriver != "smtp" {
+		return nil, fmt.Errorf("MAIL_DRIVER must be log, file or smtp")
+	}
+
+	mailDir := os.Getenv("MAIL_DIR")
+	if mailDir == "" {
+		mailDir = "mail"
+	}
+
+	mailFrom := os.Getenv("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "HOA Hub <no-reply@localhost>"
+	}
+
+	smtpHost := os.Getenv("SMTP_HOST")
+	if mailDriver == "smtp" && smtpHost == "" {
+		return nil, envErrorMsg("SMTP_HOST")
+	}
+	smtpPort := os.Getenv("SMTP_PORT")
+	if smtpPort == "" {
+		smtpPort = "587"
+	}
+
+	// set but empty turns mandatory MFA off
+	mfaRequiredRoles := []string{"admin", "treasurer"}
+	if value, ok := os.LookupEnv("MFA_REQUIRED_ROLES"); ok {
+		mfaRequiredRoles = []string{}
+		for _, role := range strings.Split(value, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				mfaRequiredRoles = append(mfaRequiredRoles, role)
+			}
+		}
+	}
+
+	s3Endpoint := os.Getenv("S3_ENDPOINT")
+	s3AccessKey := os.Getenv("S3_ACCESS_KEY")
+	s3SecretKey := os.Getenv("S3_SECRET_KEY")
+	s3Bucket := os.Getenv("S3_BUCKET")
+	if storageDriver == "s3" {
+		for _, key := range []string{"S3_ENDPOINT", "S3_ACCESS_KEY", "S3_SECRET_KEY", "S3_BUCKET"} {
+			if os.Getenv(key) == "" {
+				return nil, envErrorMsg(key)
+			}
+		}
+	}
+
+	s3UseSSL := true
+	if value := os.Getenv("S3_USE_SSL"); value != "" {
+		s3UseSSL, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("S3_USE_SSL must be true or false: %w", err)
+		}
+	}
+
 	return &Config{
-		DatabaseURL:     dbUrl,
-		Port:            port,
-		JWTIssuer:       issuer,
-		JWTAudience:     audience,
-		JWTSecret:       secret,
-		JWTCookieDomain: cookieDomain,
+		DatabaseURL:      dbUrl,
+		Port:             port,
+		JWTIssuer:        issuer,
+		JWTAudience:      audience,
+		JWTSecret:        secret,
+		JWTCookieDomain:  cookieDomain,
+		BillingDay:       billingDay,
+		BillingDueDay:    billingDueDay,
+		MaxStickers:      maxStickers,
+		StorageDriver:    storageDriver,
+		StorageDir:       storageDir,
+		PublicURL:        publicURL,
+		S3Endpoint:       s3Endpoint,
+		S3AccessKey:      s3AccessKey,
+		S3SecretKey:      s3SecretKey,
+		S3Bucket:         s3Bucket,
+		S3Region:         os.Getenv("S3_REGION"),
+		S3UseSSL:         s3UseSSL,
+		TenantBaseDomain: os.Getenv("TENANT_BASE_DOMAIN"),
+		AppURL:           appURL,
+		MailDriver:       mailDriver,
+		MailDir:          mailDir,
+		MailFrom:         mailFrom,
+		SMTPHost:         smtpHost,
+		SMTPPort:         smtpPort,
+		SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		MFARequiredRoles: mfaRequiredRoles,
 	}, nil
 }
 
+// getEnvInt reads an optional integer setting, falling back when unset.
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number: %w", key, err)
+	}
+
+	return parsed, nil
+}
+
 func envErrorMsg(envStr string) error {
 	if envStr == "" {
 		envStr = "ENV_VARIABLE"
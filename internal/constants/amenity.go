@@ -0,0 +1,8 @@
+package constants
+
+const (
+	ReservationPending   = "pending"
+	ReservationApproved  = "approved"
+	ReservationRejected  = "rejected"
+	ReservationCancelled = "cancelled"
+)
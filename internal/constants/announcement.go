@@ -0,0 +1,8 @@
+package constants
+
+const (
+	AudienceAll   = "all"
+	AudiencePhase = "phase"
+	AudienceBlock = "block"
+	AudienceRole  = "role"
+)
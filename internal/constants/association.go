@@ -0,0 +1,11 @@
+package constants
+
+const (
+	// AssociationHeader names the association a request is for when it is
+	// not implied by the subdomain or an access token.
+	AssociationHeader = "X-Association"
+
+	// TenantDBRole is the database role queries run as. Row-level security
+	// policies apply to it, unlike to the table owner.
+	TenantDBRole = "hoa_hub_tenant"
+)
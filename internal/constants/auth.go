@@ -0,0 +1,28 @@
+package constants
+
+import "time"
+
+const (
+	// PasswordResetTokenTTL is how long an emailed reset link stays valid.
+	PasswordResetTokenTTL = time.Hour
+	// PasswordResetCooldown throttles reset emails to the same account.
+	PasswordResetCooldown = 2 * time.Minute
+
+	// EmailVerificationTokenTTL is how long a verification link stays valid.
+	EmailVerificationTokenTTL = 24 * time.Hour
+	// EmailVerificationCooldown throttles resent verification emails.
+	EmailVerificationCooldown = 2 * time.Minute
+	// UnverifiedAccountTTL is how long a registration may stay unconfirmed
+	// before it is deleted, freeing its email and mobile number.
+	UnverifiedAccountTTL = 7 * 24 * time.Hour
+
+	// MFAChallengeTTL is how long a password-verified login waits for its
+	// second factor.
+	MFAChallengeTTL = 5 * time.Minute
+	// MaxMFAAttempts wrong codes in a row lock the second factor for
+	// MFALockout, which keeps six digit codes from being guessed.
+	MaxMFAAttempts = 5
+	MFALockout     = 15 * time.Minute
+	// MFARecoveryCodeCount is how many recovery codes each set holds.
+	MFARecoveryCodeCount = 10
+)
@@ -0,0 +1,18 @@
+package constants
+
+const (
+	MonthFormat = "2006-01"
+
+	RateTypeFlat   = "flat"
+	RateTypePerSqm = "per_sqm"
+
+	InvoiceStatusOpen          = "open"
+	InvoiceStatusPartiallyPaid = "partially_paid"
+	InvoiceStatusPaid          = "paid"
+	InvoiceStatusVoid          = "void"
+
+	InvoiceKindDues = "dues"
+	InvoiceKindFine = "fine"
+
+	SequenceInvoice = "invoice"
+)
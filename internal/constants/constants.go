@@ -6,6 +6,21 @@ const (
 	QueryTimeout = time.Second * 5
 	DateFormat   = "2006-01-02"
 
-	ActiveStatus   = "active"
-	InactiveStatus = "inactive"
+	// BackgroundTaskTimeout bounds work finished after the response, such
+	// as sending email.
+	BackgroundTaskTimeout = time.Second * 30
+	// ShutdownTimeout bounds how long a stopping server waits for requests
+	// in flight and the background tasks they started.
+	ShutdownTimeout = BackgroundTaskTimeout + time.Second*10
+
+	ActiveStatus    = "active"
+	InactiveStatus  = "inactive"
+	SuspendedStatus = "suspended"
+	// PendingVerificationStatus marks a registration whose email address
+	// has not been confirmed yet.
+	PendingVerificationStatus = "pending_verification"
+	// PendingApprovalStatus marks a verified registration waiting for an
+	// admin to confirm the registrant lives in the subdivision.
+	PendingApprovalStatus = "pending_approval"
+	RejectedStatus        = "rejected"
 )
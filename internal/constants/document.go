@@ -0,0 +1,17 @@
+package constants
+
+import "time"
+
+const (
+	DocumentCategoryBylaws     = "bylaws"
+	DocumentCategoryMinutes    = "minutes"
+	DocumentCategoryFinancials = "financials"
+	DocumentCategoryPolicies   = "policies"
+	DocumentCategoryForms      = "forms"
+	DocumentCategoryOther      = "other"
+
+	MaxDocumentBytes = 25 << 20
+
+	// DocumentURLExpiry is how long a download link stays valid.
+	DocumentURLExpiry = 15 * time.Minute
+)
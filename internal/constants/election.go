@@ -0,0 +1,28 @@
+package constants
+
+const (
+	ElectionDraft     = "draft"
+	ElectionScheduled = "scheduled"
+	ElectionPublished = "published"
+	ElectionCancelled = "cancelled"
+
+	// election phases are derived from the status and the voting window
+	ElectionPhaseDraft     = "draft"
+	ElectionPhaseUpcoming  = "upcoming"
+	ElectionPhaseOpen      = "open"
+	ElectionPhaseClosed    = "closed"
+	ElectionPhasePublished = "published"
+	ElectionPhaseCancelled = "cancelled"
+
+	ProxyPending  = "pending"
+	ProxyApproved = "approved"
+	ProxyRejected = "rejected"
+	ProxyRevoked  = "revoked"
+
+	MaxProxyAuthorizationBytes = 10 << 20
+
+	// VoteMixBatch is how many secret election or poll ballots are queued
+	// before their choices are shuffled into the counted votes. It is the
+	// smallest group a choice can be narrowed down to while voting is open.
+	VoteMixBatch = 10
+)
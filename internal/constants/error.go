@@ -3,9 +3,24 @@ package constants
 import "errors"
 
 var (
-	ErrRecordNotFound  = errors.New("record not found")
-	ErrRecordExists    = errors.New("record exists")
-	ErrInvalidPassword = errors.New("invalid password")
-	ErrInvalidToken    = errors.New("invalid token")
-	ErrInternalServer  = errors.New("internal server errror")
+	ErrRecordNotFound       = errors.New("record not found")
+	ErrRecordExists         = errors.New("record exists")
+	ErrInvalidInput         = errors.New("invalid input")
+	ErrInvalidPassword      = errors.New("invalid password")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrTokenReused          = errors.New("refresh token reuse detected")
+	ErrSessionRevoked       = errors.New("session revoked")
+	ErrAccountInactive      = errors.New("account is not active")
+	ErrEmailNotVerified     = errors.New("email address is not verified")
+	ErrPendingApproval      = errors.New("account is awaiting approval")
+	ErrRegistrationRejected = errors.New("registration was rejected")
+	ErrInvalidMFACode       = errors.New("invalid authentication code")
+	ErrMFALocked            = errors.New("too many invalid codes, try again later")
+	ErrTimeSlotTaken        = errors.New("time slot is already reserved")
+	ErrOverdueDues          = errors.New("property has overdue dues")
+	ErrGatePassDenied       = errors.New("gate pass is not valid for entry")
+	ErrStickerLimit         = errors.New("property has reached its sticker limit")
+	ErrEventFull            = errors.New("event is full")
+	ErrInvalidSignature     = errors.New("link is invalid or has expired")
+	ErrInternalServer       = errors.New("internal server errror")
 )
@@ -0,0 +1,22 @@
+package constants
+
+import "time"
+
+const (
+	EventGeneralAssembly = "general_assembly"
+	EventMeeting         = "meeting"
+	EventSocial          = "social"
+	EventCleanup         = "cleanup"
+	EventOther           = "other"
+
+	RSVPGoing    = "going"
+	RSVPNotGoing = "not_going"
+
+	// MaxRSVPHeadcount caps how many people one RSVP can bring, the member
+	// included.
+	MaxRSVPHeadcount = 20
+
+	// CalendarFeedLookback keeps recent past events in the feed so they do
+	// not vanish from subscribed calendars the moment they end.
+	CalendarFeedLookback = 30 * 24 * time.Hour
+)
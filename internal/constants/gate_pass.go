@@ -0,0 +1,19 @@
+package constants
+
+const (
+	VisitorGuest    = "guest"
+	VisitorDelivery = "delivery"
+	VisitorService  = "service"
+
+	GatePassScheduled = "scheduled"
+	GatePassActive    = "active"
+	GatePassUsed      = "used"
+	GatePassExpired   = "expired"
+	GatePassRevoked   = "revoked"
+
+	GateEntryPass   = "pass"
+	GateEntryWalkIn = "walk_in"
+
+	// MaxGatePassDays caps how long a single pass stays valid.
+	MaxGatePassDays = 30
+)
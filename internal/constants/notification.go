@@ -0,0 +1,13 @@
+package constants
+
+const (
+	NotificationTicketStatus    = "ticket_status"
+	NotificationViolationNotice = "violation_notice"
+	NotificationViolationFine   = "violation_fine"
+	NotificationViolationAppeal = "violation_appeal"
+	NotificationEventCancelled  = "event_cancelled"
+
+	ReferenceTicket    = "ticket"
+	ReferenceViolation = "violation"
+	ReferenceEvent     = "event"
+)
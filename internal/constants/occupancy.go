@@ -0,0 +1,8 @@
+package constants
+
+const (
+	OccupancyOwner          = "owner"
+	OccupancyCoOwner        = "co_owner"
+	OccupancyTenant         = "tenant"
+	OccupancyRepresentative = "representative"
+)
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	PaymentMethodCash         = "cash"
+	PaymentMethodCheck        = "check"
+	PaymentMethodBankTransfer = "bank_transfer"
+	PaymentMethodEWallet      = "e_wallet"
+
+	SequenceReceipt = "receipt"
+)
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	PenaltyTypeFixed    = "fixed"
+	PenaltyTypeInterest = "interest"
+
+	PenaltyStatusApplied = "applied"
+	PenaltyStatusWaived  = "waived"
+)
@@ -0,0 +1,34 @@
+package constants
+
+const (
+	PollYesNo          = "yes_no"
+	PollMultipleChoice = "multiple_choice"
+
+	PollAudienceMembers = "members"
+	PollAudienceOwners  = "owners"
+
+	PollSimpleMajority = "simple_majority"
+	PollTwoThirds      = "two_thirds"
+
+	PollDraft     = "draft"
+	PollPublished = "published"
+	PollClosed    = "closed"
+	PollCancelled = "cancelled"
+
+	// poll phases are derived from the status and the voting window
+	PollPhaseDraft     = "draft"
+	PollPhaseUpcoming  = "upcoming"
+	PollPhaseOpen      = "open"
+	PollPhaseClosed    = "closed"
+	PollPhaseCancelled = "cancelled"
+
+	PollOutcomePassed    = "passed"
+	PollOutcomeRejected  = "rejected"
+	PollOutcomeDecided   = "decided"
+	PollOutcomeUndecided = "undecided"
+	PollOutcomeNoQuorum  = "no_quorum"
+
+	// options created for every yes/no poll
+	PollOptionYes = "Yes"
+	PollOptionNo  = "No"
+)
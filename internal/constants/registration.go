@@ -0,0 +1,12 @@
+package constants
+
+const (
+	RegistrationPending  = "pending"
+	RegistrationApproved = "approved"
+	RegistrationRejected = "rejected"
+
+	ProofTitle = "title"
+	ProofLease = "lease"
+
+	MaxRegistrationProofBytes = 10 << 20
+)
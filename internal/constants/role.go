@@ -0,0 +1,27 @@
+package constants
+
+const (
+	RoleAdmin     = "admin"
+	RoleMember    = "member"
+	RoleTreasurer = "treasurer"
+	RoleGuard     = "guard"
+	RoleBoard     = "board"
+
+	PermManageUsers      = "manage_users"
+	PermManageProperties = "manage_properties"
+	PermViewReports      = "view_reports"
+	PermManageFinances   = "manage_finances"
+
+	PermManageAnnouncements = "manage_announcements"
+	PermManageAmenities     = "manage_amenities"
+	PermVerifyGatePasses    = "verify_gate_passes"
+	PermLogGateEntries      = "log_gate_entries"
+	PermViewGateLogbook     = "view_gate_logbook"
+	PermManageVehicles      = "manage_vehicles"
+	PermManageTickets       = "manage_tickets"
+	PermManageViolations    = "manage_violations"
+	PermManageDocuments     = "manage_documents"
+	PermManageElections     = "manage_elections"
+	PermManagePolls         = "manage_polls"
+	PermManageEvents        = "manage_events"
+)
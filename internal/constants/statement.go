@@ -0,0 +1,8 @@
+package constants
+
+const (
+	StatementEntryInvoice       = "invoice"
+	StatementEntryPenalty       = "penalty"
+	StatementEntryPenaltyWaiver = "penalty_waiver"
+	StatementEntryPayment       = "payment"
+)
@@ -0,0 +1,30 @@
+package constants
+
+const (
+	TicketCategoryStreetlight = "streetlight"
+	TicketCategoryDrainage    = "drainage"
+	TicketCategoryGarbage     = "garbage"
+	TicketCategoryRoad        = "road"
+	TicketCategoryWater       = "water"
+	TicketCategoryElectrical  = "electrical"
+	TicketCategoryLandscaping = "landscaping"
+	TicketCategorySecurity    = "security"
+	TicketCategoryOther       = "other"
+
+	TicketPriorityLow    = "low"
+	TicketPriorityNormal = "normal"
+	TicketPriorityHigh   = "high"
+	TicketPriorityUrgent = "urgent"
+
+	TicketOpen       = "open"
+	TicketTriaged    = "triaged"
+	TicketInProgress = "in_progress"
+	TicketResolved   = "resolved"
+	TicketClosed     = "closed"
+	TicketReopened   = "reopened"
+
+	MaxTicketPhotos     = 5
+	MaxTicketPhotoBytes = 5 << 20
+
+	SequenceTicket = "ticket"
+)
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	StickerIssued  = "issued"
+	StickerLost    = "lost"
+	StickerRevoked = "revoked"
+
+	SequenceSticker = "sticker"
+)
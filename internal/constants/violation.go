@@ -0,0 +1,37 @@
+package constants
+
+const (
+	ViolationCategoryConstruction = "construction"
+	ViolationCategoryNoise        = "noise"
+	ViolationCategoryPets         = "pets"
+	ViolationCategoryParking      = "parking"
+	ViolationCategoryGarbage      = "garbage"
+	ViolationCategoryOther        = "other"
+
+	ViolationOpen      = "open"
+	ViolationNoticed   = "noticed"
+	ViolationFined     = "fined"
+	ViolationAppealed  = "appealed"
+	ViolationResolved  = "resolved"
+	ViolationDismissed = "dismissed"
+
+	AppealPending  = "pending"
+	AppealAccepted = "accepted"
+	AppealRejected = "rejected"
+
+	ViolationEventRecorded        = "recorded"
+	ViolationEventEvidenceAdded   = "evidence_added"
+	ViolationEventNoticeIssued    = "notice_issued"
+	ViolationEventFined           = "fined"
+	ViolationEventAppealSubmitted = "appeal_submitted"
+	ViolationEventAppealAccepted  = "appeal_accepted"
+	ViolationEventAppealRejected  = "appeal_rejected"
+	ViolationEventResolved        = "resolved"
+	ViolationEventDismissed       = "dismissed"
+
+	MaxViolationAttachments   = 10
+	MaxViolationEvidenceBytes = 10 << 20
+	DefaultFineDueDays        = 30
+
+	SequenceViolation = "violation"
+)
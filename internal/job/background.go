@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+)
+
+// Background runs one-off tasks that finish after the request which started
+// them, such as sending email, and lets the process wait for them on
+// shutdown.
+type Background struct {
+	tasks sync.WaitGroup
+}
+
+func NewBackground() *Background {
+	return &Background{}
+}
+
+// Go runs task in its own goroutine. It keeps ctx's values, such as the
+// association, but not its cancellation, since the request will have
+// finished by then. Failures are logged as "failed to <name>".
+func (b *Background) Go(ctx context.Context, name string, task func(ctx context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+
+	b.tasks.Add(1)
+	go func() {
+		defer b.tasks.Done()
+
+		ctx, cancel := context.WithTimeout(ctx, constants.BackgroundTaskTimeout)
+		defer cancel()
+
+		if err := task(ctx); err != nil {
+			log.Printf("failed to %s: %v\n", name, err)
+		}
+	}()
+}
+
+// Wait blocks until every started task has finished or ctx is done.
+func (b *Background) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.tasks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
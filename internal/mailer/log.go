@@ -0,0 +1,19 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// LogMailer prints messages to the server log instead of sending them, so
+// links can be followed during local development without a mail server.
+type LogMailer struct{}
+
+func NewLogMailer() Mailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message Message) error {
+	log.Printf("mail to %s: %s\n%s\n", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+// ResolveAssociation scopes the request to the association named by the
+// :association path parameter, the X-Association header or, when baseDomain
+// is set, the subdomain of the request host. Requests naming none are left
+// unscoped; AuthMiddleware scopes them from the token instead.
+func ResolveAssociation(associationService service.AssociationService, baseDomain string) gin.HandlerFunc {
+	baseDomain = strings.ToLower(strings.TrimPrefix(baseDomain, "."))
+
+	return func(ctx *gin.Context) {
+		slug := ctx.Param("association")
+		if slug == "" {
+			slug = ctx.GetHeader(constants.AssociationHeader)
+		}
+		if slug == "" && baseDomain != "" {
+			slug = subdomain(ctx.Request.Host, baseDomain)
+		}
+		if slug == "" {
+			ctx.Next()
+			return
+		}
+
+		association, err := associationService.GetAssociationBySlug(ctx.Request.Context(), slug)
+		if err != nil {
+			if errors.Is(err, constants.ErrRecordNotFound) {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "unknown association"})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(tenant.WithAssociation(ctx.Request.Context(), association))
+		ctx.Next()
+	}
+}
+
+// RequireAssociation rejects requests that ResolveAssociation could not
+// scope, such as logins that name no association.
+func RequireAssociation() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := tenant.FromContext(ctx.Request.Context()); !ok {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "association not specified"})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// subdomain returns the single label in front of baseDomain, so
+// "acacia.hoahub.ph" yields "acacia" for base domain "hoahub.ph".
+func subdomain(host, baseDomain string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	label, ok := strings.CutSuffix(host, "."+baseDomain)
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
@@ -5,10 +5,16 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
 )
 
-func AuthMiddleware(auth *auth.JWTAuth) gin.HandlerFunc {
+// AuthMiddleware also scopes the request to the association in the token.
+// A token presented against another association's subdomain or header is
+// rejected rather than silently switching associations.
+func AuthMiddleware(auth auth.IJWTAuth, associationService service.AssociationService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
@@ -22,13 +28,33 @@ func AuthMiddleware(auth *auth.JWTAuth) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := auth.ParseAccessToken(tokenParts[1])
+		claims, err := auth.ParseAccessToken(ctx.Request.Context(), tokenParts[1])
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
 
+		associationID, err := uuid.Parse(claims.AssociationID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+		if current, ok := tenant.AssociationID(ctx.Request.Context()); ok {
+			if current != associationID {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token belongs to another association"})
+				return
+			}
+		} else {
+			association, err := associationService.GetAssociationByID(ctx.Request.Context(), associationID)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+				return
+			}
+			ctx.Request = ctx.Request.WithContext(tenant.WithAssociation(ctx.Request.Context(), association))
+		}
+
 		ctx.Set("user_id", claims.UserID)
+		ctx.Set("session_id", claims.SessionID)
 		ctx.Next()
 	}
 }
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+// RequirePermission must run after AuthMiddleware, which sets the user_id
+// that the user's effective permissions are resolved from.
+func RequirePermission(roleService service.RoleService, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := uuid.Parse(ctx.GetString("user_id"))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+			return
+		}
+
+		allowed, err := roleService.HasPermission(ctx.Request.Context(), userID, permission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !allowed {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "permission denied"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Amenity opening hours are wall-clock times in the association's time zone,
+// formatted as HH:MM:SS.
+type Amenity struct {
+	ID                uuid.UUID `db:"id"`
+	AssociationID     uuid.UUID `db:"association_id"`
+	Name              string    `db:"name"`
+	Description       *string   `db:"description"`
+	Capacity          int       `db:"capacity"`
+	OpensAt           string    `db:"opens_at"`
+	ClosesAt          string    `db:"closes_at"`
+	SlotMinutes       int       `db:"slot_minutes"`
+	FeeCents          int64     `db:"fee_cents"`
+	RequiresApproval  bool      `db:"requires_approval"`
+	CancellationHours int       `db:"cancellation_hours"`
+	Active            bool      `db:"active"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+type AmenityReservation struct {
+	ID             uuid.UUID  `db:"id"`
+	AssociationID  uuid.UUID  `db:"association_id"`
+	AmenityID      uuid.UUID  `db:"amenity_id"`
+	AmenityName    string     `db:"amenity_name"`
+	PropertyID     uuid.UUID  `db:"property_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	StartAt        time.Time  `db:"start_at"`
+	EndAt          time.Time  `db:"end_at"`
+	GuestCount     int        `db:"guest_count"`
+	Status         string     `db:"status"`
+	FeeCents       int64      `db:"fee_cents"`
+	Notes          *string    `db:"notes"`
+	DecisionReason *string    `db:"decision_reason"`
+	DecidedBy      *uuid.UUID `db:"decided_by"`
+	DecidedAt      *time.Time `db:"decided_at"`
+	CancelReason   *string    `db:"cancel_reason"`
+	CancelledAt    *time.Time `db:"cancelled_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Announcement struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	Title         string     `db:"title"`
+	Body          string     `db:"body"`
+	AudienceType  string     `db:"audience_type"`
+	AudiencePhase *string    `db:"audience_phase"`
+	AudienceBlock *string    `db:"audience_block"`
+	AudienceRole  *string    `db:"audience_role"`
+	Pinned        bool       `db:"pinned"`
+	PublishAt     time.Time  `db:"publish_at"`
+	ExpireAt      *time.Time `db:"expire_at"`
+	CreatedBy     *uuid.UUID `db:"created_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+// FeedAnnouncement is an announcement as seen by one member.
+type FeedAnnouncement struct {
+	Announcement
+	ReadAt *time.Time `db:"read_at"`
+}
+
+type AnnouncementRead struct {
+	UserID    uuid.UUID `db:"user_id"`
+	FirstName string    `db:"first_name"`
+	LastName  string    `db:"last_name"`
+	Email     string    `db:"email"`
+	ReadAt    time.Time `db:"read_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Association is one homeowners association (tenant). Every other table
+// belongs to exactly one association.
+type Association struct {
+	ID        uuid.UUID `db:"id"`
+	Slug      string    `db:"slug"`
+	Name      string    `db:"name"`
+	Timezone  string    `db:"timezone"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
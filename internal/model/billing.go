@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Amounts are stored in centavos to keep money arithmetic exact.
+
+type DuesSchedule struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	Name          string     `db:"name"`
+	PropertyType  *string    `db:"property_type"`
+	Phase         *string    `db:"phase"`
+	RateType      string     `db:"rate_type"`
+	AmountCents   int64      `db:"amount_cents"`
+	EffectiveFrom time.Time  `db:"effective_from"`
+	EffectiveTo   *time.Time `db:"effective_to"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+type BillingRun struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	BillingPeriod time.Time  `db:"billing_period"`
+	InvoiceCount  int        `db:"invoice_count"`
+	TotalCents    int64      `db:"total_cents"`
+	RunBy         *uuid.UUID `db:"run_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+type Invoice struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	InvoiceNumber string     `db:"invoice_number"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	BillingRunID  *uuid.UUID `db:"billing_run_id"`
+	Kind          string     `db:"kind"`
+	BillingPeriod time.Time  `db:"billing_period"`
+	IssueDate     time.Time  `db:"issue_date"`
+	DueDate       time.Time  `db:"due_date"`
+	Status        string     `db:"status"`
+	TotalCents    int64      `db:"total_cents"`
+	PaidCents     int64      `db:"paid_cents"`
+	PenaltyCents  int64      `db:"penalty_cents"`
+	VoidReason    *string    `db:"void_reason"`
+	VoidedAt      *time.Time `db:"voided_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+
+	LineItems []InvoiceLineItem `db:"-"`
+}
+
+type InvoiceLineItem struct {
+	ID              uuid.UUID  `db:"id"`
+	AssociationID   uuid.UUID  `db:"association_id"`
+	InvoiceID       uuid.UUID  `db:"invoice_id"`
+	DuesScheduleID  *uuid.UUID `db:"dues_schedule_id"`
+	Description     string     `db:"description"`
+	Quantity        float64    `db:"quantity"`
+	UnitAmountCents int64      `db:"unit_amount_cents"`
+	AmountCents     int64      `db:"amount_cents"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Document struct {
+	ID             uuid.UUID  `db:"id"`
+	AssociationID  uuid.UUID  `db:"association_id"`
+	Title          string     `db:"title"`
+	Description    *string    `db:"description"`
+	Category       string     `db:"category"`
+	VisibleRole    *string    `db:"visible_role"`
+	CurrentVersion int        `db:"current_version"`
+	CreatedBy      *uuid.UUID `db:"created_by"`
+	ArchivedAt     *time.Time `db:"archived_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type DocumentVersion struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	DocumentID    uuid.UUID  `db:"document_id"`
+	VersionNumber int        `db:"version_number"`
+	StorageKey    string     `db:"storage_key"`
+	FileName      string     `db:"file_name"`
+	ContentType   string     `db:"content_type"`
+	SizeBytes     int64      `db:"size_bytes"`
+	Notes         *string    `db:"notes"`
+	UploadedBy    *uuid.UUID `db:"uploaded_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Election struct {
+	ID                 uuid.UUID  `db:"id"`
+	AssociationID      uuid.UUID  `db:"association_id"`
+	Title              string     `db:"title"`
+	Description        *string    `db:"description"`
+	VotingStartsAt     time.Time  `db:"voting_starts_at"`
+	VotingEndsAt       time.Time  `db:"voting_ends_at"`
+	QuorumPercent      int        `db:"quorum_percent"`
+	Status             string     `db:"status"`
+	EligibleProperties *int       `db:"eligible_properties"`
+	ResultsPublishedAt *time.Time `db:"results_published_at"`
+	CreatedBy          *uuid.UUID `db:"created_by"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+}
+
+type ElectionPosition struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	ElectionID    uuid.UUID `db:"election_id"`
+	Title         string    `db:"title"`
+	Seats         int       `db:"seats"`
+	SortOrder     int       `db:"sort_order"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type ElectionCandidate struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ElectionID    uuid.UUID  `db:"election_id"`
+	PositionID    uuid.UUID  `db:"position_id"`
+	UserID        *uuid.UUID `db:"user_id"`
+	Name          string     `db:"name"`
+	Bio           *string    `db:"bio"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+type ElectionProxy struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ElectionID    uuid.UUID  `db:"election_id"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	GrantedBy     uuid.UUID  `db:"granted_by"`
+	ProxyUserID   uuid.UUID  `db:"proxy_user_id"`
+	StorageKey    string     `db:"storage_key"`
+	FileName      string     `db:"file_name"`
+	ContentType   string     `db:"content_type"`
+	SizeBytes     int64      `db:"size_bytes"`
+	Status        string     `db:"status"`
+	ReviewNote    *string    `db:"review_note"`
+	ReviewedBy    *uuid.UUID `db:"reviewed_by"`
+	ReviewedAt    *time.Time `db:"reviewed_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// ElectionBallot records that a property voted, not how.
+type ElectionBallot struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ElectionID    uuid.UUID  `db:"election_id"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	CastBy        uuid.UUID  `db:"cast_by"`
+	ProxyID       *uuid.UUID `db:"proxy_id"`
+	CastAt        time.Time  `db:"cast_at"`
+}
+
+type ElectionVote struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	ElectionID    uuid.UUID `db:"election_id"`
+	PositionID    uuid.UUID `db:"position_id"`
+	CandidateID   uuid.UUID `db:"candidate_id"`
+}
+
+type ElectionQuorum struct {
+	EligibleProperties int `db:"eligible_properties"`
+	BallotsCast        int `db:"ballots_cast"`
+}
+
+type ElectionTally struct {
+	CandidateID uuid.UUID `db:"candidate_id"`
+	Votes       int       `db:"votes"`
+}
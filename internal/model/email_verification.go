@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken is a single-use link sent to a new registration to
+// confirm the email address. Only a hash of the token is stored.
+type EmailVerificationToken struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	TokenHash     string     `db:"token_hash"`
+	ExpiresAt     time.Time  `db:"expires_at"`
+	UsedAt        *time.Time `db:"used_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
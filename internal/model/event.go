@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Event struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	Title         string     `db:"title"`
+	Description   *string    `db:"description"`
+	Category      string     `db:"category"`
+	Location      string     `db:"location"`
+	StartsAt      time.Time  `db:"starts_at"`
+	EndsAt        time.Time  `db:"ends_at"`
+	Capacity      *int       `db:"capacity"`
+	RSVPDeadline  *time.Time `db:"rsvp_deadline"`
+	CancelReason  *string    `db:"cancel_reason"`
+	CancelledAt   *time.Time `db:"cancelled_at"`
+	CreatedBy     *uuid.UUID `db:"created_by"`
+	// Headcount is the total of the going RSVPs; it is computed on read.
+	Headcount int       `db:"headcount"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type EventRSVP struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	EventID       uuid.UUID `db:"event_id"`
+	UserID        uuid.UUID `db:"user_id"`
+	Status        string    `db:"status"`
+	Headcount     int       `db:"headcount"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type EventAttendee struct {
+	EventRSVP
+	FirstName string `db:"first_name"`
+	LastName  string `db:"last_name"`
+	Email     string `db:"email"`
+}
+
+type CalendarToken struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	TokenHash     string     `db:"token_hash"`
+	CreatedAt     time.Time  `db:"created_at"`
+	RevokedAt     *time.Time `db:"revoked_at"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GatePass struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	HostUserID    uuid.UUID  `db:"host_user_id"`
+	VisitorName   string     `db:"visitor_name"`
+	VisitorType   string     `db:"visitor_type"`
+	VehiclePlate  *string    `db:"vehicle_plate"`
+	Purpose       *string    `db:"purpose"`
+	ValidFrom     time.Time  `db:"valid_from"`
+	ValidUntil    time.Time  `db:"valid_until"`
+	MaxUses       *int       `db:"max_uses"`
+	UseCount      int        `db:"use_count"`
+	RevokedAt     *time.Time `db:"revoked_at"`
+	RevokedBy     *uuid.UUID `db:"revoked_by"`
+	RevokeReason  *string    `db:"revoke_reason"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+// GateEntry is one line of the gate logbook: either a visitor admitted on a
+// gate pass or a walk-in logged by the guard.
+type GateEntry struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	EntryType     string     `db:"entry_type"`
+	GatePassID    *uuid.UUID `db:"gate_pass_id"`
+	VisitorName   string     `db:"visitor_name"`
+	VehiclePlate  *string    `db:"vehicle_plate"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	VisitedUserID *uuid.UUID `db:"visited_user_id"`
+	Purpose       *string    `db:"purpose"`
+	EnteredAt     time.Time  `db:"entered_at"`
+	EntryGuardID  *uuid.UUID `db:"entry_guard_id"`
+	ExitedAt      *time.Time `db:"exited_at"`
+	ExitGuardID   *uuid.UUID `db:"exit_guard_id"`
+	Notes         *string    `db:"notes"`
+}
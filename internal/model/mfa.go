@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA is a user's TOTP enrollment. It only protects sign in once
+// EnabledAt is set.
+type UserMFA struct {
+	UserID         uuid.UUID  `db:"user_id"`
+	AssociationID  uuid.UUID  `db:"association_id"`
+	Secret         string     `db:"secret"`
+	EnabledAt      *time.Time `db:"enabled_at"`
+	LastUsedStep   *int64     `db:"last_used_step"`
+	FailedAttempts int        `db:"failed_attempts"`
+	LockedUntil    *time.Time `db:"locked_until"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// MFARecoveryCode is a single-use code for signing in without the
+// authenticator app. Only a hash of the code is stored.
+type MFARecoveryCode struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	CodeHash      string     `db:"code_hash"`
+	UsedAt        *time.Time `db:"used_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
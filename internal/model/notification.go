@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	Kind          string     `db:"kind"`
+	Title         string     `db:"title"`
+	Body          string     `db:"body"`
+	ReferenceType *string    `db:"reference_type"`
+	ReferenceID   *uuid.UUID `db:"reference_id"`
+	ReadAt        *time.Time `db:"read_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
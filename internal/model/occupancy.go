@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Occupancy links a user to a property for the half-open range
+// [StartDate, EndDate). A nil EndDate means the occupancy is still current.
+type Occupancy struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	OccupancyType string     `db:"occupancy_type"`
+	StartDate     time.Time  `db:"start_date"`
+	EndDate       *time.Time `db:"end_date"`
+	Notes         *string    `db:"notes"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+type PropertyOccupant struct {
+	Occupancy
+	FirstName string `db:"first_name"`
+	LastName  string `db:"last_name"`
+	Email     string `db:"email"`
+}
+
+type UserProperty struct {
+	Property
+	OccupancyID   uuid.UUID  `db:"occupancy_id"`
+	OccupancyType string     `db:"occupancy_type"`
+	StartDate     time.Time  `db:"start_date"`
+	EndDate       *time.Time `db:"end_date"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Payment struct {
+	ID               uuid.UUID  `db:"id"`
+	AssociationID    uuid.UUID  `db:"association_id"`
+	ReceiptNumber    string     `db:"receipt_number"`
+	PropertyID       uuid.UUID  `db:"property_id"`
+	Method           string     `db:"method"`
+	ReferenceNumber  *string    `db:"reference_number"`
+	AmountCents      int64      `db:"amount_cents"`
+	UnallocatedCents int64      `db:"unallocated_cents"`
+	PaidAt           time.Time  `db:"paid_at"`
+	Notes            *string    `db:"notes"`
+	RecordedBy       *uuid.UUID `db:"recorded_by"`
+	CreatedAt        time.Time  `db:"created_at"`
+
+	Allocations []PaymentAllocation `db:"-"`
+}
+
+type PaymentAllocation struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	PaymentID     uuid.UUID `db:"payment_id"`
+	InvoiceID     uuid.UUID `db:"invoice_id"`
+	InvoiceNumber string    `db:"invoice_number"`
+	AmountCents   int64     `db:"amount_cents"`
+	CreatedAt     time.Time `db:"created_at"`
+	// AppliesFrom is the date the amount counts against the invoice balance:
+	// the payment date, or the issue date for credit paid before the invoice.
+	AppliesFrom time.Time `db:"applies_from"`
+}
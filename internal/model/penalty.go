@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PenaltyRule struct {
+	ID                 uuid.UUID  `db:"id"`
+	AssociationID      uuid.UUID  `db:"association_id"`
+	Name               string     `db:"name"`
+	GraceDays          int        `db:"grace_days"`
+	FixedCents         int64      `db:"fixed_cents"`
+	MonthlyInterestBps int        `db:"monthly_interest_bps"`
+	EffectiveFrom      time.Time  `db:"effective_from"`
+	EffectiveTo        *time.Time `db:"effective_to"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+}
+
+type InvoicePenalty struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	InvoiceID     uuid.UUID  `db:"invoice_id"`
+	RuleID        uuid.UUID  `db:"rule_id"`
+	PenaltyType   string     `db:"penalty_type"`
+	PeriodKey     time.Time  `db:"period_key"`
+	AmountCents   int64      `db:"amount_cents"`
+	Status        string     `db:"status"`
+	WaiveReason   *string    `db:"waive_reason"`
+	WaivedBy      *uuid.UUID `db:"waived_by"`
+	WaivedAt      *time.Time `db:"waived_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Poll struct {
+	ID             uuid.UUID  `db:"id"`
+	AssociationID  uuid.UUID  `db:"association_id"`
+	Title          string     `db:"title"`
+	Description    *string    `db:"description"`
+	Kind           string     `db:"kind"`
+	Audience       string     `db:"audience"`
+	Threshold      string     `db:"threshold"`
+	QuorumPercent  int        `db:"quorum_percent"`
+	Secret         bool       `db:"secret"`
+	OpensAt        time.Time  `db:"opens_at"`
+	ClosesAt       time.Time  `db:"closes_at"`
+	Status         string     `db:"status"`
+	EligibleVoters *int       `db:"eligible_voters"`
+	ClosedAt       *time.Time `db:"closed_at"`
+	CreatedBy      *uuid.UUID `db:"created_by"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type PollOption struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	PollID        uuid.UUID `db:"poll_id"`
+	Label         string    `db:"label"`
+	SortOrder     int       `db:"sort_order"`
+}
+
+// PollBallot records that a member voted, not how.
+type PollBallot struct {
+	ID            uuid.UUID `db:"id"`
+	AssociationID uuid.UUID `db:"association_id"`
+	PollID        uuid.UUID `db:"poll_id"`
+	UserID        uuid.UUID `db:"user_id"`
+	CastAt        time.Time `db:"cast_at"`
+}
+
+// PollVote is one choice; a nil OptionID is an abstention. BallotID is
+// only set on polls that are not secret.
+type PollVote struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	PollID        uuid.UUID  `db:"poll_id"`
+	OptionID      *uuid.UUID `db:"option_id"`
+	BallotID      *uuid.UUID `db:"ballot_id"`
+}
+
+type PollTurnout struct {
+	EligibleVoters int `db:"eligible_voters"`
+	BallotsCast    int `db:"ballots_cast"`
+}
+
+type PollTally struct {
+	OptionID *uuid.UUID `db:"option_id"`
+	Votes    int        `db:"votes"`
+}
+
+// PollVoter is one line of an open poll's audit trail.
+type PollVoter struct {
+	UserID    uuid.UUID  `db:"user_id"`
+	FirstName string     `db:"first_name"`
+	LastName  string     `db:"last_name"`
+	OptionID  *uuid.UUID `db:"option_id"`
+	CastAt    time.Time  `db:"cast_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Property struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	OwnerID       *uuid.UUID `db:"owner_id"`
+	Block         string     `db:"block"`
+	Lot           string     `db:"lot"`
+	Road          *string    `db:"road"`
+	Phase         string     `db:"phase"`
+	Type          *string    `db:"type"`
+	LotAreaSqm    *float64   `db:"lot_area_sqm"`
+	ArchivedAt    *time.Time `db:"archived_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken tracks an issued refresh token by its jti. Tokens rotated from
+// the same login share a FamilyID so a replayed token can revoke the chain.
+type RefreshToken struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	FamilyID      uuid.UUID  `db:"family_id"`
+	ExpiresAt     time.Time  `db:"expires_at"`
+	RevokedAt     *time.Time `db:"revoked_at"`
+	ReplacedBy    *uuid.UUID `db:"replaced_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registration is the admin review of a new account. When the registrant
+// claimed a property, PropertyID and the proof fields are set together.
+type Registration struct {
+	ID               uuid.UUID  `db:"id"`
+	AssociationID    uuid.UUID  `db:"association_id"`
+	UserID           uuid.UUID  `db:"user_id"`
+	PropertyID       *uuid.UUID `db:"property_id"`
+	ProofType        *string    `db:"proof_type"`
+	ProofKey         *string    `db:"proof_key"`
+	ProofFileName    *string    `db:"proof_file_name"`
+	ProofContentType *string    `db:"proof_content_type"`
+	ProofSizeBytes   *int64     `db:"proof_size_bytes"`
+	Status           string     `db:"status"`
+	RejectionReason  *string    `db:"rejection_reason"`
+	ReviewedBy       *uuid.UUID `db:"reviewed_by"`
+	ReviewedAt       *time.Time `db:"reviewed_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+}
+
+// RegistrationDetail adds the registrant and the claimed address for the
+// review queue.
+type RegistrationDetail struct {
+	Registration
+	FirstName    string  `db:"first_name"`
+	LastName     string  `db:"last_name"`
+	Email        string  `db:"email"`
+	MobileNumber string  `db:"mobile_number"`
+	UserStatus   string  `db:"user_status"`
+	Phase        *string `db:"phase"`
+	Block        *string `db:"block"`
+	Lot          *string `db:"lot"`
+}
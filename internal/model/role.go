@@ -0,0 +1,15 @@
+package model
+
+import "github.com/google/uuid"
+
+type Role struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+}
+
+type Permission struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementEntry is one line of a statement of account. Exactly one of
+// ChargeCents and CreditCents is non-zero.
+type StatementEntry struct {
+	EntryDate   time.Time `db:"entry_date"`
+	EntryType   string    `db:"entry_type"`
+	SourceID    uuid.UUID `db:"source_id"`
+	Reference   string    `db:"reference"`
+	Description string    `db:"description"`
+	ChargeCents int64     `db:"charge_cents"`
+	CreditCents int64     `db:"credit_cents"`
+	CreatedAt   time.Time `db:"created_at"`
+}
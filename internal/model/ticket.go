@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ticket struct {
+	ID             uuid.UUID  `db:"id"`
+	AssociationID  uuid.UUID  `db:"association_id"`
+	TicketNumber   string     `db:"ticket_number"`
+	ReporterID     uuid.UUID  `db:"reporter_id"`
+	Category       string     `db:"category"`
+	Priority       string     `db:"priority"`
+	Title          string     `db:"title"`
+	Description    string     `db:"description"`
+	PropertyID     *uuid.UUID `db:"property_id"`
+	CommonArea     *string    `db:"common_area"`
+	Status         string     `db:"status"`
+	AssignedUserID *uuid.UUID `db:"assigned_user_id"`
+	AssignedVendor *string    `db:"assigned_vendor"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+	ClosedAt       *time.Time `db:"closed_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type TicketPhoto struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	TicketID      uuid.UUID  `db:"ticket_id"`
+	StorageKey    string     `db:"storage_key"`
+	ContentType   string     `db:"content_type"`
+	SizeBytes     int64      `db:"size_bytes"`
+	UploadedBy    *uuid.UUID `db:"uploaded_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// TicketComment carries the author's name from a join.
+type TicketComment struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	TicketID      uuid.UUID  `db:"ticket_id"`
+	AuthorID      *uuid.UUID `db:"author_id"`
+	FirstName     *string    `db:"first_name"`
+	LastName      *string    `db:"last_name"`
+	Body          string     `db:"body"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+type TicketStatusChange struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	TicketID      uuid.UUID  `db:"ticket_id"`
+	FromStatus    string     `db:"from_status"`
+	ToStatus      string     `db:"to_status"`
+	Note          *string    `db:"note"`
+	ChangedBy     *uuid.UUID `db:"changed_by"`
+	ChangedAt     time.Time  `db:"changed_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Vehicle struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	PropertyID    uuid.UUID  `db:"property_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	PlateNumber   string     `db:"plate_number"`
+	Make          string     `db:"make"`
+	Model         string     `db:"model"`
+	Color         string     `db:"color"`
+	ArchivedAt    *time.Time `db:"archived_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+
+	Stickers []VehicleSticker `db:"-"`
+}
+
+// VehicleSticker carries the vehicle's plate number from a join so sticker
+// lists can be read without loading each vehicle.
+type VehicleSticker struct {
+	ID              uuid.UUID  `db:"id"`
+	AssociationID   uuid.UUID  `db:"association_id"`
+	VehicleID       uuid.UUID  `db:"vehicle_id"`
+	PlateNumber     string     `db:"plate_number"`
+	StickerYear     int        `db:"sticker_year"`
+	StickerNumber   string     `db:"sticker_number"`
+	Status          string     `db:"status"`
+	StatusReason    *string    `db:"status_reason"`
+	IssuedBy        *uuid.UUID `db:"issued_by"`
+	IssuedAt        time.Time  `db:"issued_at"`
+	StatusChangedAt *time.Time `db:"status_changed_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Violation struct {
+	ID              uuid.UUID  `db:"id"`
+	AssociationID   uuid.UUID  `db:"association_id"`
+	ViolationNumber string     `db:"violation_number"`
+	PropertyID      uuid.UUID  `db:"property_id"`
+	Category        string     `db:"category"`
+	Description     string     `db:"description"`
+	ObservedAt      time.Time  `db:"observed_at"`
+	Status          string     `db:"status"`
+	ReportedBy      *uuid.UUID `db:"reported_by"`
+	ClosedAt        *time.Time `db:"closed_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+type ViolationAttachment struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ViolationID   uuid.UUID  `db:"violation_id"`
+	StorageKey    string     `db:"storage_key"`
+	FileName      string     `db:"file_name"`
+	ContentType   string     `db:"content_type"`
+	SizeBytes     int64      `db:"size_bytes"`
+	UploadedBy    *uuid.UUID `db:"uploaded_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+type ViolationNotice struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ViolationID   uuid.UUID  `db:"violation_id"`
+	Message       string     `db:"message"`
+	CureDeadline  time.Time  `db:"cure_deadline"`
+	IssuedBy      *uuid.UUID `db:"issued_by"`
+	IssuedAt      time.Time  `db:"issued_at"`
+}
+
+// ViolationFine carries the invoice number and status from a join.
+type ViolationFine struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ViolationID   uuid.UUID  `db:"violation_id"`
+	InvoiceID     uuid.UUID  `db:"invoice_id"`
+	InvoiceNumber string     `db:"invoice_number"`
+	InvoiceStatus string     `db:"invoice_status"`
+	AmountCents   int64      `db:"amount_cents"`
+	IssuedBy      *uuid.UUID `db:"issued_by"`
+	IssuedAt      time.Time  `db:"issued_at"`
+}
+
+type ViolationAppeal struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ViolationID   uuid.UUID  `db:"violation_id"`
+	SubmittedBy   uuid.UUID  `db:"submitted_by"`
+	Reason        string     `db:"reason"`
+	Status        string     `db:"status"`
+	DecisionNote  *string    `db:"decision_note"`
+	DecidedBy     *uuid.UUID `db:"decided_by"`
+	DecidedAt     *time.Time `db:"decided_at"`
+	SubmittedAt   time.Time  `db:"submitted_at"`
+}
+
+type ViolationEvent struct {
+	ID            uuid.UUID  `db:"id"`
+	AssociationID uuid.UUID  `db:"association_id"`
+	ViolationID   uuid.UUID  `db:"violation_id"`
+	EventType     string     `db:"event_type"`
+	Note          *string    `db:"note"`
+	ActorID       *uuid.UUID `db:"actor_id"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
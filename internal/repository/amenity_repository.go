@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+// the generated during column is left out; it only backs the overlap constraint
+const reservationColumns = `r.id, r.amenity_id, a.name AS amenity_name, r.property_id, r.user_id, r.start_at, r.end_at,
+    r.guest_count, r.status, r.fee_cents, r.notes, r.decision_reason, r.decided_by, r.decided_at,
+    r.cancel_reason, r.cancelled_at, r.created_at, r.updated_at`
+
+type ReservationFilter struct {
+	AmenityID  *uuid.UUID
+	UserID     *uuid.UUID
+	PropertyID *uuid.UUID
+	Status     string
+	// ActiveOnly keeps only reservations that still hold their time slot.
+	ActiveOnly bool
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AmenityRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewAmenityRepository(db *TenantDB) AmenityRepository {
+	return &AmenityRepositoryImpl{db: db}
+}
+
+func (repo *AmenityRepositoryImpl) CreateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	amenity.ID = uuid.New()
+	amenity.CreatedAt = time.Now()
+	amenity.UpdatedAt = amenity.CreatedAt
+
+	query := `INSERT INTO amenities (id, name, description, capacity, opens_at, closes_at, slot_minutes, fee_cents, requires_approval, cancellation_hours, active, created_at, updated_at)
+    VALUES (:id, :name, :description, :capacity, :opens_at, :closes_at, :slot_minutes, :fee_cents, :requires_approval, :cancellation_hours, :active, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, amenity); err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to insert amenity: %w", err)
+	}
+
+	return amenity, nil
+}
+
+func (repo *AmenityRepositoryImpl) GetAmenityByID(ctx context.Context, id uuid.UUID) (*model.Amenity, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var amenity model.Amenity
+	query := `SELECT * FROM amenities WHERE id = $1`
+	err := repo.db.GetContext(ctx, &amenity, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get amenity by id: %w", err)
+	}
+
+	return &amenity, nil
+}
+
+func (repo *AmenityRepositoryImpl) ListAmenities(ctx context.Context, includeInactive bool) ([]model.Amenity, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `SELECT * FROM amenities`
+	if !includeInactive {
+		query += " WHERE active"
+	}
+	query += " ORDER BY name"
+
+	amenities := []model.Amenity{}
+	if err := repo.db.SelectContext(ctx, &amenities, query); err != nil {
+		return nil, fmt.Errorf("failed to list amenities: %w", err)
+	}
+
+	return amenities, nil
+}
+
+func (repo *AmenityRepositoryImpl) UpdateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	amenity.UpdatedAt = time.Now()
+
+	query := `UPDATE amenities SET name = :name, description = :description, capacity = :capacity,
+        opens_at = :opens_at, closes_at = :closes_at, slot_minutes = :slot_minutes, fee_cents = :fee_cents,
+        requires_approval = :requires_approval, cancellation_hours = :cancellation_hours, active = :active,
+        updated_at = :updated_at
+    WHERE id = :id`
+	result, err := repo.db.NamedExecContext(ctx, query, amenity)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to update amenity: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update amenity: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return amenity, nil
+}
+
+// CreateReservation relies on the amenity_reservations_no_overlap exclusion
+// constraint, so two members racing for the same slot cannot both win.
+func (repo *AmenityRepositoryImpl) CreateReservation(ctx context.Context, reservation *model.AmenityReservation) (*model.AmenityReservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	reservation.ID = uuid.New()
+	reservation.CreatedAt = time.Now()
+	reservation.UpdatedAt = reservation.CreatedAt
+
+	query := `INSERT INTO amenity_reservations (id, amenity_id, property_id, user_id, start_at, end_at, guest_count, status, fee_cents, notes, created_at, updated_at)
+    VALUES (:id, :amenity_id, :property_id, :user_id, :start_at, :end_at, :guest_count, :status, :fee_cents, :notes, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, reservation); err != nil {
+		if isExclusionViolation(err) {
+			return nil, constants.ErrTimeSlotTaken
+		}
+		return nil, fmt.Errorf("failed to insert reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (repo *AmenityRepositoryImpl) GetReservationByID(ctx context.Context, id uuid.UUID) (*model.AmenityReservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var reservation model.AmenityReservation
+	query := `SELECT ` + reservationColumns + `
+    FROM amenity_reservations r
+    JOIN amenities a ON a.id = r.amenity_id
+    WHERE r.id = $1`
+	err := repo.db.GetContext(ctx, &reservation, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
+	}
+
+	return &reservation, nil
+}
+
+func (repo *AmenityRepositoryImpl) ListReservations(ctx context.Context, filter ReservationFilter) ([]model.AmenityReservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.AmenityID != nil {
+		args = append(args, *filter.AmenityID)
+		conditions = append(conditions, fmt.Sprintf("r.amenity_id = $%d", len(args)))
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("r.user_id = $%d", len(args)))
+	}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("r.property_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("r.status = $%d", len(args)))
+	}
+	if filter.ActiveOnly {
+		conditions = append(conditions, "r.status IN ('pending', 'approved')")
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("r.end_at > $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("r.start_at < $%d", len(args)))
+	}
+
+	query := `SELECT ` + reservationColumns + `
+    FROM amenity_reservations r
+    JOIN amenities a ON a.id = r.amenity_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY r.start_at"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	reservations := []model.AmenityReservation{}
+	if err := repo.db.SelectContext(ctx, &reservations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// DecideReservation approves or rejects a pending reservation. It returns
+// constants.ErrRecordNotFound when the reservation is no longer pending.
+func (repo *AmenityRepositoryImpl) DecideReservation(ctx context.Context, id uuid.UUID, status string, reason *string, decidedBy uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE amenity_reservations SET status = $2, decision_reason = $3, decided_by = $4, decided_at = now(), updated_at = now()
+    WHERE id = $1 AND status = 'pending'`
+	result, err := repo.db.ExecContext(ctx, query, id, status, reason, decidedBy)
+	if err != nil {
+		return fmt.Errorf("failed to decide reservation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to decide reservation: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CancelReservation returns constants.ErrRecordNotFound when the reservation
+// was already rejected or cancelled.
+func (repo *AmenityRepositoryImpl) CancelReservation(ctx context.Context, id uuid.UUID, reason *string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE amenity_reservations SET status = 'cancelled', cancel_reason = $2, cancelled_at = now(), updated_at = now()
+    WHERE id = $1 AND status IN ('pending', 'approved')`
+	result, err := repo.db.ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
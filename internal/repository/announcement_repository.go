@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+// announcementVisibleTo matches announcements that are live and addressed to
+// the user in $1: everyone, a role the user holds, or the phase or block of a
+// property the user currently occupies.
+const announcementVisibleTo = `a.publish_at <= now() AND (a.expire_at IS NULL OR a.expire_at > now())
+    AND (
+        a.audience_type = 'all'
+        OR (a.audience_type = 'role' AND EXISTS (
+            SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+            WHERE ur.user_id = $1 AND r.name = a.audience_role))
+        OR (a.audience_type IN ('phase', 'block') AND EXISTS (
+            SELECT 1 FROM property_occupancies o JOIN properties p ON p.id = o.property_id
+            WHERE o.user_id = $1
+                AND o.start_date <= CURRENT_DATE AND (o.end_date IS NULL OR o.end_date > CURRENT_DATE)
+                AND p.archived_at IS NULL
+                AND p.phase = a.audience_phase
+                AND (a.audience_type = 'phase' OR p.block = a.audience_block)))
+    )`
+
+type AnnouncementFilter struct {
+	IncludeExpired bool
+	Limit          int
+	Offset         int
+}
+
+type AnnouncementRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewAnnouncementRepository(db *TenantDB) AnnouncementRepository {
+	return &AnnouncementRepositoryImpl{db: db}
+}
+
+func (repo *AnnouncementRepositoryImpl) CreateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	announcement.ID = uuid.New()
+	announcement.CreatedAt = time.Now()
+	announcement.UpdatedAt = announcement.CreatedAt
+
+	query := `INSERT INTO announcements (id, title, body, audience_type, audience_phase, audience_block, audience_role, pinned, publish_at, expire_at, created_by, created_at, updated_at)
+    VALUES (:id, :title, :body, :audience_type, :audience_phase, :audience_block, :audience_role, :pinned, :publish_at, :expire_at, :created_by, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, announcement); err != nil {
+		return nil, fmt.Errorf("failed to insert announcement: %w", err)
+	}
+
+	return announcement, nil
+}
+
+func (repo *AnnouncementRepositoryImpl) GetAnnouncementByID(ctx context.Context, id uuid.UUID) (*model.Announcement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var announcement model.Announcement
+	query := `SELECT * FROM announcements WHERE id = $1`
+	err := repo.db.GetContext(ctx, &announcement, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get announcement by id: %w", err)
+	}
+
+	return &announcement, nil
+}
+
+func (repo *AnnouncementRepositoryImpl) ListAnnouncements(ctx context.Context, filter AnnouncementFilter) ([]model.Announcement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	args := []interface{}{}
+	query := `SELECT * FROM announcements`
+	if !filter.IncludeExpired {
+		query += " WHERE expire_at IS NULL OR expire_at > now()"
+	}
+	query += " ORDER BY pinned DESC, publish_at DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	announcements := []model.Announcement{}
+	if err := repo.db.SelectContext(ctx, &announcements, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+func (repo *AnnouncementRepositoryImpl) UpdateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	announcement.UpdatedAt = time.Now()
+
+	query := `UPDATE announcements SET title = :title, body = :body, audience_type = :audience_type,
+        audience_phase = :audience_phase, audience_block = :audience_block, audience_role = :audience_role,
+        pinned = :pinned, publish_at = :publish_at, expire_at = :expire_at, updated_at = :updated_at
+    WHERE id = :id`
+	result, err := repo.db.NamedExecContext(ctx, query, announcement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return announcement, nil
+}
+
+func (repo *AnnouncementRepositoryImpl) DeleteAnnouncement(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	result, err := repo.db.ExecContext(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *AnnouncementRepositoryImpl) ListFeed(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]model.FeedAnnouncement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	args := []interface{}{userID}
+	query := `SELECT a.*, ar.read_at
+    FROM announcements a
+    LEFT JOIN announcement_reads ar ON ar.announcement_id = a.id AND ar.user_id = $1
+    WHERE ` + announcementVisibleTo
+	if unreadOnly {
+		query += " AND ar.read_at IS NULL"
+	}
+	query += " ORDER BY a.pinned DESC, a.publish_at DESC"
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if offset > 0 {
+		args = append(args, offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	announcements := []model.FeedAnnouncement{}
+	if err := repo.db.SelectContext(ctx, &announcements, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list announcement feed: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// GetFeedAnnouncement returns constants.ErrRecordNotFound both for unknown
+// announcements and for ones the user is not allowed to see.
+func (repo *AnnouncementRepositoryImpl) GetFeedAnnouncement(ctx context.Context, userID, id uuid.UUID) (*model.FeedAnnouncement, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var announcement model.FeedAnnouncement
+	query := `SELECT a.*, ar.read_at
+    FROM announcements a
+    LEFT JOIN announcement_reads ar ON ar.announcement_id = a.id AND ar.user_id = $1
+    WHERE a.id = $2 AND ` + announcementVisibleTo
+	err := repo.db.GetContext(ctx, &announcement, query, userID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+
+	return &announcement, nil
+}
+
+func (repo *AnnouncementRepositoryImpl) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var count int
+	query := `SELECT COUNT(*)
+    FROM announcements a
+    LEFT JOIN announcement_reads ar ON ar.announcement_id = a.id AND ar.user_id = $1
+    WHERE ar.read_at IS NULL AND ` + announcementVisibleTo
+	if err := repo.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread announcements: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead keeps the first read time when an announcement is opened again.
+func (repo *AnnouncementRepositoryImpl) MarkRead(ctx context.Context, announcementID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO announcement_reads (announcement_id, user_id, read_at) VALUES ($1, $2, now())
+    ON CONFLICT (announcement_id, user_id) DO NOTHING`
+	if _, err := repo.db.ExecContext(ctx, query, announcementID, userID); err != nil {
+		return fmt.Errorf("failed to mark announcement as read: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *AnnouncementRepositoryImpl) ListReadReceipts(ctx context.Context, announcementID uuid.UUID) ([]model.AnnouncementRead, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	reads := []model.AnnouncementRead{}
+	query := `SELECT ar.user_id, u.first_name, u.last_name, u.email, ar.read_at
+    FROM announcement_reads ar
+    JOIN users u ON u.id = ar.user_id
+    WHERE ar.announcement_id = $1
+    ORDER BY ar.read_at`
+	if err := repo.db.SelectContext(ctx, &reads, query, announcementID); err != nil {
+		return nil, fmt.Errorf("failed to list read receipts: %w", err)
+	}
+
+	return reads, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+// AssociationRepositoryImpl reads the tenant registry itself, which is not
+// row-level secured, so it works before a request is scoped.
+type AssociationRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewAssociationRepository(db *TenantDB) AssociationRepository {
+	return &AssociationRepositoryImpl{db: db}
+}
+
+func (repo *AssociationRepositoryImpl) SaveAssociation(ctx context.Context, association *model.Association) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	association.ID = uuid.New()
+	association.CreatedAt = now
+	association.UpdatedAt = now
+
+	query := `INSERT INTO associations (id, slug, name, timezone, created_at, updated_at)
+    VALUES (:id, :slug, :name, :timezone, :created_at, :updated_at)
+    ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, timezone = EXCLUDED.timezone, updated_at = EXCLUDED.updated_at`
+	if _, err := repo.db.NamedExecContext(ctx, query, association); err != nil {
+		return fmt.Errorf("failed to save association: %w", err)
+	}
+
+	saved, err := repo.GetAssociationBySlug(ctx, association.Slug)
+	if err != nil {
+		return err
+	}
+	*association = *saved
+
+	return nil
+}
+
+func (repo *AssociationRepositoryImpl) GetAssociationByID(ctx context.Context, id uuid.UUID) (*model.Association, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var association model.Association
+	query := `SELECT * FROM associations WHERE id = $1`
+	err := repo.db.GetContext(ctx, &association, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get association by id: %w", err)
+	}
+
+	return &association, nil
+}
+
+func (repo *AssociationRepositoryImpl) GetAssociationBySlug(ctx context.Context, slug string) (*model.Association, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var association model.Association
+	query := `SELECT * FROM associations WHERE slug = $1`
+	err := repo.db.GetContext(ctx, &association, query, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get association by slug: %w", err)
+	}
+
+	return &association, nil
+}
+
+func (repo *AssociationRepositoryImpl) ListAssociations(ctx context.Context) ([]model.Association, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	associations := []model.Association{}
+	query := `SELECT * FROM associations ORDER BY name`
+	if err := repo.db.SelectContext(ctx, &associations, query); err != nil {
+		return nil, fmt.Errorf("failed to list associations: %w", err)
+	}
+
+	return associations, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type BillingRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewBillingRepository(db *TenantDB) BillingRepository {
+	return &BillingRepositoryImpl{db: db}
+}
+
+func (repo *BillingRepositoryImpl) CreateDuesSchedule(ctx context.Context, schedule *model.DuesSchedule) (*model.DuesSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	schedule.ID = uuid.New()
+	schedule.CreatedAt = time.Now()
+	schedule.UpdatedAt = schedule.CreatedAt
+
+	query := `INSERT INTO dues_schedules (id, name, property_type, phase, rate_type, amount_cents, effective_from, effective_to, created_at, updated_at)
+    VALUES (:id, :name, :property_type, :phase, :rate_type, :amount_cents, :effective_from, :effective_to, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, schedule); err != nil {
+		return nil, fmt.Errorf("failed to insert dues schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (repo *BillingRepositoryImpl) ListDuesSchedules(ctx context.Context) ([]model.DuesSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	schedules := []model.DuesSchedule{}
+	query := `SELECT * FROM dues_schedules ORDER BY effective_from DESC, name`
+	if err := repo.db.SelectContext(ctx, &schedules, query); err != nil {
+		return nil, fmt.Errorf("failed to list dues schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+func (repo *BillingRepositoryImpl) ListDuesSchedulesEffectiveOn(ctx context.Context, date time.Time) ([]model.DuesSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	schedules := []model.DuesSchedule{}
+	query := `SELECT * FROM dues_schedules
+    WHERE effective_from <= $1 AND (effective_to IS NULL OR effective_to >= $1)
+    ORDER BY effective_from DESC`
+	if err := repo.db.SelectContext(ctx, &schedules, query, date); err != nil {
+		return nil, fmt.Errorf("failed to list effective dues schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// ListBilledPropertyIDs returns the properties with a dues invoice for the
+// period. Voided invoices only count when includeVoided is set.
+func (repo *BillingRepositoryImpl) ListBilledPropertyIDs(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	ids := []uuid.UUID{}
+	query := `SELECT DISTINCT property_id FROM invoices
+    WHERE billing_period = $1 AND kind = 'dues' AND ($2 OR status <> 'void')`
+	if err := repo.db.SelectContext(ctx, &ids, query, period, includeVoided); err != nil {
+		return nil, fmt.Errorf("failed to list billed properties: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (repo *BillingRepositoryImpl) ListBillingRuns(ctx context.Context) ([]model.BillingRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	runs := []model.BillingRun{}
+	query := `SELECT * FROM billing_runs ORDER BY created_at DESC`
+	if err := repo.db.SelectContext(ctx, &runs, query); err != nil {
+		return nil, fmt.Errorf("failed to list billing runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// CreateBillingRun stores the run and all of its invoices atomically. A
+// property that was billed for the period by a concurrent run makes the
+// whole run fail with constants.ErrRecordExists.
+func (repo *BillingRepositoryImpl) CreateBillingRun(ctx context.Context, run *model.BillingRun, invoices []model.Invoice) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout*6)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on create billing run: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	run.ID = uuid.New()
+	run.CreatedAt = time.Now()
+
+	query := `INSERT INTO billing_runs (id, billing_period, invoice_count, total_cents, run_by, created_at)
+    VALUES (:id, :billing_period, :invoice_count, :total_cents, :run_by, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, run); err != nil {
+		return fmt.Errorf("failed to insert billing run: %w", err)
+	}
+
+	for i := range invoices {
+		invoices[i].BillingRunID = &run.ID
+		if err := insertInvoice(ctx, tx, &invoices[i]); err != nil {
+			return err
+		}
+	}
+
+	// account credit from earlier overpayments settles the new invoices
+	creditIDs := []uuid.UUID{}
+	query = `SELECT DISTINCT property_id FROM payments WHERE unallocated_cents > 0`
+	if err := tx.SelectContext(ctx, &creditIDs, query); err != nil {
+		return fmt.Errorf("failed to list properties with credit: %w", err)
+	}
+	for _, propertyID := range creditIDs {
+		if err := allocateCredit(ctx, tx, propertyID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertInvoice(ctx context.Context, tx *sqlx.Tx, invoice *model.Invoice) error {
+	number, err := nextSequenceValue(ctx, tx, constants.SequenceInvoice)
+	if err != nil {
+		return err
+	}
+
+	invoice.ID = uuid.New()
+	invoice.InvoiceNumber = fmt.Sprintf("INV-%s-%06d", invoice.BillingPeriod.Format("200601"), number)
+	invoice.CreatedAt = time.Now()
+	invoice.UpdatedAt = invoice.CreatedAt
+	if invoice.Status == "" {
+		invoice.Status = constants.InvoiceStatusOpen
+	}
+	if invoice.Kind == "" {
+		invoice.Kind = constants.InvoiceKindDues
+	}
+
+	query := `INSERT INTO invoices (id, invoice_number, property_id, billing_run_id, kind, billing_period, issue_date, due_date, status, total_cents, paid_cents, created_at, updated_at)
+    VALUES (:id, :invoice_number, :property_id, :billing_run_id, :kind, :billing_period, :issue_date, :due_date, :status, :total_cents, :paid_cents, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, invoice); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert invoice: %w", err)
+	}
+
+	for i := range invoice.LineItems {
+		item := &invoice.LineItems[i]
+		item.ID = uuid.New()
+		item.InvoiceID = invoice.ID
+		item.CreatedAt = invoice.CreatedAt
+
+		query := `INSERT INTO invoice_line_items (id, invoice_id, dues_schedule_id, description, quantity, unit_amount_cents, amount_cents, created_at)
+        VALUES (:id, :invoice_id, :dues_schedule_id, :description, :quantity, :unit_amount_cents, :amount_cents, :created_at)`
+		if _, err := tx.NamedExecContext(ctx, query, item); err != nil {
+			return fmt.Errorf("failed to insert invoice line item: %w", err)
+		}
+	}
+
+	return nil
+}
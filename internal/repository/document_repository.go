@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// DocumentFilter narrows the library. VisibleTo hides documents restricted
+// to a role that user does not hold; nil shows every document.
+type DocumentFilter struct {
+	Title           string
+	Category        string
+	VisibleTo       *uuid.UUID
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
+
+type DocumentRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewDocumentRepository(db *TenantDB) DocumentRepository {
+	return &DocumentRepositoryImpl{db: db}
+}
+
+// CreateDocument stores the document with its first version. The caller
+// sets the version ID, which is already part of the file's storage key.
+func (repo *DocumentRepositoryImpl) CreateDocument(ctx context.Context, document *model.Document, version *model.DocumentVersion) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on create document: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	document.CurrentVersion = 1
+	document.CreatedAt = time.Now()
+	document.UpdatedAt = document.CreatedAt
+
+	query := `INSERT INTO documents (id, title, description, category, visible_role, current_version, created_by, created_at, updated_at)
+    VALUES (:id, :title, :description, :category, :visible_role, :current_version, :created_by, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, document); err != nil {
+		return fmt.Errorf("failed to insert document: %w", err)
+	}
+
+	version.DocumentID = document.ID
+	version.VersionNumber = document.CurrentVersion
+	version.CreatedAt = document.CreatedAt
+	if err := insertDocumentVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *DocumentRepositoryImpl) GetDocumentByID(ctx context.Context, id uuid.UUID) (*model.Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var document model.Document
+	query := `SELECT * FROM documents WHERE id = $1`
+	err := repo.db.GetContext(ctx, &document, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get document by id: %w", err)
+	}
+
+	return &document, nil
+}
+
+func (repo *DocumentRepositoryImpl) ListDocuments(ctx context.Context, filter DocumentFilter) ([]model.Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "d.archived_at IS NULL")
+	}
+	if filter.Title != "" {
+		args = append(args, "%"+escapeLike(filter.Title)+"%")
+		conditions = append(conditions, fmt.Sprintf("d.title ILIKE $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("d.category = $%d", len(args)))
+	}
+	if filter.VisibleTo != nil {
+		args = append(args, *filter.VisibleTo)
+		conditions = append(conditions, fmt.Sprintf(`(d.visible_role IS NULL OR EXISTS (
+            SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+            WHERE ur.user_id = $%d AND r.name = d.visible_role))`, len(args)))
+	}
+
+	query := `SELECT d.* FROM documents d`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY d.updated_at DESC, d.title"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	documents := []model.Document{}
+	if err := repo.db.SelectContext(ctx, &documents, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+func (repo *DocumentRepositoryImpl) UpdateDocument(ctx context.Context, document *model.Document) (*model.Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	document.UpdatedAt = time.Now()
+
+	query := `UPDATE documents
+    SET title = :title, description = :description, category = :category, visible_role = :visible_role, updated_at = :updated_at
+    WHERE id = :id AND archived_at IS NULL`
+	result, err := repo.db.NamedExecContext(ctx, query, document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return document, nil
+}
+
+func (repo *DocumentRepositoryImpl) ArchiveDocument(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE documents SET archived_at = now(), updated_at = now() WHERE id = $1 AND archived_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to archive document: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AddDocumentVersion stores a new version and makes it the document's
+// current one. The document row is locked so concurrent uploads get
+// consecutive version numbers.
+func (repo *DocumentRepositoryImpl) AddDocumentVersion(ctx context.Context, version *model.DocumentVersion) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on add document version: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var current int
+	query := `SELECT current_version FROM documents WHERE id = $1 AND archived_at IS NULL FOR UPDATE`
+	if err := tx.GetContext(ctx, &current, query, version.DocumentID); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock document: %w", err)
+	}
+
+	version.VersionNumber = current + 1
+	version.CreatedAt = time.Now()
+	if err := insertDocumentVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	query = `UPDATE documents SET current_version = $2, updated_at = now() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, version.DocumentID, version.VersionNumber); err != nil {
+		return fmt.Errorf("failed to update document version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *DocumentRepositoryImpl) GetDocumentVersion(ctx context.Context, documentID uuid.UUID, versionNumber int) (*model.DocumentVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var version model.DocumentVersion
+	query := `SELECT * FROM document_versions WHERE document_id = $1 AND version_number = $2`
+	err := repo.db.GetContext(ctx, &version, query, documentID, versionNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get document version: %w", err)
+	}
+
+	return &version, nil
+}
+
+func (repo *DocumentRepositoryImpl) ListDocumentVersions(ctx context.Context, documentID uuid.UUID) ([]model.DocumentVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	versions := []model.DocumentVersion{}
+	query := `SELECT * FROM document_versions WHERE document_id = $1 ORDER BY version_number DESC`
+	if err := repo.db.SelectContext(ctx, &versions, query, documentID); err != nil {
+		return nil, fmt.Errorf("failed to list document versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func insertDocumentVersion(ctx context.Context, tx *sqlx.Tx, version *model.DocumentVersion) error {
+	query := `INSERT INTO document_versions (id, document_id, version_number, storage_key, file_name, content_type, size_bytes, notes, uploaded_by, created_at)
+    VALUES (:id, :document_id, :version_number, :storage_key, :file_name, :content_type, :size_bytes, :notes, :uploaded_by, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, version); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert document version: %w", err)
+	}
+
+	return nil
+}
+
+// escapeLike makes the LIKE wildcards in a search term match literally.
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
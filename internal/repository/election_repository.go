@@ -0,0 +1,594 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/lib/pq"
+)
+
+type ElectionFilter struct {
+	IncludeDrafts bool
+	Limit         int
+	Offset        int
+}
+
+type ElectionRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewElectionRepository(db *TenantDB) ElectionRepository {
+	return &ElectionRepositoryImpl{db: db}
+}
+
+func (repo *ElectionRepositoryImpl) CreateElection(ctx context.Context, election *model.Election) (*model.Election, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	election.ID = uuid.New()
+	election.Status = constants.ElectionDraft
+	election.CreatedAt = time.Now()
+	election.UpdatedAt = election.CreatedAt
+
+	query := `INSERT INTO elections (id, title, description, voting_starts_at, voting_ends_at, quorum_percent, status, created_by, created_at, updated_at)
+    VALUES (:id, :title, :description, :voting_starts_at, :voting_ends_at, :quorum_percent, :status, :created_by, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, election); err != nil {
+		return nil, fmt.Errorf("failed to insert election: %w", err)
+	}
+
+	return election, nil
+}
+
+func (repo *ElectionRepositoryImpl) GetElectionByID(ctx context.Context, id uuid.UUID) (*model.Election, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var election model.Election
+	query := `SELECT * FROM elections WHERE id = $1`
+	err := repo.db.GetContext(ctx, &election, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get election by id: %w", err)
+	}
+
+	return &election, nil
+}
+
+func (repo *ElectionRepositoryImpl) ListElections(ctx context.Context, filter ElectionFilter) ([]model.Election, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if !filter.IncludeDrafts {
+		args = append(args, constants.ElectionDraft)
+		conditions = append(conditions, fmt.Sprintf("status <> $%d", len(args)))
+	}
+
+	query := `SELECT * FROM elections`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY voting_starts_at DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	elections := []model.Election{}
+	if err := repo.db.SelectContext(ctx, &elections, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list elections: %w", err)
+	}
+
+	return elections, nil
+}
+
+// UpdateElection only changes drafts; once scheduled the ballot is fixed.
+func (repo *ElectionRepositoryImpl) UpdateElection(ctx context.Context, election *model.Election) (*model.Election, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	election.UpdatedAt = time.Now()
+
+	query := `UPDATE elections
+    SET title = :title, description = :description, voting_starts_at = :voting_starts_at,
+        voting_ends_at = :voting_ends_at, quorum_percent = :quorum_percent, updated_at = :updated_at
+    WHERE id = :id AND status = 'draft'`
+	result, err := repo.db.NamedExecContext(ctx, query, election)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update election: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update election: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return election, nil
+}
+
+// ChangeElectionStatus moves the election from one status to another. It
+// returns constants.ErrRecordNotFound when the election is no longer in the
+// from status.
+func (repo *ElectionRepositoryImpl) ChangeElectionStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE elections SET status = $3, updated_at = now() WHERE id = $1 AND status = $2`
+	result, err := repo.db.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to change election status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to change election status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// PublishElectionResults publishes a scheduled election whose voting window
+// has closed, freezing its quorum base.
+func (repo *ElectionRepositoryImpl) PublishElectionResults(ctx context.Context, id uuid.UUID, eligibleProperties int) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE elections
+    SET status = 'published', eligible_properties = $2, results_published_at = now(), updated_at = now()
+    WHERE id = $1 AND status = 'scheduled' AND voting_ends_at <= now()`
+	result, err := repo.db.ExecContext(ctx, query, id, eligibleProperties)
+	if err != nil {
+		return fmt.Errorf("failed to publish election results: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to publish election results: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *ElectionRepositoryImpl) CreatePosition(ctx context.Context, position *model.ElectionPosition) (*model.ElectionPosition, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	position.ID = uuid.New()
+	position.CreatedAt = time.Now()
+
+	query := `INSERT INTO election_positions (id, election_id, title, seats, sort_order, created_at)
+    VALUES (:id, :election_id, :title, :seats, :sort_order, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, position); err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to insert election position: %w", err)
+	}
+
+	return position, nil
+}
+
+func (repo *ElectionRepositoryImpl) ListPositions(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	positions := []model.ElectionPosition{}
+	query := `SELECT * FROM election_positions WHERE election_id = $1 ORDER BY sort_order, created_at`
+	if err := repo.db.SelectContext(ctx, &positions, query, electionID); err != nil {
+		return nil, fmt.Errorf("failed to list election positions: %w", err)
+	}
+
+	return positions, nil
+}
+
+func (repo *ElectionRepositoryImpl) DeletePosition(ctx context.Context, electionID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM election_positions WHERE id = $1 AND election_id = $2`
+	result, err := repo.db.ExecContext(ctx, query, id, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete election position: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete election position: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *ElectionRepositoryImpl) CreateCandidate(ctx context.Context, candidate *model.ElectionCandidate) (*model.ElectionCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	candidate.ID = uuid.New()
+	candidate.CreatedAt = time.Now()
+
+	query := `INSERT INTO election_candidates (id, election_id, position_id, user_id, name, bio, created_at)
+    VALUES (:id, :election_id, :position_id, :user_id, :name, :bio, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, candidate); err != nil {
+		return nil, fmt.Errorf("failed to insert election candidate: %w", err)
+	}
+
+	return candidate, nil
+}
+
+func (repo *ElectionRepositoryImpl) ListCandidates(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	candidates := []model.ElectionCandidate{}
+	query := `SELECT * FROM election_candidates WHERE election_id = $1 ORDER BY name`
+	if err := repo.db.SelectContext(ctx, &candidates, query, electionID); err != nil {
+		return nil, fmt.Errorf("failed to list election candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+func (repo *ElectionRepositoryImpl) DeleteCandidate(ctx context.Context, electionID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM election_candidates WHERE id = $1 AND election_id = $2`
+	result, err := repo.db.ExecContext(ctx, query, id, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete election candidate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete election candidate: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CastBallot records that the property voted and queues its choices, in
+// one transaction. A second ballot for the property returns
+// constants.ErrRecordExists.
+//
+// Choices written with the ballot would share its transaction id and sit
+// next to it on disk, which links them back to the property. They are
+// queued instead and moved to election_votes in shuffled batches of
+// constants.VoteMixBatch ballots, so a queued choice is only traceable
+// until its batch fills.
+func (repo *ElectionRepositoryImpl) CastBallot(ctx context.Context, ballot *model.ElectionBallot, votes []model.ElectionVote) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on cast ballot: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	ballot.ID = uuid.New()
+	ballot.CastAt = time.Now()
+
+	query := `INSERT INTO election_ballots (id, election_id, property_id, cast_by, proxy_id, cast_at)
+    VALUES (:id, :election_id, :property_id, :cast_by, :proxy_id, :cast_at)`
+	if _, err := tx.NamedExecContext(ctx, query, ballot); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert ballot: %w", err)
+	}
+
+	candidateIDs := make([]string, 0, len(votes))
+	for _, vote := range votes {
+		candidateIDs = append(candidateIDs, vote.CandidateID.String())
+	}
+	query = `INSERT INTO election_vote_queue (election_id, candidate_ids) VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, query, ballot.ElectionID, pq.Array(candidateIDs)); err != nil {
+		return fmt.Errorf("failed to queue votes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// the ballot is recorded; a failed mix leaves the choices queued for the
+	// next one
+	if err := repo.mixQueuedVotes(ctx, ballot.ElectionID, false); err != nil {
+		log.Printf("failed to mix queued votes: %v\n", err)
+	}
+
+	return nil
+}
+
+// mixQueuedVotes moves queued choices into election_votes in random order,
+// in a transaction of its own. Unless final is set it waits for a full
+// batch. The final mix, once voting has closed, also reshuffles the votes
+// already moved, so all of them share one transaction.
+func (repo *ElectionRepositoryImpl) mixQueuedVotes(ctx context.Context, electionID uuid.UUID, final bool) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on mix votes: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var queued int
+	query := `SELECT count(*) FROM election_vote_queue WHERE election_id = $1`
+	if err := tx.GetContext(ctx, &queued, query, electionID); err != nil {
+		return fmt.Errorf("failed to count queued votes: %w", err)
+	}
+	if queued == 0 || (!final && queued < constants.VoteMixBatch) {
+		return nil
+	}
+
+	query = `WITH queued AS (
+        DELETE FROM election_vote_queue WHERE election_id = $1 RETURNING candidate_ids
+    ), moved AS (
+        DELETE FROM election_votes WHERE election_id = $1 AND $2 RETURNING position_id, candidate_id
+    )
+    INSERT INTO election_votes (election_id, position_id, candidate_id)
+    SELECT $1, position_id, candidate_id FROM (
+        SELECT position_id, candidate_id FROM moved
+        UNION ALL
+        SELECT c.position_id, c.id FROM queued q
+        CROSS JOIN LATERAL unnest(q.candidate_ids) AS v(candidate_id)
+        JOIN election_candidates c ON c.id = v.candidate_id
+    ) AS votes
+    ORDER BY random()`
+	if _, err := tx.ExecContext(ctx, query, electionID, final); err != nil {
+		return fmt.Errorf("failed to mix votes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ElectionRepositoryImpl) GetBallotByProperty(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionBallot, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var ballot model.ElectionBallot
+	query := `SELECT * FROM election_ballots WHERE election_id = $1 AND property_id = $2`
+	err := repo.db.GetContext(ctx, &ballot, query, electionID, propertyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get ballot: %w", err)
+	}
+
+	return &ballot, nil
+}
+
+// GetQuorum counts the ballots cast against the properties entitled to
+// vote as of asOf: active properties with a current owner, co-owner or
+// representative that are in good standing. A property that already voted
+// stays counted even if it has fallen behind on dues since.
+func (repo *ElectionRepositoryImpl) GetQuorum(ctx context.Context, electionID uuid.UUID, asOf time.Time) (*model.ElectionQuorum, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var quorum model.ElectionQuorum
+	query := `SELECT
+        (SELECT count(*) FROM election_ballots WHERE election_id = $1) AS ballots_cast,
+        (SELECT count(*) FROM properties p
+            WHERE EXISTS (
+                SELECT 1 FROM election_ballots b WHERE b.election_id = $1 AND b.property_id = p.id
+            ) OR (
+                p.archived_at IS NULL
+                AND EXISTS (
+                    SELECT 1 FROM property_occupancies o
+                    WHERE o.property_id = p.id AND o.occupancy_type IN ('owner', 'co_owner', 'representative')
+                        AND o.start_date <= $2 AND (o.end_date IS NULL OR o.end_date > $2)
+                )
+                AND NOT EXISTS (
+                    SELECT 1 FROM invoices i
+                    WHERE i.property_id = p.id AND i.status IN ('open', 'partially_paid') AND i.due_date < $2
+                )
+            )
+        ) AS eligible_properties`
+	if err := repo.db.GetContext(ctx, &quorum, query, electionID, asOf); err != nil {
+		return nil, fmt.Errorf("failed to get election quorum: %w", err)
+	}
+
+	return &quorum, nil
+}
+
+// TallyVotes counts the votes of an election whose voting has closed,
+// first mixing any choices still queued.
+func (repo *ElectionRepositoryImpl) TallyVotes(ctx context.Context, electionID uuid.UUID) ([]model.ElectionTally, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	if err := repo.mixQueuedVotes(ctx, electionID, true); err != nil {
+		return nil, err
+	}
+
+	tallies := []model.ElectionTally{}
+	query := `SELECT candidate_id, count(*) AS votes FROM election_votes WHERE election_id = $1 GROUP BY candidate_id`
+	if err := repo.db.SelectContext(ctx, &tallies, query, electionID); err != nil {
+		return nil, fmt.Errorf("failed to tally votes: %w", err)
+	}
+
+	return tallies, nil
+}
+
+// CreateProxy stores a pending proxy. The caller sets the ID, which is
+// already part of the authorization's storage key.
+func (repo *ElectionRepositoryImpl) CreateProxy(ctx context.Context, proxy *model.ElectionProxy) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	proxy.Status = constants.ProxyPending
+	proxy.CreatedAt = time.Now()
+
+	query := `INSERT INTO election_proxies (id, election_id, property_id, granted_by, proxy_user_id, storage_key, file_name, content_type, size_bytes, status, created_at)
+    VALUES (:id, :election_id, :property_id, :granted_by, :proxy_user_id, :storage_key, :file_name, :content_type, :size_bytes, :status, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, proxy); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert election proxy: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ElectionRepositoryImpl) GetProxyByID(ctx context.Context, electionID, id uuid.UUID) (*model.ElectionProxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var proxy model.ElectionProxy
+	query := `SELECT * FROM election_proxies WHERE id = $1 AND election_id = $2`
+	err := repo.db.GetContext(ctx, &proxy, query, id, electionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get election proxy: %w", err)
+	}
+
+	return &proxy, nil
+}
+
+// GetApprovedProxy returns the proxy currently holding the property's vote.
+func (repo *ElectionRepositoryImpl) GetApprovedProxy(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionProxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var proxy model.ElectionProxy
+	query := `SELECT * FROM election_proxies WHERE election_id = $1 AND property_id = $2 AND status = 'approved'`
+	err := repo.db.GetContext(ctx, &proxy, query, electionID, propertyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get approved proxy: %w", err)
+	}
+
+	return &proxy, nil
+}
+
+func (repo *ElectionRepositoryImpl) ListProxies(ctx context.Context, electionID uuid.UUID, status string) ([]model.ElectionProxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	args := []interface{}{electionID}
+	query := `SELECT * FROM election_proxies WHERE election_id = $1`
+	if status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	query += " ORDER BY created_at"
+
+	proxies := []model.ElectionProxy{}
+	if err := repo.db.SelectContext(ctx, &proxies, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list election proxies: %w", err)
+	}
+
+	return proxies, nil
+}
+
+// ListUserProxies returns the proxies the user granted or holds.
+func (repo *ElectionRepositoryImpl) ListUserProxies(ctx context.Context, userID uuid.UUID) ([]model.ElectionProxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	proxies := []model.ElectionProxy{}
+	query := `SELECT * FROM election_proxies WHERE granted_by = $1 OR proxy_user_id = $1 ORDER BY created_at DESC`
+	if err := repo.db.SelectContext(ctx, &proxies, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list user proxies: %w", err)
+	}
+
+	return proxies, nil
+}
+
+// ReviewProxy approves or rejects a pending proxy.
+func (repo *ElectionRepositoryImpl) ReviewProxy(ctx context.Context, proxy *model.ElectionProxy) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	proxy.ReviewedAt = &now
+
+	query := `UPDATE election_proxies
+    SET status = :status, review_note = :review_note, reviewed_by = :reviewed_by, reviewed_at = :reviewed_at
+    WHERE id = :id AND status = 'pending'`
+	result, err := repo.db.NamedExecContext(ctx, query, proxy)
+	if err != nil {
+		return fmt.Errorf("failed to review election proxy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to review election proxy: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RevokeProxy withdraws a pending or approved proxy that has not voted yet.
+func (repo *ElectionRepositoryImpl) RevokeProxy(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE election_proxies SET status = 'revoked'
+    WHERE id = $1 AND status IN ('pending', 'approved')
+        AND NOT EXISTS (SELECT 1 FROM election_ballots WHERE proxy_id = $1)`
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke election proxy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke election proxy: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type EmailVerificationRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewEmailVerificationRepository(db *TenantDB) EmailVerificationRepository {
+	return &EmailVerificationRepositoryImpl{db: db}
+}
+
+// CreateEmailVerificationToken stores the token for a new or resent
+// confirmation email. A resend supersedes the links sent before it, so an old
+// email found later in an inbox can no longer confirm the account.
+func (repo *EmailVerificationRepositoryImpl) CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on create email verification token: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE email_verification_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, token.UserID); err != nil {
+		return fmt.Errorf("failed to retire email verification tokens: %w", err)
+	}
+
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+
+	query = `INSERT INTO email_verification_tokens (id, user_id, token_hash, expires_at, created_at)
+    VALUES (:id, :user_id, :token_hash, :expires_at, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("failed to insert email verification token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *EmailVerificationRepositoryImpl) GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (*model.EmailVerificationToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.EmailVerificationToken
+	query := `SELECT * FROM email_verification_tokens WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`
+	err := repo.db.GetContext(ctx, &token, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (repo *EmailVerificationRepositoryImpl) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.EmailVerificationToken
+	query := `SELECT * FROM email_verification_tokens WHERE token_hash = $1`
+	err := repo.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// VerifyEmail redeems the token and moves a pending registration to status
+// together. It returns constants.ErrInvalidToken when the token was already
+// used or has expired, or the account is no longer pending verification.
+func (repo *EmailVerificationRepositoryImpl) VerifyEmail(ctx context.Context, tokenID uuid.UUID, status string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on verify email: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var userID uuid.UUID
+	query := `UPDATE email_verification_tokens SET used_at = now()
+    WHERE id = $1 AND used_at IS NULL AND expires_at > now()
+    RETURNING user_id`
+	if err := tx.GetContext(ctx, &userID, query, tokenID); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to redeem email verification token: %w", err)
+	}
+
+	query = `UPDATE users SET status = $2, updated_at = now() WHERE id = $1 AND status = $3`
+	result, err := tx.ExecContext(ctx, query, userID, status, constants.PendingVerificationStatus)
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrInvalidToken
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+const eventColumns = `e.id, e.title, e.description, e.category, e.location, e.starts_at, e.ends_at, e.capacity,
+    e.rsvp_deadline, e.cancel_reason, e.cancelled_at, e.created_by, e.created_at, e.updated_at,
+    (SELECT COALESCE(SUM(r.headcount), 0) FROM event_rsvps r WHERE r.event_id = e.id AND r.status = 'going') AS headcount`
+
+type EventFilter struct {
+	// From and To keep events that overlap the range.
+	From             *time.Time
+	To               *time.Time
+	Category         string
+	IncludeCancelled bool
+	Limit            int
+	Offset           int
+}
+
+type EventRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewEventRepository(db *TenantDB) EventRepository {
+	return &EventRepositoryImpl{db: db}
+}
+
+func (repo *EventRepositoryImpl) CreateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	event.ID = uuid.New()
+	event.CreatedAt = time.Now()
+	event.UpdatedAt = event.CreatedAt
+
+	query := `INSERT INTO events (id, title, description, category, location, starts_at, ends_at, capacity, rsvp_deadline, created_by, created_at, updated_at)
+    VALUES (:id, :title, :description, :category, :location, :starts_at, :ends_at, :capacity, :rsvp_deadline, :created_by, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, event); err != nil {
+		return nil, fmt.Errorf("failed to insert event: %w", err)
+	}
+
+	return event, nil
+}
+
+func (repo *EventRepositoryImpl) GetEventByID(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var event model.Event
+	query := `SELECT ` + eventColumns + ` FROM events e WHERE e.id = $1`
+	err := repo.db.GetContext(ctx, &event, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get event by id: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (repo *EventRepositoryImpl) ListEvents(ctx context.Context, filter EventFilter) ([]model.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("e.ends_at > $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("e.starts_at < $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("e.category = $%d", len(args)))
+	}
+	if !filter.IncludeCancelled {
+		conditions = append(conditions, "e.cancelled_at IS NULL")
+	}
+
+	query := `SELECT ` + eventColumns + ` FROM events e`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY e.starts_at"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	events := []model.Event{}
+	if err := repo.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return events, nil
+}
+
+// UpdateEvent only changes events that have not been cancelled.
+func (repo *EventRepositoryImpl) UpdateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	event.UpdatedAt = time.Now()
+
+	query := `UPDATE events
+    SET title = :title, description = :description, category = :category, location = :location, starts_at = :starts_at,
+        ends_at = :ends_at, capacity = :capacity, rsvp_deadline = :rsvp_deadline, updated_at = :updated_at
+    WHERE id = :id AND cancelled_at IS NULL`
+	result, err := repo.db.NamedExecContext(ctx, query, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return event, nil
+}
+
+// CancelEvent returns constants.ErrRecordNotFound when the event was
+// already cancelled.
+func (repo *EventRepositoryImpl) CancelEvent(ctx context.Context, id uuid.UUID, reason *string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE events SET cancel_reason = $2, cancelled_at = now(), updated_at = now() WHERE id = $1 AND cancelled_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to cancel event: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to cancel event: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SaveRSVP creates or replaces the user's RSVP. The event row is locked so
+// concurrent RSVPs cannot push the headcount past capacity; the limit is
+// checked here and reported as constants.ErrEventFull.
+func (repo *EventRepositoryImpl) SaveRSVP(ctx context.Context, rsvp *model.EventRSVP) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on save rsvp: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var capacity *int
+	query := `SELECT capacity FROM events WHERE id = $1 AND cancelled_at IS NULL FOR UPDATE`
+	if err := tx.GetContext(ctx, &capacity, query, rsvp.EventID); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock event: %w", err)
+	}
+
+	if capacity != nil && rsvp.Headcount > 0 {
+		var others int
+		query = `SELECT COALESCE(SUM(headcount), 0) FROM event_rsvps WHERE event_id = $1 AND user_id <> $2 AND status = 'going'`
+		if err := tx.GetContext(ctx, &others, query, rsvp.EventID, rsvp.UserID); err != nil {
+			return fmt.Errorf("failed to count event headcount: %w", err)
+		}
+		if others+rsvp.Headcount > *capacity {
+			return constants.ErrEventFull
+		}
+	}
+
+	rsvp.ID = uuid.New()
+	rsvp.CreatedAt = time.Now()
+	rsvp.UpdatedAt = rsvp.CreatedAt
+
+	query = `INSERT INTO event_rsvps (id, event_id, user_id, status, headcount, created_at, updated_at)
+    VALUES (:id, :event_id, :user_id, :status, :headcount, :created_at, :updated_at)
+    ON CONFLICT (event_id, user_id) DO UPDATE
+    SET status = EXCLUDED.status, headcount = EXCLUDED.headcount, updated_at = EXCLUDED.updated_at`
+	if _, err := tx.NamedExecContext(ctx, query, rsvp); err != nil {
+		return fmt.Errorf("failed to save rsvp: %w", err)
+	}
+
+	// an existing RSVP keeps its id and creation time
+	query = `SELECT * FROM event_rsvps WHERE event_id = $1 AND user_id = $2`
+	if err := tx.GetContext(ctx, rsvp, query, rsvp.EventID, rsvp.UserID); err != nil {
+		return fmt.Errorf("failed to get saved rsvp: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *EventRepositoryImpl) GetRSVP(ctx context.Context, eventID, userID uuid.UUID) (*model.EventRSVP, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var rsvp model.EventRSVP
+	query := `SELECT * FROM event_rsvps WHERE event_id = $1 AND user_id = $2`
+	err := repo.db.GetContext(ctx, &rsvp, query, eventID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get rsvp: %w", err)
+	}
+
+	return &rsvp, nil
+}
+
+func (repo *EventRepositoryImpl) ListAttendees(ctx context.Context, eventID uuid.UUID) ([]model.EventAttendee, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	attendees := []model.EventAttendee{}
+	query := `SELECT r.*, u.first_name, u.last_name, u.email
+    FROM event_rsvps r
+    JOIN users u ON u.id = r.user_id
+    WHERE r.event_id = $1
+    ORDER BY r.status, u.last_name, u.first_name`
+	if err := repo.db.SelectContext(ctx, &attendees, query, eventID); err != nil {
+		return nil, fmt.Errorf("failed to list event attendees: %w", err)
+	}
+
+	return attendees, nil
+}
+
+// ReplaceCalendarToken revokes the user's current feed token, if any, and
+// stores the new one in its place.
+func (repo *EventRepositoryImpl) ReplaceCalendarToken(ctx context.Context, token *model.CalendarToken) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on replace calendar token: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE calendar_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, token.UserID); err != nil {
+		return fmt.Errorf("failed to revoke calendar token: %w", err)
+	}
+
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+
+	query = `INSERT INTO calendar_tokens (id, user_id, token_hash, created_at) VALUES (:id, :user_id, :token_hash, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, token); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert calendar token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeCalendarToken returns constants.ErrRecordNotFound when the user has
+// no active feed token.
+func (repo *EventRepositoryImpl) RevokeCalendarToken(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE calendar_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar token: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *EventRepositoryImpl) GetActiveCalendarToken(ctx context.Context, tokenHash string) (*model.CalendarToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.CalendarToken
+	query := `SELECT * FROM calendar_tokens WHERE token_hash = $1 AND revoked_at IS NULL`
+	err := repo.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+// GatePassFilter matches passes whose validity window overlaps [From, To).
+type GatePassFilter struct {
+	HostUserID     *uuid.UUID
+	PropertyID     *uuid.UUID
+	From           *time.Time
+	To             *time.Time
+	IncludeRevoked bool
+	Limit          int
+	Offset         int
+}
+
+type GateEntryFilter struct {
+	GatePassID   *uuid.UUID
+	PropertyID   *uuid.UUID
+	EntryType    string
+	VehiclePlate string
+	From         *time.Time
+	To           *time.Time
+	InsideOnly   bool
+	Limit        int
+	Offset       int
+}
+
+type GatePassRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewGatePassRepository(db *TenantDB) GatePassRepository {
+	return &GatePassRepositoryImpl{db: db}
+}
+
+func (repo *GatePassRepositoryImpl) CreateGatePass(ctx context.Context, pass *model.GatePass) (*model.GatePass, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	pass.ID = uuid.New()
+	pass.CreatedAt = time.Now()
+	pass.UpdatedAt = pass.CreatedAt
+
+	query := `INSERT INTO gate_passes (id, property_id, host_user_id, visitor_name, visitor_type, vehicle_plate, purpose, valid_from, valid_until, max_uses, created_at, updated_at)
+    VALUES (:id, :property_id, :host_user_id, :visitor_name, :visitor_type, :vehicle_plate, :purpose, :valid_from, :valid_until, :max_uses, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, pass); err != nil {
+		return nil, fmt.Errorf("failed to insert gate pass: %w", err)
+	}
+
+	return pass, nil
+}
+
+func (repo *GatePassRepositoryImpl) GetGatePassByID(ctx context.Context, id uuid.UUID) (*model.GatePass, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var pass model.GatePass
+	query := `SELECT * FROM gate_passes WHERE id = $1`
+	err := repo.db.GetContext(ctx, &pass, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get gate pass by id: %w", err)
+	}
+
+	return &pass, nil
+}
+
+func (repo *GatePassRepositoryImpl) ListGatePasses(ctx context.Context, filter GatePassFilter) ([]model.GatePass, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.HostUserID != nil {
+		args = append(args, *filter.HostUserID)
+		conditions = append(conditions, fmt.Sprintf("host_user_id = $%d", len(args)))
+	}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("valid_until > $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("valid_from < $%d", len(args)))
+	}
+	if !filter.IncludeRevoked {
+		conditions = append(conditions, "revoked_at IS NULL")
+	}
+
+	query := `SELECT * FROM gate_passes`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY valid_from DESC, visitor_name"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	passes := []model.GatePass{}
+	if err := repo.db.SelectContext(ctx, &passes, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list gate passes: %w", err)
+	}
+
+	return passes, nil
+}
+
+func (repo *GatePassRepositoryImpl) RevokeGatePass(ctx context.Context, id uuid.UUID, reason *string, revokedBy uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE gate_passes SET revoked_at = now(), revoked_by = $2, revoke_reason = $3, updated_at = now()
+    WHERE id = $1 AND revoked_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, id, revokedBy, reason)
+	if err != nil {
+		return fmt.Errorf("failed to revoke gate pass: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke gate pass: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RecordEntry consumes one use of the pass and logs the entry atomically.
+// The pass is re-checked under the row lock so a single-use pass scanned at
+// two gates at once only lets one visitor in.
+func (repo *GatePassRepositoryImpl) RecordEntry(ctx context.Context, entry *model.GateEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on record entry: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE gate_passes SET use_count = use_count + 1, updated_at = now()
+    WHERE id = $1 AND revoked_at IS NULL AND valid_from <= now() AND valid_until > now()
+        AND (max_uses IS NULL OR use_count < max_uses)`
+	result, err := tx.ExecContext(ctx, query, entry.GatePassID)
+	if err != nil {
+		return fmt.Errorf("failed to use gate pass: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to use gate pass: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrGatePassDenied
+	}
+
+	if err := insertGateEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *GatePassRepositoryImpl) CreateWalkInEntry(ctx context.Context, entry *model.GateEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	return insertGateEntry(ctx, repo.db, entry)
+}
+
+// RecordExit returns constants.ErrRecordNotFound when the visitor was already
+// logged out.
+func (repo *GatePassRepositoryImpl) RecordExit(ctx context.Context, entryID, guardID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE gate_entries SET exited_at = now(), exit_guard_id = $2 WHERE id = $1 AND exited_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, entryID, guardID)
+	if err != nil {
+		return fmt.Errorf("failed to record exit: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record exit: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *GatePassRepositoryImpl) GetGateEntryByID(ctx context.Context, id uuid.UUID) (*model.GateEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var entry model.GateEntry
+	query := `SELECT * FROM gate_entries WHERE id = $1`
+	err := repo.db.GetContext(ctx, &entry, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get gate entry by id: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func (repo *GatePassRepositoryImpl) ListGateEntries(ctx context.Context, filter GateEntryFilter) ([]model.GateEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.GatePassID != nil {
+		args = append(args, *filter.GatePassID)
+		conditions = append(conditions, fmt.Sprintf("gate_pass_id = $%d", len(args)))
+	}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.EntryType != "" {
+		args = append(args, filter.EntryType)
+		conditions = append(conditions, fmt.Sprintf("entry_type = $%d", len(args)))
+	}
+	if filter.VehiclePlate != "" {
+		args = append(args, filter.VehiclePlate)
+		conditions = append(conditions, fmt.Sprintf("vehicle_plate = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("entered_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("entered_at < $%d", len(args)))
+	}
+	if filter.InsideOnly {
+		conditions = append(conditions, "exited_at IS NULL")
+	}
+
+	query := `SELECT * FROM gate_entries`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY entered_at DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	entries := []model.GateEntry{}
+	if err := repo.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list gate entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func insertGateEntry(ctx context.Context, exec namedExecer, entry *model.GateEntry) error {
+	entry.ID = uuid.New()
+	entry.EnteredAt = time.Now()
+
+	query := `INSERT INTO gate_entries (id, entry_type, gate_pass_id, visitor_name, vehicle_plate, property_id, visited_user_id, purpose, entered_at, entry_guard_id, notes)
+    VALUES (:id, :entry_type, :gate_pass_id, :visitor_name, :vehicle_plate, :property_id, :visited_user_id, :purpose, :entered_at, :entry_guard_id, :notes)`
+	if _, err := exec.NamedExecContext(ctx, query, entry); err != nil {
+		return fmt.Errorf("failed to insert gate entry: %w", err)
+	}
+
+	return nil
+}
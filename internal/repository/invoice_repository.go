@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type InvoiceFilter struct {
+	PropertyID    *uuid.UUID
+	Status        string
+	Kind          string
+	BillingPeriod string
+	Limit         int
+	Offset        int
+}
+
+type InvoiceRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewInvoiceRepository(db *TenantDB) InvoiceRepository {
+	return &InvoiceRepositoryImpl{db: db}
+}
+
+func (repo *InvoiceRepositoryImpl) GetInvoiceByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var invoice model.Invoice
+	query := `SELECT * FROM invoices WHERE id = $1`
+	err := repo.db.GetContext(ctx, &invoice, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice by id: %w", err)
+	}
+
+	invoice.LineItems = []model.InvoiceLineItem{}
+	query = `SELECT * FROM invoice_line_items WHERE invoice_id = $1 ORDER BY created_at, description`
+	if err := repo.db.SelectContext(ctx, &invoice.LineItems, query, id); err != nil {
+		return nil, fmt.Errorf("failed to get invoice line items: %w", err)
+	}
+
+	return &invoice, nil
+}
+
+func (repo *InvoiceRepositoryImpl) ListInvoices(ctx context.Context, filter InvoiceFilter) ([]model.Invoice, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if filter.BillingPeriod != "" {
+		args = append(args, filter.BillingPeriod)
+		conditions = append(conditions, fmt.Sprintf("billing_period = $%d", len(args)))
+	}
+
+	query := `SELECT * FROM invoices`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY billing_period DESC, invoice_number DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	invoices := []model.Invoice{}
+	if err := repo.db.SelectContext(ctx, &invoices, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// VoidInvoice only voids invoices that have no payments applied to them.
+func (repo *InvoiceRepositoryImpl) VoidInvoice(ctx context.Context, id uuid.UUID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE invoices SET status = 'void', void_reason = $2, voided_at = now(), updated_at = now()
+    WHERE id = $1 AND status = 'open' AND paid_cents = 0`
+	result, err := repo.db.ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to void invoice: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to void invoice: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// HasOverdueInvoices reports whether the property has an unpaid invoice whose
+// due date is before asOf.
+func (repo *InvoiceRepositoryImpl) HasOverdueInvoices(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var overdue bool
+	query := `SELECT EXISTS (
+        SELECT 1 FROM invoices
+        WHERE property_id = $1 AND status IN ('open', 'partially_paid') AND due_date < $2
+    )`
+	if err := repo.db.GetContext(ctx, &overdue, query, propertyID, asOf); err != nil {
+		return false, fmt.Errorf("failed to check overdue invoices: %w", err)
+	}
+
+	return overdue, nil
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type MFARepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewMFARepository(db *TenantDB) MFARepository {
+	return &MFARepositoryImpl{db: db}
+}
+
+// SaveMFASecret starts or restarts an enrollment. It returns ErrRecordExists
+// when the user already has MFA enabled, so a confirmed secret is never
+// silently replaced.
+func (repo *MFARepositoryImpl) SaveMFASecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO user_mfa (user_id, secret) VALUES ($1, $2)
+    ON CONFLICT (user_id) DO UPDATE
+        SET secret = EXCLUDED.secret, last_used_step = NULL, failed_attempts = 0, locked_until = NULL, updated_at = now()
+        WHERE user_mfa.enabled_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return fmt.Errorf("failed to save mfa secret: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to save mfa secret: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordExists
+	}
+
+	return nil
+}
+
+func (repo *MFARepositoryImpl) GetUserMFA(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var mfa model.UserMFA
+	query := `SELECT * FROM user_mfa WHERE user_id = $1`
+	err := repo.db.GetContext(ctx, &mfa, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+// EnableMFA confirms a pending enrollment with the step of its first code
+// and stores the recovery codes, in one transaction.
+func (repo *MFARepositoryImpl) EnableMFA(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on enable mfa: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE user_mfa SET enabled_at = now(), last_used_step = $2, failed_attempts = 0, locked_until = NULL, updated_at = now()
+    WHERE user_id = $1 AND enabled_at IS NULL`
+	result, err := tx.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to enable mfa: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to enable mfa: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep accepts a code's time step only when it is later than the last
+// one accepted, and clears failed attempts. It returns ErrInvalidToken for a
+// replayed step.
+func (repo *MFARepositoryImpl) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE user_mfa SET last_used_step = $2, failed_attempts = 0, locked_until = NULL, updated_at = now()
+    WHERE user_id = $1 AND enabled_at IS NOT NULL AND (last_used_step IS NULL OR last_used_step < $2)`
+	result, err := repo.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to use totp step: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to use totp step: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// UseRecoveryCode redeems an unused recovery code and clears failed
+// attempts. It returns ErrInvalidToken when no unused code matches.
+func (repo *MFARepositoryImpl) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on use recovery code: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE mfa_recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+	result, err := tx.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrInvalidToken
+	}
+
+	query = `UPDATE user_mfa SET failed_attempts = 0, locked_until = NULL, updated_at = now() WHERE user_id = $1`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to reset mfa attempts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFailedMFAAttempt counts a wrong code. Reaching maxAttempts locks the
+// enrollment until lockedUntil and starts the count again.
+func (repo *MFARepositoryImpl) RecordFailedMFAAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE user_mfa SET
+        failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+        locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+        updated_at = now()
+    WHERE user_id = $1`
+	if _, err := repo.db.ExecContext(ctx, query, userID, maxAttempts, lockedUntil); err != nil {
+		return fmt.Errorf("failed to record failed mfa attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *MFARepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var count int
+	query := `SELECT count(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	if err := repo.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
+// ReplaceRecoveryCodes swaps every recovery code, used or not, for a new set.
+func (repo *MFARepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on replace recovery codes: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMFA removes the enrollment and, through the foreign key, its
+// recovery codes.
+func (repo *MFARepositoryImpl) DeleteMFA(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM user_mfa WHERE user_id = $1`
+	result, err := repo.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user mfa: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete user mfa: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, codeHashes []string) error {
+	query := `DELETE FROM mfa_recovery_codes WHERE user_id = $1`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	now := time.Now()
+	query = `INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)`
+	for _, codeHash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, query, uuid.New(), userID, codeHash, now); err != nil {
+			return fmt.Errorf("failed to insert recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
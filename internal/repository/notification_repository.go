@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type NotificationFilter struct {
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+type NotificationRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewNotificationRepository(db *TenantDB) NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (repo *NotificationRepositoryImpl) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	notification.ID = uuid.New()
+	notification.CreatedAt = time.Now()
+
+	query := `INSERT INTO notifications (id, user_id, kind, title, body, reference_type, reference_id, created_at)
+    VALUES (:id, :user_id, :kind, :title, :body, :reference_type, :reference_id, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, notification); err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *NotificationRepositoryImpl) ListNotifications(ctx context.Context, userID uuid.UUID, filter NotificationFilter) ([]model.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+	if filter.UnreadOnly {
+		conditions = append(conditions, "read_at IS NULL")
+	}
+
+	query := `SELECT * FROM notifications WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	notifications := []model.Notification{}
+	if err := repo.db.SelectContext(ctx, &notifications, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (repo *NotificationRepositoryImpl) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var count int
+	query := `SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+	if err := repo.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead is idempotent; a notification that was already read
+// keeps its original read_at.
+func (repo *NotificationRepositoryImpl) MarkNotificationRead(ctx context.Context, id, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, now()) WHERE id = $1 AND user_id = $2`
+	result, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *NotificationRepositoryImpl) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE notifications SET read_at = now() WHERE user_id = $1 AND read_at IS NULL`
+	if _, err := repo.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+const occupantColumns = `o.id, o.property_id, o.user_id, o.occupancy_type, o.start_date, o.end_date, o.notes,
+    o.created_at, o.updated_at, u.first_name, u.last_name, u.email`
+
+type OccupancyRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewOccupancyRepository(db *TenantDB) OccupancyRepository {
+	return &OccupancyRepositoryImpl{db: db}
+}
+
+func (repo *OccupancyRepositoryImpl) CreateOccupancy(ctx context.Context, occupancy *model.Occupancy) (*model.Occupancy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	if err := insertOccupancy(ctx, repo.db, occupancy); err != nil {
+		return nil, err
+	}
+
+	return occupancy, nil
+}
+
+func (repo *OccupancyRepositoryImpl) GetOccupancyByID(ctx context.Context, id uuid.UUID) (*model.Occupancy, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var occupancy model.Occupancy
+	query := `SELECT * FROM property_occupancies WHERE id = $1`
+	err := repo.db.GetContext(ctx, &occupancy, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get occupancy by id: %w", err)
+	}
+
+	return &occupancy, nil
+}
+
+func (repo *OccupancyRepositoryImpl) EndOccupancy(ctx context.Context, id uuid.UUID, endDate time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE property_occupancies SET end_date = $2, updated_at = now() WHERE id = $1 AND end_date IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, id, endDate)
+	if err != nil {
+		return fmt.Errorf("failed to end occupancy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to end occupancy: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *OccupancyRepositoryImpl) ListOccupantsOnDate(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	occupants := []model.PropertyOccupant{}
+	query := `SELECT ` + occupantColumns + `
+    FROM property_occupancies o
+    JOIN users u ON u.id = o.user_id
+    WHERE o.property_id = $1 AND o.start_date <= $2 AND (o.end_date IS NULL OR o.end_date > $2)
+    ORDER BY o.occupancy_type, o.start_date`
+	if err := repo.db.SelectContext(ctx, &occupants, query, propertyID, date); err != nil {
+		return nil, fmt.Errorf("failed to list occupants on date: %w", err)
+	}
+
+	return occupants, nil
+}
+
+func (repo *OccupancyRepositoryImpl) ListOccupancyHistory(ctx context.Context, propertyID uuid.UUID) ([]model.PropertyOccupant, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	occupants := []model.PropertyOccupant{}
+	query := `SELECT ` + occupantColumns + `
+    FROM property_occupancies o
+    JOIN users u ON u.id = o.user_id
+    WHERE o.property_id = $1
+    ORDER BY o.start_date DESC, o.occupancy_type`
+	if err := repo.db.SelectContext(ctx, &occupants, query, propertyID); err != nil {
+		return nil, fmt.Errorf("failed to list occupancy history: %w", err)
+	}
+
+	return occupants, nil
+}
+
+func (repo *OccupancyRepositoryImpl) ListUserProperties(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	properties := []model.UserProperty{}
+	query := `SELECT p.*, o.id AS occupancy_id, o.occupancy_type, o.start_date, o.end_date
+    FROM property_occupancies o
+    JOIN properties p ON p.id = o.property_id
+    WHERE o.user_id = $1 AND o.start_date <= $2 AND (o.end_date IS NULL OR o.end_date > $2)
+    ORDER BY p.phase, p.block, p.lot`
+	if err := repo.db.SelectContext(ctx, &properties, query, userID, date); err != nil {
+		return nil, fmt.Errorf("failed to list user properties: %w", err)
+	}
+
+	return properties, nil
+}
+
+// TransferOwnership closes every current owner and co-owner occupancy on the
+// transfer date, opens the new ones and points properties.owner_id at the
+// new primary owner, all in one transaction.
+func (repo *OccupancyRepositoryImpl) TransferOwnership(ctx context.Context, propertyID uuid.UUID, owners []model.Occupancy, transferDate time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on transfer ownership: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE property_occupancies SET end_date = $2, updated_at = now()
+    WHERE property_id = $1 AND occupancy_type IN ('owner', 'co_owner') AND end_date IS NULL`
+	if _, err := tx.ExecContext(ctx, query, propertyID, transferDate); err != nil {
+		return fmt.Errorf("failed to end current ownership: %w", err)
+	}
+
+	var primaryOwnerID *uuid.UUID
+	for i := range owners {
+		owners[i].PropertyID = propertyID
+		owners[i].StartDate = transferDate
+		if err := insertOccupancy(ctx, tx, &owners[i]); err != nil {
+			return err
+		}
+		if owners[i].OccupancyType == constants.OccupancyOwner && primaryOwnerID == nil {
+			primaryOwnerID = &owners[i].UserID
+		}
+	}
+
+	query = `UPDATE properties SET owner_id = $2, updated_at = now() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, propertyID, primaryOwnerID); err != nil {
+		return fmt.Errorf("failed to update property owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertOccupancy(ctx context.Context, exec namedExecer, occupancy *model.Occupancy) error {
+	occupancy.ID = uuid.New()
+	occupancy.CreatedAt = time.Now()
+	occupancy.UpdatedAt = occupancy.CreatedAt
+
+	query := `INSERT INTO property_occupancies (id, property_id, user_id, occupancy_type, start_date, end_date, notes, created_at, updated_at)
+    VALUES (:id, :property_id, :user_id, :occupancy_type, :start_date, :end_date, :notes, :created_at, :updated_at)`
+	if _, err := exec.NamedExecContext(ctx, query, occupancy); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert occupancy: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type PasswordResetRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewPasswordResetRepository(db *TenantDB) PasswordResetRepository {
+	return &PasswordResetRepositoryImpl{db: db}
+}
+
+// CreatePasswordResetToken stores a new token and retires the user's
+// outstanding ones, so only the most recent email works.
+func (repo *PasswordResetRepositoryImpl) CreatePasswordResetToken(ctx context.Context, token *model.PasswordResetToken) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on create password reset token: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE password_reset_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, token.UserID); err != nil {
+		return fmt.Errorf("failed to retire password reset tokens: %w", err)
+	}
+
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+
+	query = `INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+    VALUES (:id, :user_id, :token_hash, :expires_at, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("failed to insert password reset token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *PasswordResetRepositoryImpl) GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.PasswordResetToken
+	query := `SELECT * FROM password_reset_tokens WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`
+	err := repo.db.GetContext(ctx, &token, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (repo *PasswordResetRepositoryImpl) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.PasswordResetToken
+	query := `SELECT * FROM password_reset_tokens WHERE token_hash = $1`
+	err := repo.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// ResetPassword redeems the token and sets the new password hash together.
+// It returns constants.ErrInvalidToken when the token was already used or
+// has expired, including when a concurrent request redeemed it first.
+func (repo *PasswordResetRepositoryImpl) ResetPassword(ctx context.Context, tokenID uuid.UUID, passwordHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on reset password: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var userID uuid.UUID
+	query := `UPDATE password_reset_tokens SET used_at = now()
+    WHERE id = $1 AND used_at IS NULL AND expires_at > now()
+    RETURNING user_id`
+	if err := tx.GetContext(ctx, &userID, query, tokenID); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to redeem password reset token: %w", err)
+	}
+
+	query = `UPDATE users SET password_hash = $2, updated_at = now() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, userID, passwordHash); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	query = `UPDATE password_reset_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to retire password reset tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type PaymentFilter struct {
+	PropertyID *uuid.UUID
+	Method     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type PaymentRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewPaymentRepository(db *TenantDB) PaymentRepository {
+	return &PaymentRepositoryImpl{db: db}
+}
+
+// CreatePayment issues the next receipt number, stores the payment and
+// applies it to the property's unpaid invoices in one transaction.
+func (repo *PaymentRepositoryImpl) CreatePayment(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on create payment: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	number, err := nextSequenceValue(ctx, tx, constants.SequenceReceipt)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.ID = uuid.New()
+	payment.ReceiptNumber = fmt.Sprintf("OR-%08d", number)
+	payment.UnallocatedCents = payment.AmountCents
+	payment.CreatedAt = time.Now()
+
+	query := `INSERT INTO payments (id, receipt_number, property_id, method, reference_number, amount_cents, unallocated_cents, paid_at, notes, recorded_by, created_at)
+    VALUES (:id, :receipt_number, :property_id, :method, :reference_number, :amount_cents, :unallocated_cents, :paid_at, :notes, :recorded_by, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, payment); err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	if err := allocateCredit(ctx, tx, payment.PropertyID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetContext(ctx, &payment.UnallocatedCents, `SELECT unallocated_cents FROM payments WHERE id = $1`, payment.ID); err != nil {
+		return nil, fmt.Errorf("failed to reload payment: %w", err)
+	}
+	if payment.Allocations, err = listPaymentAllocations(ctx, tx, payment.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (repo *PaymentRepositoryImpl) GetPaymentByID(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var payment model.Payment
+	query := `SELECT * FROM payments WHERE id = $1`
+	err := repo.db.GetContext(ctx, &payment, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment by id: %w", err)
+	}
+
+	if payment.Allocations, err = listPaymentAllocations(ctx, repo.db, id); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (repo *PaymentRepositoryImpl) ListPayments(ctx context.Context, filter PaymentFilter) ([]model.Payment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.Method != "" {
+		args = append(args, filter.Method)
+		conditions = append(conditions, fmt.Sprintf("method = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("paid_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("paid_at <= $%d", len(args)))
+	}
+
+	query := `SELECT * FROM payments`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY paid_at DESC, receipt_number DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	payments := []model.Payment{}
+	if err := repo.db.SelectContext(ctx, &payments, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+func (repo *PaymentRepositoryImpl) GetPropertyCredit(ctx context.Context, propertyID uuid.UUID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var credit int64
+	query := `SELECT COALESCE(SUM(unallocated_cents), 0) FROM payments WHERE property_id = $1`
+	if err := repo.db.GetContext(ctx, &credit, query, propertyID); err != nil {
+		return 0, fmt.Errorf("failed to get property credit: %w", err)
+	}
+
+	return credit, nil
+}
+
+func listPaymentAllocations(ctx context.Context, q selecter, paymentID uuid.UUID) ([]model.PaymentAllocation, error) {
+	allocations := []model.PaymentAllocation{}
+	query := `SELECT pa.id, pa.payment_id, pa.invoice_id, i.invoice_number, pa.amount_cents, pa.created_at
+    FROM payment_allocations pa
+    JOIN invoices i ON i.id = pa.invoice_id
+    WHERE pa.payment_id = $1
+    ORDER BY pa.created_at, i.due_date`
+	if err := q.SelectContext(ctx, &allocations, query, paymentID); err != nil {
+		return nil, fmt.Errorf("failed to list payment allocations: %w", err)
+	}
+
+	return allocations, nil
+}
+
+// allocateCredit applies a property's unallocated payments to its unpaid
+// invoices, oldest payment to oldest invoice. Invoice rows are locked before
+// payment rows so concurrent writers for the same property serialize instead
+// of deadlocking.
+func allocateCredit(ctx context.Context, tx *sqlx.Tx, propertyID uuid.UUID) error {
+	invoices := []model.Invoice{}
+	query := `SELECT * FROM invoices
+    WHERE property_id = $1 AND status IN ('open', 'partially_paid')
+    ORDER BY due_date, billing_period, invoice_number
+    FOR UPDATE`
+	if err := tx.SelectContext(ctx, &invoices, query, propertyID); err != nil {
+		return fmt.Errorf("failed to lock unpaid invoices: %w", err)
+	}
+	if len(invoices) == 0 {
+		return nil
+	}
+
+	payments := []model.Payment{}
+	query = `SELECT * FROM payments
+    WHERE property_id = $1 AND unallocated_cents > 0
+    ORDER BY paid_at, receipt_number
+    FOR UPDATE`
+	if err := tx.SelectContext(ctx, &payments, query, propertyID); err != nil {
+		return fmt.Errorf("failed to lock unallocated payments: %w", err)
+	}
+
+	i, p := 0, 0
+	for i < len(invoices) && p < len(payments) {
+		invoice := &invoices[i]
+		payment := &payments[p]
+
+		amount := min(invoice.TotalCents-invoice.PaidCents, payment.UnallocatedCents)
+		if amount > 0 {
+			query := `INSERT INTO payment_allocations (id, payment_id, invoice_id, amount_cents, created_at)
+            VALUES ($1, $2, $3, $4, now())`
+			if _, err := tx.ExecContext(ctx, query, uuid.New(), payment.ID, invoice.ID, amount); err != nil {
+				return fmt.Errorf("failed to insert payment allocation: %w", err)
+			}
+
+			query = `UPDATE payments SET unallocated_cents = unallocated_cents - $2 WHERE id = $1`
+			if _, err := tx.ExecContext(ctx, query, payment.ID, amount); err != nil {
+				return fmt.Errorf("failed to update payment balance: %w", err)
+			}
+
+			query = `UPDATE invoices SET paid_cents = paid_cents + $2,
+            status = CASE WHEN paid_cents + $2 >= total_cents THEN 'paid' ELSE 'partially_paid' END,
+            updated_at = now()
+            WHERE id = $1`
+			if _, err := tx.ExecContext(ctx, query, invoice.ID, amount); err != nil {
+				return fmt.Errorf("failed to update invoice balance: %w", err)
+			}
+
+			invoice.PaidCents += amount
+			payment.UnallocatedCents -= amount
+		}
+
+		if invoice.PaidCents >= invoice.TotalCents {
+			i++
+		}
+		if payment.UnallocatedCents == 0 {
+			p++
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type PenaltyRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewPenaltyRepository(db *TenantDB) PenaltyRepository {
+	return &PenaltyRepositoryImpl{db: db}
+}
+
+func (repo *PenaltyRepositoryImpl) CreatePenaltyRule(ctx context.Context, rule *model.PenaltyRule) (*model.PenaltyRule, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	rule.ID = uuid.New()
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+
+	query := `INSERT INTO penalty_rules (id, name, grace_days, fixed_cents, monthly_interest_bps, effective_from, effective_to, created_at, updated_at)
+    VALUES (:id, :name, :grace_days, :fixed_cents, :monthly_interest_bps, :effective_from, :effective_to, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, rule); err != nil {
+		return nil, fmt.Errorf("failed to insert penalty rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (repo *PenaltyRepositoryImpl) ListPenaltyRules(ctx context.Context) ([]model.PenaltyRule, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	rules := []model.PenaltyRule{}
+	query := `SELECT * FROM penalty_rules ORDER BY effective_from DESC, name`
+	if err := repo.db.SelectContext(ctx, &rules, query); err != nil {
+		return nil, fmt.Errorf("failed to list penalty rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (repo *PenaltyRepositoryImpl) ListOverdueInvoices(ctx context.Context, asOf time.Time) ([]model.Invoice, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	invoices := []model.Invoice{}
+	query := `SELECT * FROM invoices
+    WHERE status IN ('open', 'partially_paid') AND due_date < $1
+    ORDER BY due_date, invoice_number`
+	if err := repo.db.SelectContext(ctx, &invoices, query, asOf); err != nil {
+		return nil, fmt.Errorf("failed to list overdue invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// ListInvoiceAllocations returns the payments applied to the invoice with
+// the date each one counts from. A payment posted late still counts from the
+// day it was made.
+func (repo *PenaltyRepositoryImpl) ListInvoiceAllocations(ctx context.Context, invoiceID uuid.UUID) ([]model.PaymentAllocation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	allocations := []model.PaymentAllocation{}
+	query := `SELECT pa.id, pa.payment_id, pa.invoice_id, i.invoice_number, pa.amount_cents, pa.created_at,
+        greatest(p.paid_at, i.issue_date) AS applies_from
+    FROM payment_allocations pa
+    JOIN payments p ON p.id = pa.payment_id
+    JOIN invoices i ON i.id = pa.invoice_id
+    WHERE pa.invoice_id = $1
+    ORDER BY applies_from, pa.created_at`
+	if err := repo.db.SelectContext(ctx, &allocations, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to list invoice allocations: %w", err)
+	}
+
+	return allocations, nil
+}
+
+func (repo *PenaltyRepositoryImpl) GetPenaltyByID(ctx context.Context, id uuid.UUID) (*model.InvoicePenalty, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var penalty model.InvoicePenalty
+	query := `SELECT * FROM invoice_penalties WHERE id = $1`
+	err := repo.db.GetContext(ctx, &penalty, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get penalty by id: %w", err)
+	}
+
+	return &penalty, nil
+}
+
+func (repo *PenaltyRepositoryImpl) ListInvoicePenalties(ctx context.Context, invoiceID uuid.UUID) ([]model.InvoicePenalty, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	penalties := []model.InvoicePenalty{}
+	query := `SELECT * FROM invoice_penalties WHERE invoice_id = $1 ORDER BY period_key, penalty_type`
+	if err := repo.db.SelectContext(ctx, &penalties, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to list invoice penalties: %w", err)
+	}
+
+	return penalties, nil
+}
+
+// ApplyPenalty charges the penalty to its invoice. It reports false when the
+// same charge was recorded before, which makes reruns of the daily job safe.
+// Any account credit on the property is applied to the new balance.
+func (repo *PenaltyRepositoryImpl) ApplyPenalty(ctx context.Context, penalty *model.InvoicePenalty) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction on apply penalty: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	penalty.ID = uuid.New()
+	penalty.Status = constants.PenaltyStatusApplied
+	penalty.CreatedAt = time.Now()
+
+	query := `INSERT INTO invoice_penalties (id, invoice_id, rule_id, penalty_type, period_key, amount_cents, status, created_at)
+    VALUES (:id, :invoice_id, :rule_id, :penalty_type, :period_key, :amount_cents, :status, :created_at)
+    ON CONFLICT (invoice_id, rule_id, penalty_type, period_key) DO NOTHING`
+	result, err := tx.NamedExecContext(ctx, query, penalty)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert penalty: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to insert penalty: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	var propertyID uuid.UUID
+	query = `UPDATE invoices SET total_cents = total_cents + $2, penalty_cents = penalty_cents + $2, updated_at = now()
+    WHERE id = $1 AND status IN ('open', 'partially_paid')
+    RETURNING property_id`
+	if err := tx.GetContext(ctx, &propertyID, query, penalty.InvoiceID, penalty.AmountCents); err != nil {
+		if err == sql.ErrNoRows {
+			// paid or voided since it was listed as overdue
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to add penalty to invoice: %w", err)
+	}
+
+	if err := allocateCredit(ctx, tx, propertyID); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// WaivePenalty removes an applied penalty from its invoice. Only the unpaid
+// part of an invoice can be waived; a penalty that has already been settled
+// is rejected with constants.ErrInvalidInput.
+func (repo *PenaltyRepositoryImpl) WaivePenalty(ctx context.Context, id uuid.UUID, reason string, waivedBy uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on waive penalty: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var penalty model.InvoicePenalty
+	query := `UPDATE invoice_penalties SET status = 'waived', waive_reason = $2, waived_by = $3, waived_at = now()
+    WHERE id = $1 AND status = 'applied'
+    RETURNING *`
+	if err := tx.GetContext(ctx, &penalty, query, id, reason, waivedBy); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to waive penalty: %w", err)
+	}
+
+	query = `UPDATE invoices SET total_cents = total_cents - $2, penalty_cents = penalty_cents - $2,
+    status = CASE
+        WHEN paid_cents >= total_cents - $2 THEN 'paid'
+        WHEN paid_cents > 0 THEN 'partially_paid'
+        ELSE 'open'
+    END,
+    updated_at = now()
+    WHERE id = $1 AND status <> 'void' AND total_cents - paid_cents >= $2`
+	result, err := tx.ExecContext(ctx, query, penalty.InvoiceID, penalty.AmountCents)
+	if err != nil {
+		return fmt.Errorf("failed to remove penalty from invoice: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove penalty from invoice: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: penalty has already been paid", constants.ErrInvalidInput)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
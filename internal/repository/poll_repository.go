@@ -0,0 +1,461 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type PollFilter struct {
+	IncludeDrafts bool
+	Limit         int
+	Offset        int
+}
+
+type PollRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewPollRepository(db *TenantDB) PollRepository {
+	return &PollRepositoryImpl{db: db}
+}
+
+// CreatePoll inserts the poll as a draft together with its options.
+func (repo *PollRepositoryImpl) CreatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on create poll: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	poll.ID = uuid.New()
+	poll.Status = constants.PollDraft
+	poll.CreatedAt = time.Now()
+	poll.UpdatedAt = poll.CreatedAt
+
+	query := `INSERT INTO polls (id, title, description, kind, audience, threshold, quorum_percent, secret, opens_at, closes_at, status, created_by, created_at, updated_at)
+    VALUES (:id, :title, :description, :kind, :audience, :threshold, :quorum_percent, :secret, :opens_at, :closes_at, :status, :created_by, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, poll); err != nil {
+		return nil, fmt.Errorf("failed to insert poll: %w", err)
+	}
+
+	if err := insertPollOptions(ctx, tx, poll.ID, options); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return poll, nil
+}
+
+func (repo *PollRepositoryImpl) GetPollByID(ctx context.Context, id uuid.UUID) (*model.Poll, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var poll model.Poll
+	query := `SELECT * FROM polls WHERE id = $1`
+	err := repo.db.GetContext(ctx, &poll, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll by id: %w", err)
+	}
+
+	return &poll, nil
+}
+
+func (repo *PollRepositoryImpl) ListPolls(ctx context.Context, filter PollFilter) ([]model.Poll, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if !filter.IncludeDrafts {
+		args = append(args, constants.PollDraft)
+		conditions = append(conditions, fmt.Sprintf("status <> $%d", len(args)))
+	}
+
+	query := `SELECT * FROM polls`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY opens_at DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	polls := []model.Poll{}
+	if err := repo.db.SelectContext(ctx, &polls, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list polls: %w", err)
+	}
+
+	return polls, nil
+}
+
+// UpdatePoll rewrites a draft and replaces its options.
+func (repo *PollRepositoryImpl) UpdatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on update poll: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	poll.UpdatedAt = time.Now()
+
+	query := `UPDATE polls
+    SET title = :title, description = :description, kind = :kind, audience = :audience, threshold = :threshold,
+        quorum_percent = :quorum_percent, secret = :secret, opens_at = :opens_at, closes_at = :closes_at, updated_at = :updated_at
+    WHERE id = :id AND status = 'draft'`
+	result, err := tx.NamedExecContext(ctx, query, poll)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM poll_options WHERE poll_id = $1`, poll.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete poll options: %w", err)
+	}
+	if err := insertPollOptions(ctx, tx, poll.ID, options); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return poll, nil
+}
+
+// ChangePollStatus moves the poll from one status to another. It returns
+// constants.ErrRecordNotFound when the poll is no longer in the from
+// status.
+func (repo *PollRepositoryImpl) ChangePollStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE polls SET status = $3, updated_at = now() WHERE id = $1 AND status = $2`
+	result, err := repo.db.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to change poll status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to change poll status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ClosePoll ends voting on a published poll, early if the window is still
+// open, and freezes the eligible voter count.
+func (repo *PollRepositoryImpl) ClosePoll(ctx context.Context, id uuid.UUID, eligibleVoters int, closedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE polls
+    SET status = 'closed', eligible_voters = $2, closed_at = $3, closes_at = LEAST(closes_at, $3), updated_at = now()
+    WHERE id = $1 AND status = 'published' AND opens_at <= $3`
+	result, err := repo.db.ExecContext(ctx, query, id, eligibleVoters, closedAt)
+	if err != nil {
+		return fmt.Errorf("failed to close poll: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to close poll: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (repo *PollRepositoryImpl) ListPollOptions(ctx context.Context, pollID uuid.UUID) ([]model.PollOption, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	options := []model.PollOption{}
+	query := `SELECT * FROM poll_options WHERE poll_id = $1 ORDER BY sort_order, label`
+	if err := repo.db.SelectContext(ctx, &options, query, pollID); err != nil {
+		return nil, fmt.Errorf("failed to list poll options: %w", err)
+	}
+
+	return options, nil
+}
+
+// CastPollVote records the ballot and the choice together. The poll row is
+// share-locked so a vote cannot land after the poll has been closed. On
+// secret polls the choice is queued without a link to the ballot and moved
+// to poll_votes in shuffled batches, like election choices, so it shares
+// neither the ballot's transaction nor its place on disk once moved.
+func (repo *PollRepositoryImpl) CastPollVote(ctx context.Context, ballot *model.PollBallot, optionID *uuid.UUID, secret bool) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on cast poll vote: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var open bool
+	query := `SELECT status = 'published' AND opens_at <= now() AND closes_at > now() FROM polls WHERE id = $1 FOR SHARE`
+	if err := tx.GetContext(ctx, &open, query, ballot.PollID); err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to lock poll: %w", err)
+	}
+	if !open {
+		return fmt.Errorf("%w: poll is not open for voting", constants.ErrInvalidInput)
+	}
+
+	ballot.ID = uuid.New()
+	ballot.CastAt = time.Now()
+
+	query = `INSERT INTO poll_ballots (id, poll_id, user_id, cast_at) VALUES (:id, :poll_id, :user_id, :cast_at)`
+	if _, err := tx.NamedExecContext(ctx, query, ballot); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert poll ballot: %w", err)
+	}
+
+	if secret {
+		query = `INSERT INTO poll_vote_queue (poll_id, option_id) VALUES ($1, $2)`
+		if _, err := tx.ExecContext(ctx, query, ballot.PollID, optionID); err != nil {
+			return fmt.Errorf("failed to queue poll vote: %w", err)
+		}
+	} else {
+		vote := &model.PollVote{
+			ID:       uuid.New(),
+			PollID:   ballot.PollID,
+			OptionID: optionID,
+			BallotID: &ballot.ID,
+		}
+		query = `INSERT INTO poll_votes (id, poll_id, option_id, ballot_id) VALUES (:id, :poll_id, :option_id, :ballot_id)`
+		if _, err := tx.NamedExecContext(ctx, query, vote); err != nil {
+			return fmt.Errorf("failed to insert poll vote: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if secret {
+		// the ballot is recorded; a failed mix leaves the choice queued for
+		// the next one
+		if err := repo.mixQueuedPollVotes(ctx, ballot.PollID, false); err != nil {
+			log.Printf("failed to mix queued poll votes: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// mixQueuedPollVotes moves queued secret choices into poll_votes in random
+// order, in a transaction of its own. Unless final is set it waits for a
+// full batch; the final mix also reshuffles the secret votes already moved.
+func (repo *PollRepositoryImpl) mixQueuedPollVotes(ctx context.Context, pollID uuid.UUID, final bool) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on mix poll votes: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var queued int
+	query := `SELECT count(*) FROM poll_vote_queue WHERE poll_id = $1`
+	if err := tx.GetContext(ctx, &queued, query, pollID); err != nil {
+		return fmt.Errorf("failed to count queued poll votes: %w", err)
+	}
+	if queued == 0 || (!final && queued < constants.VoteMixBatch) {
+		return nil
+	}
+
+	query = `WITH queued AS (
+        DELETE FROM poll_vote_queue WHERE poll_id = $1 RETURNING option_id
+    ), moved AS (
+        DELETE FROM poll_votes WHERE poll_id = $1 AND ballot_id IS NULL AND $2 RETURNING option_id
+    )
+    INSERT INTO poll_votes (poll_id, option_id)
+    SELECT $1, option_id FROM (
+        SELECT option_id FROM moved
+        UNION ALL
+        SELECT option_id FROM queued
+    ) AS votes
+    ORDER BY random()`
+	if _, err := tx.ExecContext(ctx, query, pollID, final); err != nil {
+		return fmt.Errorf("failed to mix poll votes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *PollRepositoryImpl) GetPollBallot(ctx context.Context, pollID, userID uuid.UUID) (*model.PollBallot, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var ballot model.PollBallot
+	query := `SELECT * FROM poll_ballots WHERE poll_id = $1 AND user_id = $2`
+	err := repo.db.GetContext(ctx, &ballot, query, pollID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll ballot: %w", err)
+	}
+
+	return &ballot, nil
+}
+
+// GetPollTurnout counts the ballots cast against the members entitled to
+// vote as of asOf: every active member, or only the active owners and
+// co-owners of a property, depending on the audience. A member who already
+// voted stays counted even if they would no longer qualify.
+func (repo *PollRepositoryImpl) GetPollTurnout(ctx context.Context, pollID uuid.UUID, audience string, asOf time.Time) (*model.PollTurnout, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	args := []interface{}{pollID}
+	var entitled string
+	switch audience {
+	case constants.PollAudienceOwners:
+		args = append(args, asOf)
+		entitled = `SELECT 1 FROM property_occupancies o
+                    JOIN properties p ON p.id = o.property_id
+                    WHERE o.user_id = u.id AND o.occupancy_type IN ('owner', 'co_owner') AND p.archived_at IS NULL
+                        AND o.start_date <= $2 AND (o.end_date IS NULL OR o.end_date > $2)`
+	default:
+		entitled = `SELECT 1 FROM user_roles ur
+                    JOIN roles r ON r.id = ur.role_id
+                    WHERE ur.user_id = u.id AND r.name = 'member'`
+	}
+
+	var turnout model.PollTurnout
+	query := `SELECT
+        (SELECT count(*) FROM poll_ballots WHERE poll_id = $1) AS ballots_cast,
+        (SELECT count(*) FROM users u
+            WHERE EXISTS (
+                SELECT 1 FROM poll_ballots b WHERE b.poll_id = $1 AND b.user_id = u.id
+            ) OR (
+                u.status = 'active' AND EXISTS (` + entitled + `)
+            )
+        ) AS eligible_voters`
+	if err := repo.db.GetContext(ctx, &turnout, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get poll turnout: %w", err)
+	}
+
+	return &turnout, nil
+}
+
+// TallyPollVotes counts the votes of a poll whose voting has closed, first
+// mixing any secret choices still queued.
+func (repo *PollRepositoryImpl) TallyPollVotes(ctx context.Context, pollID uuid.UUID) ([]model.PollTally, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	if err := repo.mixQueuedPollVotes(ctx, pollID, true); err != nil {
+		return nil, err
+	}
+
+	tallies := []model.PollTally{}
+	query := `SELECT option_id, count(*) AS votes FROM poll_votes WHERE poll_id = $1 GROUP BY option_id`
+	if err := repo.db.SelectContext(ctx, &tallies, query, pollID); err != nil {
+		return nil, fmt.Errorf("failed to tally poll votes: %w", err)
+	}
+
+	return tallies, nil
+}
+
+// ListPollVoters returns who voted and when. The choice is only filled in
+// for polls that are not secret.
+func (repo *PollRepositoryImpl) ListPollVoters(ctx context.Context, pollID uuid.UUID) ([]model.PollVoter, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	voters := []model.PollVoter{}
+	query := `SELECT b.user_id, u.first_name, u.last_name, v.option_id, b.cast_at
+    FROM poll_ballots b
+    JOIN users u ON u.id = b.user_id
+    LEFT JOIN poll_votes v ON v.ballot_id = b.id
+    WHERE b.poll_id = $1
+    ORDER BY b.cast_at`
+	if err := repo.db.SelectContext(ctx, &voters, query, pollID); err != nil {
+		return nil, fmt.Errorf("failed to list poll voters: %w", err)
+	}
+
+	return voters, nil
+}
+
+func insertPollOptions(ctx context.Context, tx *sqlx.Tx, pollID uuid.UUID, options []model.PollOption) error {
+	for i := range options {
+		options[i].ID = uuid.New()
+		options[i].PollID = pollID
+	}
+
+	query := `INSERT INTO poll_options (id, poll_id, label, sort_order) VALUES (:id, :poll_id, :label, :sort_order)`
+	if _, err := tx.NamedExecContext(ctx, query, options); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert poll options: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type PropertyFilter struct {
+	Phase           string
+	Block           string
+	Road            string
+	Type            string
+	OwnerID         *uuid.UUID
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
+
+type PropertyRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewPropertyRepository(db *TenantDB) PropertyRepository {
+	return &PropertyRepositoryImpl{db: db}
+}
+
+// CreateProperty also records the initial owner occupancy when OwnerID is set
+// so the ownership history starts with the registry entry.
+func (repo *PropertyRepositoryImpl) CreateProperty(ctx context.Context, property *model.Property) (*model.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on create property: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	property.ID = uuid.New()
+	property.CreatedAt = time.Now()
+	property.UpdatedAt = property.CreatedAt
+
+	query := `INSERT INTO properties (id, owner_id, block, lot, road, phase, type, lot_area_sqm, created_at, updated_at)
+    VALUES (:id, :owner_id, :block, :lot, :road, :phase, :type, :lot_area_sqm, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, property); err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to insert property: %w", err)
+	}
+
+	if property.OwnerID != nil {
+		occupancy := &model.Occupancy{
+			PropertyID:    property.ID,
+			UserID:        *property.OwnerID,
+			OccupancyType: constants.OccupancyOwner,
+			StartDate:     property.CreatedAt,
+		}
+		if err := insertOccupancy(ctx, tx, occupancy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return property, nil
+}
+
+func (repo *PropertyRepositoryImpl) GetPropertyByID(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var property model.Property
+	query := `SELECT * FROM properties WHERE id = $1`
+	err := repo.db.GetContext(ctx, &property, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get property by id: %w", err)
+	}
+
+	return &property, nil
+}
+
+func (repo *PropertyRepositoryImpl) GetPropertyByAddress(ctx context.Context, phase, block, lot string) (*model.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var property model.Property
+	query := `SELECT * FROM properties WHERE phase = $1 AND block = $2 AND lot = $3 AND archived_at IS NULL`
+	err := repo.db.GetContext(ctx, &property, query, phase, block, lot)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get property by address: %w", err)
+	}
+
+	return &property, nil
+}
+
+func (repo *PropertyRepositoryImpl) ListProperties(ctx context.Context, filter PropertyFilter) ([]model.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if filter.Phase != "" {
+		addCondition("phase", filter.Phase)
+	}
+	if filter.Block != "" {
+		addCondition("block", filter.Block)
+	}
+	if filter.Road != "" {
+		addCondition("road", filter.Road)
+	}
+	if filter.Type != "" {
+		addCondition("type", filter.Type)
+	}
+	if filter.OwnerID != nil {
+		addCondition("owner_id", *filter.OwnerID)
+	}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+
+	query := `SELECT * FROM properties`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY phase, block, lot"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	properties := []model.Property{}
+	if err := repo.db.SelectContext(ctx, &properties, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list properties: %w", err)
+	}
+
+	return properties, nil
+}
+
+func (repo *PropertyRepositoryImpl) UpdateProperty(ctx context.Context, property *model.Property) (*model.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	property.UpdatedAt = time.Now()
+
+	query := `UPDATE properties
+    SET owner_id = :owner_id, block = :block, lot = :lot, road = :road, phase = :phase, type = :type,
+        lot_area_sqm = :lot_area_sqm, updated_at = :updated_at
+    WHERE id = :id AND archived_at IS NULL`
+	result, err := repo.db.NamedExecContext(ctx, query, property)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to update property: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update property: %w", err)
+	}
+	if rows == 0 {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return property, nil
+}
+
+func (repo *PropertyRepositoryImpl) ArchiveProperty(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE properties SET archived_at = now(), updated_at = now() WHERE id = $1 AND archived_at IS NULL`
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive property: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to archive property: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
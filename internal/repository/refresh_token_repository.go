@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewRefreshTokenRepository(db *TenantDB) RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{db: db}
+}
+
+func (repo *RefreshTokenRepositoryImpl) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	token.CreatedAt = time.Now()
+
+	query := `INSERT INTO refresh_tokens (id, user_id, family_id, expires_at, created_at)
+    VALUES (:id, :user_id, :family_id, :expires_at, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *RefreshTokenRepositoryImpl) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var token model.RefreshToken
+	query := `SELECT * FROM refresh_tokens WHERE id = $1`
+	err := repo.db.GetContext(ctx, &token, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken revokes oldID in favour of next in a single transaction.
+// It returns constants.ErrTokenReused when oldID was already revoked, which
+// also covers two requests racing to redeem the same token.
+func (repo *RefreshTokenRepositoryImpl) RotateRefreshToken(ctx context.Context, oldID uuid.UUID, next *model.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on rotate refresh token: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = now(), replaced_by = $2
+    WHERE id = $1 AND revoked_at IS NULL`, oldID, next.ID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrTokenReused
+	}
+
+	next.CreatedAt = time.Now()
+	query := `INSERT INTO refresh_tokens (id, user_id, family_id, expires_at, created_at)
+    VALUES (:id, :user_id, :family_id, :expires_at, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, next); err != nil {
+		return fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeSession revokes every refresh token in the family and adds the family
+// to the session denylist so access tokens already issued for it stop working.
+func (repo *RefreshTokenRepositoryImpl) RevokeSession(ctx context.Context, familyID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on revoke session: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `INSERT INTO revoked_sessions (session_id, user_id, expires_at)
+    SELECT family_id, user_id, max(expires_at) FROM refresh_tokens
+    WHERE family_id = $1
+    GROUP BY family_id, user_id
+    ON CONFLICT (session_id) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to deny session: %w", err)
+	}
+
+	query = `UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *RefreshTokenRepositoryImpl) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on revoke user sessions: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := revokeUserSessions(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// revokeUserSessions revokes the user's refresh tokens and adds their
+// sessions to the denylist inside the caller's transaction.
+func revokeUserSessions(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID) error {
+	query := `INSERT INTO revoked_sessions (session_id, user_id, expires_at)
+    SELECT family_id, user_id, max(expires_at) FROM refresh_tokens
+    WHERE user_id = $1 AND expires_at > now()
+    GROUP BY family_id, user_id
+    ON CONFLICT (session_id) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to deny user sessions: %w", err)
+	}
+
+	query = `UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *RefreshTokenRepositoryImpl) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var revoked bool
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_sessions WHERE session_id = $1)`
+	if err := repo.db.GetContext(ctx, &revoked, query, sessionID); err != nil {
+		return false, fmt.Errorf("failed to check revoked session: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// PurgeExpiredRevokedSessions deletes denylist entries whose sessions have
+// expired, since no token issued for them can be presented any more.
+func (repo *RefreshTokenRepositoryImpl) PurgeExpiredRevokedSessions(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM revoked_sessions WHERE expires_at < $1`
+	result, err := repo.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge revoked sessions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge revoked sessions: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+const registrationDetailQuery = `SELECT r.*, u.first_name, u.last_name, u.email, u.mobile_number, u.status AS user_status,
+    p.phase, p.block, p.lot
+    FROM registrations r
+    JOIN users u ON u.id = r.user_id
+    LEFT JOIN properties p ON p.id = r.property_id`
+
+type RegistrationFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+type RegistrationRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewRegistrationRepository(db *TenantDB) RegistrationRepository {
+	return &RegistrationRepositoryImpl{db: db}
+}
+
+// CreateRegistration inserts the new user and its pending registration in one
+// transaction, so no account exists without something for an admin to review.
+func (repo *RegistrationRepositoryImpl) CreateRegistration(ctx context.Context, user *model.User, registration *model.Registration) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on create registration: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	registration.UserID = user.ID
+	registration.AssociationID = user.AssociationID
+	registration.Status = constants.RegistrationPending
+	registration.CreatedAt = user.CreatedAt
+	registration.UpdatedAt = user.CreatedAt
+
+	query := `INSERT INTO registrations (id, user_id, property_id, proof_type, proof_key, proof_file_name, proof_content_type,
+        proof_size_bytes, status, created_at, updated_at)
+    VALUES (:id, :user_id, :property_id, :proof_type, :proof_key, :proof_file_name, :proof_content_type,
+        :proof_size_bytes, :status, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, registration); err != nil {
+		return fmt.Errorf("failed to insert registration: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *RegistrationRepositoryImpl) GetRegistrationByID(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var registration model.RegistrationDetail
+	query := registrationDetailQuery + ` WHERE r.id = $1`
+	err := repo.db.GetContext(ctx, &registration, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get registration by id: %w", err)
+	}
+
+	return &registration, nil
+}
+
+func (repo *RegistrationRepositoryImpl) ListRegistrations(ctx context.Context, filter RegistrationFilter) ([]model.RegistrationDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	args := []interface{}{}
+	query := registrationDetailQuery
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" WHERE r.status = $%d", len(args))
+	}
+	query += " ORDER BY r.created_at, r.id"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	registrations := []model.RegistrationDetail{}
+	if err := repo.db.SelectContext(ctx, &registrations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list registrations: %w", err)
+	}
+
+	return registrations, nil
+}
+
+// ApproveRegistration activates the registrant, grants the member role and,
+// when occupancy is set, links the claimed property, all in one transaction.
+// An owner occupancy also becomes the property's owner, which it must not
+// have yet. It returns ErrRecordNotFound when the registration is no longer
+// pending or the user is not awaiting approval.
+func (repo *RegistrationRepositoryImpl) ApproveRegistration(ctx context.Context, id, reviewedBy uuid.UUID, occupancy *model.Occupancy) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on approve registration: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var userID uuid.UUID
+	query := `UPDATE registrations SET status = $2, reviewed_by = $3, reviewed_at = $4, updated_at = $4
+    WHERE id = $1 AND status = $5
+    RETURNING user_id`
+	err = tx.GetContext(ctx, &userID, query, id, constants.RegistrationApproved, reviewedBy, time.Now(), constants.RegistrationPending)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to approve registration: %w", err)
+	}
+
+	query = `UPDATE users SET status = $2, updated_at = now() WHERE id = $1 AND status = $3`
+	result, err := tx.ExecContext(ctx, query, userID, constants.ActiveStatus, constants.PendingApprovalStatus)
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	query = `INSERT INTO user_roles (user_id, role_id) SELECT $1, id FROM roles WHERE name = $2 ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, userID, constants.RoleMember); err != nil {
+		return fmt.Errorf("failed to assign member role: %w", err)
+	}
+
+	if occupancy != nil {
+		occupancy.UserID = userID
+		if err := insertOccupancy(ctx, tx, occupancy); err != nil {
+			return err
+		}
+
+		if occupancy.OccupancyType == constants.OccupancyOwner {
+			query = `UPDATE properties SET owner_id = $2, updated_at = now() WHERE id = $1 AND owner_id IS NULL`
+			result, err := tx.ExecContext(ctx, query, occupancy.PropertyID, userID)
+			if err != nil {
+				return fmt.Errorf("failed to update property owner: %w", err)
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to update property owner: %w", err)
+			}
+			if rows == 0 {
+				return constants.ErrRecordExists
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RejectRegistration records the reason on a pending registration and marks
+// the registrant rejected so they can no longer sign in.
+func (repo *RegistrationRepositoryImpl) RejectRegistration(ctx context.Context, id uuid.UUID, reason string, reviewedBy uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on reject registration: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	var userID uuid.UUID
+	query := `UPDATE registrations SET status = $2, rejection_reason = $3, reviewed_by = $4, reviewed_at = $5, updated_at = $5
+    WHERE id = $1 AND status = $6
+    RETURNING user_id`
+	err = tx.GetContext(ctx, &userID, query, id, constants.RegistrationRejected, reason, reviewedBy, time.Now(), constants.RegistrationPending)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return constants.ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to reject registration: %w", err)
+	}
+
+	query = `UPDATE users SET status = $2, updated_at = now() WHERE id = $1 AND status = $3`
+	result, err := tx.ExecContext(ctx, query, userID, constants.RejectedStatus, constants.PendingApprovalStatus)
+	if err != nil {
+		return fmt.Errorf("failed to reject user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to reject user: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
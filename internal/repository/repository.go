@@ -2,22 +2,357 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
 	"github.com/jmoiron/sqlx"
 )
 
+type AssociationRepository interface {
+	// SaveAssociation creates the association or, when the slug is taken,
+	// updates its name and time zone.
+	SaveAssociation(ctx context.Context, association *model.Association) error
+	GetAssociationByID(ctx context.Context, id uuid.UUID) (*model.Association, error)
+	GetAssociationBySlug(ctx context.Context, slug string) (*model.Association, error)
+	ListAssociations(ctx context.Context) ([]model.Association, error)
+}
+
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	ReactivateUser(ctx context.Context, id uuid.UUID) error
+	SuspendUser(ctx context.Context, id uuid.UUID) error
+	// DeleteUnverifiedUsers removes registrations still pending
+	// verification that were created before cutoff. It returns how many were
+	// removed and the storage keys of the proofs they had uploaded.
+	DeleteUnverifiedUsers(ctx context.Context, cutoff time.Time) (int64, []string, error)
+}
+
+type RoleRepository interface {
+	ListRoles(ctx context.Context) ([]model.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*model.Role, error)
+	GetRolesByUserID(ctx context.Context, userID uuid.UUID) ([]model.Role, error)
+	GetPermissionsByUserID(ctx context.Context, userID uuid.UUID) ([]model.Permission, error)
+	UserHasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+	AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error
+	RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error
+}
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, oldID uuid.UUID, next *model.RefreshToken) error
+	RevokeSession(ctx context.Context, familyID uuid.UUID) error
+	RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error
+	IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+	PurgeExpiredRevokedSessions(ctx context.Context, now time.Time) (int64, error)
+}
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *model.PasswordResetToken) error
+	GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenID uuid.UUID, passwordHash string) error
+}
+
+type EmailVerificationRepository interface {
+	CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error
+	GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (*model.EmailVerificationToken, error)
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error)
+	VerifyEmail(ctx context.Context, tokenID uuid.UUID, status string) error
+}
+
+type MFARepository interface {
+	SaveMFASecret(ctx context.Context, userID uuid.UUID, secret string) error
+	GetUserMFA(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error)
+	EnableMFA(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	RecordFailedMFAAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) error
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	DeleteMFA(ctx context.Context, userID uuid.UUID) error
+}
+
+type RegistrationRepository interface {
+	CreateRegistration(ctx context.Context, user *model.User, registration *model.Registration) error
+	GetRegistrationByID(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error)
+	ListRegistrations(ctx context.Context, filter RegistrationFilter) ([]model.RegistrationDetail, error)
+	ApproveRegistration(ctx context.Context, id, reviewedBy uuid.UUID, occupancy *model.Occupancy) error
+	RejectRegistration(ctx context.Context, id uuid.UUID, reason string, reviewedBy uuid.UUID) error
+}
+
+type PropertyRepository interface {
+	CreateProperty(ctx context.Context, property *model.Property) (*model.Property, error)
+	GetPropertyByID(ctx context.Context, id uuid.UUID) (*model.Property, error)
+	GetPropertyByAddress(ctx context.Context, phase, block, lot string) (*model.Property, error)
+	ListProperties(ctx context.Context, filter PropertyFilter) ([]model.Property, error)
+	UpdateProperty(ctx context.Context, property *model.Property) (*model.Property, error)
+	ArchiveProperty(ctx context.Context, id uuid.UUID) error
+}
+
+type OccupancyRepository interface {
+	CreateOccupancy(ctx context.Context, occupancy *model.Occupancy) (*model.Occupancy, error)
+	GetOccupancyByID(ctx context.Context, id uuid.UUID) (*model.Occupancy, error)
+	EndOccupancy(ctx context.Context, id uuid.UUID, endDate time.Time) error
+	ListOccupantsOnDate(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error)
+	ListOccupancyHistory(ctx context.Context, propertyID uuid.UUID) ([]model.PropertyOccupant, error)
+	ListUserProperties(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error)
+	TransferOwnership(ctx context.Context, propertyID uuid.UUID, owners []model.Occupancy, transferDate time.Time) error
+}
+
+type BillingRepository interface {
+	CreateDuesSchedule(ctx context.Context, schedule *model.DuesSchedule) (*model.DuesSchedule, error)
+	ListDuesSchedules(ctx context.Context) ([]model.DuesSchedule, error)
+	ListDuesSchedulesEffectiveOn(ctx context.Context, date time.Time) ([]model.DuesSchedule, error)
+	ListBilledPropertyIDs(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error)
+	ListBillingRuns(ctx context.Context) ([]model.BillingRun, error)
+	CreateBillingRun(ctx context.Context, run *model.BillingRun, invoices []model.Invoice) error
+}
+
+type InvoiceRepository interface {
+	GetInvoiceByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error)
+	ListInvoices(ctx context.Context, filter InvoiceFilter) ([]model.Invoice, error)
+	VoidInvoice(ctx context.Context, id uuid.UUID, reason string) error
+	HasOverdueInvoices(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error)
+}
+
+type PaymentRepository interface {
+	CreatePayment(ctx context.Context, payment *model.Payment) (*model.Payment, error)
+	GetPaymentByID(ctx context.Context, id uuid.UUID) (*model.Payment, error)
+	ListPayments(ctx context.Context, filter PaymentFilter) ([]model.Payment, error)
+	GetPropertyCredit(ctx context.Context, propertyID uuid.UUID) (int64, error)
+}
+
+type PenaltyRepository interface {
+	CreatePenaltyRule(ctx context.Context, rule *model.PenaltyRule) (*model.PenaltyRule, error)
+	ListPenaltyRules(ctx context.Context) ([]model.PenaltyRule, error)
+	ListOverdueInvoices(ctx context.Context, asOf time.Time) ([]model.Invoice, error)
+	ListInvoiceAllocations(ctx context.Context, invoiceID uuid.UUID) ([]model.PaymentAllocation, error)
+	GetPenaltyByID(ctx context.Context, id uuid.UUID) (*model.InvoicePenalty, error)
+	ListInvoicePenalties(ctx context.Context, invoiceID uuid.UUID) ([]model.InvoicePenalty, error)
+	ApplyPenalty(ctx context.Context, penalty *model.InvoicePenalty) (bool, error)
+	WaivePenalty(ctx context.Context, id uuid.UUID, reason string, waivedBy uuid.UUID) error
+}
+
+type StatementRepository interface {
+	ListStatementEntries(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error)
+}
+
+type AnnouncementRepository interface {
+	CreateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error)
+	GetAnnouncementByID(ctx context.Context, id uuid.UUID) (*model.Announcement, error)
+	ListAnnouncements(ctx context.Context, filter AnnouncementFilter) ([]model.Announcement, error)
+	UpdateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error)
+	DeleteAnnouncement(ctx context.Context, id uuid.UUID) error
+	ListFeed(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]model.FeedAnnouncement, error)
+	GetFeedAnnouncement(ctx context.Context, userID, id uuid.UUID) (*model.FeedAnnouncement, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, announcementID, userID uuid.UUID) error
+	ListReadReceipts(ctx context.Context, announcementID uuid.UUID) ([]model.AnnouncementRead, error)
+}
+
+type AmenityRepository interface {
+	CreateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error)
+	GetAmenityByID(ctx context.Context, id uuid.UUID) (*model.Amenity, error)
+	ListAmenities(ctx context.Context, includeInactive bool) ([]model.Amenity, error)
+	UpdateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error)
+	CreateReservation(ctx context.Context, reservation *model.AmenityReservation) (*model.AmenityReservation, error)
+	GetReservationByID(ctx context.Context, id uuid.UUID) (*model.AmenityReservation, error)
+	ListReservations(ctx context.Context, filter ReservationFilter) ([]model.AmenityReservation, error)
+	DecideReservation(ctx context.Context, id uuid.UUID, status string, reason *string, decidedBy uuid.UUID) error
+	CancelReservation(ctx context.Context, id uuid.UUID, reason *string) error
+}
+
+type GatePassRepository interface {
+	CreateGatePass(ctx context.Context, pass *model.GatePass) (*model.GatePass, error)
+	GetGatePassByID(ctx context.Context, id uuid.UUID) (*model.GatePass, error)
+	ListGatePasses(ctx context.Context, filter GatePassFilter) ([]model.GatePass, error)
+	RevokeGatePass(ctx context.Context, id uuid.UUID, reason *string, revokedBy uuid.UUID) error
+	RecordEntry(ctx context.Context, entry *model.GateEntry) error
+	CreateWalkInEntry(ctx context.Context, entry *model.GateEntry) error
+	RecordExit(ctx context.Context, entryID, guardID uuid.UUID) error
+	GetGateEntryByID(ctx context.Context, id uuid.UUID) (*model.GateEntry, error)
+	ListGateEntries(ctx context.Context, filter GateEntryFilter) ([]model.GateEntry, error)
+}
+
+type VehicleRepository interface {
+	CreateVehicle(ctx context.Context, vehicle *model.Vehicle) (*model.Vehicle, error)
+	GetVehicleByID(ctx context.Context, id uuid.UUID) (*model.Vehicle, error)
+	GetVehicleByPlate(ctx context.Context, plateNumber string) (*model.Vehicle, error)
+	ListVehicles(ctx context.Context, filter VehicleFilter) ([]model.Vehicle, error)
+	ArchiveVehicle(ctx context.Context, id uuid.UUID) error
+	IssueSticker(ctx context.Context, sticker *model.VehicleSticker, propertyID uuid.UUID, maxPerProperty int) error
+	GetStickerByID(ctx context.Context, id uuid.UUID) (*model.VehicleSticker, error)
+	ListStickers(ctx context.Context, filter StickerFilter) ([]model.VehicleSticker, error)
+	UpdateStickerStatus(ctx context.Context, id uuid.UUID, status string, reason *string) error
+}
+
+type TicketRepository interface {
+	CreateTicket(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error)
+	GetTicketByID(ctx context.Context, id uuid.UUID) (*model.Ticket, error)
+	ListTickets(ctx context.Context, filter TicketFilter) ([]model.Ticket, error)
+	AssignTicket(ctx context.Context, id uuid.UUID, assignedUserID *uuid.UUID, assignedVendor *string) error
+	ChangeTicketStatus(ctx context.Context, change *model.TicketStatusChange) error
+	ListTicketStatusChanges(ctx context.Context, ticketID uuid.UUID) ([]model.TicketStatusChange, error)
+	AddTicketComment(ctx context.Context, comment *model.TicketComment) (*model.TicketComment, error)
+	ListTicketComments(ctx context.Context, ticketID uuid.UUID) ([]model.TicketComment, error)
+	AddTicketPhoto(ctx context.Context, photo *model.TicketPhoto) error
+	GetTicketPhoto(ctx context.Context, ticketID, photoID uuid.UUID) (*model.TicketPhoto, error)
+	ListTicketPhotos(ctx context.Context, ticketID uuid.UUID) ([]model.TicketPhoto, error)
+}
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *model.Notification) error
+	ListNotifications(ctx context.Context, userID uuid.UUID, filter NotificationFilter) ([]model.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkNotificationRead(ctx context.Context, id, userID uuid.UUID) error
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error
+}
+
+type ViolationRepository interface {
+	CreateViolation(ctx context.Context, violation *model.Violation) (*model.Violation, error)
+	GetViolationByID(ctx context.Context, id uuid.UUID) (*model.Violation, error)
+	ListViolations(ctx context.Context, filter ViolationFilter) ([]model.Violation, error)
+	AddViolationAttachment(ctx context.Context, attachment *model.ViolationAttachment) error
+	GetViolationAttachment(ctx context.Context, violationID, id uuid.UUID) (*model.ViolationAttachment, error)
+	ListViolationAttachments(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAttachment, error)
+	IssueViolationNotice(ctx context.Context, notice *model.ViolationNotice, from, to string) error
+	ListViolationNotices(ctx context.Context, violationID uuid.UUID) ([]model.ViolationNotice, error)
+	IssueViolationFine(ctx context.Context, fine *model.ViolationFine, invoice *model.Invoice, from string) error
+	ListViolationFines(ctx context.Context, violationID uuid.UUID) ([]model.ViolationFine, error)
+	SubmitViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, from string) error
+	ListViolationAppeals(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAppeal, error)
+	DecideViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, to string) error
+	CloseViolation(ctx context.Context, id uuid.UUID, from, to string, note *string, actorID uuid.UUID) error
+	ListViolationEvents(ctx context.Context, violationID uuid.UUID) ([]model.ViolationEvent, error)
+}
+
+type DocumentRepository interface {
+	CreateDocument(ctx context.Context, document *model.Document, version *model.DocumentVersion) error
+	GetDocumentByID(ctx context.Context, id uuid.UUID) (*model.Document, error)
+	ListDocuments(ctx context.Context, filter DocumentFilter) ([]model.Document, error)
+	UpdateDocument(ctx context.Context, document *model.Document) (*model.Document, error)
+	ArchiveDocument(ctx context.Context, id uuid.UUID) error
+	AddDocumentVersion(ctx context.Context, version *model.DocumentVersion) error
+	GetDocumentVersion(ctx context.Context, documentID uuid.UUID, versionNumber int) (*model.DocumentVersion, error)
+	ListDocumentVersions(ctx context.Context, documentID uuid.UUID) ([]model.DocumentVersion, error)
+}
+
+type ElectionRepository interface {
+	CreateElection(ctx context.Context, election *model.Election) (*model.Election, error)
+	GetElectionByID(ctx context.Context, id uuid.UUID) (*model.Election, error)
+	ListElections(ctx context.Context, filter ElectionFilter) ([]model.Election, error)
+	UpdateElection(ctx context.Context, election *model.Election) (*model.Election, error)
+	ChangeElectionStatus(ctx context.Context, id uuid.UUID, from, to string) error
+	PublishElectionResults(ctx context.Context, id uuid.UUID, eligibleProperties int) error
+	CreatePosition(ctx context.Context, position *model.ElectionPosition) (*model.ElectionPosition, error)
+	ListPositions(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error)
+	DeletePosition(ctx context.Context, electionID, id uuid.UUID) error
+	CreateCandidate(ctx context.Context, candidate *model.ElectionCandidate) (*model.ElectionCandidate, error)
+	ListCandidates(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error)
+	DeleteCandidate(ctx context.Context, electionID, id uuid.UUID) error
+	CastBallot(ctx context.Context, ballot *model.ElectionBallot, votes []model.ElectionVote) error
+	GetBallotByProperty(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionBallot, error)
+	GetQuorum(ctx context.Context, electionID uuid.UUID, asOf time.Time) (*model.ElectionQuorum, error)
+	TallyVotes(ctx context.Context, electionID uuid.UUID) ([]model.ElectionTally, error)
+	CreateProxy(ctx context.Context, proxy *model.ElectionProxy) error
+	GetProxyByID(ctx context.Context, electionID, id uuid.UUID) (*model.ElectionProxy, error)
+	GetApprovedProxy(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionProxy, error)
+	ListProxies(ctx context.Context, electionID uuid.UUID, status string) ([]model.ElectionProxy, error)
+	ListUserProxies(ctx context.Context, userID uuid.UUID) ([]model.ElectionProxy, error)
+	ReviewProxy(ctx context.Context, proxy *model.ElectionProxy) error
+	RevokeProxy(ctx context.Context, id uuid.UUID) error
+}
+
+type PollRepository interface {
+	CreatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error)
+	GetPollByID(ctx context.Context, id uuid.UUID) (*model.Poll, error)
+	ListPolls(ctx context.Context, filter PollFilter) ([]model.Poll, error)
+	UpdatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error)
+	ChangePollStatus(ctx context.Context, id uuid.UUID, from, to string) error
+	ClosePoll(ctx context.Context, id uuid.UUID, eligibleVoters int, closedAt time.Time) error
+	ListPollOptions(ctx context.Context, pollID uuid.UUID) ([]model.PollOption, error)
+	CastPollVote(ctx context.Context, ballot *model.PollBallot, optionID *uuid.UUID, secret bool) error
+	GetPollBallot(ctx context.Context, pollID, userID uuid.UUID) (*model.PollBallot, error)
+	GetPollTurnout(ctx context.Context, pollID uuid.UUID, audience string, asOf time.Time) (*model.PollTurnout, error)
+	TallyPollVotes(ctx context.Context, pollID uuid.UUID) ([]model.PollTally, error)
+	ListPollVoters(ctx context.Context, pollID uuid.UUID) ([]model.PollVoter, error)
+}
+
+type EventRepository interface {
+	CreateEvent(ctx context.Context, event *model.Event) (*model.Event, error)
+	GetEventByID(ctx context.Context, id uuid.UUID) (*model.Event, error)
+	ListEvents(ctx context.Context, filter EventFilter) ([]model.Event, error)
+	UpdateEvent(ctx context.Context, event *model.Event) (*model.Event, error)
+	CancelEvent(ctx context.Context, id uuid.UUID, reason *string) error
+	SaveRSVP(ctx context.Context, rsvp *model.EventRSVP) error
+	GetRSVP(ctx context.Context, eventID, userID uuid.UUID) (*model.EventRSVP, error)
+	ListAttendees(ctx context.Context, eventID uuid.UUID) ([]model.EventAttendee, error)
+	ReplaceCalendarToken(ctx context.Context, token *model.CalendarToken) error
+	RevokeCalendarToken(ctx context.Context, userID uuid.UUID) error
+	GetActiveCalendarToken(ctx context.Context, tokenHash string) (*model.CalendarToken, error)
 }
 
 type Repository struct {
-	UserRepository UserRepository
+	AssociationRepository       AssociationRepository
+	UserRepository              UserRepository
+	RoleRepository              RoleRepository
+	RefreshTokenRepository      RefreshTokenRepository
+	PasswordResetRepository     PasswordResetRepository
+	EmailVerificationRepository EmailVerificationRepository
+	MFARepository               MFARepository
+	RegistrationRepository      RegistrationRepository
+	PropertyRepository          PropertyRepository
+	OccupancyRepository         OccupancyRepository
+	BillingRepository           BillingRepository
+	InvoiceRepository           InvoiceRepository
+	PaymentRepository           PaymentRepository
+	PenaltyRepository           PenaltyRepository
+	StatementRepository         StatementRepository
+	AnnouncementRepository      AnnouncementRepository
+	AmenityRepository           AmenityRepository
+	GatePassRepository          GatePassRepository
+	VehicleRepository           VehicleRepository
+	TicketRepository            TicketRepository
+	NotificationRepository      NotificationRepository
+	ViolationRepository         ViolationRepository
+	DocumentRepository          DocumentRepository
+	ElectionRepository          ElectionRepository
+	PollRepository              PollRepository
+	EventRepository             EventRepository
 }
 
-func NewRepository(db *sqlx.DB) *Repository {
+func NewRepository(conn *sqlx.DB) *Repository {
+	db := NewTenantDB(conn)
 	return &Repository{
-		UserRepository: NewUserRepository(db),
+		AssociationRepository:       NewAssociationRepository(db),
+		UserRepository:              NewUserRepository(db),
+		RoleRepository:              NewRoleRepository(db),
+		RefreshTokenRepository:      NewRefreshTokenRepository(db),
+		PasswordResetRepository:     NewPasswordResetRepository(db),
+		EmailVerificationRepository: NewEmailVerificationRepository(db),
+		MFARepository:               NewMFARepository(db),
+		RegistrationRepository:      NewRegistrationRepository(db),
+		PropertyRepository:          NewPropertyRepository(db),
+		OccupancyRepository:         NewOccupancyRepository(db),
+		BillingRepository:           NewBillingRepository(db),
+		InvoiceRepository:           NewInvoiceRepository(db),
+		PaymentRepository:           NewPaymentRepository(db),
+		PenaltyRepository:           NewPenaltyRepository(db),
+		StatementRepository:         NewStatementRepository(db),
+		AnnouncementRepository:      NewAnnouncementRepository(db),
+		AmenityRepository:           NewAmenityRepository(db),
+		GatePassRepository:          NewGatePassRepository(db),
+		VehicleRepository:           NewVehicleRepository(db),
+		TicketRepository:            NewTicketRepository(db),
+		NotificationRepository:      NewNotificationRepository(db),
+		ViolationRepository:         NewViolationRepository(db),
+		DocumentRepository:          NewDocumentRepository(db),
+		ElectionRepository:          NewElectionRepository(db),
+		PollRepository:              NewPollRepository(db),
+		EventRepository:             NewEventRepository(db),
 	}
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type RoleRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewRoleRepository(db *TenantDB) RoleRepository {
+	return &RoleRepositoryImpl{db: db}
+}
+
+func (repo *RoleRepositoryImpl) ListRoles(ctx context.Context) ([]model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	roles := []model.Role{}
+	query := `SELECT id, name, description FROM roles ORDER BY name`
+	if err := repo.db.SelectContext(ctx, &roles, query); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (repo *RoleRepositoryImpl) GetRoleByName(ctx context.Context, name string) (*model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var role model.Role
+	query := `SELECT id, name, description FROM roles WHERE name = $1`
+	err := repo.db.GetContext(ctx, &role, query, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get role by name: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (repo *RoleRepositoryImpl) GetRolesByUserID(ctx context.Context, userID uuid.UUID) ([]model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	roles := []model.Role{}
+	query := `SELECT r.id, r.name, r.description
+    FROM roles r
+    JOIN user_roles ur ON ur.role_id = r.id
+    WHERE ur.user_id = $1
+    ORDER BY r.name`
+	if err := repo.db.SelectContext(ctx, &roles, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get roles by user id: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (repo *RoleRepositoryImpl) GetPermissionsByUserID(ctx context.Context, userID uuid.UUID) ([]model.Permission, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	permissions := []model.Permission{}
+	query := `SELECT DISTINCT p.id, p.name, p.description
+    FROM permissions p
+    JOIN role_permissions rp ON rp.permission_id = p.id
+    JOIN user_roles ur ON ur.role_id = rp.role_id
+    WHERE ur.user_id = $1
+    ORDER BY p.name`
+	if err := repo.db.SelectContext(ctx, &permissions, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get permissions by user id: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (repo *RoleRepositoryImpl) UserHasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var exists bool
+	query := `SELECT EXISTS (
+        SELECT 1
+        FROM user_roles ur
+        JOIN role_permissions rp ON rp.role_id = ur.role_id
+        JOIN permissions p ON p.id = rp.permission_id
+        WHERE ur.user_id = $1 AND p.name = $2
+    )`
+	if err := repo.db.GetContext(ctx, &exists, query, userID, permission); err != nil {
+		return false, fmt.Errorf("failed to check user permission: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (repo *RoleRepositoryImpl) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := repo.db.ExecContext(ctx, query, userID, roleID); err != nil {
+		return fmt.Errorf("failed to assign role to user: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *RoleRepositoryImpl) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`
+	result, err := repo.db.ExecContext(ctx, query, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to remove role from user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove role from user: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
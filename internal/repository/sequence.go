@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// nextSequenceValue bumps a document_sequences counter inside tx. The row lock
+// is held until the transaction ends, so numbers stay gapless.
+func nextSequenceValue(ctx context.Context, tx *sqlx.Tx, name string) (int64, error) {
+	var value int64
+	query := `INSERT INTO document_sequences (name, last_value) VALUES ($1, 1)
+    ON CONFLICT (association_id, name) DO UPDATE SET last_value = document_sequences.last_value + 1
+    RETURNING last_value`
+	if err := tx.GetContext(ctx, &value, query, name); err != nil {
+		return 0, fmt.Errorf("failed to get next %s number: %w", name, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type StatementRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewStatementRepository(db *TenantDB) StatementRepository {
+	return &StatementRepositoryImpl{db: db}
+}
+
+// ListStatementEntries returns every charge and credit posted to the property
+// up to and including the given date, oldest first. Void invoices and their
+// penalties never reach a statement.
+func (repo *StatementRepositoryImpl) ListStatementEntries(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	entries := []model.StatementEntry{}
+	query := `SELECT i.issue_date AS entry_date, 'invoice' AS entry_type, i.id AS source_id, i.invoice_number AS reference,
+        CASE i.kind
+            WHEN 'fine' THEN (SELECT string_agg(li.description, '; ') FROM invoice_line_items li WHERE li.invoice_id = i.id)
+            ELSE 'Association dues for ' || to_char(i.billing_period, 'FMMonth YYYY')
+        END AS description,
+        i.total_cents - i.penalty_cents AS charge_cents, 0::BIGINT AS credit_cents, i.created_at
+    FROM invoices i
+    WHERE i.property_id = $1 AND i.status <> 'void' AND i.issue_date <= $2
+    UNION ALL
+    SELECT p.period_key, 'penalty', p.id, i.invoice_number,
+        CASE p.penalty_type WHEN 'fixed' THEN 'Late payment penalty' ELSE 'Interest on overdue dues' END,
+        p.amount_cents, 0, p.created_at
+    FROM invoice_penalties p
+    JOIN invoices i ON i.id = p.invoice_id
+    WHERE i.property_id = $1 AND i.status <> 'void' AND p.period_key <= $2
+    UNION ALL
+    SELECT p.waived_at::DATE, 'penalty_waiver', p.id, i.invoice_number,
+        'Penalty waived: ' || COALESCE(p.waive_reason, ''),
+        0, p.amount_cents, p.waived_at
+    FROM invoice_penalties p
+    JOIN invoices i ON i.id = p.invoice_id
+    WHERE i.property_id = $1 AND i.status <> 'void' AND p.status = 'waived' AND p.waived_at::DATE <= $2
+    UNION ALL
+    SELECT pm.paid_at, 'payment', pm.id, pm.receipt_number,
+        'Payment received (' || replace(pm.method, '_', ' ') || ')',
+        0, pm.amount_cents, pm.created_at
+    FROM payments pm
+    WHERE pm.property_id = $1 AND pm.paid_at <= $2
+    ORDER BY entry_date, created_at`
+	if err := repo.db.SelectContext(ctx, &entries, query, propertyID, to); err != nil {
+		return nil, fmt.Errorf("failed to list statement entries: %w", err)
+	}
+
+	return entries, nil
+}
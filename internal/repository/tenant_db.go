@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// TenantDB runs every statement in a transaction that switches to the
+// tenant database role and sets app.association_id to the association in
+// the context. Postgres row-level security then confines the statement to
+// that association; without one, tenant tables read as empty and inserts
+// fail.
+//
+// The scope is set per transaction with SET LOCAL rather than once per
+// request, so a connection can never go back to the pool, or on to a
+// background task, still carrying an association's settings, and requests
+// do not hold a pooled connection while they wait on storage or email. The
+// scope is sent without bind parameters so it costs one round trip, which a
+// standalone statement shares with its BEGIN: BEGIN and scope, the
+// statement, then COMMIT.
+type TenantDB struct {
+	db *sqlx.DB
+}
+
+func NewTenantDB(db *sqlx.DB) *TenantDB {
+	return &TenantDB{db: db}
+}
+
+func (t *TenantDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	tx, err := t.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, scopeStatements(ctx)); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+		return nil, fmt.Errorf("failed to scope transaction to association: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (t *TenantDB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return t.run(ctx, func(conn *sqlx.Conn) error {
+		return conn.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (t *TenantDB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return t.run(ctx, func(conn *sqlx.Conn) error {
+		return conn.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+func (t *TenantDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := t.run(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		result, err = conn.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (t *TenantDB) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
+	query, args, err := t.db.BindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.ExecContext(ctx, query, args...)
+}
+
+// run returns fn's error unwrapped so callers can still match
+// sql.ErrNoRows and constraint violations. It opens the transaction itself,
+// so BEGIN travels with the scope in one round trip.
+func (t *TenantDB) run(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := t.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN; "+scopeStatements(ctx)); err != nil {
+		rollback(ctx, conn)
+		return fmt.Errorf("failed to scope statement to association: %w", err)
+	}
+
+	if err := fn(conn); err != nil {
+		rollback(ctx, conn)
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		rollback(ctx, conn)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rollback ends a transaction run opened by hand. database/sql does not know
+// about it, so a connection that cannot roll back is discarded instead of
+// going back to the pool mid-transaction.
+func rollback(ctx context.Context, conn *sqlx.Conn) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.QueryTimeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+		log.Printf("rollback failed: %v\n", err)
+		if rErr := conn.Raw(func(any) error { return driver.ErrBadConn }); rErr != nil && !errors.Is(rErr, driver.ErrBadConn) {
+			log.Printf("failed to discard connection: %v\n", rErr)
+		}
+	}
+}
+
+// scopeStatements sets the role and association for the current
+// transaction. Both values are quoted literals rather than bind parameters,
+// so lib/pq sends them over the simple query protocol in one round trip.
+func scopeStatements(ctx context.Context) string {
+	associationID := ""
+	if id, ok := tenant.AssociationID(ctx); ok {
+		associationID = id.String()
+	}
+	return fmt.Sprintf("SET LOCAL ROLE %s; SET LOCAL app.association_id = %s",
+		pq.QuoteIdentifier(constants.TenantDBRole), pq.QuoteLiteral(associationID))
+}
+
+// namedExecer and selecter are implemented by both TenantDB and sqlx.Tx,
+// for helpers shared by standalone and transactional statements.
+type namedExecer interface {
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+}
+
+type selecter interface {
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type TicketFilter struct {
+	ReporterID     *uuid.UUID
+	AssignedUserID *uuid.UUID
+	PropertyID     *uuid.UUID
+	Status         string
+	Category       string
+	Priority       string
+	Limit          int
+	Offset         int
+}
+
+type TicketRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewTicketRepository(db *TenantDB) TicketRepository {
+	return &TicketRepositoryImpl{db: db}
+}
+
+func (repo *TicketRepositoryImpl) CreateTicket(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on create ticket: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	number, err := nextSequenceValue(ctx, tx, constants.SequenceTicket)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.ID = uuid.New()
+	ticket.TicketNumber = fmt.Sprintf("TKT-%06d", number)
+	ticket.Status = constants.TicketOpen
+	ticket.CreatedAt = time.Now()
+	ticket.UpdatedAt = ticket.CreatedAt
+
+	query := `INSERT INTO tickets (id, ticket_number, reporter_id, category, priority, title, description, property_id, common_area, status, created_at, updated_at)
+    VALUES (:id, :ticket_number, :reporter_id, :category, :priority, :title, :description, :property_id, :common_area, :status, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, ticket); err != nil {
+		return nil, fmt.Errorf("failed to insert ticket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ticket, nil
+}
+
+func (repo *TicketRepositoryImpl) GetTicketByID(ctx context.Context, id uuid.UUID) (*model.Ticket, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var ticket model.Ticket
+	query := `SELECT * FROM tickets WHERE id = $1`
+	err := repo.db.GetContext(ctx, &ticket, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket by id: %w", err)
+	}
+
+	return &ticket, nil
+}
+
+func (repo *TicketRepositoryImpl) ListTickets(ctx context.Context, filter TicketFilter) ([]model.Ticket, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.ReporterID != nil {
+		args = append(args, *filter.ReporterID)
+		conditions = append(conditions, fmt.Sprintf("reporter_id = $%d", len(args)))
+	}
+	if filter.AssignedUserID != nil {
+		args = append(args, *filter.AssignedUserID)
+		conditions = append(conditions, fmt.Sprintf("assigned_user_id = $%d", len(args)))
+	}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if filter.Priority != "" {
+		args = append(args, filter.Priority)
+		conditions = append(conditions, fmt.Sprintf("priority = $%d", len(args)))
+	}
+
+	query := `SELECT * FROM tickets`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	tickets := []model.Ticket{}
+	if err := repo.db.SelectContext(ctx, &tickets, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+func (repo *TicketRepositoryImpl) AssignTicket(ctx context.Context, id uuid.UUID, assignedUserID *uuid.UUID, assignedVendor *string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE tickets SET assigned_user_id = $2, assigned_vendor = $3, updated_at = now() WHERE id = $1`
+	result, err := repo.db.ExecContext(ctx, query, id, assignedUserID, assignedVendor)
+	if err != nil {
+		return fmt.Errorf("failed to assign ticket: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to assign ticket: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ChangeTicketStatus moves a ticket from one status to another and records
+// the change. It returns constants.ErrRecordNotFound when the ticket is no
+// longer in the from status, so two concurrent changes cannot both apply.
+func (repo *TicketRepositoryImpl) ChangeTicketStatus(ctx context.Context, change *model.TicketStatusChange) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on change ticket status: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE tickets SET status = $3::text,
+        resolved_at = CASE WHEN $3::text = 'resolved' THEN now() WHEN $3::text = 'reopened' THEN NULL ELSE resolved_at END,
+        closed_at = CASE WHEN $3::text = 'closed' THEN now() WHEN $3::text = 'reopened' THEN NULL ELSE closed_at END,
+        updated_at = now()
+    WHERE id = $1 AND status = $2`
+	result, err := tx.ExecContext(ctx, query, change.TicketID, change.FromStatus, change.ToStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update ticket status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	change.ID = uuid.New()
+	change.ChangedAt = time.Now()
+
+	query = `INSERT INTO ticket_status_changes (id, ticket_id, from_status, to_status, note, changed_by, changed_at)
+    VALUES (:id, :ticket_id, :from_status, :to_status, :note, :changed_by, :changed_at)`
+	if _, err := tx.NamedExecContext(ctx, query, change); err != nil {
+		return fmt.Errorf("failed to insert ticket status change: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *TicketRepositoryImpl) ListTicketStatusChanges(ctx context.Context, ticketID uuid.UUID) ([]model.TicketStatusChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	changes := []model.TicketStatusChange{}
+	query := `SELECT * FROM ticket_status_changes WHERE ticket_id = $1 ORDER BY changed_at`
+	if err := repo.db.SelectContext(ctx, &changes, query, ticketID); err != nil {
+		return nil, fmt.Errorf("failed to list ticket status changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (repo *TicketRepositoryImpl) AddTicketComment(ctx context.Context, comment *model.TicketComment) (*model.TicketComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	comment.ID = uuid.New()
+	comment.CreatedAt = time.Now()
+
+	query := `INSERT INTO ticket_comments (id, ticket_id, author_id, body, created_at)
+    VALUES (:id, :ticket_id, :author_id, :body, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, comment); err != nil {
+		return nil, fmt.Errorf("failed to insert ticket comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (repo *TicketRepositoryImpl) ListTicketComments(ctx context.Context, ticketID uuid.UUID) ([]model.TicketComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	comments := []model.TicketComment{}
+	query := `SELECT c.id, c.ticket_id, c.author_id, u.first_name, u.last_name, c.body, c.created_at
+    FROM ticket_comments c
+    LEFT JOIN users u ON u.id = c.author_id
+    WHERE c.ticket_id = $1
+    ORDER BY c.created_at`
+	if err := repo.db.SelectContext(ctx, &comments, query, ticketID); err != nil {
+		return nil, fmt.Errorf("failed to list ticket comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// AddTicketPhoto keeps the caller's photo ID, which is already part of the
+// photo's storage key.
+func (repo *TicketRepositoryImpl) AddTicketPhoto(ctx context.Context, photo *model.TicketPhoto) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	photo.CreatedAt = time.Now()
+
+	query := `INSERT INTO ticket_photos (id, ticket_id, storage_key, content_type, size_bytes, uploaded_by, created_at)
+    VALUES (:id, :ticket_id, :storage_key, :content_type, :size_bytes, :uploaded_by, :created_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, photo); err != nil {
+		return fmt.Errorf("failed to insert ticket photo: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *TicketRepositoryImpl) GetTicketPhoto(ctx context.Context, ticketID, photoID uuid.UUID) (*model.TicketPhoto, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var photo model.TicketPhoto
+	query := `SELECT * FROM ticket_photos WHERE id = $1 AND ticket_id = $2`
+	err := repo.db.GetContext(ctx, &photo, query, photoID, ticketID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket photo: %w", err)
+	}
+
+	return &photo, nil
+}
+
+func (repo *TicketRepositoryImpl) ListTicketPhotos(ctx context.Context, ticketID uuid.UUID) ([]model.TicketPhoto, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	photos := []model.TicketPhoto{}
+	query := `SELECT * FROM ticket_photos WHERE ticket_id = $1 ORDER BY created_at`
+	if err := repo.db.SelectContext(ctx, &photos, query, ticketID); err != nil {
+		return nil, fmt.Errorf("failed to list ticket photos: %w", err)
+	}
+
+	return photos, nil
+}
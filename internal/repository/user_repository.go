@@ -10,14 +10,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
-	"github.com/jmoiron/sqlx"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
 )
 
 type UserRepositoryImpl struct {
-	db *sqlx.DB
+	db *TenantDB
 }
 
-func NewUserRepository(db *sqlx.DB) UserRepository {
+func NewUserRepository(db *TenantDB) UserRepository {
 	return &UserRepositoryImpl{db: db}
 }
 
@@ -35,15 +35,8 @@ func (repo *UserRepositoryImpl) CreateUser(ctx context.Context, user *model.User
 		}
 	}()
 
-	user.ID = uuid.New()
-	user.CreatedAt = time.Now()
-
-	query := `INSERT INTO users (id, first_name, last_name, middle_name, date_of_birth, mobile_number, gender, email, password_hash, status, created_at)
-    VALUES (:id, :first_name, :last_name, :middle_name, :date_of_birth, :mobile_number, :gender, :email, :password_hash, :status, :created_at)`
-
-	_, err = tx.NamedExecContext(ctx, query, user)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert user: %w", err)
+	if err := insertUser(ctx, tx, user); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -53,6 +46,24 @@ func (repo *UserRepositoryImpl) CreateUser(ctx context.Context, user *model.User
 	return user, nil
 }
 
+func insertUser(ctx context.Context, exec namedExecer, user *model.User) error {
+	user.ID = uuid.New()
+	// the column defaults to the same value; tokens need it on the struct
+	user.AssociationID, _ = tenant.AssociationID(ctx)
+	user.CreatedAt = time.Now()
+
+	query := `INSERT INTO users (id, first_name, last_name, middle_name, date_of_birth, mobile_number, gender, email, password_hash, status, created_at)
+    VALUES (:id, :first_name, :last_name, :middle_name, :date_of_birth, :mobile_number, :gender, :email, :password_hash, :status, :created_at)`
+	if _, err := exec.NamedExecContext(ctx, query, user); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	return nil
+}
+
 func (repo *UserRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
 	defer cancel()
@@ -69,3 +80,111 @@ func (repo *UserRepositoryImpl) GetUserByEmail(ctx context.Context, email string
 
 	return &user, nil
 }
+
+func (repo *UserRepositoryImpl) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var user model.User
+	query := `SELECT * FROM users WHERE id = $1`
+	err := repo.db.GetContext(ctx, &user, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ReactivateUser makes a suspended or inactive account active again. Accounts
+// in any other status report constants.ErrRecordNotFound, so registrations
+// cannot skip email verification or approval this way.
+func (repo *UserRepositoryImpl) ReactivateUser(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE users SET status = 'active', updated_at = now() WHERE id = $1 AND status IN ('suspended', 'inactive')`
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SuspendUser marks the user suspended and revokes all of their sessions in
+// one transaction, so a suspended account never keeps a working token.
+func (repo *UserRepositoryImpl) SuspendUser(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on suspend user: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE users SET status = $2, updated_at = now() WHERE id = $1`
+	result, err := tx.ExecContext(ctx, query, id, constants.SuspendedStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	if err := revokeUserSessions(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *UserRepositoryImpl) DeleteUnverifiedUsers(ctx context.Context, cutoff time.Time) (int64, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	// the registrations go with the users; their proofs are returned so the
+	// files can be removed as well
+	var deleted []struct {
+		ID       uuid.UUID `db:"id"`
+		ProofKey *string   `db:"proof_key"`
+	}
+	query := `WITH deleted AS (DELETE FROM users WHERE status = $1 AND created_at < $2 RETURNING id)
+    SELECT d.id, r.proof_key FROM deleted d LEFT JOIN registrations r ON r.user_id = d.id`
+	if err := repo.db.SelectContext(ctx, &deleted, query, constants.PendingVerificationStatus, cutoff); err != nil {
+		return 0, nil, fmt.Errorf("failed to delete unverified users: %w", err)
+	}
+
+	proofKeys := []string{}
+	for _, user := range deleted {
+		if user.ProofKey != nil {
+			proofKeys = append(proofKeys, *user.ProofKey)
+		}
+	}
+
+	return int64(len(deleted)), proofKeys, nil
+}
@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type VehicleFilter struct {
+	PropertyID      *uuid.UUID
+	UserID          *uuid.UUID
+	PlateNumber     string
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
+
+type StickerFilter struct {
+	Year   int
+	Status string
+	Limit  int
+	Offset int
+}
+
+type VehicleRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewVehicleRepository(db *TenantDB) VehicleRepository {
+	return &VehicleRepositoryImpl{db: db}
+}
+
+func (repo *VehicleRepositoryImpl) CreateVehicle(ctx context.Context, vehicle *model.Vehicle) (*model.Vehicle, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	vehicle.ID = uuid.New()
+	vehicle.CreatedAt = time.Now()
+	vehicle.UpdatedAt = vehicle.CreatedAt
+	vehicle.Stickers = []model.VehicleSticker{}
+
+	query := `INSERT INTO vehicles (id, property_id, user_id, plate_number, make, model, color, created_at, updated_at)
+    VALUES (:id, :property_id, :user_id, :plate_number, :make, :model, :color, :created_at, :updated_at)`
+	if _, err := repo.db.NamedExecContext(ctx, query, vehicle); err != nil {
+		if isUniqueViolation(err) {
+			return nil, constants.ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to insert vehicle: %w", err)
+	}
+
+	return vehicle, nil
+}
+
+func (repo *VehicleRepositoryImpl) GetVehicleByID(ctx context.Context, id uuid.UUID) (*model.Vehicle, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var vehicle model.Vehicle
+	query := `SELECT * FROM vehicles WHERE id = $1`
+	err := repo.db.GetContext(ctx, &vehicle, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get vehicle by id: %w", err)
+	}
+
+	if vehicle.Stickers, err = listVehicleStickers(ctx, repo.db, vehicle.ID); err != nil {
+		return nil, err
+	}
+
+	return &vehicle, nil
+}
+
+// GetVehicleByPlate only matches vehicles that are still registered.
+func (repo *VehicleRepositoryImpl) GetVehicleByPlate(ctx context.Context, plateNumber string) (*model.Vehicle, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var vehicle model.Vehicle
+	query := `SELECT * FROM vehicles WHERE plate_number = $1 AND archived_at IS NULL`
+	err := repo.db.GetContext(ctx, &vehicle, query, plateNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get vehicle by plate: %w", err)
+	}
+
+	if vehicle.Stickers, err = listVehicleStickers(ctx, repo.db, vehicle.ID); err != nil {
+		return nil, err
+	}
+
+	return &vehicle, nil
+}
+
+func (repo *VehicleRepositoryImpl) ListVehicles(ctx context.Context, filter VehicleFilter) ([]model.Vehicle, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.PlateNumber != "" {
+		args = append(args, filter.PlateNumber+"%")
+		conditions = append(conditions, fmt.Sprintf("plate_number LIKE $%d", len(args)))
+	}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+
+	query := `SELECT * FROM vehicles`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY plate_number"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	vehicles := []model.Vehicle{}
+	if err := repo.db.SelectContext(ctx, &vehicles, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list vehicles: %w", err)
+	}
+
+	return vehicles, nil
+}
+
+// ArchiveVehicle removes the vehicle from the registry and revokes any
+// sticker it still holds.
+func (repo *VehicleRepositoryImpl) ArchiveVehicle(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on archive vehicle: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE vehicles SET archived_at = now(), updated_at = now() WHERE id = $1 AND archived_at IS NULL`
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive vehicle: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to archive vehicle: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	query = `UPDATE vehicle_stickers SET status = 'revoked', status_reason = 'vehicle removed from registry',
+        status_changed_at = now(), updated_at = now()
+    WHERE vehicle_id = $1 AND status = 'issued'`
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to revoke vehicle stickers: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IssueSticker numbers and stores a sticker for the given year. The property
+// row is locked while its issued stickers are counted, so concurrent
+// issuance cannot go over maxPerProperty.
+func (repo *VehicleRepositoryImpl) IssueSticker(ctx context.Context, sticker *model.VehicleSticker, propertyID uuid.UUID, maxPerProperty int) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on issue sticker: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `SELECT id FROM properties WHERE id = $1 FOR UPDATE`
+	if _, err := tx.ExecContext(ctx, query, propertyID); err != nil {
+		return fmt.Errorf("failed to lock property: %w", err)
+	}
+
+	var issued int
+	query = `SELECT count(*) FROM vehicle_stickers s
+    JOIN vehicles v ON v.id = s.vehicle_id
+    WHERE v.property_id = $1 AND s.sticker_year = $2 AND s.status = 'issued'`
+	if err := tx.GetContext(ctx, &issued, query, propertyID, sticker.StickerYear); err != nil {
+		return fmt.Errorf("failed to count issued stickers: %w", err)
+	}
+	if issued >= maxPerProperty {
+		return constants.ErrStickerLimit
+	}
+
+	number, err := nextSequenceValue(ctx, tx, fmt.Sprintf("%s-%d", constants.SequenceSticker, sticker.StickerYear))
+	if err != nil {
+		return err
+	}
+
+	sticker.ID = uuid.New()
+	sticker.StickerNumber = fmt.Sprintf("%d-%05d", sticker.StickerYear, number)
+	sticker.Status = constants.StickerIssued
+	sticker.IssuedAt = time.Now()
+	sticker.CreatedAt = sticker.IssuedAt
+	sticker.UpdatedAt = sticker.IssuedAt
+
+	query = `INSERT INTO vehicle_stickers (id, vehicle_id, sticker_year, sticker_number, status, issued_by, issued_at, created_at, updated_at)
+    VALUES (:id, :vehicle_id, :sticker_year, :sticker_number, :status, :issued_by, :issued_at, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, sticker); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert sticker: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *VehicleRepositoryImpl) GetStickerByID(ctx context.Context, id uuid.UUID) (*model.VehicleSticker, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var sticker model.VehicleSticker
+	query := `SELECT s.*, v.plate_number
+    FROM vehicle_stickers s
+    JOIN vehicles v ON v.id = s.vehicle_id
+    WHERE s.id = $1`
+	err := repo.db.GetContext(ctx, &sticker, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get sticker by id: %w", err)
+	}
+
+	return &sticker, nil
+}
+
+func (repo *VehicleRepositoryImpl) ListStickers(ctx context.Context, filter StickerFilter) ([]model.VehicleSticker, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.Year != 0 {
+		args = append(args, filter.Year)
+		conditions = append(conditions, fmt.Sprintf("s.sticker_year = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("s.status = $%d", len(args)))
+	}
+
+	query := `SELECT s.*, v.plate_number
+    FROM vehicle_stickers s
+    JOIN vehicles v ON v.id = s.vehicle_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY s.sticker_number"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	stickers := []model.VehicleSticker{}
+	if err := repo.db.SelectContext(ctx, &stickers, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list stickers: %w", err)
+	}
+
+	return stickers, nil
+}
+
+// UpdateStickerStatus marks an issued sticker as lost or revoked. It returns
+// constants.ErrRecordNotFound when the sticker is no longer issued.
+func (repo *VehicleRepositoryImpl) UpdateStickerStatus(ctx context.Context, id uuid.UUID, status string, reason *string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	query := `UPDATE vehicle_stickers SET status = $2, status_reason = $3, status_changed_at = now(), updated_at = now()
+    WHERE id = $1 AND status = 'issued'`
+	result, err := repo.db.ExecContext(ctx, query, id, status, reason)
+	if err != nil {
+		return fmt.Errorf("failed to update sticker status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update sticker status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func listVehicleStickers(ctx context.Context, db selecter, vehicleID uuid.UUID) ([]model.VehicleSticker, error) {
+	stickers := []model.VehicleSticker{}
+	query := `SELECT s.*, v.plate_number
+    FROM vehicle_stickers s
+    JOIN vehicles v ON v.id = s.vehicle_id
+    WHERE s.vehicle_id = $1
+    ORDER BY s.sticker_year DESC, s.issued_at DESC`
+	if err := db.SelectContext(ctx, &stickers, query, vehicleID); err != nil {
+		return nil, fmt.Errorf("failed to list vehicle stickers: %w", err)
+	}
+
+	return stickers, nil
+}
@@ -0,0 +1,533 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type ViolationFilter struct {
+	PropertyID *uuid.UUID
+	Status     string
+	Category   string
+	Limit      int
+	Offset     int
+}
+
+type ViolationRepositoryImpl struct {
+	db *TenantDB
+}
+
+func NewViolationRepository(db *TenantDB) ViolationRepository {
+	return &ViolationRepositoryImpl{db: db}
+}
+
+func (repo *ViolationRepositoryImpl) CreateViolation(ctx context.Context, violation *model.Violation) (*model.Violation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction on create violation: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	number, err := nextSequenceValue(ctx, tx, constants.SequenceViolation)
+	if err != nil {
+		return nil, err
+	}
+
+	violation.ID = uuid.New()
+	violation.ViolationNumber = fmt.Sprintf("VIO-%06d", number)
+	violation.Status = constants.ViolationOpen
+	violation.CreatedAt = time.Now()
+	violation.UpdatedAt = violation.CreatedAt
+
+	query := `INSERT INTO violations (id, violation_number, property_id, category, description, observed_at, status, reported_by, created_at, updated_at)
+    VALUES (:id, :violation_number, :property_id, :category, :description, :observed_at, :status, :reported_by, :created_at, :updated_at)`
+	if _, err := tx.NamedExecContext(ctx, query, violation); err != nil {
+		return nil, fmt.Errorf("failed to insert violation: %w", err)
+	}
+
+	if err := insertViolationEvent(ctx, tx, violation.ID, constants.ViolationEventRecorded, nil, violation.ReportedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return violation, nil
+}
+
+func (repo *ViolationRepositoryImpl) GetViolationByID(ctx context.Context, id uuid.UUID) (*model.Violation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var violation model.Violation
+	query := `SELECT * FROM violations WHERE id = $1`
+	err := repo.db.GetContext(ctx, &violation, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get violation by id: %w", err)
+	}
+
+	return &violation, nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolations(ctx context.Context, filter ViolationFilter) ([]model.Violation, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.PropertyID != nil {
+		args = append(args, *filter.PropertyID)
+		conditions = append(conditions, fmt.Sprintf("property_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+
+	query := `SELECT * FROM violations`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY observed_at DESC, violation_number DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	violations := []model.Violation{}
+	if err := repo.db.SelectContext(ctx, &violations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list violations: %w", err)
+	}
+
+	return violations, nil
+}
+
+// AddViolationAttachment keeps the caller's attachment ID, which is already
+// part of the attachment's storage key.
+func (repo *ViolationRepositoryImpl) AddViolationAttachment(ctx context.Context, attachment *model.ViolationAttachment) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on add violation attachment: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	attachment.CreatedAt = time.Now()
+
+	query := `INSERT INTO violation_attachments (id, violation_id, storage_key, file_name, content_type, size_bytes, uploaded_by, created_at)
+    VALUES (:id, :violation_id, :storage_key, :file_name, :content_type, :size_bytes, :uploaded_by, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, attachment); err != nil {
+		return fmt.Errorf("failed to insert violation attachment: %w", err)
+	}
+
+	if err := insertViolationEvent(ctx, tx, attachment.ViolationID, constants.ViolationEventEvidenceAdded, &attachment.FileName, attachment.UploadedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ViolationRepositoryImpl) GetViolationAttachment(ctx context.Context, violationID, id uuid.UUID) (*model.ViolationAttachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	var attachment model.ViolationAttachment
+	query := `SELECT * FROM violation_attachments WHERE id = $1 AND violation_id = $2`
+	err := repo.db.GetContext(ctx, &attachment, query, id, violationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, constants.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get violation attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolationAttachments(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAttachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	attachments := []model.ViolationAttachment{}
+	query := `SELECT * FROM violation_attachments WHERE violation_id = $1 ORDER BY created_at`
+	if err := repo.db.SelectContext(ctx, &attachments, query, violationID); err != nil {
+		return nil, fmt.Errorf("failed to list violation attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// IssueViolationNotice records the notice and moves the violation from one
+// status to another. It returns constants.ErrRecordNotFound when the
+// violation is no longer in the from status.
+func (repo *ViolationRepositoryImpl) IssueViolationNotice(ctx context.Context, notice *model.ViolationNotice, from, to string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on issue violation notice: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := moveViolation(ctx, tx, notice.ViolationID, from, to); err != nil {
+		return err
+	}
+
+	notice.ID = uuid.New()
+	notice.IssuedAt = time.Now()
+
+	query := `INSERT INTO violation_notices (id, violation_id, message, cure_deadline, issued_by, issued_at)
+    VALUES (:id, :violation_id, :message, :cure_deadline, :issued_by, :issued_at)`
+	if _, err := tx.NamedExecContext(ctx, query, notice); err != nil {
+		return fmt.Errorf("failed to insert violation notice: %w", err)
+	}
+
+	note := "Cure by " + notice.CureDeadline.Format(constants.DateFormat)
+	if err := insertViolationEvent(ctx, tx, notice.ViolationID, constants.ViolationEventNoticeIssued, &note, notice.IssuedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolationNotices(ctx context.Context, violationID uuid.UUID) ([]model.ViolationNotice, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	notices := []model.ViolationNotice{}
+	query := `SELECT * FROM violation_notices WHERE violation_id = $1 ORDER BY issued_at`
+	if err := repo.db.SelectContext(ctx, &notices, query, violationID); err != nil {
+		return nil, fmt.Errorf("failed to list violation notices: %w", err)
+	}
+
+	return notices, nil
+}
+
+// IssueViolationFine posts the fine as an invoice on the property's account
+// and moves the violation from the from status to fined. Account credit
+// from earlier overpayments settles the fine straight away.
+func (repo *ViolationRepositoryImpl) IssueViolationFine(ctx context.Context, fine *model.ViolationFine, invoice *model.Invoice, from string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on issue violation fine: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := moveViolation(ctx, tx, fine.ViolationID, from, constants.ViolationFined); err != nil {
+		return err
+	}
+
+	invoice.Kind = constants.InvoiceKindFine
+	if err := insertInvoice(ctx, tx, invoice); err != nil {
+		return err
+	}
+
+	fine.ID = uuid.New()
+	fine.InvoiceID = invoice.ID
+	fine.InvoiceNumber = invoice.InvoiceNumber
+	fine.InvoiceStatus = invoice.Status
+	fine.IssuedAt = time.Now()
+
+	query := `INSERT INTO violation_fines (id, violation_id, invoice_id, amount_cents, issued_by, issued_at)
+    VALUES (:id, :violation_id, :invoice_id, :amount_cents, :issued_by, :issued_at)`
+	if _, err := tx.NamedExecContext(ctx, query, fine); err != nil {
+		return fmt.Errorf("failed to insert violation fine: %w", err)
+	}
+
+	if err := insertViolationEvent(ctx, tx, fine.ViolationID, constants.ViolationEventFined, &invoice.InvoiceNumber, fine.IssuedBy); err != nil {
+		return err
+	}
+
+	if err := allocateCredit(ctx, tx, invoice.PropertyID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolationFines(ctx context.Context, violationID uuid.UUID) ([]model.ViolationFine, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	fines := []model.ViolationFine{}
+	query := `SELECT f.id, f.violation_id, f.invoice_id, i.invoice_number, i.status AS invoice_status, f.amount_cents, f.issued_by, f.issued_at
+    FROM violation_fines f
+    JOIN invoices i ON i.id = f.invoice_id
+    WHERE f.violation_id = $1
+    ORDER BY f.issued_at`
+	if err := repo.db.SelectContext(ctx, &fines, query, violationID); err != nil {
+		return nil, fmt.Errorf("failed to list violation fines: %w", err)
+	}
+
+	return fines, nil
+}
+
+// SubmitViolationAppeal records the appeal and moves the violation from the
+// from status to appealed. A violation can only have one pending appeal at a
+// time; a second one returns constants.ErrRecordExists.
+func (repo *ViolationRepositoryImpl) SubmitViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, from string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on submit violation appeal: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := moveViolation(ctx, tx, appeal.ViolationID, from, constants.ViolationAppealed); err != nil {
+		return err
+	}
+
+	appeal.ID = uuid.New()
+	appeal.Status = constants.AppealPending
+	appeal.SubmittedAt = time.Now()
+
+	query := `INSERT INTO violation_appeals (id, violation_id, submitted_by, reason, status, submitted_at)
+    VALUES (:id, :violation_id, :submitted_by, :reason, :status, :submitted_at)`
+	if _, err := tx.NamedExecContext(ctx, query, appeal); err != nil {
+		if isUniqueViolation(err) {
+			return constants.ErrRecordExists
+		}
+		return fmt.Errorf("failed to insert violation appeal: %w", err)
+	}
+
+	if err := insertViolationEvent(ctx, tx, appeal.ViolationID, constants.ViolationEventAppealSubmitted, nil, &appeal.SubmittedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolationAppeals(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAppeal, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	appeals := []model.ViolationAppeal{}
+	query := `SELECT * FROM violation_appeals WHERE violation_id = $1 ORDER BY submitted_at`
+	if err := repo.db.SelectContext(ctx, &appeals, query, violationID); err != nil {
+		return nil, fmt.Errorf("failed to list violation appeals: %w", err)
+	}
+
+	return appeals, nil
+}
+
+// DecideViolationAppeal records the decision on a pending appeal and moves
+// the violation out of appealed to the given status. Dismissing the
+// violation voids its fines that have not been paid yet.
+func (repo *ViolationRepositoryImpl) DecideViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, to string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on decide violation appeal: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	query := `UPDATE violation_appeals SET status = $3, decision_note = $4, decided_by = $5, decided_at = now()
+    WHERE id = $1 AND violation_id = $2 AND status = 'pending'`
+	result, err := tx.ExecContext(ctx, query, appeal.ID, appeal.ViolationID, appeal.Status, appeal.DecisionNote, appeal.DecidedBy)
+	if err != nil {
+		return fmt.Errorf("failed to decide violation appeal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to decide violation appeal: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	if err := moveViolation(ctx, tx, appeal.ViolationID, constants.ViolationAppealed, to); err != nil {
+		return err
+	}
+	if to == constants.ViolationDismissed {
+		if err := voidViolationFines(ctx, tx, appeal.ViolationID, "Appeal accepted"); err != nil {
+			return err
+		}
+	}
+
+	eventType := constants.ViolationEventAppealRejected
+	if appeal.Status == constants.AppealAccepted {
+		eventType = constants.ViolationEventAppealAccepted
+	}
+	if err := insertViolationEvent(ctx, tx, appeal.ViolationID, eventType, appeal.DecisionNote, appeal.DecidedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CloseViolation moves the violation from one status to resolved or
+// dismissed. Dismissing it voids its fines that have not been paid yet.
+func (repo *ViolationRepositoryImpl) CloseViolation(ctx context.Context, id uuid.UUID, from, to string, note *string, actorID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction on close violation: %w", err)
+	}
+	defer func() {
+		if rErr := tx.Rollback(); rErr != nil && rErr != sql.ErrTxDone {
+			log.Printf("rollback failed: %v\n", rErr)
+		}
+	}()
+
+	if err := moveViolation(ctx, tx, id, from, to); err != nil {
+		return err
+	}
+
+	eventType := constants.ViolationEventResolved
+	if to == constants.ViolationDismissed {
+		eventType = constants.ViolationEventDismissed
+		if err := voidViolationFines(ctx, tx, id, "Violation dismissed"); err != nil {
+			return err
+		}
+	}
+	if err := insertViolationEvent(ctx, tx, id, eventType, note, &actorID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ViolationRepositoryImpl) ListViolationEvents(ctx context.Context, violationID uuid.UUID) ([]model.ViolationEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.QueryTimeout)
+	defer cancel()
+
+	events := []model.ViolationEvent{}
+	query := `SELECT * FROM violation_events WHERE violation_id = $1 ORDER BY created_at`
+	if err := repo.db.SelectContext(ctx, &events, query, violationID); err != nil {
+		return nil, fmt.Errorf("failed to list violation events: %w", err)
+	}
+
+	return events, nil
+}
+
+// moveViolation changes the violation's status only while it is still in
+// the from status, so two concurrent actions cannot both apply.
+func moveViolation(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, from, to string) error {
+	query := `UPDATE violations SET status = $3::text,
+        closed_at = CASE WHEN $3::text IN ('resolved', 'dismissed') THEN now() ELSE NULL END,
+        updated_at = now()
+    WHERE id = $1 AND status = $2`
+	result, err := tx.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to update violation status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update violation status: %w", err)
+	}
+	if rows == 0 {
+		return constants.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// voidViolationFines voids the violation's fine invoices that nothing has
+// been paid against. Paid fines are left for the treasurer to refund.
+func voidViolationFines(ctx context.Context, tx *sqlx.Tx, violationID uuid.UUID, reason string) error {
+	query := `UPDATE invoices SET status = 'void', void_reason = $2, voided_at = now(), updated_at = now()
+    WHERE id IN (SELECT invoice_id FROM violation_fines WHERE violation_id = $1)
+        AND status = 'open' AND paid_cents = 0`
+	if _, err := tx.ExecContext(ctx, query, violationID, reason); err != nil {
+		return fmt.Errorf("failed to void violation fines: %w", err)
+	}
+
+	return nil
+}
+
+func insertViolationEvent(ctx context.Context, tx *sqlx.Tx, violationID uuid.UUID, eventType string, note *string, actorID *uuid.UUID) error {
+	query := `INSERT INTO violation_events (id, violation_id, event_type, note, actor_id, created_at)
+    VALUES ($1, $2, $3, $4, $5, now())`
+	if _, err := tx.ExecContext(ctx, query, uuid.New(), violationID, eventType, note, actorID); err != nil {
+		return fmt.Errorf("failed to insert violation event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type AmenityHandler struct {
+	amenityService service.AmenityService
+}
+
+func NewAmenityHandler(service service.AmenityService) *AmenityHandler {
+	return &AmenityHandler{
+		amenityService: service,
+	}
+}
+
+func (h *AmenityHandler) CreateAmenity(c *gin.Context) {
+	var request service.AmenityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.CreateAmenity(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) ListAmenities(c *gin.Context) {
+	h.listAmenities(c, false)
+}
+
+func (h *AmenityHandler) ListAllAmenities(c *gin.Context) {
+	h.listAmenities(c, true)
+}
+
+func (h *AmenityHandler) GetAmenity(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.amenityService.GetAmenity(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) UpdateAmenity(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.AmenityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.UpdateAmenity(c.Request.Context(), id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) GetAvailability(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.AvailabilityRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.GetAvailability(c.Request.Context(), id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) CreateReservation(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	amenityID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.ReservationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.CreateReservation(c.Request.Context(), userID, amenityID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) ListReservations(c *gin.Context) {
+	var request service.ListReservationsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.ListReservations(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) GetMyReservations(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request service.ListReservationsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.amenityService.ListUserReservations(c.Request.Context(), userID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) ApproveReservation(c *gin.Context) {
+	h.decideReservation(c, h.amenityService.ApproveReservation)
+}
+
+func (h *AmenityHandler) RejectReservation(c *gin.Context) {
+	h.decideReservation(c, h.amenityService.RejectReservation)
+}
+
+func (h *AmenityHandler) CancelReservation(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.CancelReservationRequest
+	if !bindOptionalJSON(c, &request) {
+		return
+	}
+
+	response, err := h.amenityService.CancelReservation(c.Request.Context(), id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) CancelMyReservation(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.CancelReservationRequest
+	if !bindOptionalJSON(c, &request) {
+		return
+	}
+
+	response, err := h.amenityService.CancelUserReservation(c.Request.Context(), userID, id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *AmenityHandler) listAmenities(c *gin.Context, includeInactive bool) {
+	response, err := h.amenityService.ListAmenities(c.Request.Context(), includeInactive)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+type reservationDecision func(ctx context.Context, id, decidedBy uuid.UUID, req *service.ReservationDecisionRequest) (*service.ReservationResponse, error)
+
+func (h *AmenityHandler) decideReservation(c *gin.Context, decide reservationDecision) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.ReservationDecisionRequest
+	if !bindOptionalJSON(c, &request) {
+		return
+	}
+
+	response, err := decide(c.Request.Context(), id, userID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
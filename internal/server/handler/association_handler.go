@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type AssociationHandler struct {
+	associationService service.AssociationService
+}
+
+func NewAssociationHandler(service service.AssociationService) *AssociationHandler {
+	return &AssociationHandler{
+		associationService: service,
+	}
+}
+
+// GetAssociation lets clients show the name of the association they
+// resolved to before signing in.
+func (h *AssociationHandler) GetAssociation(c *gin.Context) {
+	response, err := h.associationService.GetCurrentAssociation(c.Request.Context())
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type DocumentHandler struct {
+	documentService service.DocumentService
+}
+
+func NewDocumentHandler(service service.DocumentService) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: service,
+	}
+}
+
+// CreateDocument takes a multipart form with the file in "file" and the
+// document details as form fields.
+func (h *DocumentHandler) CreateDocument(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxDocumentBytes+1<<20)
+	var request service.DocumentRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	header, file, ok := documentFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	response, err := h.documentService.CreateDocument(c.Request.Context(), userID, &request, &service.FileUpload{
+		FileName:  header.Filename,
+		SizeBytes: header.Size,
+		Body:      file,
+	})
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.DocumentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.documentService.UpdateDocument(c.Request.Context(), id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// UploadVersion takes a multipart form with the new file in "file" and
+// optional change notes in "notes".
+func (h *DocumentHandler) UploadVersion(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxDocumentBytes+1<<20)
+	var request service.DocumentVersionRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	header, file, ok := documentFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	response, err := h.documentService.AddVersion(c.Request.Context(), id, userID, &request, &service.FileUpload{
+		FileName:  header.Filename,
+		SizeBytes: header.Size,
+		Body:      file,
+	})
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *DocumentHandler) ArchiveDocument(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.ArchiveDocument(c.Request.Context(), id); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DocumentHandler) ListDocuments(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request service.ListDocumentsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.documentService.ListDocuments(c.Request.Context(), userID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.documentService.GetDocument(c.Request.Context(), userID, id)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// GetDownloadURL returns a signed link rather than the file itself, so
+// clients can hand it to a browser or share it until it expires.
+func (h *DocumentHandler) GetDownloadURL(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.DocumentDownloadRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.documentService.GetDownloadURL(c.Request.Context(), userID, id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func documentFile(c *gin.Context) (*multipart.FileHeader, multipart.File, bool) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+	return header, file, true
+}
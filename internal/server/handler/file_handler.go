@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type FileHandler struct {
+	fileService service.FileService
+}
+
+func NewFileHandler(service service.FileService) *FileHandler {
+	return &FileHandler{
+		fileService: service,
+	}
+}
+
+// GetSignedFile serves a signed download link. The signature in the query
+// string stands in for authentication, so the route is public.
+func (h *FileHandler) GetSignedFile(c *gin.Context) {
+	var request service.SignedFileRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.Key = strings.TrimPrefix(c.Param("key"), "/")
+
+	file, err := h.fileService.OpenSignedFile(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	writeFile(c, file)
+}
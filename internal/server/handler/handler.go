@@ -1,17 +1,119 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
 )
 
 type Handler struct {
-	UserHandler *UserHandler
-	Auth        auth.IJWTAuth
+	UserHandler         *UserHandler
+	RegistrationHandler *RegistrationHandler
+	MFAHandler          *MFAHandler
+	RoleHandler         *RoleHandler
+	PropertyHandler     *PropertyHandler
+	OccupancyHandler    *OccupancyHandler
+	BillingHandler      *BillingHandler
+	PaymentHandler      *PaymentHandler
+	PenaltyHandler      *PenaltyHandler
+	StatementHandler    *StatementHandler
+	AnnouncementHandler *AnnouncementHandler
+	AmenityHandler      *AmenityHandler
+	GatePassHandler     *GatePassHandler
+	VehicleHandler      *VehicleHandler
+	TicketHandler       *TicketHandler
+	NotificationHandler *NotificationHandler
+	ViolationHandler    *ViolationHandler
+	DocumentHandler     *DocumentHandler
+	FileHandler         *FileHandler
+	ElectionHandler     *ElectionHandler
+	PollHandler         *PollHandler
+	EventHandler        *EventHandler
+	AssociationHandler  *AssociationHandler
+	Auth                auth.IJWTAuth
 }
 
 func NewHandler(services *service.Service, auth auth.IJWTAuth) *Handler {
 	return &Handler{
-		UserHandler: NewUserHandler(services.UserService, auth),
+		UserHandler:         NewUserHandler(services.UserService, services.AuthService, services.MFAService, auth),
+		RegistrationHandler: NewRegistrationHandler(services.RegistrationService),
+		MFAHandler:          NewMFAHandler(services.MFAService),
+		RoleHandler:         NewRoleHandler(services.RoleService),
+		PropertyHandler:     NewPropertyHandler(services.PropertyService),
+		OccupancyHandler:    NewOccupancyHandler(services.OccupancyService),
+		BillingHandler:      NewBillingHandler(services.BillingService),
+		PaymentHandler:      NewPaymentHandler(services.PaymentService),
+		PenaltyHandler:      NewPenaltyHandler(services.PenaltyService),
+		StatementHandler:    NewStatementHandler(services.StatementService),
+		AnnouncementHandler: NewAnnouncementHandler(services.AnnouncementService),
+		AmenityHandler:      NewAmenityHandler(services.AmenityService),
+		GatePassHandler:     NewGatePassHandler(services.GatePassService),
+		VehicleHandler:      NewVehicleHandler(services.VehicleService),
+		TicketHandler:       NewTicketHandler(services.TicketService),
+		NotificationHandler: NewNotificationHandler(services.NotificationService),
+		ViolationHandler:    NewViolationHandler(services.ViolationService),
+		DocumentHandler:     NewDocumentHandler(services.DocumentService),
+		FileHandler:         NewFileHandler(services.FileService),
+		ElectionHandler:     NewElectionHandler(services.ElectionService),
+		PollHandler:         NewPollHandler(services.PollService),
+		EventHandler:        NewEventHandler(services.EventService),
+		AssociationHandler:  NewAssociationHandler(services.AssociationService),
+		Auth:                auth,
+	}
+}
+
+// statusFromError maps the shared service errors to HTTP status codes.
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrRecordExists), errors.Is(err, constants.ErrTimeSlotTaken),
+		errors.Is(err, constants.ErrStickerLimit), errors.Is(err, constants.ErrEventFull):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrOverdueDues), errors.Is(err, constants.ErrGatePassDenied),
+		errors.Is(err, constants.ErrInvalidSignature):
+		return http.StatusForbidden
+	case errors.Is(err, constants.ErrInvalidInput), errors.Is(err, constants.ErrInvalidMFACode):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrMFALocked):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// bindOptionalJSON binds the request body when one was sent, so endpoints
+// whose fields are all optional also accept an empty POST.
+func bindOptionalJSON(c *gin.Context, request any) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func uuidParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return uuid.Nil, false
 	}
+	return id, true
 }
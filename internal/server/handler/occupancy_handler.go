@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type OccupancyHandler struct {
+	occupancyService service.OccupancyService
+}
+
+func NewOccupancyHandler(service service.OccupancyService) *OccupancyHandler {
+	return &OccupancyHandler{
+		occupancyService: service,
+	}
+}
+
+func (h *OccupancyHandler) AddOccupant(c *gin.Context) {
+	propertyID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.AddOccupantRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.occupancyService.AddOccupant(c.Request.Context(), propertyID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *OccupancyHandler) EndOccupancy(c *gin.Context) {
+	propertyID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+	occupancyID, ok := uuidParam(c, "occupancyId")
+	if !ok {
+		return
+	}
+
+	var request service.EndOccupancyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.occupancyService.EndOccupancy(c.Request.Context(), propertyID, occupancyID, &request); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OccupancyHandler) GetOccupants(c *gin.Context) {
+	propertyID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.occupancyService.GetOccupants(c.Request.Context(), propertyID, c.Query("date"))
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *OccupancyHandler) GetOccupancyHistory(c *gin.Context) {
+	propertyID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.occupancyService.GetOccupancyHistory(c.Request.Context(), propertyID)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *OccupancyHandler) LookupOccupants(c *gin.Context) {
+	var request service.OccupancyLookupRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.occupancyService.LookupOccupants(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *OccupancyHandler) TransferOwnership(c *gin.Context) {
+	propertyID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.occupancyService.TransferOwnership(c.Request.Context(), propertyID, &request); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OccupancyHandler) GetMyProperties(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.occupancyService.GetUserProperties(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *OccupancyHandler) GetUserProperties(c *gin.Context) {
+	userID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.occupancyService.GetUserProperties(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
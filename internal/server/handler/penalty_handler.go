@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type PenaltyHandler struct {
+	penaltyService service.PenaltyService
+}
+
+func NewPenaltyHandler(service service.PenaltyService) *PenaltyHandler {
+	return &PenaltyHandler{
+		penaltyService: service,
+	}
+}
+
+func (h *PenaltyHandler) CreatePenaltyRule(c *gin.Context) {
+	var request service.PenaltyRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.penaltyService.CreatePenaltyRule(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+func (h *PenaltyHandler) ListPenaltyRules(c *gin.Context) {
+	response, err := h.penaltyService.ListPenaltyRules(c.Request.Context())
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *PenaltyHandler) ListInvoicePenalties(c *gin.Context) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.penaltyService.ListInvoicePenalties(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+func (h *PenaltyHandler) WaivePenalty(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request service.WaivePenaltyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.penaltyService.WaivePenalty(c.Request.Context(), id, &request, userID); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
+)
+
+type StatementHandler struct {
+	statementService service.StatementService
+}
+
+func NewStatementHandler(service service.StatementService) *StatementHandler {
+	return &StatementHandler{
+		statementService: service,
+	}
+}
+
+func (h *StatementHandler) GetMyStatement(c *gin.Context) {
+	statement, ok := h.myStatement(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": statement})
+}
+
+func (h *StatementHandler) GetMyStatementPDF(c *gin.Context) {
+	statement, ok := h.myStatement(c)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, statement)
+}
+
+func (h *StatementHandler) GetPropertyStatement(c *gin.Context) {
+	statement, ok := h.propertyStatement(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": statement})
+}
+
+func (h *StatementHandler) GetPropertyStatementPDF(c *gin.Context) {
+	statement, ok := h.propertyStatement(c)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, statement)
+}
+
+func (h *StatementHandler) myStatement(c *gin.Context) (*service.StatementResponse, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	var request service.StatementRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	statement, err := h.statementService.GetUserStatement(c.Request.Context(), userID, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return statement, true
+}
+
+func (h *StatementHandler) propertyStatement(c *gin.Context) (*service.StatementResponse, bool) {
+	id, ok := uuidParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+
+	var request service.StatementRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	statement, err := h.statementService.GetPropertyStatement(c.Request.Context(), id, &request)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return statement, true
+}
+
+func (h *StatementHandler) writePDF(c *gin.Context, statement *service.StatementResponse) {
+	document, err := h.statementService.RenderStatementPDF(statement)
+	if err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	name := fmt.Sprintf("statement-%s-%s-%s-%s.pdf", statement.Property.Phase, statement.Property.Block, statement.Property.Lot, statement.To)
+	name = strings.NewReplacer(" ", "_", "/", "_", `"`, "_").Replace(name)
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
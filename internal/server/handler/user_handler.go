@@ -1,35 +1,68 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
 )
 
 type UserHandler struct {
 	userService service.UserService
+	authService service.AuthService
+	mfaService  service.MFAService
 	auth        auth.IJWTAuth
 }
 
-func NewUserHandler(service service.UserService, auth auth.IJWTAuth) *UserHandler {
+func NewUserHandler(service service.UserService, authService service.AuthService, mfaService service.MFAService, auth auth.IJWTAuth) *UserHandler {
 	return &UserHandler{
 		userService: service,
+		authService: authService,
+		mfaService:  mfaService,
 		auth:        auth,
 	}
 }
 
+// RegisterUser takes JSON, or a multipart form when the registrant claims a
+// property and attaches the title or lease as "proof".
 func (h *UserHandler) RegisterUser(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxRegistrationProofBytes+1<<20)
 	var request service.CreateUserRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.ShouldBind(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	response, err := h.userService.CreateUser(c.Request.Context(), &request)
+	var proof *service.FileUpload
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("proof")
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if header != nil {
+			file, err := header.Open()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			defer file.Close()
+
+			proof = &service.FileUpload{
+				FileName:  header.Filename,
+				SizeBytes: header.Size,
+				Body:      file,
+			}
+		}
+	}
+
+	response, err := h.userService.CreateUser(c.Request.Context(), &request, proof)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -45,26 +78,273 @@ func (h *UserHandler) Login(c *gin.Context) {
 
 	userResp, err := h.userService.LoginUser(c.Request.Context(), &request)
 	if err != nil {
+		if errors.Is(err, constants.ErrAccountInactive) || errors.Is(err, constants.ErrEmailNotVerified) ||
+			errors.Is(err, constants.ErrPendingApproval) || errors.Is(err, constants.ErrRegistrationRejected) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	tokenPairs, err := h.auth.GenerateToken(userResp)
+	// a second factor is redeemed at /auth/mfa/verify before any tokens
+	challenge, err := h.mfaService.ChallengeLogin(c.Request.Context(), userResp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	tokenPairs, err := h.authService.IssueTokens(c.Request.Context(), userResp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writeTokenResponse(c, userResp, tokenPairs)
+}
+
+// EnrollMFA gives a user whose role requires MFA, and who has not set it up,
+// a secret to add to their authenticator app before finishing sign in.
+func (h *UserHandler) EnrollMFA(c *gin.Context) {
+	var request service.MFAChallengeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.mfaService.EnrollWithChallenge(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(mfaLoginStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// VerifyMFA finishes a sign in that was answered with a challenge. The first
+// sign in after a forced enrollment also returns the recovery codes.
+func (h *UserHandler) VerifyMFA(c *gin.Context) {
+	var request service.MFALoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userResp, recoveryCodes, err := h.mfaService.CompleteLogin(c.Request.Context(), &request)
 	if err != nil {
+		c.JSON(mfaLoginStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenPairs, err := h.authService.IssueTokens(c.Request.Context(), userResp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := h.tokenResponse(c, userResp, tokenPairs)
+	if len(recoveryCodes) > 0 {
+		body["recovery_codes"] = recoveryCodes
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+func (h *UserHandler) Refresh(c *gin.Context) {
+	refreshToken, err := c.Cookie(h.auth.RefreshCookieName())
+	if err != nil || refreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrInvalidToken.Error()})
+		return
+	}
+
+	userResp, tokenPairs, err := h.authService.RefreshTokens(c.Request.Context(), refreshToken)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) || errors.Is(err, constants.ErrTokenReused) || errors.Is(err, constants.ErrAccountInactive) {
+			http.SetCookie(c.Writer, h.auth.GetExpiredRefreshCookie())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	h.writeTokenResponse(c, userResp, tokenPairs)
+}
+
+func (h *UserHandler) Logout(c *gin.Context) {
+	if refreshToken, err := c.Cookie(h.auth.RefreshCookieName()); err == nil && refreshToken != "" {
+		if err := h.authService.Logout(c.Request.Context(), refreshToken); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	http.SetCookie(c.Writer, h.auth.GetExpiredRefreshCookie())
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var request service.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.userService.VerifyEmail(c.Request.Context(), &request); err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "verification link is invalid or has expired"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification answers the same way whether or not the email belongs
+// to a pending registration.
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	var request service.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.userService.ResendVerification(c.Request.Context(), &request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"data": gin.H{"message": "if the registration is awaiting verification, a new link has been sent"}})
+}
+
+// ForgotPassword answers the same way whether or not the email belongs to
+// an account.
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var request service.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(c.Request.Context(), &request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"data": gin.H{"message": "if the email is registered, a reset link has been sent"}})
+}
+
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var request service.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), &request); err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "reset link is invalid or has expired"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	http.SetCookie(c.Writer, h.auth.GetExpiredRefreshCookie())
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	http.SetCookie(c.Writer, h.auth.GetExpiredRefreshCookie())
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	userID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.userService.SuspendUser(c.Request.Context(), userID); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	userID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.userService.ReactivateUser(c.Request.Context(), userID); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) RevokeUserSessions(c *gin.Context) {
+	userID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) writeTokenResponse(c *gin.Context, user *model.User, tokenPairs auth.TokenPairs) {
+	c.JSON(http.StatusOK, h.tokenResponse(c, user, tokenPairs))
+}
+
+// tokenResponse sets the refresh cookie and returns the body that carries
+// the access token.
+func (h *UserHandler) tokenResponse(c *gin.Context, user *model.User, tokenPairs auth.TokenPairs) gin.H {
 	refreshCookie := h.auth.GetRefreshCookie(tokenPairs.RefreshToken)
 	http.SetCookie(c.Writer, refreshCookie)
 
-	c.JSON(http.StatusOK, gin.H{
+	return gin.H{
 		"access_token": tokenPairs.AccessToken,
 		"user": gin.H{
-			"id":         userResp.ID.String(),
-			"first_name": userResp.FirstName,
-			"last_name":  userResp.LastName,
-			"email":      userResp.Email,
+			"id":         user.ID.String(),
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"email":      user.Email,
 		},
-	})
+	}
+}
+
+// mfaLoginStatus treats a bad challenge or code like a bad password.
+func mfaLoginStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrInvalidToken), errors.Is(err, constants.ErrInvalidMFACode):
+		return http.StatusUnauthorized
+	case errors.Is(err, constants.ErrAccountInactive):
+		return http.StatusForbidden
+	default:
+		return statusFromError(err)
+	}
 }
@@ -12,58 +12,200 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
 )
 
 type MockUserService struct {
-	CreateUserFn func(ctx context.Context, req *service.CreateUserRequest) (*service.CreatUserResponse, error)
-	LoginUserFn  func(ctx context.Context, req *service.LoginUserRequest) (*model.User, error)
+	CreateUserFn            func(ctx context.Context, req *service.CreateUserRequest, proof *service.FileUpload) (*service.CreatUserResponse, error)
+	LoginUserFn             func(ctx context.Context, req *service.LoginUserRequest) (*model.User, error)
+	SuspendUserFn           func(ctx context.Context, userID uuid.UUID) error
+	ReactivateUserFn        func(ctx context.Context, userID uuid.UUID) error
+	VerifyEmailFn           func(ctx context.Context, req *service.VerifyEmailRequest) error
+	ResendVerificationFn    func(ctx context.Context, req *service.ResendVerificationRequest) error
+	ExpireUnverifiedUsersFn func(ctx context.Context, now time.Time) error
 }
 
-func (m *MockUserService) CreateUser(ctx context.Context, req *service.CreateUserRequest) (*service.CreatUserResponse, error) {
-	return m.CreateUserFn(ctx, req)
+func (m *MockUserService) CreateUser(ctx context.Context, req *service.CreateUserRequest, proof *service.FileUpload) (*service.CreatUserResponse, error) {
+	return m.CreateUserFn(ctx, req, proof)
 }
 
 func (m *MockUserService) LoginUser(ctx context.Context, req *service.LoginUserRequest) (*model.User, error) {
 	return m.LoginUserFn(ctx, req)
 }
 
+func (m *MockUserService) SuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return m.SuspendUserFn(ctx, userID)
+}
+
+func (m *MockUserService) ReactivateUser(ctx context.Context, userID uuid.UUID) error {
+	return m.ReactivateUserFn(ctx, userID)
+}
+
+func (m *MockUserService) VerifyEmail(ctx context.Context, req *service.VerifyEmailRequest) error {
+	return m.VerifyEmailFn(ctx, req)
+}
+
+func (m *MockUserService) ResendVerification(ctx context.Context, req *service.ResendVerificationRequest) error {
+	return m.ResendVerificationFn(ctx, req)
+}
+
+func (m *MockUserService) ExpireUnverifiedUsers(ctx context.Context, now time.Time) error {
+	return m.ExpireUnverifiedUsersFn(ctx, now)
+}
+
+type MockAuthService struct {
+	IssueTokensFn          func(ctx context.Context, user *model.User) (auth.TokenPairs, error)
+	RefreshTokensFn        func(ctx context.Context, refreshToken string) (*model.User, auth.TokenPairs, error)
+	LogoutFn               func(ctx context.Context, refreshToken string) error
+	RevokeAllSessionsFn    func(ctx context.Context, userID uuid.UUID) error
+	PurgeRevokedSessionsFn func(ctx context.Context, now time.Time) error
+	RequestPasswordResetFn func(ctx context.Context, req *service.ForgotPasswordRequest) error
+	ResetPasswordFn        func(ctx context.Context, req *service.ResetPasswordRequest) error
+}
+
+func (m *MockAuthService) IssueTokens(ctx context.Context, user *model.User) (auth.TokenPairs, error) {
+	return m.IssueTokensFn(ctx, user)
+}
+
+func (m *MockAuthService) RefreshTokens(ctx context.Context, refreshToken string) (*model.User, auth.TokenPairs, error) {
+	return m.RefreshTokensFn(ctx, refreshToken)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error {
+	return m.LogoutFn(ctx, refreshToken)
+}
+
+func (m *MockAuthService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	return m.RevokeAllSessionsFn(ctx, userID)
+}
+
+func (m *MockAuthService) PurgeRevokedSessions(ctx context.Context, now time.Time) error {
+	return m.PurgeRevokedSessionsFn(ctx, now)
+}
+
+func (m *MockAuthService) RequestPasswordReset(ctx context.Context, req *service.ForgotPasswordRequest) error {
+	return m.RequestPasswordResetFn(ctx, req)
+}
+
+func (m *MockAuthService) ResetPassword(ctx context.Context, req *service.ResetPasswordRequest) error {
+	return m.ResetPasswordFn(ctx, req)
+}
+
+type MockMFAService struct {
+	ChallengeLoginFn          func(ctx context.Context, user *model.User) (*service.MFAChallengeResponse, error)
+	EnrollWithChallengeFn     func(ctx context.Context, req *service.MFAChallengeRequest) (*service.MFAEnrollmentResponse, error)
+	CompleteLoginFn           func(ctx context.Context, req *service.MFALoginRequest) (*model.User, []string, error)
+	GetMFAStatusFn            func(ctx context.Context, userID uuid.UUID) (*service.MFAStatusResponse, error)
+	StartEnrollmentFn         func(ctx context.Context, userID uuid.UUID) (*service.MFAEnrollmentResponse, error)
+	ConfirmEnrollmentFn       func(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) (*service.MFARecoveryCodesResponse, error)
+	RegenerateRecoveryCodesFn func(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) (*service.MFARecoveryCodesResponse, error)
+	DisableMFAFn              func(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) error
+	ResetMFAFn                func(ctx context.Context, userID uuid.UUID) error
+}
+
+func (m *MockMFAService) ChallengeLogin(ctx context.Context, user *model.User) (*service.MFAChallengeResponse, error) {
+	return m.ChallengeLoginFn(ctx, user)
+}
+
+func (m *MockMFAService) EnrollWithChallenge(ctx context.Context, req *service.MFAChallengeRequest) (*service.MFAEnrollmentResponse, error) {
+	return m.EnrollWithChallengeFn(ctx, req)
+}
+
+func (m *MockMFAService) CompleteLogin(ctx context.Context, req *service.MFALoginRequest) (*model.User, []string, error) {
+	return m.CompleteLoginFn(ctx, req)
+}
+
+func (m *MockMFAService) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*service.MFAStatusResponse, error) {
+	return m.GetMFAStatusFn(ctx, userID)
+}
+
+func (m *MockMFAService) StartEnrollment(ctx context.Context, userID uuid.UUID) (*service.MFAEnrollmentResponse, error) {
+	return m.StartEnrollmentFn(ctx, userID)
+}
+
+func (m *MockMFAService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) (*service.MFARecoveryCodesResponse, error) {
+	return m.ConfirmEnrollmentFn(ctx, userID, req)
+}
+
+func (m *MockMFAService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) (*service.MFARecoveryCodesResponse, error) {
+	return m.RegenerateRecoveryCodesFn(ctx, userID, req)
+}
+
+func (m *MockMFAService) DisableMFA(ctx context.Context, userID uuid.UUID, req *service.MFACodeRequest) error {
+	return m.DisableMFAFn(ctx, userID, req)
+}
+
+func (m *MockMFAService) ResetMFA(ctx context.Context, userID uuid.UUID) error {
+	return m.ResetMFAFn(ctx, userID)
+}
+
 type MockJWTAuth struct {
-	GenerateTokenFn        func(user *model.User) (auth.TokenPairs, error)
+	GenerateTokenFn        func(user *model.User, familyID uuid.UUID) (auth.TokenPairs, error)
 	GenerateTokenCalled    bool
-	ParseAccessTokenFn     func(tokenStr string) (*auth.Claims, error)
+	ParseAccessTokenFn     func(ctx context.Context, tokenStr string) (*auth.Claims, error)
 	ParseAccessTokenCalled bool
+	ParseRefreshTokenFn    func(tokenStr string) (*auth.RefreshClaims, error)
+	GenerateGatePassFn     func(passID uuid.UUID, expiresAt time.Time) (string, error)
+	ParseGatePassFn        func(tokenStr string) (*auth.GatePassClaims, error)
+	GenerateMFAChallengeFn func(user *model.User, expiresAt time.Time) (string, error)
+	ParseMFAChallengeFn    func(tokenStr string) (*auth.MFAChallengeClaims, error)
 }
 
-func (m *MockJWTAuth) GenerateToken(user *model.User) (auth.TokenPairs, error) {
+func (m *MockJWTAuth) GenerateToken(user *model.User, familyID uuid.UUID) (auth.TokenPairs, error) {
 	m.GenerateTokenCalled = true
-	return m.GenerateTokenFn(user)
+	return m.GenerateTokenFn(user, familyID)
 }
 
-func (m *MockJWTAuth) ParseAccessToken(tokenStr string) (*auth.Claims, error) {
+func (m *MockJWTAuth) ParseAccessToken(ctx context.Context, tokenStr string) (*auth.Claims, error) {
 	m.ParseAccessTokenCalled = true
-	return m.ParseAccessTokenFn(tokenStr)
+	return m.ParseAccessTokenFn(ctx, tokenStr)
+}
+
+func (m *MockJWTAuth) ParseRefreshToken(tokenStr string) (*auth.RefreshClaims, error) {
+	return m.ParseRefreshTokenFn(tokenStr)
+}
+
+func (m *MockJWTAuth) GenerateGatePass(passID uuid.UUID, expiresAt time.Time) (string, error) {
+	return m.GenerateGatePassFn(passID, expiresAt)
+}
+
+func (m *MockJWTAuth) ParseGatePass(tokenStr string) (*auth.GatePassClaims, error) {
+	return m.ParseGatePassFn(tokenStr)
+}
+
+func (m *MockJWTAuth) GenerateMFAChallenge(user *model.User, expiresAt time.Time) (string, error) {
+	return m.GenerateMFAChallengeFn(user, expiresAt)
+}
+
+func (m *MockJWTAuth) ParseMFAChallenge(tokenStr string) (*auth.MFAChallengeClaims, error) {
+	return m.ParseMFAChallengeFn(tokenStr)
 }
 
 func (m *MockJWTAuth) GetRefreshCookie(refreshToken string) *http.Cookie {
-	return &http.Cookie{}
+	return &http.Cookie{Name: "refresh_token", Value: refreshToken}
 }
 
 func (m *MockJWTAuth) GetExpiredRefreshCookie() *http.Cookie {
-	return &http.Cookie{}
+	return &http.Cookie{Name: "refresh_token", MaxAge: -1}
+}
+
+func (m *MockJWTAuth) RefreshCookieName() string {
+	return "refresh_token"
 }
 
 func setupJWTManagerMock() *MockJWTAuth {
 	return &MockJWTAuth{
-		GenerateTokenFn: func(user *model.User) (auth.TokenPairs, error) {
+		GenerateTokenFn: func(user *model.User, familyID uuid.UUID) (auth.TokenPairs, error) {
 			return auth.TokenPairs{
 				AccessToken:  "token12345",
 				RefreshToken: "refresh12345",
 			}, nil
 		},
-		ParseAccessTokenFn: func(tokenStr string) (*auth.Claims, error) {
+		ParseAccessTokenFn: func(ctx context.Context, tokenStr string) (*auth.Claims, error) {
 			return &auth.Claims{
 				UserID: "12345",
 				Name:   "John Doe",
@@ -97,7 +239,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			},
 			mockService: func() *MockUserService {
 				return &MockUserService{
-					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest) (*service.CreatUserResponse, error) {
+					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest, proof *service.FileUpload) (*service.CreatUserResponse, error) {
 						return &service.CreatUserResponse{Email: req.Email}, nil
 					},
 				}
@@ -111,7 +253,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			},
 			mockService: func() *MockUserService {
 				return &MockUserService{
-					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest) (*service.CreatUserResponse, error) {
+					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest, proof *service.FileUpload) (*service.CreatUserResponse, error) {
 						return nil, errors.New("some error")
 					},
 				}
@@ -130,7 +272,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			},
 			mockService: func() *MockUserService {
 				return &MockUserService{
-					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest) (*service.CreatUserResponse, error) {
+					CreateUserFn: func(ctx context.Context, req *service.CreateUserRequest, proof *service.FileUpload) (*service.CreatUserResponse, error) {
 						return nil, errors.New("some error")
 					},
 				}
@@ -146,7 +288,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			mockJwtManager := setupJWTManagerMock()
 
 			mockSvc := tc.mockService()
-			h := NewUserHandler(mockSvc, mockJwtManager)
+			h := NewUserHandler(mockSvc, &MockAuthService{}, &MockMFAService{}, mockJwtManager)
 
 			r.POST("/register", h.RegisterUser)
 
@@ -163,3 +305,214 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name           string
+		cookie         *http.Cookie
+		refreshErr     error
+		expectedStatus int
+		expectCleared  bool
+	}{
+		{
+			name:           "success",
+			cookie:         &http.Cookie{Name: "refresh_token", Value: "refresh12345"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing cookie",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "reused token",
+			cookie:         &http.Cookie{Name: "refresh_token", Value: "refresh12345"},
+			refreshErr:     constants.ErrTokenReused,
+			expectedStatus: http.StatusUnauthorized,
+			expectCleared:  true,
+		},
+		{
+			name:           "service error",
+			cookie:         &http.Cookie{Name: "refresh_token", Value: "refresh12345"},
+			refreshErr:     errors.New("some error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+
+			authSvc := &MockAuthService{
+				RefreshTokensFn: func(ctx context.Context, refreshToken string) (*model.User, auth.TokenPairs, error) {
+					if tc.refreshErr != nil {
+						return nil, auth.TokenPairs{}, tc.refreshErr
+					}
+					return &model.User{ID: uuid.New()}, auth.TokenPairs{AccessToken: "token67890", RefreshToken: "refresh67890"}, nil
+				},
+			}
+			h := NewUserHandler(&MockUserService{}, authSvc, &MockMFAService{}, setupJWTManagerMock())
+
+			r.POST("/refresh", h.Refresh)
+
+			req, _ := http.NewRequest(http.MethodPost, "/refresh", nil)
+			if tc.cookie != nil {
+				req.AddCookie(tc.cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			cleared := false
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == "refresh_token" && cookie.MaxAge < 0 {
+					cleared = true
+				}
+			}
+			if cleared != tc.expectCleared {
+				t.Errorf("expected cookie cleared %v, got %v", tc.expectCleared, cleared)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	tests := []struct {
+		name          string
+		cookie        *http.Cookie
+		expectRevoked bool
+	}{
+		{
+			name:          "revokes current session",
+			cookie:        &http.Cookie{Name: "refresh_token", Value: "refresh12345"},
+			expectRevoked: true,
+		},
+		{
+			name: "no cookie still clears",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+
+			revoked := false
+			authSvc := &MockAuthService{
+				LogoutFn: func(ctx context.Context, refreshToken string) error {
+					revoked = refreshToken == "refresh12345"
+					return nil
+				},
+			}
+			h := NewUserHandler(&MockUserService{}, authSvc, &MockMFAService{}, setupJWTManagerMock())
+
+			r.POST("/logout", h.Logout)
+
+			req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
+			if tc.cookie != nil {
+				req.AddCookie(tc.cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+			}
+			if revoked != tc.expectRevoked {
+				t.Errorf("expected revoked %v, got %v", tc.expectRevoked, revoked)
+			}
+
+			cleared := false
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == "refresh_token" && cookie.MaxAge < 0 {
+					cleared = true
+				}
+			}
+			if !cleared {
+				t.Error("expected refresh cookie to be cleared")
+			}
+		})
+	}
+}
+
+func TestUserHandler_VerifyMFA(t *testing.T) {
+	tests := []struct {
+		name                string
+		recoveryCodes       []string
+		verifyErr           error
+		expectedStatus      int
+		expectRecoveryCodes bool
+	}{
+		{
+			name:           "success",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                "first sign in after enrollment",
+			recoveryCodes:       []string{"abcde-fghij"},
+			expectedStatus:      http.StatusOK,
+			expectRecoveryCodes: true,
+		},
+		{
+			name:           "wrong code",
+			verifyErr:      constants.ErrInvalidMFACode,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "expired challenge",
+			verifyErr:      constants.ErrInvalidToken,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "locked",
+			verifyErr:      constants.ErrMFALocked,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+
+			mfaSvc := &MockMFAService{
+				CompleteLoginFn: func(ctx context.Context, req *service.MFALoginRequest) (*model.User, []string, error) {
+					if tc.verifyErr != nil {
+						return nil, nil, tc.verifyErr
+					}
+					return &model.User{ID: uuid.New()}, tc.recoveryCodes, nil
+				},
+			}
+			authSvc := &MockAuthService{
+				IssueTokensFn: func(ctx context.Context, user *model.User) (auth.TokenPairs, error) {
+					return auth.TokenPairs{AccessToken: "token12345", RefreshToken: "refresh12345"}, nil
+				},
+			}
+			h := NewUserHandler(&MockUserService{}, authSvc, mfaSvc, setupJWTManagerMock())
+
+			r.POST("/mfa/verify", h.VerifyMFA)
+
+			body, _ := json.Marshal(map[string]string{"challengeToken": "challenge12345", "code": "123456"})
+			req, _ := http.NewRequest(http.MethodPost, "/mfa/verify", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			var resp map[string]interface{}
+			_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+			if _, ok := resp["recovery_codes"]; ok != tc.expectRecoveryCodes {
+				t.Errorf("expected recovery codes %v, got %v", tc.expectRecoveryCodes, ok)
+			}
+		})
+	}
+}
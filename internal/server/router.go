@@ -5,21 +5,310 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/middleware"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/server/handler"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/service"
 )
 
-func NewRouter(services *service.Service, jwt auth.IJWTAuth) *gin.Engine {
+func NewRouter(services *service.Service, jwt auth.IJWTAuth, tenantBaseDomain string) *gin.Engine {
 	r := gin.Default()
 
 	handler := handler.NewHandler(services, jwt)
-	_ = handler
-	v1 := r.Group("/v1")
+	requireAuth := middleware.AuthMiddleware(jwt, services.AssociationService)
+	requireAssociation := middleware.RequireAssociation()
+	requirePermission := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(services.RoleService, permission)
+	}
+
+	v1 := r.Group("/v1", middleware.ResolveAssociation(services.AssociationService, tenantBaseDomain))
 	{
 		v1.GET("/health", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "OK"})
 		})
 
+		// signed download links carry their own authorization
+		v1.GET("/files/*key", handler.FileHandler.GetSignedFile)
+		// calendar apps subscribe without credentials; the token is the secret
+		v1.GET("/calendar/:association/:token", requireAssociation, handler.EventHandler.GetCalendarFeed)
+
+		v1.GET("/association", requireAssociation, handler.AssociationHandler.GetAssociation)
+
+		authRoutes := v1.Group("/auth", requireAssociation)
+		{
+			authRoutes.POST("/register", handler.UserHandler.RegisterUser)
+			authRoutes.POST("/login", handler.UserHandler.Login)
+			authRoutes.POST("/mfa/enroll", handler.UserHandler.EnrollMFA)
+			authRoutes.POST("/mfa/verify", handler.UserHandler.VerifyMFA)
+			authRoutes.POST("/refresh", handler.UserHandler.Refresh)
+			authRoutes.POST("/logout", handler.UserHandler.Logout)
+			authRoutes.POST("/verify-email", handler.UserHandler.VerifyEmail)
+			authRoutes.POST("/resend-verification", handler.UserHandler.ResendVerification)
+			authRoutes.POST("/forgot-password", handler.UserHandler.ForgotPassword)
+			authRoutes.POST("/reset-password", handler.UserHandler.ResetPassword)
+		}
+
+		me := v1.Group("/me", requireAuth)
+		{
+			me.GET("/access", handler.RoleHandler.GetMyAccess)
+			me.POST("/logout-all", handler.UserHandler.LogoutAll)
+			me.GET("/mfa", handler.MFAHandler.GetMyMFAStatus)
+			me.POST("/mfa/enroll", handler.MFAHandler.StartMyEnrollment)
+			me.POST("/mfa/confirm", handler.MFAHandler.ConfirmMyEnrollment)
+			me.POST("/mfa/recovery-codes", handler.MFAHandler.RegenerateMyRecoveryCodes)
+			me.POST("/mfa/disable", handler.MFAHandler.DisableMyMFA)
+			me.GET("/properties", handler.OccupancyHandler.GetMyProperties)
+			me.GET("/statement", handler.StatementHandler.GetMyStatement)
+			me.GET("/statement/pdf", handler.StatementHandler.GetMyStatementPDF)
+			me.GET("/reservations", handler.AmenityHandler.GetMyReservations)
+			me.POST("/reservations/:id/cancel", handler.AmenityHandler.CancelMyReservation)
+			me.POST("/gate-passes", handler.GatePassHandler.CreateGatePass)
+			me.GET("/gate-passes", handler.GatePassHandler.GetMyGatePasses)
+			me.GET("/gate-passes/:id", handler.GatePassHandler.GetMyGatePass)
+			me.POST("/gate-passes/:id/revoke", handler.GatePassHandler.RevokeMyGatePass)
+			me.POST("/vehicles", handler.VehicleHandler.RegisterVehicle)
+			me.GET("/vehicles", handler.VehicleHandler.GetMyVehicles)
+			me.DELETE("/vehicles/:id", handler.VehicleHandler.RemoveMyVehicle)
+			me.POST("/tickets", handler.TicketHandler.CreateTicket)
+			me.GET("/tickets", handler.TicketHandler.GetMyTickets)
+			me.GET("/tickets/:id", handler.TicketHandler.GetMyTicket)
+			me.POST("/tickets/:id/comments", handler.TicketHandler.CommentOnMyTicket)
+			me.POST("/tickets/:id/photos", handler.TicketHandler.UploadMyTicketPhoto)
+			me.GET("/tickets/:id/photos/:photoId", handler.TicketHandler.GetMyTicketPhoto)
+			me.POST("/tickets/:id/reopen", handler.TicketHandler.ReopenMyTicket)
+			me.GET("/violations", handler.ViolationHandler.GetMyViolations)
+			me.GET("/violations/:id", handler.ViolationHandler.GetMyViolation)
+			me.GET("/violations/:id/evidence/:attachmentId", handler.ViolationHandler.GetMyEvidence)
+			me.POST("/violations/:id/appeal", handler.ViolationHandler.AppealMyViolation)
+			me.GET("/election-proxies", handler.ElectionHandler.GetMyProxies)
+			me.POST("/calendar-feed", handler.EventHandler.CreateMyCalendarFeed)
+			me.DELETE("/calendar-feed", handler.EventHandler.RevokeMyCalendarFeed)
+			me.GET("/notifications", handler.NotificationHandler.ListNotifications)
+			me.GET("/notifications/unread-count", handler.NotificationHandler.CountUnread)
+			me.POST("/notifications/:id/read", handler.NotificationHandler.MarkRead)
+			me.POST("/notifications/read-all", handler.NotificationHandler.MarkAllRead)
+		}
+
+		v1.GET("/roles", requireAuth, requirePermission(constants.PermManageUsers), handler.RoleHandler.ListRoles)
+
+		users := v1.Group("/users", requireAuth, requirePermission(constants.PermManageUsers))
+		{
+			users.GET("/:id/access", handler.RoleHandler.GetUserAccess)
+			users.POST("/:id/roles", handler.RoleHandler.AssignRole)
+			users.DELETE("/:id/roles/:role", handler.RoleHandler.RemoveRole)
+			users.POST("/:id/suspend", handler.UserHandler.SuspendUser)
+			users.POST("/:id/reactivate", handler.UserHandler.ReactivateUser)
+			users.DELETE("/:id/sessions", handler.UserHandler.RevokeUserSessions)
+			users.DELETE("/:id/mfa", handler.MFAHandler.ResetUserMFA)
+			users.GET("/:id/properties", handler.OccupancyHandler.GetUserProperties)
+		}
+
+		registrations := v1.Group("/registrations", requireAuth, requirePermission(constants.PermManageUsers))
+		{
+			registrations.GET("", handler.RegistrationHandler.ListRegistrations)
+			registrations.GET("/:id", handler.RegistrationHandler.GetRegistration)
+			registrations.GET("/:id/proof", handler.RegistrationHandler.GetRegistrationProof)
+			registrations.POST("/:id/approve", handler.RegistrationHandler.ApproveRegistration)
+			registrations.POST("/:id/reject", handler.RegistrationHandler.RejectRegistration)
+		}
+
+		properties := v1.Group("/properties", requireAuth, requirePermission(constants.PermManageProperties))
+		{
+			properties.POST("", handler.PropertyHandler.CreateProperty)
+			properties.GET("", handler.PropertyHandler.ListProperties)
+			properties.GET("/:id", handler.PropertyHandler.GetProperty)
+			properties.PUT("/:id", handler.PropertyHandler.UpdateProperty)
+			properties.POST("/:id/archive", handler.PropertyHandler.ArchiveProperty)
+			properties.GET("/:id/occupants", handler.OccupancyHandler.GetOccupants)
+			properties.GET("/:id/occupants/history", handler.OccupancyHandler.GetOccupancyHistory)
+			properties.POST("/:id/occupants", handler.OccupancyHandler.AddOccupant)
+			properties.POST("/:id/occupants/:occupancyId/end", handler.OccupancyHandler.EndOccupancy)
+			properties.POST("/:id/transfer", handler.OccupancyHandler.TransferOwnership)
+		}
+
+		v1.GET("/occupancy", requireAuth, requirePermission(constants.PermManageProperties), handler.OccupancyHandler.LookupOccupants)
+
+		finances := v1.Group("", requireAuth, requirePermission(constants.PermManageFinances))
+		{
+			finances.POST("/dues-schedules", handler.BillingHandler.CreateDuesSchedule)
+			finances.GET("/dues-schedules", handler.BillingHandler.ListDuesSchedules)
+			finances.POST("/billing/preview", handler.BillingHandler.PreviewBillingRun)
+			finances.POST("/billing/runs", handler.BillingHandler.RunBilling)
+			finances.GET("/billing/runs", handler.BillingHandler.ListBillingRuns)
+			finances.GET("/invoices", handler.BillingHandler.ListInvoices)
+			finances.GET("/invoices/:id", handler.BillingHandler.GetInvoice)
+			finances.POST("/invoices/:id/void", handler.BillingHandler.VoidInvoice)
+			finances.GET("/invoices/:id/penalties", handler.PenaltyHandler.ListInvoicePenalties)
+			finances.POST("/penalty-rules", handler.PenaltyHandler.CreatePenaltyRule)
+			finances.GET("/penalty-rules", handler.PenaltyHandler.ListPenaltyRules)
+			finances.POST("/penalties/:id/waive", handler.PenaltyHandler.WaivePenalty)
+			finances.POST("/payments", handler.PaymentHandler.RecordPayment)
+			finances.GET("/payments", handler.PaymentHandler.ListPayments)
+			finances.GET("/payments/:id", handler.PaymentHandler.GetPayment)
+			finances.GET("/properties/:id/credit", handler.PaymentHandler.GetPropertyCredit)
+			finances.GET("/properties/:id/statement", handler.StatementHandler.GetPropertyStatement)
+			finances.GET("/properties/:id/statement/pdf", handler.StatementHandler.GetPropertyStatementPDF)
+		}
+
+		announcements := v1.Group("/announcements", requireAuth)
+		{
+			announcements.GET("", handler.AnnouncementHandler.GetFeed)
+			announcements.GET("/unread-count", handler.AnnouncementHandler.CountUnread)
+			announcements.GET("/:id", handler.AnnouncementHandler.GetFeedAnnouncement)
+			announcements.POST("/:id/read", handler.AnnouncementHandler.MarkRead)
+
+			manage := announcements.Group("", requirePermission(constants.PermManageAnnouncements))
+			manage.POST("", handler.AnnouncementHandler.CreateAnnouncement)
+			manage.GET("/manage", handler.AnnouncementHandler.ListAnnouncements)
+			manage.PUT("/:id", handler.AnnouncementHandler.UpdateAnnouncement)
+			manage.DELETE("/:id", handler.AnnouncementHandler.DeleteAnnouncement)
+			manage.GET("/:id/reads", handler.AnnouncementHandler.ListReadReceipts)
+		}
+
+		amenities := v1.Group("/amenities", requireAuth)
+		{
+			amenities.GET("", handler.AmenityHandler.ListAmenities)
+			amenities.GET("/:id", handler.AmenityHandler.GetAmenity)
+			amenities.GET("/:id/availability", handler.AmenityHandler.GetAvailability)
+			amenities.POST("/:id/reservations", handler.AmenityHandler.CreateReservation)
+
+			manage := amenities.Group("", requirePermission(constants.PermManageAmenities))
+			manage.POST("", handler.AmenityHandler.CreateAmenity)
+			manage.GET("/manage", handler.AmenityHandler.ListAllAmenities)
+			manage.PUT("/:id", handler.AmenityHandler.UpdateAmenity)
+		}
+
+		reservations := v1.Group("/amenity-reservations", requireAuth, requirePermission(constants.PermManageAmenities))
+		{
+			reservations.GET("", handler.AmenityHandler.ListReservations)
+			reservations.POST("/:id/approve", handler.AmenityHandler.ApproveReservation)
+			reservations.POST("/:id/reject", handler.AmenityHandler.RejectReservation)
+			reservations.POST("/:id/cancel", handler.AmenityHandler.CancelReservation)
+		}
+
+		vehicles := v1.Group("", requireAuth, requirePermission(constants.PermManageVehicles))
+		{
+			vehicles.GET("/vehicles", handler.VehicleHandler.ListVehicles)
+			vehicles.GET("/vehicles/:id", handler.VehicleHandler.GetVehicle)
+			vehicles.DELETE("/vehicles/:id", handler.VehicleHandler.RemoveVehicle)
+			vehicles.POST("/vehicles/:id/stickers", handler.VehicleHandler.IssueSticker)
+			vehicles.GET("/stickers", handler.VehicleHandler.ListStickers)
+			vehicles.POST("/stickers/:id/lost", handler.VehicleHandler.MarkStickerLost)
+			vehicles.POST("/stickers/:id/revoke", handler.VehicleHandler.RevokeSticker)
+		}
+
+		tickets := v1.Group("/tickets", requireAuth, requirePermission(constants.PermManageTickets))
+		{
+			tickets.GET("", handler.TicketHandler.ListTickets)
+			tickets.GET("/:id", handler.TicketHandler.GetTicket)
+			tickets.POST("/:id/assign", handler.TicketHandler.AssignTicket)
+			tickets.POST("/:id/status", handler.TicketHandler.ChangeTicketStatus)
+			tickets.POST("/:id/comments", handler.TicketHandler.CommentOnTicket)
+			tickets.GET("/:id/photos/:photoId", handler.TicketHandler.GetTicketPhoto)
+		}
+
+		violations := v1.Group("", requireAuth, requirePermission(constants.PermManageViolations))
+		{
+			violations.POST("/violations", handler.ViolationHandler.RecordViolation)
+			violations.GET("/violations", handler.ViolationHandler.ListViolations)
+			violations.GET("/violations/:id", handler.ViolationHandler.GetViolation)
+			violations.POST("/violations/:id/evidence", handler.ViolationHandler.UploadEvidence)
+			violations.GET("/violations/:id/evidence/:attachmentId", handler.ViolationHandler.GetEvidence)
+			violations.POST("/violations/:id/notices", handler.ViolationHandler.IssueNotice)
+			violations.POST("/violations/:id/fines", handler.ViolationHandler.IssueFine)
+			violations.POST("/violations/:id/appeals/:appealId/decide", handler.ViolationHandler.DecideAppeal)
+			violations.POST("/violations/:id/resolve", handler.ViolationHandler.ResolveViolation)
+			violations.POST("/violations/:id/dismiss", handler.ViolationHandler.DismissViolation)
+			violations.GET("/properties/:id/violations", handler.ViolationHandler.GetPropertyViolations)
+		}
+
+		documents := v1.Group("/documents", requireAuth, requirePermission(constants.PermViewReports))
+		{
+			documents.GET("", handler.DocumentHandler.ListDocuments)
+			documents.GET("/:id", handler.DocumentHandler.GetDocument)
+			documents.GET("/:id/download", handler.DocumentHandler.GetDownloadURL)
+
+			manage := documents.Group("", requirePermission(constants.PermManageDocuments))
+			manage.POST("", handler.DocumentHandler.CreateDocument)
+			manage.PUT("/:id", handler.DocumentHandler.UpdateDocument)
+			manage.POST("/:id/versions", handler.DocumentHandler.UploadVersion)
+			manage.POST("/:id/archive", handler.DocumentHandler.ArchiveDocument)
+		}
+
+		elections := v1.Group("/elections", requireAuth)
+		{
+			elections.GET("", handler.ElectionHandler.ListElections)
+			elections.GET("/:id", handler.ElectionHandler.GetElection)
+			elections.GET("/:id/quorum", handler.ElectionHandler.GetQuorum)
+			elections.GET("/:id/eligibility", handler.ElectionHandler.GetEligibility)
+			elections.POST("/:id/ballots", handler.ElectionHandler.CastBallot)
+			elections.GET("/:id/results", handler.ElectionHandler.GetResults)
+			elections.POST("/:id/proxies", handler.ElectionHandler.GrantProxy)
+			elections.POST("/:id/proxies/:proxyId/revoke", handler.ElectionHandler.RevokeProxy)
+
+			manage := elections.Group("", requirePermission(constants.PermManageElections))
+			manage.POST("", handler.ElectionHandler.CreateElection)
+			manage.GET("/manage", handler.ElectionHandler.ListAllElections)
+			manage.PUT("/:id", handler.ElectionHandler.UpdateElection)
+			manage.POST("/:id/positions", handler.ElectionHandler.AddPosition)
+			manage.DELETE("/:id/positions/:positionId", handler.ElectionHandler.RemovePosition)
+			manage.POST("/:id/positions/:positionId/candidates", handler.ElectionHandler.AddCandidate)
+			manage.DELETE("/:id/candidates/:candidateId", handler.ElectionHandler.RemoveCandidate)
+			manage.POST("/:id/schedule", handler.ElectionHandler.ScheduleElection)
+			manage.POST("/:id/cancel", handler.ElectionHandler.CancelElection)
+			manage.POST("/:id/publish", handler.ElectionHandler.PublishResults)
+			manage.GET("/:id/proxies", handler.ElectionHandler.ListProxies)
+			manage.GET("/:id/proxies/:proxyId/authorization", handler.ElectionHandler.GetProxyAuthorization)
+			manage.POST("/:id/proxies/:proxyId/review", handler.ElectionHandler.ReviewProxy)
+		}
+
+		polls := v1.Group("/polls", requireAuth)
+		{
+			polls.GET("", handler.PollHandler.ListPolls)
+			polls.GET("/:id", handler.PollHandler.GetPoll)
+			polls.POST("/:id/votes", handler.PollHandler.CastVote)
+			polls.GET("/:id/results", handler.PollHandler.GetResults)
+			polls.GET("/:id/results/csv", handler.PollHandler.GetResultsCSV)
+
+			manage := polls.Group("", requirePermission(constants.PermManagePolls))
+			manage.POST("", handler.PollHandler.CreatePoll)
+			manage.GET("/manage", handler.PollHandler.ListAllPolls)
+			manage.PUT("/:id", handler.PollHandler.UpdatePoll)
+			manage.POST("/:id/publish", handler.PollHandler.PublishPoll)
+			manage.POST("/:id/cancel", handler.PollHandler.CancelPoll)
+			manage.POST("/:id/close", handler.PollHandler.ClosePoll)
+		}
+
+		events := v1.Group("/events", requireAuth)
+		{
+			events.GET("", handler.EventHandler.ListEvents)
+			events.GET("/:id", handler.EventHandler.GetEvent)
+			events.PUT("/:id/rsvp", handler.EventHandler.RSVP)
+
+			manage := events.Group("", requirePermission(constants.PermManageEvents))
+			manage.POST("", handler.EventHandler.CreateEvent)
+			manage.PUT("/:id", handler.EventHandler.UpdateEvent)
+			manage.POST("/:id/cancel", handler.EventHandler.CancelEvent)
+			manage.GET("/:id/attendees", handler.EventHandler.ListAttendees)
+		}
+
+		// guards only hold the gate permissions, so this is their whole API
+		gate := v1.Group("/gate", requireAuth)
+		{
+			verify := gate.Group("", requirePermission(constants.PermVerifyGatePasses))
+			verify.POST("/verify", handler.GatePassHandler.VerifyGatePass)
+			verify.GET("/expected", handler.GatePassHandler.ListExpectedVisitors)
+			verify.POST("/entries", handler.GatePassHandler.RecordEntry)
+			verify.POST("/passes/:id/revoke", handler.GatePassHandler.RevokeGatePass)
+			verify.GET("/vehicles/:plate", handler.VehicleHandler.LookupPlate)
+
+			logbook := gate.Group("", requirePermission(constants.PermLogGateEntries))
+			logbook.POST("/walk-ins", handler.GatePassHandler.RecordWalkIn)
+			logbook.GET("/entries", handler.GatePassHandler.ListGateEntries)
+			logbook.POST("/entries/:id/exit", handler.GatePassHandler.RecordExit)
+
+			gate.GET("/logbook", requirePermission(constants.PermViewGateLogbook), handler.GatePassHandler.SearchLogbook)
+		}
 	}
 	return r
 }
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
@@ -12,18 +14,29 @@ import (
 type Server struct {
 	Port   string
 	Engine *gin.Engine
+	http   *http.Server
 }
 
 func New(services *service.Service, cfg *config.Config, jwt auth.IJWTAuth) *Server {
-	router := NewRouter(services, jwt)
+	router := NewRouter(services, jwt, cfg.TenantBaseDomain)
 
 	return &Server{
 		Port:   cfg.Port,
 		Engine: router,
+		http:   &http.Server{Addr: ":" + cfg.Port, Handler: router},
 	}
 }
 
+// Run serves until Shutdown is called, after which it returns nil.
 func (s *Server) Run() error {
 	fmt.Printf("Starting server at port %s\n", s.Port)
-	return s.Engine.Run(":" + s.Port)
+	if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for requests in flight.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
 }
@@ -0,0 +1,473 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+const clockFormat = "15:04"
+
+type AmenityServiceImpl struct {
+	amenityRepo   repository.AmenityRepository
+	invoiceRepo   repository.InvoiceRepository
+	occupancyRepo repository.OccupancyRepository
+}
+
+func NewAmenityService(amenityRepo repository.AmenityRepository, invoiceRepo repository.InvoiceRepository, occupancyRepo repository.OccupancyRepository) AmenityService {
+	return &AmenityServiceImpl{
+		amenityRepo:   amenityRepo,
+		invoiceRepo:   invoiceRepo,
+		occupancyRepo: occupancyRepo,
+	}
+}
+
+func (s *AmenityServiceImpl) CreateAmenity(ctx context.Context, req *AmenityRequest) (*AmenityResponse, error) {
+	amenity := &model.Amenity{}
+	if err := applyAmenityRequest(amenity, req); err != nil {
+		return nil, err
+	}
+
+	created, err := s.amenityRepo.CreateAmenity(ctx, amenity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAmenityResponse(created), nil
+}
+
+func (s *AmenityServiceImpl) GetAmenity(ctx context.Context, id uuid.UUID) (*AmenityResponse, error) {
+	amenity, err := s.amenityRepo.GetAmenityByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAmenityResponse(amenity), nil
+}
+
+func (s *AmenityServiceImpl) ListAmenities(ctx context.Context, includeInactive bool) ([]AmenityResponse, error) {
+	amenities, err := s.amenityRepo.ListAmenities(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]AmenityResponse, 0, len(amenities))
+	for i := range amenities {
+		resp = append(resp, *toAmenityResponse(&amenities[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *AmenityServiceImpl) UpdateAmenity(ctx context.Context, id uuid.UUID, req *AmenityRequest) (*AmenityResponse, error) {
+	amenity, err := s.amenityRepo.GetAmenityByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyAmenityRequest(amenity, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.amenityRepo.UpdateAmenity(ctx, amenity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAmenityResponse(updated), nil
+}
+
+// GetAvailability lists every bookable slot of the day and whether a pending
+// or approved reservation already holds it.
+func (s *AmenityServiceImpl) GetAvailability(ctx context.Context, id uuid.UUID, req *AvailabilityRequest) (*AvailabilityResponse, error) {
+	amenity, err := s.amenityRepo.GetAmenityByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := parseDateOrToday(ctx, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	opens, closes, err := s.openingHours(ctx, amenity, date)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.amenityRepo.ListReservations(ctx, repository.ReservationFilter{
+		AmenityID:  &amenity.ID,
+		ActiveOnly: true,
+		From:       &opens,
+		To:         &closes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slot := time.Duration(amenity.SlotMinutes) * time.Minute
+	slots := []AvailabilitySlot{}
+	for start := opens; !start.Add(slot).After(closes); start = start.Add(slot) {
+		end := start.Add(slot)
+		available := true
+		for _, reservation := range reservations {
+			if reservation.StartAt.Before(end) && reservation.EndAt.After(start) {
+				available = false
+				break
+			}
+		}
+		slots = append(slots, AvailabilitySlot{StartAt: start, EndAt: end, Available: available})
+	}
+
+	return &AvailabilityResponse{
+		AmenityID:   amenity.ID.String(),
+		Date:        date.Format(constants.DateFormat),
+		SlotMinutes: amenity.SlotMinutes,
+		Slots:       slots,
+	}, nil
+}
+
+// CreateReservation books the amenity for one of the member's properties.
+// Overlapping bookings are rejected by the database, so the check here only
+// covers the catalog rules and the property's standing.
+func (s *AmenityServiceImpl) CreateReservation(ctx context.Context, userID, amenityID uuid.UUID, req *ReservationRequest) (*ReservationResponse, error) {
+	amenity, err := s.amenityRepo.GetAmenityByID(ctx, amenityID)
+	if err != nil {
+		return nil, err
+	}
+	if !amenity.Active {
+		return nil, invalidInput("amenity is not open for reservations")
+	}
+	if req.GuestCount > amenity.Capacity {
+		return nil, invalidInput("guest count exceeds the amenity capacity of %d", amenity.Capacity)
+	}
+	if !req.StartAt.After(time.Now()) {
+		return nil, invalidInput("reservations must start in the future")
+	}
+
+	slots, err := s.countSlots(ctx, amenity, req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	overdue, err := s.invoiceRepo.HasOverdueInvoices(ctx, property.ID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if overdue {
+		return nil, constants.ErrOverdueDues
+	}
+
+	status := constants.ReservationApproved
+	if amenity.RequiresApproval {
+		status = constants.ReservationPending
+	}
+
+	created, err := s.amenityRepo.CreateReservation(ctx, &model.AmenityReservation{
+		AmenityID:   amenity.ID,
+		AmenityName: amenity.Name,
+		PropertyID:  property.ID,
+		UserID:      userID,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		GuestCount:  req.GuestCount,
+		Status:      status,
+		FeeCents:    int64(slots) * amenity.FeeCents,
+		Notes:       trimmedOrNil(req.Notes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toReservationResponse(created), nil
+}
+
+func (s *AmenityServiceImpl) ListReservations(ctx context.Context, req *ListReservationsRequest) ([]ReservationResponse, error) {
+	return s.listReservations(ctx, repository.ReservationFilter{}, req)
+}
+
+func (s *AmenityServiceImpl) ListUserReservations(ctx context.Context, userID uuid.UUID, req *ListReservationsRequest) ([]ReservationResponse, error) {
+	return s.listReservations(ctx, repository.ReservationFilter{UserID: &userID}, req)
+}
+
+func (s *AmenityServiceImpl) ApproveReservation(ctx context.Context, id, decidedBy uuid.UUID, req *ReservationDecisionRequest) (*ReservationResponse, error) {
+	return s.decideReservation(ctx, id, constants.ReservationApproved, trimmedOrNil(req.Reason), decidedBy)
+}
+
+func (s *AmenityServiceImpl) RejectReservation(ctx context.Context, id, decidedBy uuid.UUID, req *ReservationDecisionRequest) (*ReservationResponse, error) {
+	reason := trimmedOrNil(req.Reason)
+	if reason == nil {
+		return nil, invalidInput("a reason is required to reject a reservation")
+	}
+
+	return s.decideReservation(ctx, id, constants.ReservationRejected, reason, decidedBy)
+}
+
+// CancelReservation lets staff cancel any active reservation regardless of
+// the amenity's cancellation window.
+func (s *AmenityServiceImpl) CancelReservation(ctx context.Context, id uuid.UUID, req *CancelReservationRequest) (*ReservationResponse, error) {
+	if err := s.amenityRepo.CancelReservation(ctx, id, trimmedOrNil(req.Reason)); err != nil {
+		return nil, err
+	}
+
+	return s.getReservation(ctx, id)
+}
+
+// CancelUserReservation cancels the member's own reservation. Approved
+// reservations can only be cancelled up to the amenity's cancellation window
+// before they start; pending ones can be withdrawn at any time.
+func (s *AmenityServiceImpl) CancelUserReservation(ctx context.Context, userID, id uuid.UUID, req *CancelReservationRequest) (*ReservationResponse, error) {
+	reservation, err := s.amenityRepo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.UserID != userID {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	if reservation.Status == constants.ReservationApproved {
+		amenity, err := s.amenityRepo.GetAmenityByID(ctx, reservation.AmenityID)
+		if err != nil {
+			return nil, err
+		}
+		deadline := reservation.StartAt.Add(-time.Duration(amenity.CancellationHours) * time.Hour)
+		if time.Now().After(deadline) {
+			return nil, invalidInput("reservations must be cancelled at least %d hours before they start", amenity.CancellationHours)
+		}
+	}
+
+	if err := s.amenityRepo.CancelReservation(ctx, id, trimmedOrNil(req.Reason)); err != nil {
+		return nil, err
+	}
+
+	return s.getReservation(ctx, id)
+}
+
+func (s *AmenityServiceImpl) listReservations(ctx context.Context, filter repository.ReservationFilter, req *ListReservationsRequest) ([]ReservationResponse, error) {
+	if req.AmenityID != "" {
+		amenityID, err := uuid.Parse(req.AmenityID)
+		if err != nil {
+			return nil, invalidInput("amenity id must be a uuid")
+		}
+		filter.AmenityID = &amenityID
+	}
+	if req.From != "" {
+		from, err := parseDate(req.From)
+		if err != nil {
+			return nil, err
+		}
+		from = localMidnight(ctx, from)
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := parseDate(req.To)
+		if err != nil {
+			return nil, err
+		}
+		to = localMidnight(ctx, to).AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	filter.Status = req.Status
+	filter.Limit = pageSize(req.Limit)
+	filter.Offset = req.Offset
+
+	reservations, err := s.amenityRepo.ListReservations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]ReservationResponse, 0, len(reservations))
+	for i := range reservations {
+		resp = append(resp, *toReservationResponse(&reservations[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *AmenityServiceImpl) decideReservation(ctx context.Context, id uuid.UUID, status string, reason *string, decidedBy uuid.UUID) (*ReservationResponse, error) {
+	if err := s.amenityRepo.DecideReservation(ctx, id, status, reason, decidedBy); err != nil {
+		return nil, err
+	}
+
+	return s.getReservation(ctx, id)
+}
+
+func (s *AmenityServiceImpl) getReservation(ctx context.Context, id uuid.UUID) (*ReservationResponse, error) {
+	reservation, err := s.amenityRepo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReservationResponse(reservation), nil
+}
+
+// countSlots checks that the range falls within one day's opening hours and
+// lines up with the amenity's booking granularity, returning how many slots
+// it covers.
+func (s *AmenityServiceImpl) countSlots(ctx context.Context, amenity *model.Amenity, startAt, endAt time.Time) (int, error) {
+	if !endAt.After(startAt) {
+		return 0, invalidInput("endAt must be after startAt")
+	}
+
+	start := startAt.In(tenant.Location(ctx))
+	date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	opens, closes, err := s.openingHours(ctx, amenity, date)
+	if err != nil {
+		return 0, err
+	}
+	if startAt.Before(opens) || endAt.After(closes) {
+		return 0, invalidInput("reservations must fall within opening hours (%s to %s)",
+			opens.Format(clockFormat), closes.Format(clockFormat))
+	}
+
+	slot := time.Duration(amenity.SlotMinutes) * time.Minute
+	if startAt.Sub(opens)%slot != 0 || endAt.Sub(startAt)%slot != 0 {
+		return 0, invalidInput("reservations must be booked in %d-minute slots starting from %s",
+			amenity.SlotMinutes, opens.Format(clockFormat))
+	}
+
+	return int(endAt.Sub(startAt) / slot), nil
+}
+
+// openingHours returns when the amenity opens and closes on the given
+// calendar date in the association's time zone.
+func (s *AmenityServiceImpl) openingHours(ctx context.Context, amenity *model.Amenity, date time.Time) (time.Time, time.Time, error) {
+	opensAt, err := parseClock(amenity.OpensAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	closesAt, err := parseClock(amenity.ClosesAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	location := tenant.Location(ctx)
+	opens := time.Date(date.Year(), date.Month(), date.Day(), opensAt.Hour(), opensAt.Minute(), 0, 0, location)
+	closes := time.Date(date.Year(), date.Month(), date.Day(), closesAt.Hour(), closesAt.Minute(), 0, 0, location)
+	return opens, closes, nil
+}
+
+// localMidnight returns the start of the date in the association's time zone.
+func localMidnight(ctx context.Context, date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tenant.Location(ctx))
+}
+
+func applyAmenityRequest(amenity *model.Amenity, req *AmenityRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return invalidInput("name is required")
+	}
+
+	opensAt, err := parseClock(req.OpensAt)
+	if err != nil {
+		return err
+	}
+	closesAt, err := parseClock(req.ClosesAt)
+	if err != nil {
+		return err
+	}
+	if !closesAt.After(opensAt) {
+		return invalidInput("closesAt must be after opensAt")
+	}
+	if closesAt.Sub(opensAt) < time.Duration(req.SlotMinutes)*time.Minute {
+		return invalidInput("slotMinutes must fit within the opening hours")
+	}
+
+	requiresApproval := req.FeeCents > 0
+	if req.RequiresApproval != nil {
+		requiresApproval = *req.RequiresApproval
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	amenity.Name = name
+	amenity.Description = trimmedOrNil(req.Description)
+	amenity.Capacity = req.Capacity
+	amenity.OpensAt = opensAt.Format(time.TimeOnly)
+	amenity.ClosesAt = closesAt.Format(time.TimeOnly)
+	amenity.SlotMinutes = req.SlotMinutes
+	amenity.FeeCents = req.FeeCents
+	amenity.RequiresApproval = requiresApproval
+	amenity.CancellationHours = req.CancellationHours
+	amenity.Active = active
+
+	return nil
+}
+
+// parseClock accepts both HH:MM from requests and HH:MM:SS as Postgres
+// returns TIME columns.
+func parseClock(value string) (time.Time, error) {
+	if clock, err := time.Parse(time.TimeOnly, value); err == nil {
+		return clock, nil
+	}
+	clock, err := time.Parse(clockFormat, value)
+	if err != nil {
+		return time.Time{}, invalidInput("times must use the HH:MM format")
+	}
+	return clock, nil
+}
+
+func formatClock(value string) string {
+	clock, err := parseClock(value)
+	if err != nil {
+		return value
+	}
+	return clock.Format(clockFormat)
+}
+
+func toAmenityResponse(amenity *model.Amenity) *AmenityResponse {
+	return &AmenityResponse{
+		ID:                amenity.ID.String(),
+		Name:              amenity.Name,
+		Description:       amenity.Description,
+		Capacity:          amenity.Capacity,
+		OpensAt:           formatClock(amenity.OpensAt),
+		ClosesAt:          formatClock(amenity.ClosesAt),
+		SlotMinutes:       amenity.SlotMinutes,
+		FeeCents:          amenity.FeeCents,
+		RequiresApproval:  amenity.RequiresApproval,
+		CancellationHours: amenity.CancellationHours,
+		Active:            amenity.Active,
+		CreatedAt:         amenity.CreatedAt,
+		UpdatedAt:         amenity.UpdatedAt,
+	}
+}
+
+func toReservationResponse(reservation *model.AmenityReservation) *ReservationResponse {
+	resp := &ReservationResponse{
+		ID:             reservation.ID.String(),
+		AmenityID:      reservation.AmenityID.String(),
+		AmenityName:    reservation.AmenityName,
+		PropertyID:     reservation.PropertyID.String(),
+		UserID:         reservation.UserID.String(),
+		StartAt:        reservation.StartAt,
+		EndAt:          reservation.EndAt,
+		GuestCount:     reservation.GuestCount,
+		Status:         reservation.Status,
+		FeeCents:       reservation.FeeCents,
+		Notes:          reservation.Notes,
+		DecisionReason: reservation.DecisionReason,
+		DecidedAt:      reservation.DecidedAt,
+		CancelReason:   reservation.CancelReason,
+		CancelledAt:    reservation.CancelledAt,
+		CreatedAt:      reservation.CreatedAt,
+	}
+	if reservation.DecidedBy != nil {
+		decidedBy := reservation.DecidedBy.String()
+		resp.DecidedBy = &decidedBy
+	}
+	return resp
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockAmenityRepository struct {
+	CreateAmenityFn      func(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error)
+	GetAmenityByIDFn     func(ctx context.Context, id uuid.UUID) (*model.Amenity, error)
+	ListAmenitiesFn      func(ctx context.Context, includeInactive bool) ([]model.Amenity, error)
+	UpdateAmenityFn      func(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error)
+	CreateReservationFn  func(ctx context.Context, reservation *model.AmenityReservation) (*model.AmenityReservation, error)
+	GetReservationByIDFn func(ctx context.Context, id uuid.UUID) (*model.AmenityReservation, error)
+	ListReservationsFn   func(ctx context.Context, filter repository.ReservationFilter) ([]model.AmenityReservation, error)
+	DecideReservationFn  func(ctx context.Context, id uuid.UUID, status string, reason *string, decidedBy uuid.UUID) error
+	CancelReservationFn  func(ctx context.Context, id uuid.UUID, reason *string) error
+}
+
+func (m *MockAmenityRepository) CreateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error) {
+	return m.CreateAmenityFn(ctx, amenity)
+}
+
+func (m *MockAmenityRepository) GetAmenityByID(ctx context.Context, id uuid.UUID) (*model.Amenity, error) {
+	return m.GetAmenityByIDFn(ctx, id)
+}
+
+func (m *MockAmenityRepository) ListAmenities(ctx context.Context, includeInactive bool) ([]model.Amenity, error) {
+	return m.ListAmenitiesFn(ctx, includeInactive)
+}
+
+func (m *MockAmenityRepository) UpdateAmenity(ctx context.Context, amenity *model.Amenity) (*model.Amenity, error) {
+	return m.UpdateAmenityFn(ctx, amenity)
+}
+
+func (m *MockAmenityRepository) CreateReservation(ctx context.Context, reservation *model.AmenityReservation) (*model.AmenityReservation, error) {
+	return m.CreateReservationFn(ctx, reservation)
+}
+
+func (m *MockAmenityRepository) GetReservationByID(ctx context.Context, id uuid.UUID) (*model.AmenityReservation, error) {
+	return m.GetReservationByIDFn(ctx, id)
+}
+
+func (m *MockAmenityRepository) ListReservations(ctx context.Context, filter repository.ReservationFilter) ([]model.AmenityReservation, error) {
+	return m.ListReservationsFn(ctx, filter)
+}
+
+func (m *MockAmenityRepository) DecideReservation(ctx context.Context, id uuid.UUID, status string, reason *string, decidedBy uuid.UUID) error {
+	return m.DecideReservationFn(ctx, id, status, reason, decidedBy)
+}
+
+func (m *MockAmenityRepository) CancelReservation(ctx context.Context, id uuid.UUID, reason *string) error {
+	return m.CancelReservationFn(ctx, id, reason)
+}
+
+func TestAmenityService_CreateReservation(t *testing.T) {
+	manila := time.FixedZone("PHT", 8*60*60)
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{Timezone: "Asia/Manila"})
+	day := time.Now().In(manila).AddDate(0, 0, 7)
+	at := func(hour, minute int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, manila)
+	}
+
+	clubhouse := &model.Amenity{
+		ID:               uuid.New(),
+		Name:             "Clubhouse",
+		Capacity:         50,
+		OpensAt:          "08:00:00",
+		ClosesAt:         "22:00:00",
+		SlotMinutes:      60,
+		FeeCents:         50000,
+		RequiresApproval: true,
+		Active:           true,
+	}
+	court := &model.Amenity{
+		ID:          uuid.New(),
+		Name:        "Basketball court",
+		Capacity:    20,
+		OpensAt:     "06:00:00",
+		ClosesAt:    "21:00:00",
+		SlotMinutes: 30,
+		Active:      true,
+	}
+
+	tests := []struct {
+		name           string
+		amenity        *model.Amenity
+		req            *ReservationRequest
+		overdue        bool
+		expectedStatus string
+		expectedFee    int64
+		expectedErr    error
+	}{
+		{
+			name:           "paid amenity waits for approval",
+			amenity:        clubhouse,
+			req:            &ReservationRequest{StartAt: at(14, 0), EndAt: at(17, 0), GuestCount: 30},
+			expectedStatus: constants.ReservationPending,
+			expectedFee:    150000,
+		},
+		{
+			name:           "free amenity is approved",
+			amenity:        court,
+			req:            &ReservationRequest{StartAt: at(6, 30), EndAt: at(7, 30), GuestCount: 10},
+			expectedStatus: constants.ReservationApproved,
+		},
+		{
+			name:        "outside opening hours",
+			amenity:     clubhouse,
+			req:         &ReservationRequest{StartAt: at(21, 0), EndAt: at(23, 0), GuestCount: 10},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "not aligned to slots",
+			amenity:     clubhouse,
+			req:         &ReservationRequest{StartAt: at(14, 30), EndAt: at(15, 30), GuestCount: 10},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "over capacity",
+			amenity:     court,
+			req:         &ReservationRequest{StartAt: at(7, 0), EndAt: at(8, 0), GuestCount: 21},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "end before start",
+			amenity:     court,
+			req:         &ReservationRequest{StartAt: at(8, 0), EndAt: at(7, 0), GuestCount: 2},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "overdue dues",
+			amenity:     court,
+			req:         &ReservationRequest{StartAt: at(7, 0), EndAt: at(8, 0), GuestCount: 2},
+			overdue:     true,
+			expectedErr: constants.ErrOverdueDues,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			property := model.Property{ID: uuid.New()}
+			amenityRepo := &MockAmenityRepository{
+				GetAmenityByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Amenity, error) {
+					return tc.amenity, nil
+				},
+				CreateReservationFn: func(ctx context.Context, reservation *model.AmenityReservation) (*model.AmenityReservation, error) {
+					return reservation, nil
+				},
+			}
+			invoiceRepo := &MockInvoiceRepository{
+				HasOverdueInvoicesFn: func(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error) {
+					return tc.overdue, nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					return []model.UserProperty{{Property: property}}, nil
+				},
+			}
+
+			service := NewAmenityService(amenityRepo, invoiceRepo, occupancyRepo)
+			resp, err := service.CreateReservation(ctx, uuid.New(), tc.amenity.ID, tc.req)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != tc.expectedStatus {
+				t.Errorf("expected status %s, got %s", tc.expectedStatus, resp.Status)
+			}
+			if resp.FeeCents != tc.expectedFee {
+				t.Errorf("expected fee %d, got %d", tc.expectedFee, resp.FeeCents)
+			}
+			if resp.PropertyID != property.ID.String() {
+				t.Errorf("expected property %s, got %s", property.ID, resp.PropertyID)
+			}
+		})
+	}
+}
+
+func TestAmenityService_CancelUserReservation(t *testing.T) {
+	userID := uuid.New()
+	amenity := &model.Amenity{ID: uuid.New(), CancellationHours: 24}
+
+	tests := []struct {
+		name        string
+		reservation *model.AmenityReservation
+		expectedErr error
+	}{
+		{
+			name:        "approved outside the window",
+			reservation: &model.AmenityReservation{UserID: userID, Status: constants.ReservationApproved, StartAt: time.Now().Add(48 * time.Hour)},
+		},
+		{
+			name:        "approved inside the window",
+			reservation: &model.AmenityReservation{UserID: userID, Status: constants.ReservationApproved, StartAt: time.Now().Add(12 * time.Hour)},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "pending inside the window",
+			reservation: &model.AmenityReservation{UserID: userID, Status: constants.ReservationPending, StartAt: time.Now().Add(12 * time.Hour)},
+		},
+		{
+			name:        "someone else's reservation",
+			reservation: &model.AmenityReservation{UserID: uuid.New(), Status: constants.ReservationPending, StartAt: time.Now().Add(48 * time.Hour)},
+			expectedErr: constants.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.reservation.ID = uuid.New()
+			tc.reservation.AmenityID = amenity.ID
+			cancelled := false
+			amenityRepo := &MockAmenityRepository{
+				GetAmenityByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Amenity, error) {
+					return amenity, nil
+				},
+				GetReservationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.AmenityReservation, error) {
+					return tc.reservation, nil
+				},
+				CancelReservationFn: func(ctx context.Context, id uuid.UUID, reason *string) error {
+					cancelled = true
+					return nil
+				},
+			}
+
+			service := NewAmenityService(amenityRepo, &MockInvoiceRepository{}, &MockOccupancyRepository{})
+			_, err := service.CancelUserReservation(context.Background(), userID, tc.reservation.ID, &CancelReservationRequest{})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if cancelled {
+					t.Error("expected reservation to be kept")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cancelled {
+				t.Error("expected reservation to be cancelled")
+			}
+		})
+	}
+}
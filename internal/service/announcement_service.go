@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type AnnouncementServiceImpl struct {
+	announcementRepo repository.AnnouncementRepository
+	roleRepo         repository.RoleRepository
+}
+
+func NewAnnouncementService(announcementRepo repository.AnnouncementRepository, roleRepo repository.RoleRepository) AnnouncementService {
+	return &AnnouncementServiceImpl{
+		announcementRepo: announcementRepo,
+		roleRepo:         roleRepo,
+	}
+}
+
+func (s *AnnouncementServiceImpl) CreateAnnouncement(ctx context.Context, req *AnnouncementRequest, createdBy uuid.UUID) (*AnnouncementResponse, error) {
+	announcement := &model.Announcement{CreatedBy: &createdBy}
+	if err := s.applyRequest(ctx, announcement, req); err != nil {
+		return nil, err
+	}
+
+	created, err := s.announcementRepo.CreateAnnouncement(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnnouncementResponse(created), nil
+}
+
+func (s *AnnouncementServiceImpl) GetAnnouncement(ctx context.Context, id uuid.UUID) (*AnnouncementResponse, error) {
+	announcement, err := s.announcementRepo.GetAnnouncementByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnnouncementResponse(announcement), nil
+}
+
+func (s *AnnouncementServiceImpl) ListAnnouncements(ctx context.Context, req *ListAnnouncementsRequest) ([]AnnouncementResponse, error) {
+	announcements, err := s.announcementRepo.ListAnnouncements(ctx, repository.AnnouncementFilter{
+		IncludeExpired: req.IncludeExpired,
+		Limit:          pageSize(req.Limit),
+		Offset:         req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]AnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		resp = append(resp, *toAnnouncementResponse(&announcements[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *AnnouncementServiceImpl) UpdateAnnouncement(ctx context.Context, id uuid.UUID, req *AnnouncementRequest) (*AnnouncementResponse, error) {
+	announcement, err := s.announcementRepo.GetAnnouncementByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(ctx, announcement, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.announcementRepo.UpdateAnnouncement(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnnouncementResponse(updated), nil
+}
+
+func (s *AnnouncementServiceImpl) DeleteAnnouncement(ctx context.Context, id uuid.UUID) error {
+	return s.announcementRepo.DeleteAnnouncement(ctx, id)
+}
+
+func (s *AnnouncementServiceImpl) GetFeed(ctx context.Context, userID uuid.UUID, req *AnnouncementFeedRequest) ([]FeedAnnouncementResponse, error) {
+	announcements, err := s.announcementRepo.ListFeed(ctx, userID, req.Unread, pageSize(req.Limit), req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]FeedAnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		resp = append(resp, *toFeedAnnouncementResponse(&announcements[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *AnnouncementServiceImpl) GetFeedAnnouncement(ctx context.Context, userID, id uuid.UUID) (*FeedAnnouncementResponse, error) {
+	announcement, err := s.announcementRepo.GetFeedAnnouncement(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFeedAnnouncementResponse(announcement), nil
+}
+
+func (s *AnnouncementServiceImpl) CountUnread(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error) {
+	count, err := s.announcementRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnreadCountResponse{Unread: count}, nil
+}
+
+// MarkRead records a read receipt, but only for announcements the user can
+// actually see in their feed.
+func (s *AnnouncementServiceImpl) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.announcementRepo.GetFeedAnnouncement(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.announcementRepo.MarkRead(ctx, id, userID)
+}
+
+func (s *AnnouncementServiceImpl) ListReadReceipts(ctx context.Context, id uuid.UUID) ([]AnnouncementReadResponse, error) {
+	if _, err := s.announcementRepo.GetAnnouncementByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	reads, err := s.announcementRepo.ListReadReceipts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]AnnouncementReadResponse, 0, len(reads))
+	for _, read := range reads {
+		resp = append(resp, AnnouncementReadResponse{
+			UserID:    read.UserID.String(),
+			FirstName: read.FirstName,
+			LastName:  read.LastName,
+			Email:     read.Email,
+			ReadAt:    read.ReadAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// applyRequest validates the request and copies it onto the announcement,
+// keeping only the audience fields that the audience type uses.
+func (s *AnnouncementServiceImpl) applyRequest(ctx context.Context, announcement *model.Announcement, req *AnnouncementRequest) error {
+	title := strings.TrimSpace(req.Title)
+	body := strings.TrimSpace(req.Body)
+	if title == "" || body == "" {
+		return invalidInput("title and body are required")
+	}
+
+	phase := trimmedOrNil(req.AudiencePhase)
+	block := trimmedOrNil(req.AudienceBlock)
+	role := trimmedOrNil(req.AudienceRole)
+
+	announcement.AudiencePhase = nil
+	announcement.AudienceBlock = nil
+	announcement.AudienceRole = nil
+	switch req.AudienceType {
+	case constants.AudienceAll:
+	case constants.AudiencePhase:
+		if phase == nil {
+			return invalidInput("audiencePhase is required for a phase audience")
+		}
+		announcement.AudiencePhase = phase
+	case constants.AudienceBlock:
+		if phase == nil || block == nil {
+			return invalidInput("audiencePhase and audienceBlock are required for a block audience")
+		}
+		announcement.AudiencePhase = phase
+		announcement.AudienceBlock = block
+	case constants.AudienceRole:
+		if role == nil {
+			return invalidInput("audienceRole is required for a role audience")
+		}
+		if _, err := s.roleRepo.GetRoleByName(ctx, *role); err != nil {
+			if errors.Is(err, constants.ErrRecordNotFound) {
+				return invalidInput("unknown role %q", *role)
+			}
+			return err
+		}
+		announcement.AudienceRole = role
+	default:
+		return invalidInput("unsupported audience type %q", req.AudienceType)
+	}
+
+	publishAt := time.Now()
+	if req.PublishAt != nil {
+		publishAt = *req.PublishAt
+	}
+	if req.ExpireAt != nil && !req.ExpireAt.After(publishAt) {
+		return invalidInput("expireAt must be after publishAt")
+	}
+
+	announcement.Title = title
+	announcement.Body = body
+	announcement.AudienceType = req.AudienceType
+	announcement.Pinned = req.Pinned
+	announcement.PublishAt = publishAt
+	announcement.ExpireAt = req.ExpireAt
+
+	return nil
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func toAnnouncementResponse(announcement *model.Announcement) *AnnouncementResponse {
+	resp := &AnnouncementResponse{
+		ID:            announcement.ID.String(),
+		Title:         announcement.Title,
+		Body:          announcement.Body,
+		AudienceType:  announcement.AudienceType,
+		AudiencePhase: announcement.AudiencePhase,
+		AudienceBlock: announcement.AudienceBlock,
+		AudienceRole:  announcement.AudienceRole,
+		Pinned:        announcement.Pinned,
+		PublishAt:     announcement.PublishAt,
+		ExpireAt:      announcement.ExpireAt,
+		CreatedAt:     announcement.CreatedAt,
+		UpdatedAt:     announcement.UpdatedAt,
+	}
+	if announcement.CreatedBy != nil {
+		createdBy := announcement.CreatedBy.String()
+		resp.CreatedBy = &createdBy
+	}
+	return resp
+}
+
+func toFeedAnnouncementResponse(announcement *model.FeedAnnouncement) *FeedAnnouncementResponse {
+	return &FeedAnnouncementResponse{
+		AnnouncementResponse: *toAnnouncementResponse(&announcement.Announcement),
+		Read:                 announcement.ReadAt != nil,
+		ReadAt:               announcement.ReadAt,
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockAnnouncementRepository struct {
+	CreateAnnouncementFn  func(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error)
+	GetAnnouncementByIDFn func(ctx context.Context, id uuid.UUID) (*model.Announcement, error)
+	ListAnnouncementsFn   func(ctx context.Context, filter repository.AnnouncementFilter) ([]model.Announcement, error)
+	UpdateAnnouncementFn  func(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error)
+	DeleteAnnouncementFn  func(ctx context.Context, id uuid.UUID) error
+	ListFeedFn            func(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]model.FeedAnnouncement, error)
+	GetFeedAnnouncementFn func(ctx context.Context, userID, id uuid.UUID) (*model.FeedAnnouncement, error)
+	CountUnreadFn         func(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkReadFn            func(ctx context.Context, announcementID, userID uuid.UUID) error
+	ListReadReceiptsFn    func(ctx context.Context, announcementID uuid.UUID) ([]model.AnnouncementRead, error)
+}
+
+func (m *MockAnnouncementRepository) CreateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error) {
+	return m.CreateAnnouncementFn(ctx, announcement)
+}
+
+func (m *MockAnnouncementRepository) GetAnnouncementByID(ctx context.Context, id uuid.UUID) (*model.Announcement, error) {
+	return m.GetAnnouncementByIDFn(ctx, id)
+}
+
+func (m *MockAnnouncementRepository) ListAnnouncements(ctx context.Context, filter repository.AnnouncementFilter) ([]model.Announcement, error) {
+	return m.ListAnnouncementsFn(ctx, filter)
+}
+
+func (m *MockAnnouncementRepository) UpdateAnnouncement(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error) {
+	return m.UpdateAnnouncementFn(ctx, announcement)
+}
+
+func (m *MockAnnouncementRepository) DeleteAnnouncement(ctx context.Context, id uuid.UUID) error {
+	return m.DeleteAnnouncementFn(ctx, id)
+}
+
+func (m *MockAnnouncementRepository) ListFeed(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]model.FeedAnnouncement, error) {
+	return m.ListFeedFn(ctx, userID, unreadOnly, limit, offset)
+}
+
+func (m *MockAnnouncementRepository) GetFeedAnnouncement(ctx context.Context, userID, id uuid.UUID) (*model.FeedAnnouncement, error) {
+	return m.GetFeedAnnouncementFn(ctx, userID, id)
+}
+
+func (m *MockAnnouncementRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	return m.CountUnreadFn(ctx, userID)
+}
+
+func (m *MockAnnouncementRepository) MarkRead(ctx context.Context, announcementID, userID uuid.UUID) error {
+	return m.MarkReadFn(ctx, announcementID, userID)
+}
+
+func (m *MockAnnouncementRepository) ListReadReceipts(ctx context.Context, announcementID uuid.UUID) ([]model.AnnouncementRead, error) {
+	return m.ListReadReceiptsFn(ctx, announcementID)
+}
+
+func TestAnnouncementService_CreateAnnouncement(t *testing.T) {
+	phase := "2"
+	block := "4"
+	blank := " "
+	treasurer := constants.RoleTreasurer
+	unknownRole := "janitor"
+	publishAt := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	expireBefore := publishAt.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		req         *AnnouncementRequest
+		expectedErr error
+	}{
+		{
+			name: "everyone",
+			req:  &AnnouncementRequest{Title: "Water interruption", Body: "**Tuesday** 9am-5pm", AudienceType: constants.AudienceAll, AudiencePhase: &phase},
+		},
+		{
+			name: "block audience",
+			req:  &AnnouncementRequest{Title: "Road repair", Body: "Block 4 road closed", AudienceType: constants.AudienceBlock, AudiencePhase: &phase, AudienceBlock: &block},
+		},
+		{
+			name: "role audience",
+			req:  &AnnouncementRequest{Title: "Audit", Body: "Bring receipts", AudienceType: constants.AudienceRole, AudienceRole: &treasurer},
+		},
+		{
+			name:        "block audience without block",
+			req:         &AnnouncementRequest{Title: "Road repair", Body: "closed", AudienceType: constants.AudienceBlock, AudiencePhase: &phase, AudienceBlock: &blank},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown role",
+			req:         &AnnouncementRequest{Title: "Hello", Body: "World", AudienceType: constants.AudienceRole, AudienceRole: &unknownRole},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "expires before publishing",
+			req:         &AnnouncementRequest{Title: "Hello", Body: "World", AudienceType: constants.AudienceAll, PublishAt: &publishAt, ExpireAt: &expireBefore},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			roleRepo := &MockRoleRepository{
+				GetRoleByNameFn: func(ctx context.Context, name string) (*model.Role, error) {
+					if name == constants.RoleTreasurer {
+						return &model.Role{ID: uuid.New(), Name: name}, nil
+					}
+					return nil, constants.ErrRecordNotFound
+				},
+			}
+			announcementRepo := &MockAnnouncementRepository{
+				CreateAnnouncementFn: func(ctx context.Context, announcement *model.Announcement) (*model.Announcement, error) {
+					if announcement.AudienceType == constants.AudienceAll && announcement.AudiencePhase != nil {
+						t.Error("expected unused audience fields to be cleared")
+					}
+					return announcement, nil
+				},
+			}
+
+			service := NewAnnouncementService(announcementRepo, roleRepo)
+			resp, err := service.CreateAnnouncement(context.Background(), tc.req, uuid.New())
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.CreatedBy == nil {
+				t.Error("expected the author to be recorded")
+			}
+		})
+	}
+}
+
+func TestAnnouncementService_MarkRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		visibleErr  error
+		expectedErr error
+	}{
+		{name: "visible announcement"},
+		{name: "not addressed to the user", visibleErr: constants.ErrRecordNotFound, expectedErr: constants.ErrRecordNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			marked := false
+			announcementRepo := &MockAnnouncementRepository{
+				GetFeedAnnouncementFn: func(ctx context.Context, userID, id uuid.UUID) (*model.FeedAnnouncement, error) {
+					if tc.visibleErr != nil {
+						return nil, tc.visibleErr
+					}
+					return &model.FeedAnnouncement{Announcement: model.Announcement{ID: id}}, nil
+				},
+				MarkReadFn: func(ctx context.Context, announcementID, userID uuid.UUID) error {
+					marked = true
+					return nil
+				},
+			}
+
+			service := NewAnnouncementService(announcementRepo, &MockRoleRepository{})
+			err := service.MarkRead(context.Background(), uuid.New(), uuid.New())
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if marked != (tc.expectedErr == nil) {
+				t.Errorf("expected marked %v, got %v", tc.expectedErr == nil, marked)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+// slugs double as DNS labels so each association can have a subdomain
+var associationSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type AssociationServiceImpl struct {
+	associationRepo repository.AssociationRepository
+}
+
+func NewAssociationService(associationRepo repository.AssociationRepository) AssociationService {
+	return &AssociationServiceImpl{
+		associationRepo: associationRepo,
+	}
+}
+
+func (s *AssociationServiceImpl) GetAssociationBySlug(ctx context.Context, slug string) (*model.Association, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !associationSlugPattern.MatchString(slug) {
+		return nil, constants.ErrRecordNotFound
+	}
+	return s.associationRepo.GetAssociationBySlug(ctx, slug)
+}
+
+func (s *AssociationServiceImpl) GetAssociationByID(ctx context.Context, id uuid.UUID) (*model.Association, error) {
+	return s.associationRepo.GetAssociationByID(ctx, id)
+}
+
+func (s *AssociationServiceImpl) GetCurrentAssociation(ctx context.Context) (*AssociationResponse, error) {
+	association, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, constants.ErrRecordNotFound
+	}
+	return toAssociationResponse(association), nil
+}
+
+func (s *AssociationServiceImpl) SaveAssociation(ctx context.Context, req *AssociationRequest) (*AssociationResponse, error) {
+	association := &model.Association{
+		Slug:     strings.ToLower(strings.TrimSpace(req.Slug)),
+		Name:     strings.TrimSpace(req.Name),
+		Timezone: strings.TrimSpace(req.Timezone),
+	}
+	if !associationSlugPattern.MatchString(association.Slug) {
+		return nil, invalidInput("slug must be lowercase letters, digits and dashes")
+	}
+	if association.Name == "" {
+		return nil, invalidInput("name is required")
+	}
+	if association.Timezone == "" {
+		association.Timezone = "Asia/Manila"
+	}
+	if _, err := time.LoadLocation(association.Timezone); err != nil {
+		return nil, invalidInput("timezone is not a valid time zone")
+	}
+
+	if err := s.associationRepo.SaveAssociation(ctx, association); err != nil {
+		return nil, err
+	}
+
+	return toAssociationResponse(association), nil
+}
+
+// ForEachAssociation runs fn once per association with ctx scoped to it,
+// for background jobs. A failure in one association does not stop the
+// others; all failures are returned together.
+func (s *AssociationServiceImpl) ForEachAssociation(ctx context.Context, fn func(ctx context.Context) error) error {
+	associations, err := s.associationRepo.ListAssociations(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range associations {
+		association := &associations[i]
+		if err := fn(tenant.WithAssociation(ctx, association)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", association.Slug, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func toAssociationResponse(association *model.Association) *AssociationResponse {
+	return &AssociationResponse{
+		ID:       association.ID.String(),
+		Slug:     association.Slug,
+		Name:     association.Name,
+		Timezone: association.Timezone,
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockAssociationRepository struct {
+	SaveAssociationFn      func(ctx context.Context, association *model.Association) error
+	GetAssociationByIDFn   func(ctx context.Context, id uuid.UUID) (*model.Association, error)
+	GetAssociationBySlugFn func(ctx context.Context, slug string) (*model.Association, error)
+	ListAssociationsFn     func(ctx context.Context) ([]model.Association, error)
+}
+
+func (m *MockAssociationRepository) SaveAssociation(ctx context.Context, association *model.Association) error {
+	return m.SaveAssociationFn(ctx, association)
+}
+
+func (m *MockAssociationRepository) GetAssociationByID(ctx context.Context, id uuid.UUID) (*model.Association, error) {
+	return m.GetAssociationByIDFn(ctx, id)
+}
+
+func (m *MockAssociationRepository) GetAssociationBySlug(ctx context.Context, slug string) (*model.Association, error) {
+	return m.GetAssociationBySlugFn(ctx, slug)
+}
+
+func (m *MockAssociationRepository) ListAssociations(ctx context.Context) ([]model.Association, error) {
+	return m.ListAssociationsFn(ctx)
+}
+
+func TestAssociationService_SaveAssociation(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              *AssociationRequest
+		expectedSlug     string
+		expectedTimezone string
+		expectedErr      error
+	}{
+		{
+			name:             "defaults the time zone",
+			req:              &AssociationRequest{Slug: " Acacia-Heights ", Name: "Acacia Heights HOA"},
+			expectedSlug:     "acacia-heights",
+			expectedTimezone: "Asia/Manila",
+		},
+		{
+			name:             "explicit time zone",
+			req:              &AssociationRequest{Slug: "maple", Name: "Maple Grove", Timezone: "America/Chicago"},
+			expectedSlug:     "maple",
+			expectedTimezone: "America/Chicago",
+		},
+		{
+			name:        "slug is not a dns label",
+			req:         &AssociationRequest{Slug: "acacia.heights", Name: "Acacia Heights HOA"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "missing name",
+			req:         &AssociationRequest{Slug: "acacia", Name: "  "},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown time zone",
+			req:         &AssociationRequest{Slug: "acacia", Name: "Acacia", Timezone: "Mars/Olympus"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			associationRepo := &MockAssociationRepository{
+				SaveAssociationFn: func(ctx context.Context, association *model.Association) error {
+					association.ID = uuid.New()
+					return nil
+				},
+			}
+
+			service := NewAssociationService(associationRepo)
+			resp, err := service.SaveAssociation(context.Background(), tt.req)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if resp.Slug != tt.expectedSlug {
+				t.Errorf("expected slug %s, got %s", tt.expectedSlug, resp.Slug)
+			}
+			if resp.Timezone != tt.expectedTimezone {
+				t.Errorf("expected time zone %s, got %s", tt.expectedTimezone, resp.Timezone)
+			}
+		})
+	}
+}
+
+func TestAssociationService_ForEachAssociation(t *testing.T) {
+	associations := []model.Association{
+		{ID: uuid.New(), Slug: "acacia"},
+		{ID: uuid.New(), Slug: "maple"},
+		{ID: uuid.New(), Slug: "narra"},
+	}
+	associationRepo := &MockAssociationRepository{
+		ListAssociationsFn: func(ctx context.Context) ([]model.Association, error) {
+			return associations, nil
+		},
+	}
+
+	var visited []uuid.UUID
+	service := NewAssociationService(associationRepo)
+	err := service.ForEachAssociation(context.Background(), func(ctx context.Context) error {
+		id, ok := tenant.AssociationID(ctx)
+		if !ok {
+			t.Fatalf("expected ctx to be scoped to an association")
+		}
+		visited = append(visited, id)
+		if id == associations[1].ID {
+			return errors.New("billing failed")
+		}
+		return nil
+	})
+
+	if len(visited) != len(associations) {
+		t.Fatalf("expected every association to run after a failure, ran %d", len(visited))
+	}
+	for i, association := range associations {
+		if visited[i] != association.ID {
+			t.Errorf("expected association %s at %d, got %s", association.Slug, i, visited[i])
+		}
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "maple: ") {
+		t.Errorf("expected the failure to name the association, got %v", err)
+	}
+}
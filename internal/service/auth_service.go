@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/util"
+)
+
+type AuthServiceImpl struct {
+	jwt               auth.IJWTAuth
+	userRepo          repository.UserRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	passwordResetRepo repository.PasswordResetRepository
+	mailer            mailer.Mailer
+	background        *job.Background
+	// appURL is the web app that hosts the reset password page
+	appURL string
+}
+
+func NewAuthService(jwt auth.IJWTAuth, userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository,
+	passwordResetRepo repository.PasswordResetRepository, mailer mailer.Mailer, background *job.Background, appURL string) AuthService {
+	return &AuthServiceImpl{
+		jwt:               jwt,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		passwordResetRepo: passwordResetRepo,
+		mailer:            mailer,
+		background:        background,
+		appURL:            strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// IssueTokens starts a new refresh token family for a fresh login.
+func (s *AuthServiceImpl) IssueTokens(ctx context.Context, user *model.User) (auth.TokenPairs, error) {
+	tokenPairs, err := s.jwt.GenerateToken(user, uuid.New())
+	if err != nil {
+		return auth.TokenPairs{}, err
+	}
+
+	err = s.refreshTokenRepo.CreateRefreshToken(ctx, &model.RefreshToken{
+		ID:        tokenPairs.RefreshTokenID,
+		UserID:    user.ID,
+		FamilyID:  tokenPairs.FamilyID,
+		ExpiresAt: tokenPairs.RefreshExpiresAt,
+	})
+	if err != nil {
+		return auth.TokenPairs{}, err
+	}
+
+	return tokenPairs, nil
+}
+
+// RefreshTokens redeems a refresh token for a new pair in the same family.
+// Presenting a token that was already rotated revokes the whole family.
+func (s *AuthServiceImpl) RefreshTokens(ctx context.Context, refreshToken string) (*model.User, auth.TokenPairs, error) {
+	claims, err := s.jwt.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, auth.TokenPairs{}, constants.ErrInvalidToken
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, auth.TokenPairs{}, constants.ErrInvalidToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetRefreshTokenByID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, auth.TokenPairs{}, constants.ErrInvalidToken
+		}
+		return nil, auth.TokenPairs{}, err
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, auth.TokenPairs{}, s.revokeFamily(ctx, stored.FamilyID)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, auth.TokenPairs{}, constants.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, auth.TokenPairs{}, constants.ErrInvalidToken
+		}
+		return nil, auth.TokenPairs{}, err
+	}
+
+	if user.Status != constants.ActiveStatus {
+		if err := s.refreshTokenRepo.RevokeSession(ctx, stored.FamilyID); err != nil {
+			return nil, auth.TokenPairs{}, err
+		}
+		return nil, auth.TokenPairs{}, constants.ErrAccountInactive
+	}
+
+	tokenPairs, err := s.jwt.GenerateToken(user, stored.FamilyID)
+	if err != nil {
+		return nil, auth.TokenPairs{}, err
+	}
+
+	err = s.refreshTokenRepo.RotateRefreshToken(ctx, stored.ID, &model.RefreshToken{
+		ID:        tokenPairs.RefreshTokenID,
+		UserID:    user.ID,
+		FamilyID:  stored.FamilyID,
+		ExpiresAt: tokenPairs.RefreshExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrTokenReused) {
+			return nil, auth.TokenPairs{}, s.revokeFamily(ctx, stored.FamilyID)
+		}
+		return nil, auth.TokenPairs{}, err
+	}
+
+	return user, tokenPairs, nil
+}
+
+// Logout revokes the session the refresh token belongs to. Tokens that no
+// longer parse are ignored since there is nothing left to revoke.
+func (s *AuthServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := s.jwt.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil
+	}
+
+	familyID, err := uuid.Parse(claims.FamilyID)
+	if err != nil {
+		return nil
+	}
+
+	return s.refreshTokenRepo.RevokeSession(ctx, familyID)
+}
+
+func (s *AuthServiceImpl) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	return s.refreshTokenRepo.RevokeAllUserSessions(ctx, userID)
+}
+
+// PurgeRevokedSessions drops denylist entries for sessions that have expired
+// on their own. Every authenticated request checks the denylist, so it should
+// only hold sessions whose tokens could still be presented.
+func (s *AuthServiceImpl) PurgeRevokedSessions(ctx context.Context, now time.Time) error {
+	purged, err := s.refreshTokenRepo.PurgeExpiredRevokedSessions(ctx, now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d expired revoked sessions\n", purged)
+	}
+	return nil
+}
+
+// RequestPasswordReset emails a reset link to an active account. The lookup
+// and the email happen in the background and the call returns nil straight
+// away, so neither the response nor its timing reveals whether an email is
+// registered.
+func (s *AuthServiceImpl) RequestPasswordReset(ctx context.Context, req *ForgotPasswordRequest) error {
+	association, ok := tenant.FromContext(ctx)
+	if !ok {
+		return constants.ErrRecordNotFound
+	}
+
+	email := strings.TrimSpace(req.Email)
+	s.background.Go(ctx, "send password reset email", func(ctx context.Context) error {
+		return s.sendPasswordReset(ctx, association, email)
+	})
+
+	return nil
+}
+
+// sendPasswordReset does nothing for unknown, inactive and throttled
+// accounts.
+func (s *AuthServiceImpl) sendPasswordReset(ctx context.Context, association *model.Association, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Status != constants.ActiveStatus {
+		return nil
+	}
+
+	latest, err := s.passwordResetRepo.GetLatestPasswordResetToken(ctx, user.ID)
+	if err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < constants.PasswordResetCooldown {
+		return nil
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	err = s.passwordResetRepo.CreatePasswordResetToken(ctx, &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(constants.PasswordResetTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := s.appURL + "/reset-password?" + url.Values{"association": {association.Slug}, "token": {token}}.Encode()
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your " + association.Name + " password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below within %d minutes to choose a new one:\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.FirstName, int(constants.PasswordResetTokenTTL.Minutes()), link),
+	})
+}
+
+// ResetPassword sets a new password using an emailed token and signs the
+// user out everywhere, since whoever held the old password may still hold a
+// session.
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, req *ResetPasswordRequest) error {
+	token, err := s.passwordResetRepo.GetPasswordResetTokenByHash(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return constants.ErrInvalidToken
+		}
+		return err
+	}
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return constants.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return constants.ErrInvalidToken
+		}
+		return err
+	}
+	if user.Status != constants.ActiveStatus {
+		return constants.ErrInvalidToken
+	}
+
+	passwordHash, err := util.HashPassword(req.Password)
+	if err != nil {
+		return constants.ErrInternalServer
+	}
+	if err := s.passwordResetRepo.ResetPassword(ctx, token.ID, passwordHash); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllUserSessions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Your " + tenant.Name(ctx) + " password was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nYour password was just changed and every device was signed out. If this was not you, contact your association office right away.\n",
+			user.FirstName),
+	})
+	if err != nil {
+		log.Printf("failed to send password changed email: %v\n", err)
+	}
+
+	return nil
+}
+
+func (s *AuthServiceImpl) revokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := s.refreshTokenRepo.RevokeSession(ctx, familyID); err != nil {
+		return err
+	}
+	return constants.ErrTokenReused
+}
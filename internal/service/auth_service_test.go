@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/util"
+)
+
+type MockJWTAuth struct {
+	GenerateTokenFn        func(user *model.User, familyID uuid.UUID) (auth.TokenPairs, error)
+	ParseAccessTokenFn     func(ctx context.Context, tokenStr string) (*auth.Claims, error)
+	ParseRefreshTokenFn    func(tokenStr string) (*auth.RefreshClaims, error)
+	GenerateGatePassFn     func(passID uuid.UUID, expiresAt time.Time) (string, error)
+	ParseGatePassFn        func(tokenStr string) (*auth.GatePassClaims, error)
+	GenerateMFAChallengeFn func(user *model.User, expiresAt time.Time) (string, error)
+	ParseMFAChallengeFn    func(tokenStr string) (*auth.MFAChallengeClaims, error)
+}
+
+func (m *MockJWTAuth) GenerateToken(user *model.User, familyID uuid.UUID) (auth.TokenPairs, error) {
+	return m.GenerateTokenFn(user, familyID)
+}
+
+func (m *MockJWTAuth) GenerateGatePass(passID uuid.UUID, expiresAt time.Time) (string, error) {
+	return m.GenerateGatePassFn(passID, expiresAt)
+}
+
+func (m *MockJWTAuth) ParseGatePass(tokenStr string) (*auth.GatePassClaims, error) {
+	return m.ParseGatePassFn(tokenStr)
+}
+
+func (m *MockJWTAuth) GenerateMFAChallenge(user *model.User, expiresAt time.Time) (string, error) {
+	return m.GenerateMFAChallengeFn(user, expiresAt)
+}
+
+func (m *MockJWTAuth) ParseMFAChallenge(tokenStr string) (*auth.MFAChallengeClaims, error) {
+	return m.ParseMFAChallengeFn(tokenStr)
+}
+
+func (m *MockJWTAuth) ParseAccessToken(ctx context.Context, tokenStr string) (*auth.Claims, error) {
+	return m.ParseAccessTokenFn(ctx, tokenStr)
+}
+
+func (m *MockJWTAuth) ParseRefreshToken(tokenStr string) (*auth.RefreshClaims, error) {
+	return m.ParseRefreshTokenFn(tokenStr)
+}
+
+func (m *MockJWTAuth) GetRefreshCookie(refreshToken string) *http.Cookie {
+	return &http.Cookie{}
+}
+
+func (m *MockJWTAuth) GetExpiredRefreshCookie() *http.Cookie {
+	return &http.Cookie{}
+}
+
+func (m *MockJWTAuth) RefreshCookieName() string {
+	return "refresh_token"
+}
+
+type MockRefreshTokenRepository struct {
+	CreateRefreshTokenFn          func(ctx context.Context, token *model.RefreshToken) error
+	GetRefreshTokenByIDFn         func(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error)
+	RotateRefreshTokenFn          func(ctx context.Context, oldID uuid.UUID, next *model.RefreshToken) error
+	RevokeSessionFn               func(ctx context.Context, familyID uuid.UUID) error
+	RevokeAllUserSessionsFn       func(ctx context.Context, userID uuid.UUID) error
+	IsSessionRevokedFn            func(ctx context.Context, sessionID uuid.UUID) (bool, error)
+	PurgeExpiredRevokedSessionsFn func(ctx context.Context, now time.Time) (int64, error)
+}
+
+func (m *MockRefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	return m.CreateRefreshTokenFn(ctx, token)
+}
+
+func (m *MockRefreshTokenRepository) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error) {
+	return m.GetRefreshTokenByIDFn(ctx, id)
+}
+
+func (m *MockRefreshTokenRepository) RotateRefreshToken(ctx context.Context, oldID uuid.UUID, next *model.RefreshToken) error {
+	return m.RotateRefreshTokenFn(ctx, oldID, next)
+}
+
+func (m *MockRefreshTokenRepository) RevokeSession(ctx context.Context, familyID uuid.UUID) error {
+	return m.RevokeSessionFn(ctx, familyID)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return m.RevokeAllUserSessionsFn(ctx, userID)
+}
+
+func (m *MockRefreshTokenRepository) IsSessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	return m.IsSessionRevokedFn(ctx, sessionID)
+}
+
+func (m *MockRefreshTokenRepository) PurgeExpiredRevokedSessions(ctx context.Context, now time.Time) (int64, error) {
+	return m.PurgeExpiredRevokedSessionsFn(ctx, now)
+}
+
+func TestAuthService_RefreshTokens(t *testing.T) {
+	userID := uuid.New()
+	familyID := uuid.New()
+	tokenID := uuid.New()
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		stored        *model.RefreshToken
+		rotateErr     error
+		expectedErr   error
+		expectRevoked bool
+		expectRotated bool
+	}{
+		{
+			name: "success",
+			stored: &model.RefreshToken{
+				ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour),
+			},
+			expectRotated: true,
+		},
+		{
+			name: "already rotated token revokes family",
+			stored: &model.RefreshToken{
+				ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+			},
+			expectedErr:   constants.ErrTokenReused,
+			expectRevoked: true,
+		},
+		{
+			name: "concurrent rotation revokes family",
+			stored: &model.RefreshToken{
+				ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour),
+			},
+			rotateErr:     constants.ErrTokenReused,
+			expectedErr:   constants.ErrTokenReused,
+			expectRevoked: true,
+			expectRotated: true,
+		},
+		{
+			name: "expired token",
+			stored: &model.RefreshToken{
+				ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "unknown token",
+			expectedErr: constants.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			revoked, rotated := false, false
+
+			jwtAuth := &MockJWTAuth{
+				ParseRefreshTokenFn: func(tokenStr string) (*auth.RefreshClaims, error) {
+					return &auth.RefreshClaims{
+						UserID:           userID.String(),
+						FamilyID:         familyID.String(),
+						RegisteredClaims: jwt.RegisteredClaims{ID: tokenID.String()},
+					}, nil
+				},
+				GenerateTokenFn: func(user *model.User, family uuid.UUID) (auth.TokenPairs, error) {
+					if family != familyID {
+						t.Errorf("expected family %s, got %s", familyID, family)
+					}
+					return auth.TokenPairs{RefreshTokenID: uuid.New(), FamilyID: family}, nil
+				},
+			}
+			tokenRepo := &MockRefreshTokenRepository{
+				GetRefreshTokenByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error) {
+					if tc.stored == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.stored, nil
+				},
+				RotateRefreshTokenFn: func(ctx context.Context, oldID uuid.UUID, next *model.RefreshToken) error {
+					rotated = true
+					return tc.rotateErr
+				},
+				RevokeSessionFn: func(ctx context.Context, id uuid.UUID) error {
+					revoked = id == familyID
+					return nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return &model.User{ID: id, Status: constants.ActiveStatus}, nil
+				},
+			}
+
+			service := NewAuthService(jwtAuth, userRepo, tokenRepo, &MockPasswordResetRepository{}, &MockMailer{}, job.NewBackground(), "http://localhost:3000")
+			user, _, err := service.RefreshTokens(context.Background(), "refresh12345")
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && (user == nil || user.ID != userID) {
+				t.Errorf("expected user %s, got %v", userID, user)
+			}
+			if revoked != tc.expectRevoked {
+				t.Errorf("expected revoked %v, got %v", tc.expectRevoked, revoked)
+			}
+			if rotated != tc.expectRotated {
+				t.Errorf("expected rotated %v, got %v", tc.expectRotated, rotated)
+			}
+		})
+	}
+}
+
+type MockPasswordResetRepository struct {
+	CreatePasswordResetTokenFn    func(ctx context.Context, token *model.PasswordResetToken) error
+	GetLatestPasswordResetTokenFn func(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error)
+	GetPasswordResetTokenByHashFn func(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error)
+	ResetPasswordFn               func(ctx context.Context, tokenID uuid.UUID, passwordHash string) error
+}
+
+func (m *MockPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, token *model.PasswordResetToken) error {
+	return m.CreatePasswordResetTokenFn(ctx, token)
+}
+
+func (m *MockPasswordResetRepository) GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error) {
+	return m.GetLatestPasswordResetTokenFn(ctx, userID)
+}
+
+func (m *MockPasswordResetRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+	return m.GetPasswordResetTokenByHashFn(ctx, tokenHash)
+}
+
+func (m *MockPasswordResetRepository) ResetPassword(ctx context.Context, tokenID uuid.UUID, passwordHash string) error {
+	return m.ResetPasswordFn(ctx, tokenID, passwordHash)
+}
+
+type MockMailer struct {
+	SendFn func(ctx context.Context, message mailer.Message) error
+}
+
+func (m *MockMailer) Send(ctx context.Context, message mailer.Message) error {
+	return m.SendFn(ctx, message)
+}
+
+func TestAuthService_RequestPasswordReset(t *testing.T) {
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "acacia", Name: "Acacia Heights"})
+
+	tests := []struct {
+		name       string
+		user       *model.User
+		latest     *model.PasswordResetToken
+		sendErr    error
+		expectMail bool
+	}{
+		{
+			name:       "active account",
+			user:       &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus},
+			expectMail: true,
+		},
+		{
+			name: "unknown email",
+		},
+		{
+			name: "suspended account",
+			user: &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.SuspendedStatus},
+		},
+		{
+			name:   "requested again within the cooldown",
+			user:   &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus},
+			latest: &model.PasswordResetToken{CreatedAt: time.Now().Add(-30 * time.Second)},
+		},
+		{
+			name:       "requested again after the cooldown",
+			user:       &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus},
+			latest:     &model.PasswordResetToken{CreatedAt: time.Now().Add(-constants.PasswordResetCooldown - time.Second)},
+			expectMail: true,
+		},
+		{
+			name:       "mail delivery fails",
+			user:       &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus},
+			sendErr:    errors.New("connection refused"),
+			expectMail: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := &MockUserRepository{
+				GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+					if tc.user == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.user, nil
+				},
+			}
+			var stored *model.PasswordResetToken
+			resetRepo := &MockPasswordResetRepository{
+				GetLatestPasswordResetTokenFn: func(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error) {
+					if tc.latest == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.latest, nil
+				},
+				CreatePasswordResetTokenFn: func(ctx context.Context, token *model.PasswordResetToken) error {
+					stored = token
+					return nil
+				},
+			}
+			var sent *mailer.Message
+			mail := &MockMailer{
+				SendFn: func(ctx context.Context, message mailer.Message) error {
+					sent = &message
+					return tc.sendErr
+				},
+			}
+
+			background := job.NewBackground()
+			service := NewAuthService(&MockJWTAuth{}, userRepo, &MockRefreshTokenRepository{}, resetRepo, mail, background, "https://app.example.com/")
+			if err := service.RequestPasswordReset(ctx, &ForgotPasswordRequest{Email: " ana@example.com "}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			background.Wait(ctx)
+
+			if (sent != nil) != tc.expectMail {
+				t.Fatalf("expected mail sent %v, got %v", tc.expectMail, sent != nil)
+			}
+			if !tc.expectMail {
+				return
+			}
+
+			if sent.To != tc.user.Email {
+				t.Errorf("expected mail to %s, got %s", tc.user.Email, sent.To)
+			}
+			start := strings.Index(sent.Body, "https://app.example.com/reset-password?")
+			if start < 0 {
+				t.Fatalf("expected a reset link in %q", sent.Body)
+			}
+			link, err := url.Parse(strings.Fields(sent.Body[start:])[0])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if link.Query().Get("association") != "acacia" {
+				t.Errorf("expected the link to name the association, got %s", link)
+			}
+			token := link.Query().Get("token")
+			if token == "" || stored.TokenHash != hashToken(token) {
+				t.Errorf("expected only the hash of the emailed token to be stored")
+			}
+			if stored.UserID != tc.user.ID || time.Until(stored.ExpiresAt) > constants.PasswordResetTokenTTL {
+				t.Errorf("unexpected stored token %+v", stored)
+			}
+		})
+	}
+}
+
+func TestAuthService_RequestPasswordResetReturnsBeforeMailing(t *testing.T) {
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "acacia", Name: "Acacia Heights"})
+
+	userRepo := &MockUserRepository{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, Status: constants.ActiveStatus}, nil
+		},
+	}
+	resetRepo := &MockPasswordResetRepository{
+		GetLatestPasswordResetTokenFn: func(ctx context.Context, userID uuid.UUID) (*model.PasswordResetToken, error) {
+			return nil, constants.ErrRecordNotFound
+		},
+		CreatePasswordResetTokenFn: func(ctx context.Context, token *model.PasswordResetToken) error {
+			return nil
+		},
+	}
+	release := make(chan struct{})
+	mail := &MockMailer{
+		SendFn: func(ctx context.Context, message mailer.Message) error {
+			<-release
+			return nil
+		},
+	}
+
+	background := job.NewBackground()
+	service := NewAuthService(&MockJWTAuth{}, userRepo, &MockRefreshTokenRepository{}, resetRepo, mail, background, "https://app.example.com")
+	if err := service.RequestPasswordReset(ctx, &ForgotPasswordRequest{Email: "ana@example.com"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// reaching here while the mailer is still blocked shows the response
+	// does not wait for it
+	close(release)
+	background.Wait(ctx)
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		token         *model.PasswordResetToken
+		userStatus    string
+		expectedErr   error
+		expectRevoked bool
+	}{
+		{
+			name:          "valid token",
+			token:         &model.PasswordResetToken{ExpiresAt: time.Now().Add(time.Minute)},
+			userStatus:    constants.ActiveStatus,
+			expectRevoked: true,
+		},
+		{
+			name:        "unknown token",
+			userStatus:  constants.ActiveStatus,
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "already used",
+			token:       &model.PasswordResetToken{ExpiresAt: time.Now().Add(time.Minute), UsedAt: &usedAt},
+			userStatus:  constants.ActiveStatus,
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "expired",
+			token:       &model.PasswordResetToken{ExpiresAt: time.Now().Add(-time.Minute)},
+			userStatus:  constants.ActiveStatus,
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "account suspended since the request",
+			token:       &model.PasswordResetToken{ExpiresAt: time.Now().Add(time.Minute)},
+			userStatus:  constants.SuspendedStatus,
+			expectedErr: constants.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			var newHash string
+			resetRepo := &MockPasswordResetRepository{
+				GetPasswordResetTokenByHashFn: func(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+					if tc.token == nil || tokenHash != hashToken("reset-token") {
+						return nil, constants.ErrRecordNotFound
+					}
+					tc.token.ID = uuid.New()
+					tc.token.UserID = userID
+					return tc.token, nil
+				},
+				ResetPasswordFn: func(ctx context.Context, tokenID uuid.UUID, passwordHash string) error {
+					newHash = passwordHash
+					return nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return &model.User{ID: id, Email: "ana@example.com", Status: tc.userStatus}, nil
+				},
+			}
+			revoked := false
+			tokenRepo := &MockRefreshTokenRepository{
+				RevokeAllUserSessionsFn: func(ctx context.Context, id uuid.UUID) error {
+					revoked = id == userID
+					return nil
+				},
+			}
+			mail := &MockMailer{
+				SendFn: func(ctx context.Context, message mailer.Message) error {
+					return nil
+				},
+			}
+
+			service := NewAuthService(&MockJWTAuth{}, userRepo, tokenRepo, resetRepo, mail, job.NewBackground(), "https://app.example.com")
+			err := service.ResetPassword(context.Background(), &ResetPasswordRequest{Token: "reset-token", Password: "n3w-passw0rd"})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if revoked != tc.expectRevoked {
+				t.Errorf("expected sessions revoked %v, got %v", tc.expectRevoked, revoked)
+			}
+			if tc.expectedErr == nil && !util.VerifyPasswordHash("n3w-passw0rd", newHash) {
+				t.Errorf("expected the new password to be stored hashed")
+			}
+		})
+	}
+}
+
+func TestAuthService_PurgeRevokedSessions(t *testing.T) {
+	now := time.Now()
+	var cutoff time.Time
+	tokenRepo := &MockRefreshTokenRepository{
+		PurgeExpiredRevokedSessionsFn: func(ctx context.Context, at time.Time) (int64, error) {
+			cutoff = at
+			return 3, nil
+		},
+	}
+
+	service := NewAuthService(&MockJWTAuth{}, &MockUserRepository{}, tokenRepo, &MockPasswordResetRepository{}, &MockMailer{}, job.NewBackground(), "")
+	if err := service.PurgeRevokedSessions(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cutoff.Equal(now) {
+		t.Errorf("expected sessions expired before %s to be purged, got %s", now, cutoff)
+	}
+}
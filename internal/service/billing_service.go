@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type BillingServiceImpl struct {
+	billingRepo  repository.BillingRepository
+	invoiceRepo  repository.InvoiceRepository
+	propertyRepo repository.PropertyRepository
+	billingDay   int
+	dueDay       int
+}
+
+func NewBillingService(billingRepo repository.BillingRepository, invoiceRepo repository.InvoiceRepository, propertyRepo repository.PropertyRepository, billingDay, dueDay int) BillingService {
+	return &BillingServiceImpl{
+		billingRepo:  billingRepo,
+		invoiceRepo:  invoiceRepo,
+		propertyRepo: propertyRepo,
+		billingDay:   billingDay,
+		dueDay:       dueDay,
+	}
+}
+
+func (s *BillingServiceImpl) CreateDuesSchedule(ctx context.Context, req *DuesScheduleRequest) (*DuesScheduleResponse, error) {
+	effectiveFrom, err := parseDate(req.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &model.DuesSchedule{
+		Name:          strings.TrimSpace(req.Name),
+		PropertyType:  req.PropertyType,
+		Phase:         req.Phase,
+		RateType:      req.RateType,
+		AmountCents:   req.AmountCents,
+		EffectiveFrom: effectiveFrom,
+	}
+	if req.EffectiveTo != "" {
+		effectiveTo, err := parseDate(req.EffectiveTo)
+		if err != nil {
+			return nil, err
+		}
+		if effectiveTo.Before(effectiveFrom) {
+			return nil, invalidInput("effective to must not be before effective from")
+		}
+		schedule.EffectiveTo = &effectiveTo
+	}
+
+	created, err := s.billingRepo.CreateDuesSchedule(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDuesScheduleResponse(created), nil
+}
+
+func (s *BillingServiceImpl) ListDuesSchedules(ctx context.Context) ([]DuesScheduleResponse, error) {
+	schedules, err := s.billingRepo.ListDuesSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]DuesScheduleResponse, 0, len(schedules))
+	for i := range schedules {
+		resp = append(resp, *toDuesScheduleResponse(&schedules[i]))
+	}
+
+	return resp, nil
+}
+
+// PreviewBillingRun computes the invoices a run would create without
+// persisting anything, so treasurers can review them first.
+func (s *BillingServiceImpl) PreviewBillingRun(ctx context.Context, req *BillingRunRequest) (*BillingRunPreview, error) {
+	period, err := parseBillingPeriod(req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, skipped, err := s.draftInvoices(ctx, period, false)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &BillingRunPreview{
+		Period:   period.Format(constants.MonthFormat),
+		Invoices: make([]InvoicePreview, 0, len(invoices)),
+		Skipped:  skipped,
+	}
+	for _, draft := range invoices {
+		preview.InvoiceCount++
+		preview.TotalCents += draft.invoice.TotalCents
+		preview.Invoices = append(preview.Invoices, InvoicePreview{
+			PropertyID: draft.property.ID.String(),
+			Phase:      draft.property.Phase,
+			Block:      draft.property.Block,
+			Lot:        draft.property.Lot,
+			DueDate:    draft.invoice.DueDate.Format(constants.DateFormat),
+			TotalCents: draft.invoice.TotalCents,
+			LineItems:  toLineItemResponses(draft.invoice.LineItems),
+		})
+	}
+
+	return preview, nil
+}
+
+func (s *BillingServiceImpl) RunBilling(ctx context.Context, req *BillingRunRequest, runBy *uuid.UUID) (*BillingRunResponse, error) {
+	period, err := parseBillingPeriod(req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.commitRun(ctx, period, runBy, false)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, invalidInput("every active property is already billed for %s", period.Format(constants.MonthFormat))
+	}
+
+	return toBillingRunResponse(run), nil
+}
+
+// RunScheduledBilling is called periodically by the job scheduler. From the
+// configured billing day onwards, counted in the association's time zone, it
+// bills every property that has no invoice for the current month yet, so
+// reruns and late additions are safe. A voided invoice still counts, so a
+// waived charge is only re-issued by a manual run.
+func (s *BillingServiceImpl) RunScheduledBilling(ctx context.Context, now time.Time) error {
+	local := localDate(ctx, now)
+	if local.Day() < s.billingDay {
+		return nil
+	}
+
+	period := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, time.UTC)
+	run, err := s.commitRun(ctx, period, nil, true)
+	if err != nil {
+		return err
+	}
+	if run != nil {
+		log.Printf("billing run %s created %d invoices for %s\n", run.ID, run.InvoiceCount, period.Format(constants.MonthFormat))
+	}
+
+	return nil
+}
+
+func (s *BillingServiceImpl) ListBillingRuns(ctx context.Context) ([]BillingRunResponse, error) {
+	runs, err := s.billingRepo.ListBillingRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]BillingRunResponse, 0, len(runs))
+	for i := range runs {
+		resp = append(resp, *toBillingRunResponse(&runs[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *BillingServiceImpl) ListInvoices(ctx context.Context, req *ListInvoicesRequest) ([]InvoiceResponse, error) {
+	filter := repository.InvoiceFilter{
+		Status: req.Status,
+		Kind:   req.Kind,
+		Limit:  pageSize(req.Limit),
+		Offset: req.Offset,
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return nil, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+	if req.Period != "" {
+		period, err := parseBillingPeriod(req.Period)
+		if err != nil {
+			return nil, err
+		}
+		filter.BillingPeriod = period.Format(constants.DateFormat)
+	}
+
+	invoices, err := s.invoiceRepo.ListInvoices(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]InvoiceResponse, 0, len(invoices))
+	for i := range invoices {
+		resp = append(resp, *toInvoiceResponse(&invoices[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *BillingServiceImpl) GetInvoice(ctx context.Context, id uuid.UUID) (*InvoiceResponse, error) {
+	invoice, err := s.invoiceRepo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvoiceResponse(invoice), nil
+}
+
+func (s *BillingServiceImpl) VoidInvoice(ctx context.Context, id uuid.UUID, req *VoidInvoiceRequest) error {
+	invoice, err := s.invoiceRepo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != constants.InvoiceStatusOpen || invoice.PaidCents > 0 {
+		return invalidInput("only unpaid open invoices can be voided")
+	}
+
+	return s.invoiceRepo.VoidInvoice(ctx, id, strings.TrimSpace(req.Reason))
+}
+
+// commitRun bills the period. skipVoided also leaves out properties whose
+// invoice for it was voided.
+func (s *BillingServiceImpl) commitRun(ctx context.Context, period time.Time, runBy *uuid.UUID, skipVoided bool) (*model.BillingRun, error) {
+	drafts, _, err := s.draftInvoices(ctx, period, skipVoided)
+	if err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, nil
+	}
+
+	run := &model.BillingRun{
+		BillingPeriod: period,
+		RunBy:         runBy,
+	}
+	invoices := make([]model.Invoice, 0, len(drafts))
+	for _, draft := range drafts {
+		run.InvoiceCount++
+		run.TotalCents += draft.invoice.TotalCents
+		invoices = append(invoices, draft.invoice)
+	}
+
+	if err := s.billingRepo.CreateBillingRun(ctx, run, invoices); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+type invoiceDraft struct {
+	property model.Property
+	invoice  model.Invoice
+}
+
+func (s *BillingServiceImpl) draftInvoices(ctx context.Context, period time.Time, skipVoided bool) ([]invoiceDraft, []BillingSkip, error) {
+	properties, err := s.propertyRepo.ListProperties(ctx, repository.PropertyFilter{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedules, err := s.billingRepo.ListDuesSchedulesEffectiveOn(ctx, period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	billedIDs, err := s.billingRepo.ListBilledPropertyIDs(ctx, period, skipVoided)
+	if err != nil {
+		return nil, nil, err
+	}
+	billed := make(map[uuid.UUID]bool, len(billedIDs))
+	for _, id := range billedIDs {
+		billed[id] = true
+	}
+
+	issueDate := today(ctx)
+	dueDate := period.AddDate(0, 0, s.dueDay-1)
+
+	drafts := []invoiceDraft{}
+	skipped := []BillingSkip{}
+	for _, property := range properties {
+		skip := func(reason string) {
+			skipped = append(skipped, BillingSkip{
+				PropertyID: property.ID.String(),
+				Phase:      property.Phase,
+				Block:      property.Block,
+				Lot:        property.Lot,
+				Reason:     reason,
+			})
+		}
+
+		if billed[property.ID] {
+			skip("already billed for this period")
+			continue
+		}
+
+		schedule := selectDuesSchedule(schedules, &property)
+		if schedule == nil {
+			skip("no dues schedule matches this property")
+			continue
+		}
+
+		lineItem, err := duesLineItem(schedule, &property, period)
+		if err != nil {
+			skip(err.Error())
+			continue
+		}
+
+		drafts = append(drafts, invoiceDraft{
+			property: property,
+			invoice: model.Invoice{
+				PropertyID:    property.ID,
+				BillingPeriod: period,
+				IssueDate:     issueDate,
+				DueDate:       dueDate,
+				Status:        constants.InvoiceStatusOpen,
+				TotalCents:    lineItem.AmountCents,
+				LineItems:     []model.InvoiceLineItem{lineItem},
+			},
+		})
+	}
+
+	return drafts, skipped, nil
+}
+
+// selectDuesSchedule picks the most specific schedule for the property: a
+// match on both type and phase beats type only, which beats phase only, which
+// beats a catch-all. Ties go to the most recent effective_from.
+func selectDuesSchedule(schedules []model.DuesSchedule, property *model.Property) *model.DuesSchedule {
+	var best *model.DuesSchedule
+	bestScore := -1
+	for i := range schedules {
+		schedule := &schedules[i]
+		score := 0
+
+		if schedule.PropertyType != nil {
+			if property.Type == nil || !strings.EqualFold(*schedule.PropertyType, *property.Type) {
+				continue
+			}
+			score += 2
+		}
+		if schedule.Phase != nil {
+			if !strings.EqualFold(*schedule.Phase, property.Phase) {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore || (score == bestScore && schedule.EffectiveFrom.After(best.EffectiveFrom)) {
+			best = schedule
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func duesLineItem(schedule *model.DuesSchedule, property *model.Property, period time.Time) (model.InvoiceLineItem, error) {
+	description := fmt.Sprintf("Association dues for %s", period.Format("January 2006"))
+	scheduleID := schedule.ID
+
+	switch schedule.RateType {
+	case constants.RateTypeFlat:
+		return model.InvoiceLineItem{
+			DuesScheduleID:  &scheduleID,
+			Description:     description,
+			Quantity:        1,
+			UnitAmountCents: schedule.AmountCents,
+			AmountCents:     schedule.AmountCents,
+		}, nil
+	case constants.RateTypePerSqm:
+		if property.LotAreaSqm == nil || *property.LotAreaSqm <= 0 {
+			return model.InvoiceLineItem{}, fmt.Errorf("lot area is required for per square meter dues")
+		}
+		area := *property.LotAreaSqm
+		return model.InvoiceLineItem{
+			DuesScheduleID:  &scheduleID,
+			Description:     fmt.Sprintf("%s (%.2f sqm)", description, area),
+			Quantity:        area,
+			UnitAmountCents: schedule.AmountCents,
+			AmountCents:     int64(math.Round(area * float64(schedule.AmountCents))),
+		}, nil
+	default:
+		return model.InvoiceLineItem{}, fmt.Errorf("unsupported rate type %q", schedule.RateType)
+	}
+}
+
+func parseBillingPeriod(value string) (time.Time, error) {
+	period, err := time.Parse(constants.MonthFormat, value)
+	if err != nil {
+		return time.Time{}, invalidInput("billing period must use the %s format", constants.MonthFormat)
+	}
+	return period, nil
+}
+
+func toDuesScheduleResponse(schedule *model.DuesSchedule) *DuesScheduleResponse {
+	return &DuesScheduleResponse{
+		ID:            schedule.ID.String(),
+		Name:          schedule.Name,
+		PropertyType:  schedule.PropertyType,
+		Phase:         schedule.Phase,
+		RateType:      schedule.RateType,
+		AmountCents:   schedule.AmountCents,
+		EffectiveFrom: schedule.EffectiveFrom.Format(constants.DateFormat),
+		EffectiveTo:   formatOptionalDate(schedule.EffectiveTo),
+	}
+}
+
+func toBillingRunResponse(run *model.BillingRun) *BillingRunResponse {
+	resp := &BillingRunResponse{
+		ID:           run.ID.String(),
+		Period:       run.BillingPeriod.Format(constants.MonthFormat),
+		InvoiceCount: run.InvoiceCount,
+		TotalCents:   run.TotalCents,
+		CreatedAt:    run.CreatedAt,
+	}
+	if run.RunBy != nil {
+		runBy := run.RunBy.String()
+		resp.RunBy = &runBy
+	}
+	return resp
+}
+
+func toInvoiceResponse(invoice *model.Invoice) *InvoiceResponse {
+	return &InvoiceResponse{
+		ID:            invoice.ID.String(),
+		InvoiceNumber: invoice.InvoiceNumber,
+		PropertyID:    invoice.PropertyID.String(),
+		Kind:          invoice.Kind,
+		Period:        invoice.BillingPeriod.Format(constants.MonthFormat),
+		IssueDate:     invoice.IssueDate.Format(constants.DateFormat),
+		DueDate:       invoice.DueDate.Format(constants.DateFormat),
+		Status:        invoice.Status,
+		TotalCents:    invoice.TotalCents,
+		PaidCents:     invoice.PaidCents,
+		PenaltyCents:  invoice.PenaltyCents,
+		BalanceCents:  invoice.TotalCents - invoice.PaidCents,
+		VoidReason:    invoice.VoidReason,
+		LineItems:     toLineItemResponses(invoice.LineItems),
+	}
+}
+
+func toLineItemResponses(items []model.InvoiceLineItem) []InvoiceLineItemResponse {
+	resp := make([]InvoiceLineItemResponse, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, InvoiceLineItemResponse{
+			Description:     item.Description,
+			Quantity:        item.Quantity,
+			UnitAmountCents: item.UnitAmountCents,
+			AmountCents:     item.AmountCents,
+		})
+	}
+	return resp
+}
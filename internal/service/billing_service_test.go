@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockBillingRepository struct {
+	CreateDuesScheduleFn           func(ctx context.Context, schedule *model.DuesSchedule) (*model.DuesSchedule, error)
+	ListDuesSchedulesFn            func(ctx context.Context) ([]model.DuesSchedule, error)
+	ListDuesSchedulesEffectiveOnFn func(ctx context.Context, date time.Time) ([]model.DuesSchedule, error)
+	ListBilledPropertyIDsFn        func(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error)
+	ListBillingRunsFn              func(ctx context.Context) ([]model.BillingRun, error)
+	CreateBillingRunFn             func(ctx context.Context, run *model.BillingRun, invoices []model.Invoice) error
+}
+
+func (m *MockBillingRepository) CreateDuesSchedule(ctx context.Context, schedule *model.DuesSchedule) (*model.DuesSchedule, error) {
+	return m.CreateDuesScheduleFn(ctx, schedule)
+}
+
+func (m *MockBillingRepository) ListDuesSchedules(ctx context.Context) ([]model.DuesSchedule, error) {
+	return m.ListDuesSchedulesFn(ctx)
+}
+
+func (m *MockBillingRepository) ListDuesSchedulesEffectiveOn(ctx context.Context, date time.Time) ([]model.DuesSchedule, error) {
+	return m.ListDuesSchedulesEffectiveOnFn(ctx, date)
+}
+
+func (m *MockBillingRepository) ListBilledPropertyIDs(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error) {
+	return m.ListBilledPropertyIDsFn(ctx, period, includeVoided)
+}
+
+func (m *MockBillingRepository) ListBillingRuns(ctx context.Context) ([]model.BillingRun, error) {
+	return m.ListBillingRunsFn(ctx)
+}
+
+func (m *MockBillingRepository) CreateBillingRun(ctx context.Context, run *model.BillingRun, invoices []model.Invoice) error {
+	return m.CreateBillingRunFn(ctx, run, invoices)
+}
+
+type MockInvoiceRepository struct {
+	GetInvoiceByIDFn     func(ctx context.Context, id uuid.UUID) (*model.Invoice, error)
+	ListInvoicesFn       func(ctx context.Context, filter repository.InvoiceFilter) ([]model.Invoice, error)
+	VoidInvoiceFn        func(ctx context.Context, id uuid.UUID, reason string) error
+	HasOverdueInvoicesFn func(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error)
+}
+
+func (m *MockInvoiceRepository) GetInvoiceByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	return m.GetInvoiceByIDFn(ctx, id)
+}
+
+func (m *MockInvoiceRepository) ListInvoices(ctx context.Context, filter repository.InvoiceFilter) ([]model.Invoice, error) {
+	return m.ListInvoicesFn(ctx, filter)
+}
+
+func (m *MockInvoiceRepository) VoidInvoice(ctx context.Context, id uuid.UUID, reason string) error {
+	return m.VoidInvoiceFn(ctx, id, reason)
+}
+
+func (m *MockInvoiceRepository) HasOverdueInvoices(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error) {
+	return m.HasOverdueInvoicesFn(ctx, propertyID, asOf)
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestSelectDuesSchedule(t *testing.T) {
+	catchAll := model.DuesSchedule{ID: uuid.New(), EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newerCatchAll := model.DuesSchedule{ID: uuid.New(), EffectiveFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	phaseOnly := model.DuesSchedule{ID: uuid.New(), Phase: stringPtr("2")}
+	typeOnly := model.DuesSchedule{ID: uuid.New(), PropertyType: stringPtr("commercial")}
+	typeAndPhase := model.DuesSchedule{ID: uuid.New(), PropertyType: stringPtr("commercial"), Phase: stringPtr("2")}
+
+	tests := []struct {
+		name      string
+		schedules []model.DuesSchedule
+		property  model.Property
+		expected  *uuid.UUID
+	}{
+		{
+			name:      "type and phase beats everything",
+			schedules: []model.DuesSchedule{catchAll, phaseOnly, typeOnly, typeAndPhase},
+			property:  model.Property{Phase: "2", Type: stringPtr("Commercial")},
+			expected:  &typeAndPhase.ID,
+		},
+		{
+			name:      "type beats phase",
+			schedules: []model.DuesSchedule{catchAll, phaseOnly, typeOnly},
+			property:  model.Property{Phase: "2", Type: stringPtr("commercial")},
+			expected:  &typeOnly.ID,
+		},
+		{
+			name:      "newest catch-all wins a tie",
+			schedules: []model.DuesSchedule{catchAll, newerCatchAll, typeOnly},
+			property:  model.Property{Phase: "1"},
+			expected:  &newerCatchAll.ID,
+		},
+		{
+			name:      "no match",
+			schedules: []model.DuesSchedule{phaseOnly, typeOnly},
+			property:  model.Property{Phase: "1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := selectDuesSchedule(tc.schedules, &tc.property)
+			if tc.expected == nil {
+				if got != nil {
+					t.Errorf("expected no schedule, got %s", got.ID)
+				}
+				return
+			}
+			if got == nil || got.ID != *tc.expected {
+				t.Errorf("expected schedule %s, got %v", *tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBillingService_PreviewBillingRun(t *testing.T) {
+	flatProperty := model.Property{ID: uuid.New(), Phase: "1", Block: "1", Lot: "1"}
+	sqmProperty := model.Property{ID: uuid.New(), Phase: "2", Block: "4", Lot: "12", LotAreaSqm: floatPtr(120.5)}
+	noAreaProperty := model.Property{ID: uuid.New(), Phase: "2", Block: "4", Lot: "13"}
+	billedProperty := model.Property{ID: uuid.New(), Phase: "1", Block: "1", Lot: "2"}
+
+	propertyRepo := &MockPropertyRepository{
+		ListPropertiesFn: func(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error) {
+			return []model.Property{flatProperty, sqmProperty, noAreaProperty, billedProperty}, nil
+		},
+	}
+	billingRepo := &MockBillingRepository{
+		ListDuesSchedulesEffectiveOnFn: func(ctx context.Context, date time.Time) ([]model.DuesSchedule, error) {
+			if date != time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) {
+				t.Errorf("unexpected period %s", date)
+			}
+			return []model.DuesSchedule{
+				{ID: uuid.New(), RateType: constants.RateTypeFlat, AmountCents: 150000},
+				{ID: uuid.New(), Phase: stringPtr("2"), RateType: constants.RateTypePerSqm, AmountCents: 1500},
+			}, nil
+		},
+		ListBilledPropertyIDsFn: func(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error) {
+			return []uuid.UUID{billedProperty.ID}, nil
+		},
+	}
+
+	service := NewBillingService(billingRepo, &MockInvoiceRepository{}, propertyRepo, 1, 15)
+	preview, err := service.PreviewBillingRun(context.Background(), &BillingRunRequest{Period: "2026-03"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.InvoiceCount != 2 {
+		t.Fatalf("expected 2 invoices, got %d", preview.InvoiceCount)
+	}
+	if preview.Invoices[0].TotalCents != 150000 {
+		t.Errorf("expected flat dues 150000, got %d", preview.Invoices[0].TotalCents)
+	}
+	if preview.Invoices[1].TotalCents != 180750 {
+		t.Errorf("expected per sqm dues 180750, got %d", preview.Invoices[1].TotalCents)
+	}
+	if preview.Invoices[0].DueDate != "2026-03-15" {
+		t.Errorf("expected due date 2026-03-15, got %s", preview.Invoices[0].DueDate)
+	}
+	if preview.TotalCents != 330750 {
+		t.Errorf("expected total 330750, got %d", preview.TotalCents)
+	}
+	if len(preview.Skipped) != 2 {
+		t.Errorf("expected 2 skipped properties, got %d", len(preview.Skipped))
+	}
+}
+
+func TestBillingService_RunScheduledBilling(t *testing.T) {
+	property := model.Property{ID: uuid.New(), Phase: "1"}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		timezone  string
+		expectRun bool
+	}{
+		{name: "before billing day", now: time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)},
+		{name: "on billing day", now: time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC), expectRun: true},
+		// 20:00 UTC on the 4th is already the 5th in Manila
+		{name: "on local billing day", now: time.Date(2026, 3, 4, 20, 0, 0, 0, time.UTC), timezone: "Asia/Manila", expectRun: true},
+		{name: "before local billing day", now: time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC), timezone: "America/Los_Angeles"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ran := false
+			propertyRepo := &MockPropertyRepository{
+				ListPropertiesFn: func(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error) {
+					return []model.Property{property}, nil
+				},
+			}
+			billingRepo := &MockBillingRepository{
+				ListDuesSchedulesEffectiveOnFn: func(ctx context.Context, date time.Time) ([]model.DuesSchedule, error) {
+					return []model.DuesSchedule{{RateType: constants.RateTypeFlat, AmountCents: 100000}}, nil
+				},
+				ListBilledPropertyIDsFn: func(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error) {
+					return nil, nil
+				},
+				CreateBillingRunFn: func(ctx context.Context, run *model.BillingRun, invoices []model.Invoice) error {
+					ran = true
+					if run.RunBy != nil {
+						t.Error("expected scheduled run without a user")
+					}
+					if len(invoices) != 1 || run.TotalCents != 100000 {
+						t.Errorf("unexpected run %+v", run)
+					}
+					if !run.BillingPeriod.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+						t.Errorf("expected billing period 2026-03, got %s", run.BillingPeriod)
+					}
+					return nil
+				},
+			}
+
+			ctx := tenant.WithAssociation(context.Background(), &model.Association{Timezone: tc.timezone})
+			service := NewBillingService(billingRepo, &MockInvoiceRepository{}, propertyRepo, 5, 15)
+			if err := service.RunScheduledBilling(ctx, tc.now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ran != tc.expectRun {
+				t.Errorf("expected run %v, got %v", tc.expectRun, ran)
+			}
+		})
+	}
+}
+
+func TestBillingService_RunScheduledBillingAfterVoid(t *testing.T) {
+	property := model.Property{ID: uuid.New(), Phase: "1"}
+	now := time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)
+
+	invoices := []model.Invoice{}
+	propertyRepo := &MockPropertyRepository{
+		ListPropertiesFn: func(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error) {
+			return []model.Property{property}, nil
+		},
+	}
+	billingRepo := &MockBillingRepository{
+		ListDuesSchedulesEffectiveOnFn: func(ctx context.Context, date time.Time) ([]model.DuesSchedule, error) {
+			return []model.DuesSchedule{{RateType: constants.RateTypeFlat, AmountCents: 100000}}, nil
+		},
+		ListBilledPropertyIDsFn: func(ctx context.Context, period time.Time, includeVoided bool) ([]uuid.UUID, error) {
+			ids := []uuid.UUID{}
+			for _, invoice := range invoices {
+				if invoice.BillingPeriod.Equal(period) && (includeVoided || invoice.Status != constants.InvoiceStatusVoid) {
+					ids = append(ids, invoice.PropertyID)
+				}
+			}
+			return ids, nil
+		},
+		CreateBillingRunFn: func(ctx context.Context, run *model.BillingRun, created []model.Invoice) error {
+			for _, invoice := range created {
+				invoice.ID = uuid.New()
+				invoices = append(invoices, invoice)
+			}
+			return nil
+		},
+	}
+	invoiceRepo := &MockInvoiceRepository{
+		GetInvoiceByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+			for i := range invoices {
+				if invoices[i].ID == id {
+					return &invoices[i], nil
+				}
+			}
+			return nil, constants.ErrRecordNotFound
+		},
+		VoidInvoiceFn: func(ctx context.Context, id uuid.UUID, reason string) error {
+			for i := range invoices {
+				if invoices[i].ID == id {
+					invoices[i].Status = constants.InvoiceStatusVoid
+				}
+			}
+			return nil
+		},
+	}
+
+	service := NewBillingService(billingRepo, invoiceRepo, propertyRepo, 5, 15)
+	if err := service.RunScheduledBilling(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoices) != 1 {
+		t.Fatalf("expected 1 invoice, got %d", len(invoices))
+	}
+
+	if err := service.VoidInvoice(context.Background(), invoices[0].ID, &VoidInvoiceRequest{Reason: "waived"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.RunScheduledBilling(context.Background(), now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoices) != 1 {
+		t.Fatalf("expected the voided invoice not to be re-issued, got %d invoices", len(invoices))
+	}
+
+	// a treasurer can still re-issue it explicitly
+	if _, err := service.RunBilling(context.Background(), &BillingRunRequest{Period: "2026-03"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoices) != 2 {
+		t.Errorf("expected the manual run to re-issue the invoice, got %d invoices", len(invoices))
+	}
+}
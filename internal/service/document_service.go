@@ -0,0 +1,378 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+)
+
+var documentCategories = map[string]bool{
+	constants.DocumentCategoryBylaws:     true,
+	constants.DocumentCategoryMinutes:    true,
+	constants.DocumentCategoryFinancials: true,
+	constants.DocumentCategoryPolicies:   true,
+	constants.DocumentCategoryForms:      true,
+	constants.DocumentCategoryOther:      true,
+}
+
+// officeDocumentTypes maps the extensions of Office Open XML files, which
+// sniff as plain zip archives, to their real content types.
+var officeDocumentTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+var documentImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+type DocumentServiceImpl struct {
+	documentRepo repository.DocumentRepository
+	roleRepo     repository.RoleRepository
+	store        storage.Storage
+}
+
+func NewDocumentService(documentRepo repository.DocumentRepository, roleRepo repository.RoleRepository, store storage.Storage) DocumentService {
+	return &DocumentServiceImpl{
+		documentRepo: documentRepo,
+		roleRepo:     roleRepo,
+		store:        store,
+	}
+}
+
+func (s *DocumentServiceImpl) CreateDocument(ctx context.Context, uploadedBy uuid.UUID, req *DocumentRequest, upload *FileUpload) (*DocumentDetailResponse, error) {
+	document := &model.Document{
+		ID:        uuid.New(),
+		CreatedBy: &uploadedBy,
+	}
+	if err := s.applyRequest(ctx, document, req); err != nil {
+		return nil, err
+	}
+
+	version := &model.DocumentVersion{
+		ID:         uuid.New(),
+		DocumentID: document.ID,
+		Notes:      trimmedOrNil(req.Notes),
+		UploadedBy: &uploadedBy,
+	}
+	if err := s.storeVersion(ctx, version, upload); err != nil {
+		return nil, err
+	}
+	if err := s.documentRepo.CreateDocument(ctx, document, version); err != nil {
+		s.removeOrphan(ctx, version.StorageKey)
+		return nil, err
+	}
+
+	return &DocumentDetailResponse{
+		DocumentResponse: *toDocumentResponse(document),
+		Versions:         []DocumentVersionResponse{*toDocumentVersionResponse(version)},
+	}, nil
+}
+
+func (s *DocumentServiceImpl) UpdateDocument(ctx context.Context, id uuid.UUID, req *DocumentRequest) (*DocumentResponse, error) {
+	document, err := s.documentRepo.GetDocumentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(ctx, document, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.documentRepo.UpdateDocument(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocumentResponse(updated), nil
+}
+
+func (s *DocumentServiceImpl) AddVersion(ctx context.Context, id, uploadedBy uuid.UUID, req *DocumentVersionRequest, upload *FileUpload) (*DocumentVersionResponse, error) {
+	document, err := s.documentRepo.GetDocumentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if document.ArchivedAt != nil {
+		return nil, invalidInput("document is archived")
+	}
+
+	version := &model.DocumentVersion{
+		ID:         uuid.New(),
+		DocumentID: document.ID,
+		Notes:      trimmedOrNil(req.Notes),
+		UploadedBy: &uploadedBy,
+	}
+	if err := s.storeVersion(ctx, version, upload); err != nil {
+		return nil, err
+	}
+	if err := s.documentRepo.AddDocumentVersion(ctx, version); err != nil {
+		s.removeOrphan(ctx, version.StorageKey)
+		return nil, err
+	}
+
+	return toDocumentVersionResponse(version), nil
+}
+
+// ArchiveDocument hides the document from members. Its files are kept so
+// the board can still retrieve superseded records.
+func (s *DocumentServiceImpl) ArchiveDocument(ctx context.Context, id uuid.UUID) error {
+	return s.documentRepo.ArchiveDocument(ctx, id)
+}
+
+func (s *DocumentServiceImpl) ListDocuments(ctx context.Context, userID uuid.UUID, req *ListDocumentsRequest) ([]DocumentResponse, error) {
+	if req.Category != "" && !documentCategories[req.Category] {
+		return nil, invalidInput("unsupported category %q", req.Category)
+	}
+
+	manager, err := s.roleRepo.UserHasPermission(ctx, userID, constants.PermManageDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.DocumentFilter{
+		Title:    strings.TrimSpace(req.Q),
+		Category: req.Category,
+		Limit:    pageSize(req.Limit),
+		Offset:   req.Offset,
+	}
+	if manager {
+		filter.IncludeArchived = req.IncludeArchived
+	} else {
+		filter.VisibleTo = &userID
+	}
+
+	documents, err := s.documentRepo.ListDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]DocumentResponse, 0, len(documents))
+	for i := range documents {
+		resp = append(resp, *toDocumentResponse(&documents[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *DocumentServiceImpl) GetDocument(ctx context.Context, userID, id uuid.UUID) (*DocumentDetailResponse, error) {
+	document, err := s.visibleDocument(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.documentRepo.ListDocumentVersions(ctx, document.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DocumentDetailResponse{
+		DocumentResponse: *toDocumentResponse(document),
+		Versions:         make([]DocumentVersionResponse, 0, len(versions)),
+	}
+	for i := range versions {
+		resp.Versions = append(resp.Versions, *toDocumentVersionResponse(&versions[i]))
+	}
+
+	return resp, nil
+}
+
+// GetDownloadURL signs a short-lived link to the current version, or to
+// the requested one.
+func (s *DocumentServiceImpl) GetDownloadURL(ctx context.Context, userID, id uuid.UUID, req *DocumentDownloadRequest) (*DocumentDownloadResponse, error) {
+	document, err := s.visibleDocument(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	versionNumber := document.CurrentVersion
+	if req.Version > 0 {
+		versionNumber = req.Version
+	}
+	version, err := s.documentRepo.GetDocumentVersion(ctx, document.ID, versionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(constants.DocumentURLExpiry)
+	url, err := s.store.SignedURL(ctx, version.StorageKey, version.FileName, constants.DocumentURLExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocumentDownloadResponse{
+		URL:           url,
+		FileName:      version.FileName,
+		VersionNumber: version.VersionNumber,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// visibleDocument loads a document the user may read. Documents the user
+// cannot see are reported as missing so their titles do not leak.
+func (s *DocumentServiceImpl) visibleDocument(ctx context.Context, userID, id uuid.UUID) (*model.Document, error) {
+	document, err := s.documentRepo.GetDocumentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := s.roleRepo.UserHasPermission(ctx, userID, constants.PermManageDocuments)
+	if err != nil {
+		return nil, err
+	}
+	if manager {
+		return document, nil
+	}
+	if document.ArchivedAt != nil {
+		return nil, constants.ErrRecordNotFound
+	}
+	if document.VisibleRole == nil {
+		return document, nil
+	}
+
+	roles, err := s.roleRepo.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.Name == *document.VisibleRole {
+			return document, nil
+		}
+	}
+
+	return nil, constants.ErrRecordNotFound
+}
+
+func (s *DocumentServiceImpl) applyRequest(ctx context.Context, document *model.Document, req *DocumentRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return invalidInput("title is required")
+	}
+	if !documentCategories[req.Category] {
+		return invalidInput("unsupported category %q", req.Category)
+	}
+
+	visibleRole := trimmedOrNil(req.VisibleRole)
+	if visibleRole != nil {
+		if _, err := s.roleRepo.GetRoleByName(ctx, *visibleRole); err != nil {
+			if errors.Is(err, constants.ErrRecordNotFound) {
+				return invalidInput("unknown role %q", *visibleRole)
+			}
+			return err
+		}
+	}
+
+	document.Title = title
+	document.Description = trimmedOrNil(req.Description)
+	document.Category = req.Category
+	document.VisibleRole = visibleRole
+
+	return nil
+}
+
+// storeVersion checks the upload and writes it to storage, filling in the
+// version's file details.
+func (s *DocumentServiceImpl) storeVersion(ctx context.Context, version *model.DocumentVersion, upload *FileUpload) error {
+	if upload.SizeBytes <= 0 || upload.SizeBytes > constants.MaxDocumentBytes {
+		return invalidInput("documents must be at most %d MB", constants.MaxDocumentBytes>>20)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("failed to read document: %w", err)
+	}
+	head = head[:n]
+
+	fileName := documentFileName(upload.FileName)
+	contentType, ok := documentContentType(head, fileName)
+	if !ok {
+		return invalidInput("documents must be a PDF, a Word, Excel or PowerPoint file, or a JPEG or PNG image")
+	}
+
+	version.FileName = fileName
+	version.ContentType = contentType
+	version.SizeBytes = upload.SizeBytes
+	version.StorageKey = fmt.Sprintf("documents/%s/%s", version.DocumentID, version.ID)
+
+	body := io.MultiReader(bytes.NewReader(head), upload.Body)
+	return s.store.Put(ctx, version.StorageKey, body, contentType)
+}
+
+func (s *DocumentServiceImpl) removeOrphan(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("failed to remove orphaned document %s: %v\n", key, err)
+	}
+}
+
+func documentContentType(head []byte, fileName string) (string, bool) {
+	contentType := http.DetectContentType(head)
+	switch {
+	case contentType == "application/pdf", documentImageTypes[contentType]:
+		return contentType, true
+	case contentType == "application/zip":
+		officeType, ok := officeDocumentTypes[strings.ToLower(filepath.Ext(fileName))]
+		return officeType, ok
+	}
+	return "", false
+}
+
+func documentFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func toDocumentResponse(document *model.Document) *DocumentResponse {
+	resp := &DocumentResponse{
+		ID:             document.ID.String(),
+		Title:          document.Title,
+		Description:    document.Description,
+		Category:       document.Category,
+		VisibleRole:    document.VisibleRole,
+		CurrentVersion: document.CurrentVersion,
+		ArchivedAt:     document.ArchivedAt,
+		CreatedAt:      document.CreatedAt,
+		UpdatedAt:      document.UpdatedAt,
+	}
+	if document.CreatedBy != nil {
+		createdBy := document.CreatedBy.String()
+		resp.CreatedBy = &createdBy
+	}
+	return resp
+}
+
+func toDocumentVersionResponse(version *model.DocumentVersion) *DocumentVersionResponse {
+	resp := &DocumentVersionResponse{
+		ID:            version.ID.String(),
+		VersionNumber: version.VersionNumber,
+		FileName:      version.FileName,
+		ContentType:   version.ContentType,
+		SizeBytes:     version.SizeBytes,
+		Notes:         version.Notes,
+		CreatedAt:     version.CreatedAt,
+	}
+	if version.UploadedBy != nil {
+		uploadedBy := version.UploadedBy.String()
+		resp.UploadedBy = &uploadedBy
+	}
+	return resp
+}
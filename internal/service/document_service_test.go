@@ -0,0 +1,333 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockDocumentRepository struct {
+	CreateDocumentFn       func(ctx context.Context, document *model.Document, version *model.DocumentVersion) error
+	GetDocumentByIDFn      func(ctx context.Context, id uuid.UUID) (*model.Document, error)
+	ListDocumentsFn        func(ctx context.Context, filter repository.DocumentFilter) ([]model.Document, error)
+	UpdateDocumentFn       func(ctx context.Context, document *model.Document) (*model.Document, error)
+	ArchiveDocumentFn      func(ctx context.Context, id uuid.UUID) error
+	AddDocumentVersionFn   func(ctx context.Context, version *model.DocumentVersion) error
+	GetDocumentVersionFn   func(ctx context.Context, documentID uuid.UUID, versionNumber int) (*model.DocumentVersion, error)
+	ListDocumentVersionsFn func(ctx context.Context, documentID uuid.UUID) ([]model.DocumentVersion, error)
+}
+
+func (m *MockDocumentRepository) CreateDocument(ctx context.Context, document *model.Document, version *model.DocumentVersion) error {
+	return m.CreateDocumentFn(ctx, document, version)
+}
+
+func (m *MockDocumentRepository) GetDocumentByID(ctx context.Context, id uuid.UUID) (*model.Document, error) {
+	return m.GetDocumentByIDFn(ctx, id)
+}
+
+func (m *MockDocumentRepository) ListDocuments(ctx context.Context, filter repository.DocumentFilter) ([]model.Document, error) {
+	return m.ListDocumentsFn(ctx, filter)
+}
+
+func (m *MockDocumentRepository) UpdateDocument(ctx context.Context, document *model.Document) (*model.Document, error) {
+	return m.UpdateDocumentFn(ctx, document)
+}
+
+func (m *MockDocumentRepository) ArchiveDocument(ctx context.Context, id uuid.UUID) error {
+	return m.ArchiveDocumentFn(ctx, id)
+}
+
+func (m *MockDocumentRepository) AddDocumentVersion(ctx context.Context, version *model.DocumentVersion) error {
+	return m.AddDocumentVersionFn(ctx, version)
+}
+
+func (m *MockDocumentRepository) GetDocumentVersion(ctx context.Context, documentID uuid.UUID, versionNumber int) (*model.DocumentVersion, error) {
+	return m.GetDocumentVersionFn(ctx, documentID, versionNumber)
+}
+
+func (m *MockDocumentRepository) ListDocumentVersions(ctx context.Context, documentID uuid.UUID) ([]model.DocumentVersion, error) {
+	return m.ListDocumentVersionsFn(ctx, documentID)
+}
+
+type MockStorage struct {
+	PutFn       func(ctx context.Context, key string, body io.Reader, contentType string) error
+	GetFn       func(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteFn    func(ctx context.Context, key string) error
+	SignedURLFn func(ctx context.Context, key, fileName string, expiry time.Duration) (string, error)
+}
+
+func (m *MockStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	return m.PutFn(ctx, key, body, contentType)
+}
+
+func (m *MockStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return m.GetFn(ctx, key)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	return m.DeleteFn(ctx, key)
+}
+
+func (m *MockStorage) SignedURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	return m.SignedURLFn(ctx, key, fileName, expiry)
+}
+
+func TestDocumentService_CreateDocument(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<<>>\nendobj\n")
+	zip := []byte("PK\x03\x04\x14\x00\x06\x00\x08\x00\x00\x00!\x00")
+
+	tests := []struct {
+		name                string
+		fileName            string
+		content             []byte
+		expectedContentType string
+		expectedErr         error
+	}{
+		{
+			name:                "pdf",
+			fileName:            "bylaws-2025.pdf",
+			content:             pdf,
+			expectedContentType: "application/pdf",
+		},
+		{
+			name:                "word document",
+			fileName:            "C:\\Users\\board\\minutes.DOCX",
+			content:             zip,
+			expectedContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		},
+		{
+			name:        "plain zip archive",
+			fileName:    "archive.zip",
+			content:     zip,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "text file",
+			fileName:    "notes.txt",
+			content:     []byte("meeting notes"),
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored []byte
+			store := &MockStorage{
+				PutFn: func(ctx context.Context, key string, body io.Reader, contentType string) error {
+					var err error
+					stored, err = io.ReadAll(body)
+					return err
+				},
+			}
+			var created *model.DocumentVersion
+			documentRepo := &MockDocumentRepository{
+				CreateDocumentFn: func(ctx context.Context, document *model.Document, version *model.DocumentVersion) error {
+					document.CurrentVersion = 1
+					version.VersionNumber = 1
+					created = version
+					return nil
+				},
+			}
+
+			service := NewDocumentService(documentRepo, &MockRoleRepository{}, store)
+			resp, err := service.CreateDocument(context.Background(), uuid.New(), &DocumentRequest{
+				Title:    " Bylaws ",
+				Category: constants.DocumentCategoryBylaws,
+			}, &FileUpload{
+				FileName:  tt.fileName,
+				SizeBytes: int64(len(tt.content)),
+				Body:      bytes.NewReader(tt.content),
+			})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if resp.Title != "Bylaws" {
+				t.Errorf("expected trimmed title, got %q", resp.Title)
+			}
+			if created.ContentType != tt.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tt.expectedContentType, created.ContentType)
+			}
+			if created.StorageKey != "documents/"+resp.ID+"/"+created.ID.String() {
+				t.Errorf("unexpected storage key %s", created.StorageKey)
+			}
+			if !bytes.Equal(stored, tt.content) {
+				t.Errorf("stored file does not match the upload")
+			}
+		})
+	}
+}
+
+func TestDocumentService_GetDocument(t *testing.T) {
+	board := constants.RoleBoard
+	archivedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		visibleRole *string
+		archivedAt  *time.Time
+		manager     bool
+		userRoles   []model.Role
+		expectedErr error
+	}{
+		{
+			name:      "visible to every reader",
+			userRoles: []model.Role{{Name: constants.RoleMember}},
+		},
+		{
+			name:        "restricted to a role the user holds",
+			visibleRole: &board,
+			userRoles:   []model.Role{{Name: constants.RoleMember}, {Name: constants.RoleBoard}},
+		},
+		{
+			name:        "restricted to a role the user lacks",
+			visibleRole: &board,
+			userRoles:   []model.Role{{Name: constants.RoleMember}},
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:        "archived",
+			archivedAt:  &archivedAt,
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:        "managers see restricted and archived documents",
+			visibleRole: &board,
+			archivedAt:  &archivedAt,
+			manager:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := &model.Document{
+				ID:             uuid.New(),
+				Title:          "Board minutes",
+				Category:       constants.DocumentCategoryMinutes,
+				VisibleRole:    tt.visibleRole,
+				CurrentVersion: 1,
+				ArchivedAt:     tt.archivedAt,
+			}
+			documentRepo := &MockDocumentRepository{
+				GetDocumentByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Document, error) {
+					return document, nil
+				},
+				ListDocumentVersionsFn: func(ctx context.Context, documentID uuid.UUID) ([]model.DocumentVersion, error) {
+					return []model.DocumentVersion{{ID: uuid.New(), DocumentID: documentID, VersionNumber: 1}}, nil
+				},
+			}
+			roleRepo := &MockRoleRepository{
+				UserHasPermissionFn: func(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+					return tt.manager && permission == constants.PermManageDocuments, nil
+				},
+				GetRolesByUserIDFn: func(ctx context.Context, userID uuid.UUID) ([]model.Role, error) {
+					return tt.userRoles, nil
+				},
+			}
+
+			service := NewDocumentService(documentRepo, roleRepo, &MockStorage{})
+			resp, err := service.GetDocument(context.Background(), uuid.New(), document.ID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr == nil && len(resp.Versions) != 1 {
+				t.Errorf("expected 1 version, got %d", len(resp.Versions))
+			}
+		})
+	}
+}
+
+func TestDocumentService_GetDownloadURL(t *testing.T) {
+	document := &model.Document{
+		ID:             uuid.New(),
+		Title:          "Audited financials",
+		Category:       constants.DocumentCategoryFinancials,
+		CurrentVersion: 3,
+	}
+
+	tests := []struct {
+		name            string
+		req             *DocumentDownloadRequest
+		expectedVersion int
+		expectedErr     error
+	}{
+		{
+			name:            "current version",
+			req:             &DocumentDownloadRequest{},
+			expectedVersion: 3,
+		},
+		{
+			name:            "earlier version",
+			req:             &DocumentDownloadRequest{Version: 1},
+			expectedVersion: 1,
+		},
+		{
+			name:        "unknown version",
+			req:         &DocumentDownloadRequest{Version: 4},
+			expectedErr: constants.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documentRepo := &MockDocumentRepository{
+				GetDocumentByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Document, error) {
+					return document, nil
+				},
+				GetDocumentVersionFn: func(ctx context.Context, documentID uuid.UUID, versionNumber int) (*model.DocumentVersion, error) {
+					if versionNumber > document.CurrentVersion {
+						return nil, constants.ErrRecordNotFound
+					}
+					return &model.DocumentVersion{
+						DocumentID:    documentID,
+						VersionNumber: versionNumber,
+						StorageKey:    "documents/key",
+						FileName:      "financials.pdf",
+					}, nil
+				},
+			}
+			roleRepo := &MockRoleRepository{
+				UserHasPermissionFn: func(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+					return false, nil
+				},
+			}
+			var expiry time.Duration
+			store := &MockStorage{
+				SignedURLFn: func(ctx context.Context, key, fileName string, exp time.Duration) (string, error) {
+					expiry = exp
+					return "https://files.example.com/" + key + "?sig=abc", nil
+				},
+			}
+
+			service := NewDocumentService(documentRepo, roleRepo, store)
+			resp, err := service.GetDownloadURL(context.Background(), uuid.New(), document.ID, tt.req)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if resp.VersionNumber != tt.expectedVersion {
+				t.Errorf("expected version %d, got %d", tt.expectedVersion, resp.VersionNumber)
+			}
+			if expiry != constants.DocumentURLExpiry {
+				t.Errorf("expected expiry %s, got %s", constants.DocumentURLExpiry, expiry)
+			}
+			if resp.URL != "https://files.example.com/documents/key?sig=abc" {
+				t.Errorf("unexpected url %s", resp.URL)
+			}
+		})
+	}
+}
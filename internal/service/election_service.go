@@ -0,0 +1,1082 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+)
+
+var proxyAuthorizationTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// votingOccupancyTypes are the occupants who may cast or delegate a
+// property's vote; tenants cannot.
+var votingOccupancyTypes = map[string]bool{
+	constants.OccupancyOwner:          true,
+	constants.OccupancyCoOwner:        true,
+	constants.OccupancyRepresentative: true,
+}
+
+type ElectionServiceImpl struct {
+	electionRepo  repository.ElectionRepository
+	occupancyRepo repository.OccupancyRepository
+	invoiceRepo   repository.InvoiceRepository
+	propertyRepo  repository.PropertyRepository
+	userRepo      repository.UserRepository
+	roleRepo      repository.RoleRepository
+	store         storage.Storage
+}
+
+func NewElectionService(electionRepo repository.ElectionRepository, occupancyRepo repository.OccupancyRepository, invoiceRepo repository.InvoiceRepository, propertyRepo repository.PropertyRepository, userRepo repository.UserRepository, roleRepo repository.RoleRepository, store storage.Storage) ElectionService {
+	return &ElectionServiceImpl{
+		electionRepo:  electionRepo,
+		occupancyRepo: occupancyRepo,
+		invoiceRepo:   invoiceRepo,
+		propertyRepo:  propertyRepo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		store:         store,
+	}
+}
+
+func (s *ElectionServiceImpl) CreateElection(ctx context.Context, createdBy uuid.UUID, req *ElectionRequest) (*ElectionResponse, error) {
+	election := &model.Election{CreatedBy: &createdBy}
+	if err := applyElectionRequest(election, req); err != nil {
+		return nil, err
+	}
+
+	created, err := s.electionRepo.CreateElection(ctx, election)
+	if err != nil {
+		return nil, err
+	}
+
+	return toElectionResponse(created, time.Now()), nil
+}
+
+func (s *ElectionServiceImpl) UpdateElection(ctx context.Context, id uuid.UUID, req *ElectionRequest) (*ElectionResponse, error) {
+	election, err := s.draftElection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyElectionRequest(election, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.electionRepo.UpdateElection(ctx, election)
+	if err != nil {
+		return nil, err
+	}
+
+	return toElectionResponse(updated, time.Now()), nil
+}
+
+func (s *ElectionServiceImpl) AddPosition(ctx context.Context, electionID uuid.UUID, req *ElectionPositionRequest) (*ElectionPositionResponse, error) {
+	election, err := s.draftElection(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, invalidInput("title is required")
+	}
+	seats := req.Seats
+	if seats == 0 {
+		seats = 1
+	}
+
+	position, err := s.electionRepo.CreatePosition(ctx, &model.ElectionPosition{
+		ElectionID: election.ID,
+		Title:      title,
+		Seats:      seats,
+		SortOrder:  req.SortOrder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toElectionPositionResponse(position, nil), nil
+}
+
+func (s *ElectionServiceImpl) RemovePosition(ctx context.Context, electionID, positionID uuid.UUID) error {
+	if _, err := s.draftElection(ctx, electionID); err != nil {
+		return err
+	}
+	return s.electionRepo.DeletePosition(ctx, electionID, positionID)
+}
+
+func (s *ElectionServiceImpl) AddCandidate(ctx context.Context, electionID, positionID uuid.UUID, req *CandidateRequest) (*CandidateResponse, error) {
+	election, err := s.draftElection(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.electionRepo.ListPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	if findPosition(positions, positionID) == nil {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, invalidInput("name is required")
+	}
+
+	candidate := &model.ElectionCandidate{
+		ElectionID: election.ID,
+		PositionID: positionID,
+		Name:       name,
+		Bio:        trimmedOrNil(req.Bio),
+	}
+	if req.UserID != nil && *req.UserID != "" {
+		userID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			return nil, invalidInput("user id must be a uuid")
+		}
+		if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+			return nil, err
+		}
+		candidate.UserID = &userID
+	}
+
+	candidate, err = s.electionRepo.CreateCandidate(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCandidateResponse(candidate), nil
+}
+
+func (s *ElectionServiceImpl) RemoveCandidate(ctx context.Context, electionID, candidateID uuid.UUID) error {
+	if _, err := s.draftElection(ctx, electionID); err != nil {
+		return err
+	}
+	return s.electionRepo.DeleteCandidate(ctx, electionID, candidateID)
+}
+
+// ScheduleElection locks the ballot. Voting opens on its own once the
+// window starts.
+func (s *ElectionServiceImpl) ScheduleElection(ctx context.Context, id uuid.UUID) (*ElectionResponse, error) {
+	election, err := s.draftElection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !election.VotingEndsAt.After(time.Now()) {
+		return nil, invalidInput("the voting window has already ended")
+	}
+
+	positions, err := s.electionRepo.ListPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 {
+		return nil, invalidInput("add at least one position before scheduling")
+	}
+	candidates, err := s.electionRepo.ListCandidates(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range positions {
+		if len(candidatesFor(candidates, position.ID)) == 0 {
+			return nil, invalidInput("position %q has no candidates", position.Title)
+		}
+	}
+
+	if err := s.electionRepo.ChangeElectionStatus(ctx, election.ID, constants.ElectionDraft, constants.ElectionScheduled); err != nil {
+		return nil, err
+	}
+	election.Status = constants.ElectionScheduled
+
+	return toElectionResponse(election, time.Now()), nil
+}
+
+func (s *ElectionServiceImpl) CancelElection(ctx context.Context, id uuid.UUID) (*ElectionResponse, error) {
+	election, err := s.electionRepo.GetElectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch election.Status {
+	case constants.ElectionDraft, constants.ElectionScheduled:
+	default:
+		return nil, invalidInput("election is already %s", election.Status)
+	}
+
+	if err := s.electionRepo.ChangeElectionStatus(ctx, election.ID, election.Status, constants.ElectionCancelled); err != nil {
+		return nil, err
+	}
+	election.Status = constants.ElectionCancelled
+
+	return toElectionResponse(election, time.Now()), nil
+}
+
+// PublishResults makes the tally visible to members. It is only allowed
+// once the voting window has closed.
+func (s *ElectionServiceImpl) PublishResults(ctx context.Context, id uuid.UUID) (*ElectionResultsResponse, error) {
+	election, err := s.electionRepo.GetElectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if election.Status != constants.ElectionScheduled {
+		return nil, invalidInput("a %s election cannot be published", election.Status)
+	}
+	if time.Now().Before(election.VotingEndsAt) {
+		return nil, invalidInput("results can only be published after voting closes")
+	}
+
+	quorum, err := s.electionRepo.GetQuorum(ctx, election.ID, election.VotingEndsAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.electionRepo.PublishElectionResults(ctx, election.ID, quorum.EligibleProperties); err != nil {
+		return nil, err
+	}
+
+	published, err := s.electionRepo.GetElectionByID(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.results(ctx, published)
+}
+
+func (s *ElectionServiceImpl) ListAllElections(ctx context.Context, req *ListElectionsRequest) ([]ElectionResponse, error) {
+	return s.listElections(ctx, req, true)
+}
+
+func (s *ElectionServiceImpl) ListElections(ctx context.Context, req *ListElectionsRequest) ([]ElectionResponse, error) {
+	return s.listElections(ctx, req, false)
+}
+
+func (s *ElectionServiceImpl) GetElection(ctx context.Context, userID, id uuid.UUID) (*ElectionDetailResponse, error) {
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.electionRepo.ListPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.electionRepo.ListCandidates(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ElectionDetailResponse{
+		ElectionResponse: *toElectionResponse(election, time.Now()),
+		Positions:        make([]ElectionPositionResponse, 0, len(positions)),
+	}
+	for i := range positions {
+		resp.Positions = append(resp.Positions, *toElectionPositionResponse(&positions[i], candidatesFor(candidates, positions[i].ID)))
+	}
+
+	return resp, nil
+}
+
+// GetQuorum reports turnout so far. While voting is open the eligible
+// count follows payments and ownership changes as they happen.
+func (s *ElectionServiceImpl) GetQuorum(ctx context.Context, userID, id uuid.UUID) (*QuorumResponse, error) {
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if election.Status == constants.ElectionDraft {
+		return nil, invalidInput("election has not been scheduled")
+	}
+
+	return s.quorum(ctx, election)
+}
+
+// GetEligibility lists the properties the user can vote for in the
+// election, directly or as an approved proxy, and whether each still can.
+func (s *ElectionServiceImpl) GetEligibility(ctx context.Context, userID, id uuid.UUID) ([]BallotEligibilityResponse, error) {
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := s.occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := s.electionRepo.ListUserProxies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []BallotEligibilityResponse{}
+	seen := map[uuid.UUID]bool{}
+	for i := range properties {
+		property := &properties[i]
+		if !votingOccupancyTypes[property.OccupancyType] || seen[property.ID] {
+			continue
+		}
+		seen[property.ID] = true
+
+		eligibility, err := s.eligibility(ctx, election, userID, &property.Property, false)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *eligibility)
+	}
+	for _, proxy := range proxies {
+		if proxy.ElectionID != election.ID || proxy.ProxyUserID != userID || proxy.Status != constants.ProxyApproved || seen[proxy.PropertyID] {
+			continue
+		}
+		seen[proxy.PropertyID] = true
+
+		property, err := s.propertyRepo.GetPropertyByID(ctx, proxy.PropertyID)
+		if err != nil {
+			return nil, err
+		}
+		eligibility, err := s.eligibility(ctx, election, userID, property, true)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *eligibility)
+	}
+
+	return resp, nil
+}
+
+// CastBallot records one vote for the property. Only the ballot's existence
+// is tied to the property; the choices are stored without it.
+func (s *ElectionServiceImpl) CastBallot(ctx context.Context, userID, id uuid.UUID, req *BallotRequest) (*BallotReceiptResponse, error) {
+	propertyID, err := uuid.Parse(req.PropertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if phase := electionPhase(election, time.Now()); phase != constants.ElectionPhaseOpen {
+		return nil, invalidInput("voting is not open (election is %s)", phase)
+	}
+
+	proxy, err := s.ballotAuthority(ctx, election, userID, propertyID)
+	if err != nil {
+		return nil, err
+	}
+	overdue, err := s.invoiceRepo.HasOverdueInvoices(ctx, propertyID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if overdue {
+		return nil, constants.ErrOverdueDues
+	}
+
+	votes, err := s.ballotVotes(ctx, election, req.Selections)
+	if err != nil {
+		return nil, err
+	}
+
+	ballot := &model.ElectionBallot{
+		ElectionID: election.ID,
+		PropertyID: propertyID,
+		CastBy:     userID,
+	}
+	if proxy != nil {
+		ballot.ProxyID = &proxy.ID
+	}
+	if err := s.electionRepo.CastBallot(ctx, ballot, votes); err != nil {
+		return nil, err
+	}
+
+	return &BallotReceiptResponse{
+		ElectionID: election.ID.String(),
+		PropertyID: propertyID.String(),
+		ViaProxy:   proxy != nil,
+		CastAt:     ballot.CastAt,
+	}, nil
+}
+
+// GetResults returns the tally once published. Election managers can see
+// it as soon as voting closes, to review it before publishing.
+func (s *ElectionServiceImpl) GetResults(ctx context.Context, userID, id uuid.UUID) (*ElectionResultsResponse, error) {
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch electionPhase(election, time.Now()) {
+	case constants.ElectionPhasePublished:
+	case constants.ElectionPhaseClosed:
+		manager, err := s.roleRepo.UserHasPermission(ctx, userID, constants.PermManageElections)
+		if err != nil {
+			return nil, err
+		}
+		if !manager {
+			return nil, invalidInput("results have not been published yet")
+		}
+	default:
+		return nil, invalidInput("results are only available after voting closes")
+	}
+
+	return s.results(ctx, election)
+}
+
+// GrantProxy assigns the property's vote to another member, backed by an
+// uploaded signed authorization. The board approves it before it can be
+// used.
+func (s *ElectionServiceImpl) GrantProxy(ctx context.Context, userID, id uuid.UUID, req *ProxyRequest, upload *FileUpload) (*ProxyResponse, error) {
+	propertyID, err := uuid.Parse(req.PropertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+
+	election, err := s.visibleElection(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch electionPhase(election, time.Now()) {
+	case constants.ElectionPhaseUpcoming, constants.ElectionPhaseOpen:
+	default:
+		return nil, invalidInput("proxies can only be granted before voting closes")
+	}
+
+	if err := s.requireVotingOccupant(ctx, userID, propertyID); err != nil {
+		return nil, err
+	}
+	if _, err := s.electionRepo.GetBallotByProperty(ctx, election.ID, propertyID); err == nil {
+		return nil, invalidInput("this property has already voted")
+	} else if !errors.Is(err, constants.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	holder, err := s.userRepo.GetUserByEmail(ctx, strings.TrimSpace(req.ProxyEmail))
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, invalidInput("no member is registered with that email")
+		}
+		return nil, err
+	}
+	if holder.ID == userID {
+		return nil, invalidInput("you cannot name yourself as proxy")
+	}
+	if holder.Status != constants.ActiveStatus {
+		return nil, invalidInput("the proxy's account is not active")
+	}
+
+	if upload.SizeBytes <= 0 || upload.SizeBytes > constants.MaxProxyAuthorizationBytes {
+		return nil, invalidInput("authorizations must be at most %d MB", constants.MaxProxyAuthorizationBytes>>20)
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read authorization: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !proxyAuthorizationTypes[contentType] {
+		return nil, invalidInput("the authorization must be a PDF or a JPEG or PNG image")
+	}
+
+	proxy := &model.ElectionProxy{
+		ID:          uuid.New(),
+		ElectionID:  election.ID,
+		PropertyID:  propertyID,
+		GrantedBy:   userID,
+		ProxyUserID: holder.ID,
+		FileName:    authorizationFileName(upload.FileName),
+		ContentType: contentType,
+		SizeBytes:   upload.SizeBytes,
+	}
+	proxy.StorageKey = fmt.Sprintf("elections/%s/proxies/%s", election.ID, proxy.ID)
+
+	body := io.MultiReader(bytes.NewReader(head), upload.Body)
+	if err := s.store.Put(ctx, proxy.StorageKey, body, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.electionRepo.CreateProxy(ctx, proxy); err != nil {
+		if dErr := s.store.Delete(ctx, proxy.StorageKey); dErr != nil {
+			log.Printf("failed to remove orphaned authorization %s: %v\n", proxy.StorageKey, dErr)
+		}
+		return nil, err
+	}
+
+	return toProxyResponse(proxy), nil
+}
+
+// RevokeProxy lets the member who granted a proxy withdraw it, as long as
+// the proxy has not voted.
+func (s *ElectionServiceImpl) RevokeProxy(ctx context.Context, userID, electionID, proxyID uuid.UUID) (*ProxyResponse, error) {
+	proxy, err := s.electionRepo.GetProxyByID(ctx, electionID, proxyID)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.GrantedBy != userID {
+		return nil, constants.ErrRecordNotFound
+	}
+	switch proxy.Status {
+	case constants.ProxyPending, constants.ProxyApproved:
+	default:
+		return nil, invalidInput("proxy is already %s", proxy.Status)
+	}
+
+	if err := s.electionRepo.RevokeProxy(ctx, proxy.ID); err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, invalidInput("the proxy has already voted")
+		}
+		return nil, err
+	}
+	proxy.Status = constants.ProxyRevoked
+
+	return toProxyResponse(proxy), nil
+}
+
+func (s *ElectionServiceImpl) ListUserProxies(ctx context.Context, userID uuid.UUID) ([]ProxyResponse, error) {
+	proxies, err := s.electionRepo.ListUserProxies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProxyResponses(proxies), nil
+}
+
+func (s *ElectionServiceImpl) ListProxies(ctx context.Context, electionID uuid.UUID, req *ListProxiesRequest) ([]ProxyResponse, error) {
+	if _, err := s.electionRepo.GetElectionByID(ctx, electionID); err != nil {
+		return nil, err
+	}
+
+	proxies, err := s.electionRepo.ListProxies(ctx, electionID, req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProxyResponses(proxies), nil
+}
+
+func (s *ElectionServiceImpl) GetProxyAuthorization(ctx context.Context, electionID, proxyID uuid.UUID) (*StoredFile, error) {
+	proxy, err := s.electionRepo.GetProxyByID(ctx, electionID, proxyID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.store.Get(ctx, proxy.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredFile{
+		ContentType: proxy.ContentType,
+		SizeBytes:   proxy.SizeBytes,
+		Body:        body,
+	}, nil
+}
+
+func (s *ElectionServiceImpl) ReviewProxy(ctx context.Context, electionID, proxyID, reviewedBy uuid.UUID, req *ProxyReviewRequest) (*ProxyResponse, error) {
+	election, err := s.electionRepo.GetElectionByID(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := s.electionRepo.GetProxyByID(ctx, electionID, proxyID)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.Status != constants.ProxyPending {
+		return nil, invalidInput("proxy is already %s", proxy.Status)
+	}
+
+	proxy.Status = constants.ProxyRejected
+	if req.Approve {
+		switch electionPhase(election, time.Now()) {
+		case constants.ElectionPhaseDraft, constants.ElectionPhaseUpcoming, constants.ElectionPhaseOpen:
+		default:
+			return nil, invalidInput("voting has closed")
+		}
+		proxy.Status = constants.ProxyApproved
+	}
+	proxy.ReviewNote = trimmedOrNil(req.Note)
+	proxy.ReviewedBy = &reviewedBy
+
+	if err := s.electionRepo.ReviewProxy(ctx, proxy); err != nil {
+		return nil, err
+	}
+
+	return toProxyResponse(proxy), nil
+}
+
+func (s *ElectionServiceImpl) listElections(ctx context.Context, req *ListElectionsRequest, includeDrafts bool) ([]ElectionResponse, error) {
+	elections, err := s.electionRepo.ListElections(ctx, repository.ElectionFilter{
+		IncludeDrafts: includeDrafts,
+		Limit:         pageSize(req.Limit),
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := make([]ElectionResponse, 0, len(elections))
+	for i := range elections {
+		resp = append(resp, *toElectionResponse(&elections[i], now))
+	}
+
+	return resp, nil
+}
+
+func (s *ElectionServiceImpl) draftElection(ctx context.Context, id uuid.UUID) (*model.Election, error) {
+	election, err := s.electionRepo.GetElectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if election.Status != constants.ElectionDraft {
+		return nil, invalidInput("only draft elections can be changed")
+	}
+	return election, nil
+}
+
+// visibleElection hides drafts from members who cannot manage elections.
+func (s *ElectionServiceImpl) visibleElection(ctx context.Context, userID, id uuid.UUID) (*model.Election, error) {
+	election, err := s.electionRepo.GetElectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if election.Status != constants.ElectionDraft {
+		return election, nil
+	}
+
+	manager, err := s.roleRepo.UserHasPermission(ctx, userID, constants.PermManageElections)
+	if err != nil {
+		return nil, err
+	}
+	if !manager {
+		return nil, constants.ErrRecordNotFound
+	}
+	return election, nil
+}
+
+// ballotAuthority checks that the user may vote for the property and
+// returns the proxy they vote through, if any. An approved proxy takes the
+// vote away from the property's own occupants.
+func (s *ElectionServiceImpl) ballotAuthority(ctx context.Context, election *model.Election, userID, propertyID uuid.UUID) (*model.ElectionProxy, error) {
+	proxy, err := s.electionRepo.GetApprovedProxy(ctx, election.ID, propertyID)
+	switch {
+	case err == nil:
+		if proxy.ProxyUserID != userID {
+			return nil, invalidInput("this property's vote has been assigned to a proxy")
+		}
+		return proxy, nil
+	case !errors.Is(err, constants.ErrRecordNotFound):
+		return nil, err
+	}
+
+	if err := s.requireVotingOccupant(ctx, userID, propertyID); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ElectionServiceImpl) requireVotingOccupant(ctx context.Context, userID, propertyID uuid.UUID) error {
+	properties, err := s.occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, property := range properties {
+		if property.ID != propertyID {
+			continue
+		}
+		found = true
+		if votingOccupancyTypes[property.OccupancyType] {
+			return nil
+		}
+	}
+	if found {
+		return invalidInput("only owners and representatives can vote for a property")
+	}
+	return constants.ErrRecordNotFound
+}
+
+// ballotVotes checks the selections against the ballot and turns them into
+// votes. Positions left out are abstentions.
+func (s *ElectionServiceImpl) ballotVotes(ctx context.Context, election *model.Election, selections []BallotSelection) ([]model.ElectionVote, error) {
+	positions, err := s.electionRepo.ListPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.electionRepo.ListCandidates(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidatePositions := make(map[uuid.UUID]uuid.UUID, len(candidates))
+	for _, candidate := range candidates {
+		candidatePositions[candidate.ID] = candidate.PositionID
+	}
+
+	votes := []model.ElectionVote{}
+	seenPositions := map[uuid.UUID]bool{}
+	for _, selection := range selections {
+		positionID, err := uuid.Parse(selection.PositionID)
+		if err != nil {
+			return nil, invalidInput("position id must be a uuid")
+		}
+		position := findPosition(positions, positionID)
+		if position == nil {
+			return nil, invalidInput("position %s is not on this ballot", positionID)
+		}
+		if seenPositions[positionID] {
+			return nil, invalidInput("position %q is selected more than once", position.Title)
+		}
+		seenPositions[positionID] = true
+		if len(selection.CandidateIDs) > position.Seats {
+			return nil, invalidInput("vote for at most %d candidates for %q", position.Seats, position.Title)
+		}
+
+		seenCandidates := map[uuid.UUID]bool{}
+		for _, value := range selection.CandidateIDs {
+			candidateID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, invalidInput("candidate id must be a uuid")
+			}
+			if candidatePositions[candidateID] != positionID {
+				return nil, invalidInput("candidate %s is not running for %q", candidateID, position.Title)
+			}
+			if seenCandidates[candidateID] {
+				return nil, invalidInput("candidate %s is selected more than once", candidateID)
+			}
+			seenCandidates[candidateID] = true
+
+			votes = append(votes, model.ElectionVote{
+				ID:          uuid.New(),
+				ElectionID:  election.ID,
+				PositionID:  positionID,
+				CandidateID: candidateID,
+			})
+		}
+	}
+
+	return votes, nil
+}
+
+func (s *ElectionServiceImpl) eligibility(ctx context.Context, election *model.Election, userID uuid.UUID, property *model.Property, viaProxy bool) (*BallotEligibilityResponse, error) {
+	resp := &BallotEligibilityResponse{
+		PropertyID: property.ID.String(),
+		Phase:      property.Phase,
+		Block:      property.Block,
+		Lot:        property.Lot,
+		ViaProxy:   viaProxy,
+	}
+
+	if _, err := s.electionRepo.GetBallotByProperty(ctx, election.ID, property.ID); err == nil {
+		resp.Voted = true
+		return resp, nil
+	} else if !errors.Is(err, constants.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	reason, err := s.ineligibleReason(ctx, election, userID, property.ID, viaProxy)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		resp.CanVote = true
+	} else {
+		resp.Reason = &reason
+	}
+
+	return resp, nil
+}
+
+// ineligibleReason explains why the user cannot vote for a property that
+// has not voted yet, or returns "" when they can.
+func (s *ElectionServiceImpl) ineligibleReason(ctx context.Context, election *model.Election, userID, propertyID uuid.UUID, viaProxy bool) (string, error) {
+	if phase := electionPhase(election, time.Now()); phase != constants.ElectionPhaseOpen {
+		return "voting is " + phase, nil
+	}
+
+	if !viaProxy {
+		proxy, err := s.electionRepo.GetApprovedProxy(ctx, election.ID, propertyID)
+		if err == nil && proxy.ProxyUserID != userID {
+			return "vote assigned to a proxy", nil
+		} else if err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
+			return "", err
+		}
+	}
+
+	overdue, err := s.invoiceRepo.HasOverdueInvoices(ctx, propertyID, today(ctx))
+	if err != nil {
+		return "", err
+	}
+	if overdue {
+		return "property has overdue dues", nil
+	}
+
+	return "", nil
+}
+
+func (s *ElectionServiceImpl) quorum(ctx context.Context, election *model.Election) (*QuorumResponse, error) {
+	asOf := today(ctx)
+	if !time.Now().Before(election.VotingEndsAt) {
+		asOf = election.VotingEndsAt
+	}
+
+	quorum, err := s.electionRepo.GetQuorum(ctx, election.ID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if election.EligibleProperties != nil {
+		quorum.EligibleProperties = *election.EligibleProperties
+	}
+
+	return toQuorumResponse(quorum, election.QuorumPercent), nil
+}
+
+func (s *ElectionServiceImpl) results(ctx context.Context, election *model.Election) (*ElectionResultsResponse, error) {
+	quorum, err := s.quorum(ctx, election)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.electionRepo.ListPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.electionRepo.ListCandidates(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	tallies, err := s.electionRepo.TallyVotes(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make(map[uuid.UUID]int, len(tallies))
+	for _, tally := range tallies {
+		votes[tally.CandidateID] = tally.Votes
+	}
+
+	resp := &ElectionResultsResponse{
+		ElectionID:  election.ID.String(),
+		Title:       election.Title,
+		Status:      election.Status,
+		PublishedAt: election.ResultsPublishedAt,
+		Quorum:      *quorum,
+		Positions:   make([]PositionResultResponse, 0, len(positions)),
+	}
+	for _, position := range positions {
+		resp.Positions = append(resp.Positions, positionResult(position, candidatesFor(candidates, position.ID), votes))
+	}
+
+	return resp, nil
+}
+
+// positionResult ranks the position's candidates. The top candidates win
+// the seats, except that candidates tied for the last seat are all left
+// unelected and the position is marked tied.
+func positionResult(position model.ElectionPosition, candidates []model.ElectionCandidate, votes map[uuid.UUID]int) PositionResultResponse {
+	resp := PositionResultResponse{
+		PositionID: position.ID.String(),
+		Title:      position.Title,
+		Seats:      position.Seats,
+		Candidates: make([]CandidateResultResponse, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		resp.Candidates = append(resp.Candidates, CandidateResultResponse{
+			CandidateID: candidate.ID.String(),
+			Name:        candidate.Name,
+			Votes:       votes[candidate.ID],
+		})
+	}
+	sort.SliceStable(resp.Candidates, func(i, j int) bool {
+		return resp.Candidates[i].Votes > resp.Candidates[j].Votes
+	})
+
+	runnerUp := 0
+	if len(resp.Candidates) > position.Seats {
+		runnerUp = resp.Candidates[position.Seats].Votes
+	}
+	for i := range resp.Candidates {
+		candidate := &resp.Candidates[i]
+		if i >= position.Seats || candidate.Votes == 0 {
+			break
+		}
+		if candidate.Votes == runnerUp {
+			resp.Tied = true
+			break
+		}
+		candidate.Elected = true
+	}
+
+	return resp
+}
+
+func applyElectionRequest(election *model.Election, req *ElectionRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return invalidInput("title is required")
+	}
+	if !req.VotingEndsAt.After(req.VotingStartsAt) {
+		return invalidInput("votingEndsAt must be after votingStartsAt")
+	}
+
+	election.Title = title
+	election.Description = trimmedOrNil(req.Description)
+	election.VotingStartsAt = req.VotingStartsAt
+	election.VotingEndsAt = req.VotingEndsAt
+	election.QuorumPercent = req.QuorumPercent
+
+	return nil
+}
+
+func electionPhase(election *model.Election, now time.Time) string {
+	switch election.Status {
+	case constants.ElectionDraft:
+		return constants.ElectionPhaseDraft
+	case constants.ElectionCancelled:
+		return constants.ElectionPhaseCancelled
+	case constants.ElectionPublished:
+		return constants.ElectionPhasePublished
+	}
+
+	switch {
+	case now.Before(election.VotingStartsAt):
+		return constants.ElectionPhaseUpcoming
+	case now.Before(election.VotingEndsAt):
+		return constants.ElectionPhaseOpen
+	default:
+		return constants.ElectionPhaseClosed
+	}
+}
+
+func findPosition(positions []model.ElectionPosition, id uuid.UUID) *model.ElectionPosition {
+	for i := range positions {
+		if positions[i].ID == id {
+			return &positions[i]
+		}
+	}
+	return nil
+}
+
+func candidatesFor(candidates []model.ElectionCandidate, positionID uuid.UUID) []model.ElectionCandidate {
+	matched := []model.ElectionCandidate{}
+	for _, candidate := range candidates {
+		if candidate.PositionID == positionID {
+			matched = append(matched, candidate)
+		}
+	}
+	return matched
+}
+
+func authorizationFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "authorization"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func toElectionResponse(election *model.Election, now time.Time) *ElectionResponse {
+	resp := &ElectionResponse{
+		ID:                 election.ID.String(),
+		Title:              election.Title,
+		Description:        election.Description,
+		VotingStartsAt:     election.VotingStartsAt,
+		VotingEndsAt:       election.VotingEndsAt,
+		QuorumPercent:      election.QuorumPercent,
+		Status:             election.Status,
+		Phase:              electionPhase(election, now),
+		ResultsPublishedAt: election.ResultsPublishedAt,
+		CreatedAt:          election.CreatedAt,
+		UpdatedAt:          election.UpdatedAt,
+	}
+	if election.CreatedBy != nil {
+		createdBy := election.CreatedBy.String()
+		resp.CreatedBy = &createdBy
+	}
+	return resp
+}
+
+func toElectionPositionResponse(position *model.ElectionPosition, candidates []model.ElectionCandidate) *ElectionPositionResponse {
+	resp := &ElectionPositionResponse{
+		ID:         position.ID.String(),
+		Title:      position.Title,
+		Seats:      position.Seats,
+		SortOrder:  position.SortOrder,
+		Candidates: make([]CandidateResponse, 0, len(candidates)),
+	}
+	for i := range candidates {
+		resp.Candidates = append(resp.Candidates, *toCandidateResponse(&candidates[i]))
+	}
+	return resp
+}
+
+func toCandidateResponse(candidate *model.ElectionCandidate) *CandidateResponse {
+	resp := &CandidateResponse{
+		ID:         candidate.ID.String(),
+		PositionID: candidate.PositionID.String(),
+		Name:       candidate.Name,
+		Bio:        candidate.Bio,
+	}
+	if candidate.UserID != nil {
+		userID := candidate.UserID.String()
+		resp.UserID = &userID
+	}
+	return resp
+}
+
+func toQuorumResponse(quorum *model.ElectionQuorum, percent int) *QuorumResponse {
+	// round up: a 50% quorum of 7 properties needs 4 ballots
+	required := (quorum.EligibleProperties*percent + 99) / 100
+	return &QuorumResponse{
+		EligibleProperties: quorum.EligibleProperties,
+		BallotsCast:        quorum.BallotsCast,
+		QuorumPercent:      percent,
+		RequiredBallots:    required,
+		Reached:            quorum.EligibleProperties > 0 && quorum.BallotsCast >= required,
+	}
+}
+
+func toProxyResponse(proxy *model.ElectionProxy) *ProxyResponse {
+	resp := &ProxyResponse{
+		ID:          proxy.ID.String(),
+		ElectionID:  proxy.ElectionID.String(),
+		PropertyID:  proxy.PropertyID.String(),
+		GrantedBy:   proxy.GrantedBy.String(),
+		ProxyUserID: proxy.ProxyUserID.String(),
+		FileName:    proxy.FileName,
+		ContentType: proxy.ContentType,
+		SizeBytes:   proxy.SizeBytes,
+		Status:      proxy.Status,
+		ReviewNote:  proxy.ReviewNote,
+		ReviewedAt:  proxy.ReviewedAt,
+		CreatedAt:   proxy.CreatedAt,
+	}
+	if proxy.ReviewedBy != nil {
+		reviewedBy := proxy.ReviewedBy.String()
+		resp.ReviewedBy = &reviewedBy
+	}
+	return resp
+}
+
+func toProxyResponses(proxies []model.ElectionProxy) []ProxyResponse {
+	resp := make([]ProxyResponse, 0, len(proxies))
+	for i := range proxies {
+		resp = append(resp, *toProxyResponse(&proxies[i]))
+	}
+	return resp
+}
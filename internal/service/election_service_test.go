@@ -0,0 +1,520 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockElectionRepository struct {
+	CreateElectionFn         func(ctx context.Context, election *model.Election) (*model.Election, error)
+	GetElectionByIDFn        func(ctx context.Context, id uuid.UUID) (*model.Election, error)
+	ListElectionsFn          func(ctx context.Context, filter repository.ElectionFilter) ([]model.Election, error)
+	UpdateElectionFn         func(ctx context.Context, election *model.Election) (*model.Election, error)
+	ChangeElectionStatusFn   func(ctx context.Context, id uuid.UUID, from, to string) error
+	PublishElectionResultsFn func(ctx context.Context, id uuid.UUID, eligibleProperties int) error
+	CreatePositionFn         func(ctx context.Context, position *model.ElectionPosition) (*model.ElectionPosition, error)
+	ListPositionsFn          func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error)
+	DeletePositionFn         func(ctx context.Context, electionID, id uuid.UUID) error
+	CreateCandidateFn        func(ctx context.Context, candidate *model.ElectionCandidate) (*model.ElectionCandidate, error)
+	ListCandidatesFn         func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error)
+	DeleteCandidateFn        func(ctx context.Context, electionID, id uuid.UUID) error
+	CastBallotFn             func(ctx context.Context, ballot *model.ElectionBallot, votes []model.ElectionVote) error
+	GetBallotByPropertyFn    func(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionBallot, error)
+	GetQuorumFn              func(ctx context.Context, electionID uuid.UUID, asOf time.Time) (*model.ElectionQuorum, error)
+	TallyVotesFn             func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionTally, error)
+	CreateProxyFn            func(ctx context.Context, proxy *model.ElectionProxy) error
+	GetProxyByIDFn           func(ctx context.Context, electionID, id uuid.UUID) (*model.ElectionProxy, error)
+	GetApprovedProxyFn       func(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionProxy, error)
+	ListProxiesFn            func(ctx context.Context, electionID uuid.UUID, status string) ([]model.ElectionProxy, error)
+	ListUserProxiesFn        func(ctx context.Context, userID uuid.UUID) ([]model.ElectionProxy, error)
+	ReviewProxyFn            func(ctx context.Context, proxy *model.ElectionProxy) error
+	RevokeProxyFn            func(ctx context.Context, id uuid.UUID) error
+}
+
+func (m *MockElectionRepository) CreateElection(ctx context.Context, election *model.Election) (*model.Election, error) {
+	return m.CreateElectionFn(ctx, election)
+}
+
+func (m *MockElectionRepository) GetElectionByID(ctx context.Context, id uuid.UUID) (*model.Election, error) {
+	return m.GetElectionByIDFn(ctx, id)
+}
+
+func (m *MockElectionRepository) ListElections(ctx context.Context, filter repository.ElectionFilter) ([]model.Election, error) {
+	return m.ListElectionsFn(ctx, filter)
+}
+
+func (m *MockElectionRepository) UpdateElection(ctx context.Context, election *model.Election) (*model.Election, error) {
+	return m.UpdateElectionFn(ctx, election)
+}
+
+func (m *MockElectionRepository) ChangeElectionStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	return m.ChangeElectionStatusFn(ctx, id, from, to)
+}
+
+func (m *MockElectionRepository) PublishElectionResults(ctx context.Context, id uuid.UUID, eligibleProperties int) error {
+	return m.PublishElectionResultsFn(ctx, id, eligibleProperties)
+}
+
+func (m *MockElectionRepository) CreatePosition(ctx context.Context, position *model.ElectionPosition) (*model.ElectionPosition, error) {
+	return m.CreatePositionFn(ctx, position)
+}
+
+func (m *MockElectionRepository) ListPositions(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error) {
+	return m.ListPositionsFn(ctx, electionID)
+}
+
+func (m *MockElectionRepository) DeletePosition(ctx context.Context, electionID, id uuid.UUID) error {
+	return m.DeletePositionFn(ctx, electionID, id)
+}
+
+func (m *MockElectionRepository) CreateCandidate(ctx context.Context, candidate *model.ElectionCandidate) (*model.ElectionCandidate, error) {
+	return m.CreateCandidateFn(ctx, candidate)
+}
+
+func (m *MockElectionRepository) ListCandidates(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error) {
+	return m.ListCandidatesFn(ctx, electionID)
+}
+
+func (m *MockElectionRepository) DeleteCandidate(ctx context.Context, electionID, id uuid.UUID) error {
+	return m.DeleteCandidateFn(ctx, electionID, id)
+}
+
+func (m *MockElectionRepository) CastBallot(ctx context.Context, ballot *model.ElectionBallot, votes []model.ElectionVote) error {
+	return m.CastBallotFn(ctx, ballot, votes)
+}
+
+func (m *MockElectionRepository) GetBallotByProperty(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionBallot, error) {
+	return m.GetBallotByPropertyFn(ctx, electionID, propertyID)
+}
+
+func (m *MockElectionRepository) GetQuorum(ctx context.Context, electionID uuid.UUID, asOf time.Time) (*model.ElectionQuorum, error) {
+	return m.GetQuorumFn(ctx, electionID, asOf)
+}
+
+func (m *MockElectionRepository) TallyVotes(ctx context.Context, electionID uuid.UUID) ([]model.ElectionTally, error) {
+	return m.TallyVotesFn(ctx, electionID)
+}
+
+func (m *MockElectionRepository) CreateProxy(ctx context.Context, proxy *model.ElectionProxy) error {
+	return m.CreateProxyFn(ctx, proxy)
+}
+
+func (m *MockElectionRepository) GetProxyByID(ctx context.Context, electionID, id uuid.UUID) (*model.ElectionProxy, error) {
+	return m.GetProxyByIDFn(ctx, electionID, id)
+}
+
+func (m *MockElectionRepository) GetApprovedProxy(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionProxy, error) {
+	return m.GetApprovedProxyFn(ctx, electionID, propertyID)
+}
+
+func (m *MockElectionRepository) ListProxies(ctx context.Context, electionID uuid.UUID, status string) ([]model.ElectionProxy, error) {
+	return m.ListProxiesFn(ctx, electionID, status)
+}
+
+func (m *MockElectionRepository) ListUserProxies(ctx context.Context, userID uuid.UUID) ([]model.ElectionProxy, error) {
+	return m.ListUserProxiesFn(ctx, userID)
+}
+
+func (m *MockElectionRepository) ReviewProxy(ctx context.Context, proxy *model.ElectionProxy) error {
+	return m.ReviewProxyFn(ctx, proxy)
+}
+
+func (m *MockElectionRepository) RevokeProxy(ctx context.Context, id uuid.UUID) error {
+	return m.RevokeProxyFn(ctx, id)
+}
+
+func TestElectionService_CastBallot(t *testing.T) {
+	userID := uuid.New()
+	propertyID := uuid.New()
+	presidentID := uuid.New()
+	directorsID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	carol := uuid.New()
+	dave := uuid.New()
+
+	positions := []model.ElectionPosition{
+		{ID: presidentID, Title: "President", Seats: 1},
+		{ID: directorsID, Title: "Director", Seats: 2},
+	}
+	candidates := []model.ElectionCandidate{
+		{ID: alice, PositionID: presidentID, Name: "Alice"},
+		{ID: bob, PositionID: presidentID, Name: "Bob"},
+		{ID: carol, PositionID: directorsID, Name: "Carol"},
+		{ID: dave, PositionID: directorsID, Name: "Dave"},
+	}
+	validSelections := []BallotSelection{
+		{PositionID: presidentID.String(), CandidateIDs: []string{alice.String()}},
+		{PositionID: directorsID.String(), CandidateIDs: []string{carol.String(), dave.String()}},
+	}
+
+	tests := []struct {
+		name          string
+		startsIn      time.Duration
+		endsIn        time.Duration
+		occupancyType string
+		proxyUserID   *uuid.UUID
+		overdue       bool
+		selections    []BallotSelection
+		castErr       error
+		expectedVotes int
+		expectedProxy bool
+		expectedErr   error
+	}{
+		{
+			name:          "owner votes",
+			occupancyType: constants.OccupancyOwner,
+			selections:    validSelections,
+			expectedVotes: 3,
+		},
+		{
+			name:          "abstains from a position",
+			occupancyType: constants.OccupancyRepresentative,
+			selections:    validSelections[:1],
+			expectedVotes: 1,
+		},
+		{
+			name:          "tenant cannot vote",
+			occupancyType: constants.OccupancyTenant,
+			selections:    validSelections,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:        "not an occupant",
+			selections:  validSelections,
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:          "votes as approved proxy",
+			proxyUserID:   &userID,
+			selections:    validSelections,
+			expectedVotes: 3,
+			expectedProxy: true,
+		},
+		{
+			name:          "owner after assigning a proxy",
+			occupancyType: constants.OccupancyOwner,
+			proxyUserID:   &alice,
+			selections:    validSelections,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:          "overdue dues",
+			occupancyType: constants.OccupancyOwner,
+			overdue:       true,
+			selections:    validSelections,
+			expectedErr:   constants.ErrOverdueDues,
+		},
+		{
+			name:          "before voting opens",
+			startsIn:      time.Hour,
+			endsIn:        2 * time.Hour,
+			occupancyType: constants.OccupancyOwner,
+			selections:    validSelections,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:          "after voting closes",
+			startsIn:      -2 * time.Hour,
+			endsIn:        -time.Hour,
+			occupancyType: constants.OccupancyOwner,
+			selections:    validSelections,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:          "too many candidates for the seats",
+			occupancyType: constants.OccupancyOwner,
+			selections: []BallotSelection{
+				{PositionID: presidentID.String(), CandidateIDs: []string{alice.String(), bob.String()}},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:          "candidate running for another position",
+			occupancyType: constants.OccupancyOwner,
+			selections: []BallotSelection{
+				{PositionID: presidentID.String(), CandidateIDs: []string{carol.String()}},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:          "same candidate twice",
+			occupancyType: constants.OccupancyOwner,
+			selections: []BallotSelection{
+				{PositionID: directorsID.String(), CandidateIDs: []string{carol.String(), carol.String()}},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:          "property already voted",
+			occupancyType: constants.OccupancyOwner,
+			selections:    validSelections,
+			castErr:       constants.ErrRecordExists,
+			expectedErr:   constants.ErrRecordExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startsIn, endsIn := tt.startsIn, tt.endsIn
+			if startsIn == 0 && endsIn == 0 {
+				startsIn, endsIn = -time.Hour, time.Hour
+			}
+			election := &model.Election{
+				ID:             uuid.New(),
+				Title:          "2026 board election",
+				VotingStartsAt: time.Now().Add(startsIn),
+				VotingEndsAt:   time.Now().Add(endsIn),
+				QuorumPercent:  50,
+				Status:         constants.ElectionScheduled,
+			}
+
+			var castVotes []model.ElectionVote
+			var castBallot *model.ElectionBallot
+			electionRepo := &MockElectionRepository{
+				GetElectionByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Election, error) {
+					return election, nil
+				},
+				GetApprovedProxyFn: func(ctx context.Context, electionID, propertyID uuid.UUID) (*model.ElectionProxy, error) {
+					if tt.proxyUserID == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return &model.ElectionProxy{ID: uuid.New(), PropertyID: propertyID, ProxyUserID: *tt.proxyUserID}, nil
+				},
+				ListPositionsFn: func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error) {
+					return positions, nil
+				},
+				ListCandidatesFn: func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error) {
+					return candidates, nil
+				},
+				CastBallotFn: func(ctx context.Context, ballot *model.ElectionBallot, votes []model.ElectionVote) error {
+					castBallot = ballot
+					castVotes = votes
+					return tt.castErr
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					if tt.occupancyType == "" {
+						return []model.UserProperty{}, nil
+					}
+					return []model.UserProperty{{Property: model.Property{ID: propertyID}, OccupancyType: tt.occupancyType}}, nil
+				},
+			}
+			invoiceRepo := &MockInvoiceRepository{
+				HasOverdueInvoicesFn: func(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error) {
+					return tt.overdue, nil
+				},
+			}
+
+			service := NewElectionService(electionRepo, occupancyRepo, invoiceRepo, &MockPropertyRepository{}, &MockUserRepository{}, &MockRoleRepository{}, &MockStorage{})
+			resp, err := service.CastBallot(context.Background(), userID, election.ID, &BallotRequest{
+				PropertyID: propertyID.String(),
+				Selections: tt.selections,
+			})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if len(castVotes) != tt.expectedVotes {
+				t.Errorf("expected %d votes, got %d", tt.expectedVotes, len(castVotes))
+			}
+			if resp.ViaProxy != tt.expectedProxy || (castBallot.ProxyID != nil) != tt.expectedProxy {
+				t.Errorf("expected via proxy %v, got %v", tt.expectedProxy, resp.ViaProxy)
+			}
+			if castBallot.PropertyID != propertyID || castBallot.CastBy != userID {
+				t.Errorf("ballot recorded for the wrong property or voter")
+			}
+		})
+	}
+}
+
+func TestPositionResult(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	candidates := []model.ElectionCandidate{
+		{ID: ids[0], Name: "Alice"},
+		{ID: ids[1], Name: "Bob"},
+		{ID: ids[2], Name: "Carol"},
+		{ID: ids[3], Name: "Dave"},
+	}
+
+	tests := []struct {
+		name            string
+		seats           int
+		votes           map[uuid.UUID]int
+		expectedElected []string
+		expectedTied    bool
+	}{
+		{
+			name:            "single seat",
+			seats:           1,
+			votes:           map[uuid.UUID]int{ids[0]: 3, ids[1]: 7, ids[2]: 1},
+			expectedElected: []string{"Bob"},
+		},
+		{
+			name:            "two seats",
+			seats:           2,
+			votes:           map[uuid.UUID]int{ids[0]: 3, ids[1]: 7, ids[2]: 1, ids[3]: 5},
+			expectedElected: []string{"Bob", "Dave"},
+		},
+		{
+			name:            "tie for the last seat",
+			seats:           2,
+			votes:           map[uuid.UUID]int{ids[0]: 5, ids[1]: 7, ids[3]: 5},
+			expectedElected: []string{"Bob"},
+			expectedTied:    true,
+		},
+		{
+			name:         "tie for the only seat",
+			seats:        1,
+			votes:        map[uuid.UUID]int{ids[0]: 4, ids[1]: 4},
+			expectedTied: true,
+		},
+		{
+			name:            "more seats than candidates",
+			seats:           5,
+			votes:           map[uuid.UUID]int{ids[0]: 2, ids[1]: 1, ids[2]: 1},
+			expectedElected: []string{"Alice", "Bob", "Carol"},
+		},
+		{
+			name:  "no votes",
+			seats: 1,
+			votes: map[uuid.UUID]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := positionResult(model.ElectionPosition{ID: uuid.New(), Title: "Director", Seats: tt.seats}, candidates, tt.votes)
+
+			elected := []string{}
+			for _, candidate := range resp.Candidates {
+				if candidate.Elected {
+					elected = append(elected, candidate.Name)
+				}
+			}
+			if len(elected) != len(tt.expectedElected) {
+				t.Fatalf("expected elected %v, got %v", tt.expectedElected, elected)
+			}
+			for i := range elected {
+				if elected[i] != tt.expectedElected[i] {
+					t.Errorf("expected elected %v, got %v", tt.expectedElected, elected)
+				}
+			}
+			if resp.Tied != tt.expectedTied {
+				t.Errorf("expected tied %v, got %v", tt.expectedTied, resp.Tied)
+			}
+		})
+	}
+}
+
+func TestElectionService_GetResults(t *testing.T) {
+	publishedAt := time.Now().Add(-time.Minute)
+	eligible := 40
+
+	tests := []struct {
+		name        string
+		endsIn      time.Duration
+		status      string
+		manager     bool
+		expectedErr error
+	}{
+		{
+			name:        "voting still open",
+			endsIn:      time.Hour,
+			status:      constants.ElectionScheduled,
+			manager:     true,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "closed but not published",
+			endsIn:      -time.Hour,
+			status:      constants.ElectionScheduled,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:    "managers review before publishing",
+			endsIn:  -time.Hour,
+			status:  constants.ElectionScheduled,
+			manager: true,
+		},
+		{
+			name:   "published",
+			endsIn: -time.Hour,
+			status: constants.ElectionPublished,
+		},
+		{
+			name:        "cancelled",
+			endsIn:      -time.Hour,
+			status:      constants.ElectionCancelled,
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			election := &model.Election{
+				ID:             uuid.New(),
+				Title:          "2026 board election",
+				VotingStartsAt: time.Now().Add(tt.endsIn - 24*time.Hour),
+				VotingEndsAt:   time.Now().Add(tt.endsIn),
+				QuorumPercent:  50,
+				Status:         tt.status,
+			}
+			if tt.status == constants.ElectionPublished {
+				election.EligibleProperties = &eligible
+				election.ResultsPublishedAt = &publishedAt
+			}
+
+			electionRepo := &MockElectionRepository{
+				GetElectionByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Election, error) {
+					return election, nil
+				},
+				GetQuorumFn: func(ctx context.Context, electionID uuid.UUID, asOf time.Time) (*model.ElectionQuorum, error) {
+					return &model.ElectionQuorum{EligibleProperties: 50, BallotsCast: 20}, nil
+				},
+				ListPositionsFn: func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionPosition, error) {
+					return []model.ElectionPosition{}, nil
+				},
+				ListCandidatesFn: func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionCandidate, error) {
+					return []model.ElectionCandidate{}, nil
+				},
+				TallyVotesFn: func(ctx context.Context, electionID uuid.UUID) ([]model.ElectionTally, error) {
+					return []model.ElectionTally{}, nil
+				},
+			}
+			roleRepo := &MockRoleRepository{
+				UserHasPermissionFn: func(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+					return tt.manager && permission == constants.PermManageElections, nil
+				},
+			}
+
+			service := NewElectionService(electionRepo, &MockOccupancyRepository{}, &MockInvoiceRepository{}, &MockPropertyRepository{}, &MockUserRepository{}, roleRepo, &MockStorage{})
+			resp, err := service.GetResults(context.Background(), uuid.New(), election.ID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			expectedEligible := 50
+			if election.EligibleProperties != nil {
+				expectedEligible = *election.EligibleProperties
+			}
+			if resp.Quorum.EligibleProperties != expectedEligible {
+				t.Errorf("expected %d eligible properties, got %d", expectedEligible, resp.Quorum.EligibleProperties)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+)
+
+// invalidInput wraps constants.ErrInvalidInput so handlers can answer 400.
+func invalidInput(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", constants.ErrInvalidInput, fmt.Sprintf(format, args...))
+}
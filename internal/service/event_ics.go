@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// renderCalendar writes an RFC 5545 calendar with the community events and
+// the member's amenity reservations. Times are written in UTC so clients
+// show them in their own zone.
+func renderCalendar(name string, events []model.Event, reservations []model.AmenityReservation, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(key, value string) {
+		writeICSLine(&buf, key+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//HOA Hub//Community Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeICSText(name))
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, event := range events {
+		status := "CONFIRMED"
+		if event.CancelledAt != nil {
+			status = "CANCELLED"
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", "event-"+event.ID.String()+"@hoa-hub")
+		line("DTSTAMP", now.UTC().Format(icsTimeFormat))
+		line("LAST-MODIFIED", event.UpdatedAt.UTC().Format(icsTimeFormat))
+		line("DTSTART", event.StartsAt.UTC().Format(icsTimeFormat))
+		line("DTEND", event.EndsAt.UTC().Format(icsTimeFormat))
+		line("SUMMARY", escapeICSText(event.Title))
+		line("LOCATION", escapeICSText(event.Location))
+		if event.Description != nil {
+			line("DESCRIPTION", escapeICSText(*event.Description))
+		}
+		line("CATEGORIES", escapeICSText(strings.ReplaceAll(event.Category, "_", " ")))
+		line("STATUS", status)
+		line("END", "VEVENT")
+	}
+
+	for _, reservation := range reservations {
+		status := "CONFIRMED"
+		if reservation.Status == constants.ReservationPending {
+			status = "TENTATIVE"
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", "reservation-"+reservation.ID.String()+"@hoa-hub")
+		line("DTSTAMP", now.UTC().Format(icsTimeFormat))
+		line("LAST-MODIFIED", reservation.UpdatedAt.UTC().Format(icsTimeFormat))
+		line("DTSTART", reservation.StartAt.UTC().Format(icsTimeFormat))
+		line("DTEND", reservation.EndAt.UTC().Format(icsTimeFormat))
+		line("SUMMARY", escapeICSText(reservation.AmenityName+" reservation"))
+		line("LOCATION", escapeICSText(reservation.AmenityName))
+		line("DESCRIPTION", escapeICSText(fmt.Sprintf("%d guest(s), %s", reservation.GuestCount, reservation.Status)))
+		line("STATUS", status)
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+func escapeICSText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// writeICSLine folds content lines longer than 75 octets, never splitting
+// a UTF-8 sequence, and ends each with CRLF.
+func writeICSLine(buf *bytes.Buffer, content string) {
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		// continuation lines start with a space, which counts
+		limit = 74
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type EventServiceImpl struct {
+	eventRepo        repository.EventRepository
+	amenityRepo      repository.AmenityRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	publicURL        string
+}
+
+func NewEventService(eventRepo repository.EventRepository, amenityRepo repository.AmenityRepository, userRepo repository.UserRepository, notificationRepo repository.NotificationRepository, publicURL string) EventService {
+	return &EventServiceImpl{
+		eventRepo:        eventRepo,
+		amenityRepo:      amenityRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		publicURL:        strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *EventServiceImpl) CreateEvent(ctx context.Context, createdBy uuid.UUID, req *EventRequest) (*EventResponse, error) {
+	event := &model.Event{CreatedBy: &createdBy}
+	if err := applyEventRequest(event, req); err != nil {
+		return nil, err
+	}
+	if !event.StartsAt.After(time.Now()) {
+		return nil, invalidInput("startsAt must be in the future")
+	}
+
+	created, err := s.eventRepo.CreateEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEventResponse(created, time.Now()), nil
+}
+
+func (s *EventServiceImpl) UpdateEvent(ctx context.Context, id uuid.UUID, req *EventRequest) (*EventResponse, error) {
+	event, err := s.eventRepo.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event.CancelledAt != nil {
+		return nil, invalidInput("cancelled events cannot be changed")
+	}
+	if !event.EndsAt.After(time.Now()) {
+		return nil, invalidInput("past events cannot be changed")
+	}
+	if err := applyEventRequest(event, req); err != nil {
+		return nil, err
+	}
+	if event.Capacity != nil && *event.Capacity < event.Headcount {
+		return nil, invalidInput("capacity cannot be below the %d people already going", event.Headcount)
+	}
+
+	updated, err := s.eventRepo.UpdateEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEventResponse(updated, time.Now()), nil
+}
+
+// CancelEvent cancels the event and lets everyone who said they were going
+// know. Notification failures are logged and do not undo the cancellation.
+func (s *EventServiceImpl) CancelEvent(ctx context.Context, id uuid.UUID, req *CancelEventRequest) (*EventResponse, error) {
+	reason := trimmedOrNil(req.Reason)
+	if err := s.eventRepo.CancelEvent(ctx, id, reason); err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attendees, err := s.eventRepo.ListAttendees(ctx, event.ID)
+	if err != nil {
+		log.Printf("failed to list attendees of cancelled event %s: %v\n", event.ID, err)
+		attendees = nil
+	}
+	body := fmt.Sprintf("%s on %s has been cancelled.", event.Title, event.StartsAt.In(tenant.Location(ctx)).Format("Mon, 2 Jan 2006 3:04 PM"))
+	if reason != nil {
+		body += "\n\n" + *reason
+	}
+	referenceType := constants.ReferenceEvent
+	for _, attendee := range attendees {
+		if attendee.Status != constants.RSVPGoing {
+			continue
+		}
+		notification := &model.Notification{
+			UserID:        attendee.UserID,
+			Kind:          constants.NotificationEventCancelled,
+			Title:         fmt.Sprintf("%s is cancelled", event.Title),
+			Body:          body,
+			ReferenceType: &referenceType,
+			ReferenceID:   &event.ID,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			log.Printf("failed to notify attendee %s of cancelled event %s: %v\n", attendee.UserID, event.ID, err)
+		}
+	}
+
+	return toEventResponse(event, time.Now()), nil
+}
+
+func (s *EventServiceImpl) ListAttendees(ctx context.Context, id uuid.UUID) (*EventAttendeesResponse, error) {
+	event, err := s.eventRepo.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	attendees, err := s.eventRepo.ListAttendees(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &EventAttendeesResponse{
+		EventID:   event.ID.String(),
+		Headcount: event.Headcount,
+		Capacity:  event.Capacity,
+		Attendees: make([]EventAttendeeResponse, 0, len(attendees)),
+	}
+	for _, attendee := range attendees {
+		resp.Attendees = append(resp.Attendees, EventAttendeeResponse{
+			UserID:    attendee.UserID.String(),
+			Name:      strings.TrimSpace(attendee.FirstName + " " + attendee.LastName),
+			Email:     attendee.Email,
+			Status:    attendee.Status,
+			Headcount: attendee.Headcount,
+			UpdatedAt: attendee.UpdatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// ListEvents returns upcoming events unless a date range is given.
+func (s *EventServiceImpl) ListEvents(ctx context.Context, req *ListEventsRequest) ([]EventResponse, error) {
+	filter := repository.EventFilter{
+		Category:         req.Category,
+		IncludeCancelled: req.IncludeCancelled,
+		Limit:            pageSize(req.Limit),
+		Offset:           req.Offset,
+	}
+	now := time.Now()
+	if req.From != "" {
+		from, err := parseDate(req.From)
+		if err != nil {
+			return nil, err
+		}
+		from = localMidnight(ctx, from)
+		filter.From = &from
+	} else {
+		filter.From = &now
+	}
+	if req.To != "" {
+		to, err := parseDate(req.To)
+		if err != nil {
+			return nil, err
+		}
+		to = localMidnight(ctx, to).AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	events, err := s.eventRepo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]EventResponse, 0, len(events))
+	for i := range events {
+		resp = append(resp, *toEventResponse(&events[i], now))
+	}
+
+	return resp, nil
+}
+
+func (s *EventServiceImpl) GetEvent(ctx context.Context, userID, id uuid.UUID) (*EventDetailResponse, error) {
+	event, err := s.eventRepo.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &EventDetailResponse{EventResponse: *toEventResponse(event, time.Now())}
+	rsvp, err := s.eventRepo.GetRSVP(ctx, event.ID, userID)
+	switch {
+	case err == nil:
+		resp.MyRSVP = toRSVPResponse(rsvp)
+	case !errors.Is(err, constants.ErrRecordNotFound):
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RSVP records whether the user is going and how many people they bring,
+// themselves included. It can be changed until the RSVP deadline.
+func (s *EventServiceImpl) RSVP(ctx context.Context, userID, id uuid.UUID, req *RSVPRequest) (*RSVPResponse, error) {
+	event, err := s.eventRepo.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event.CancelledAt != nil {
+		return nil, invalidInput("event has been cancelled")
+	}
+	if !rsvpOpen(event, time.Now()) {
+		return nil, invalidInput("RSVPs for this event are closed")
+	}
+
+	rsvp := &model.EventRSVP{
+		EventID: event.ID,
+		UserID:  userID,
+		Status:  req.Status,
+	}
+	switch req.Status {
+	case constants.RSVPGoing:
+		rsvp.Headcount = req.Headcount
+		if rsvp.Headcount == 0 {
+			rsvp.Headcount = 1
+		}
+	default:
+		if req.Headcount > 0 {
+			return nil, invalidInput("headcount only applies when going")
+		}
+	}
+
+	if err := s.eventRepo.SaveRSVP(ctx, rsvp); err != nil {
+		return nil, err
+	}
+
+	return toRSVPResponse(rsvp), nil
+}
+
+// CreateCalendarFeed issues a new calendar feed URL for the user and
+// revokes the previous one. Only a hash of the token is stored, so the URL
+// is shown once. The URL names the association since calendar apps send no
+// other context.
+func (s *EventServiceImpl) CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (*CalendarFeedResponse, error) {
+	association, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	calendarToken := &model.CalendarToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+	}
+	if err := s.eventRepo.ReplaceCalendarToken(ctx, calendarToken); err != nil {
+		return nil, err
+	}
+
+	return &CalendarFeedResponse{
+		URL:       s.publicURL + "/v1/calendar/" + association.Slug + "/" + token + ".ics",
+		CreatedAt: calendarToken.CreatedAt,
+	}, nil
+}
+
+func (s *EventServiceImpl) RevokeCalendarFeed(ctx context.Context, userID uuid.UUID) error {
+	return s.eventRepo.RevokeCalendarToken(ctx, userID)
+}
+
+// GetCalendarFeed renders the feed behind a token: community events and
+// the token owner's active amenity reservations. Unknown and revoked tokens,
+// and tokens of inactive accounts, are reported as not found.
+func (s *EventServiceImpl) GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	calendarToken, err := s.eventRepo.GetActiveCalendarToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByID(ctx, calendarToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != constants.ActiveStatus {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	now := time.Now()
+	from := now.Add(-constants.CalendarFeedLookback)
+	events, err := s.eventRepo.ListEvents(ctx, repository.EventFilter{
+		From:             &from,
+		IncludeCancelled: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.amenityRepo.ListReservations(ctx, repository.ReservationFilter{
+		UserID:     &user.ID,
+		ActiveOnly: true,
+		From:       &from,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderCalendar(tenant.Name(ctx), events, reservations, now), nil
+}
+
+// rsvpOpen reports whether RSVPs are still accepted. Without an explicit
+// deadline they close when the event starts.
+func rsvpOpen(event *model.Event, now time.Time) bool {
+	deadline := event.StartsAt
+	if event.RSVPDeadline != nil {
+		deadline = *event.RSVPDeadline
+	}
+	return event.CancelledAt == nil && now.Before(deadline)
+}
+
+func applyEventRequest(event *model.Event, req *EventRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return invalidInput("title is required")
+	}
+	location := strings.TrimSpace(req.Location)
+	if location == "" {
+		return invalidInput("location is required")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return invalidInput("endsAt must be after startsAt")
+	}
+	if req.RSVPDeadline != nil && req.RSVPDeadline.After(req.StartsAt) {
+		return invalidInput("rsvpDeadline cannot be after startsAt")
+	}
+
+	event.Title = title
+	event.Description = trimmedOrNil(req.Description)
+	event.Category = req.Category
+	if event.Category == "" {
+		event.Category = constants.EventOther
+	}
+	event.Location = location
+	event.StartsAt = req.StartsAt
+	event.EndsAt = req.EndsAt
+	event.Capacity = req.Capacity
+	event.RSVPDeadline = req.RSVPDeadline
+
+	return nil
+}
+
+func toEventResponse(event *model.Event, now time.Time) *EventResponse {
+	resp := &EventResponse{
+		ID:           event.ID.String(),
+		Title:        event.Title,
+		Description:  event.Description,
+		Category:     event.Category,
+		Location:     event.Location,
+		StartsAt:     event.StartsAt,
+		EndsAt:       event.EndsAt,
+		Capacity:     event.Capacity,
+		RSVPDeadline: event.RSVPDeadline,
+		RSVPOpen:     rsvpOpen(event, now),
+		Headcount:    event.Headcount,
+		CancelReason: event.CancelReason,
+		CancelledAt:  event.CancelledAt,
+		CreatedAt:    event.CreatedAt,
+		UpdatedAt:    event.UpdatedAt,
+	}
+	if event.Capacity != nil {
+		left := max(*event.Capacity-event.Headcount, 0)
+		resp.SpotsLeft = &left
+	}
+	if event.CreatedBy != nil {
+		createdBy := event.CreatedBy.String()
+		resp.CreatedBy = &createdBy
+	}
+	return resp
+}
+
+func toRSVPResponse(rsvp *model.EventRSVP) *RSVPResponse {
+	return &RSVPResponse{
+		ID:        rsvp.ID.String(),
+		EventID:   rsvp.EventID.String(),
+		UserID:    rsvp.UserID.String(),
+		Status:    rsvp.Status,
+		Headcount: rsvp.Headcount,
+		CreatedAt: rsvp.CreatedAt,
+		UpdatedAt: rsvp.UpdatedAt,
+	}
+}
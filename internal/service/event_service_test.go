@@ -0,0 +1,338 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockEventRepository struct {
+	CreateEventFn            func(ctx context.Context, event *model.Event) (*model.Event, error)
+	GetEventByIDFn           func(ctx context.Context, id uuid.UUID) (*model.Event, error)
+	ListEventsFn             func(ctx context.Context, filter repository.EventFilter) ([]model.Event, error)
+	UpdateEventFn            func(ctx context.Context, event *model.Event) (*model.Event, error)
+	CancelEventFn            func(ctx context.Context, id uuid.UUID, reason *string) error
+	SaveRSVPFn               func(ctx context.Context, rsvp *model.EventRSVP) error
+	GetRSVPFn                func(ctx context.Context, eventID, userID uuid.UUID) (*model.EventRSVP, error)
+	ListAttendeesFn          func(ctx context.Context, eventID uuid.UUID) ([]model.EventAttendee, error)
+	ReplaceCalendarTokenFn   func(ctx context.Context, token *model.CalendarToken) error
+	RevokeCalendarTokenFn    func(ctx context.Context, userID uuid.UUID) error
+	GetActiveCalendarTokenFn func(ctx context.Context, tokenHash string) (*model.CalendarToken, error)
+}
+
+func (m *MockEventRepository) CreateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
+	return m.CreateEventFn(ctx, event)
+}
+
+func (m *MockEventRepository) GetEventByID(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+	return m.GetEventByIDFn(ctx, id)
+}
+
+func (m *MockEventRepository) ListEvents(ctx context.Context, filter repository.EventFilter) ([]model.Event, error) {
+	return m.ListEventsFn(ctx, filter)
+}
+
+func (m *MockEventRepository) UpdateEvent(ctx context.Context, event *model.Event) (*model.Event, error) {
+	return m.UpdateEventFn(ctx, event)
+}
+
+func (m *MockEventRepository) CancelEvent(ctx context.Context, id uuid.UUID, reason *string) error {
+	return m.CancelEventFn(ctx, id, reason)
+}
+
+func (m *MockEventRepository) SaveRSVP(ctx context.Context, rsvp *model.EventRSVP) error {
+	return m.SaveRSVPFn(ctx, rsvp)
+}
+
+func (m *MockEventRepository) GetRSVP(ctx context.Context, eventID, userID uuid.UUID) (*model.EventRSVP, error) {
+	return m.GetRSVPFn(ctx, eventID, userID)
+}
+
+func (m *MockEventRepository) ListAttendees(ctx context.Context, eventID uuid.UUID) ([]model.EventAttendee, error) {
+	return m.ListAttendeesFn(ctx, eventID)
+}
+
+func (m *MockEventRepository) ReplaceCalendarToken(ctx context.Context, token *model.CalendarToken) error {
+	return m.ReplaceCalendarTokenFn(ctx, token)
+}
+
+func (m *MockEventRepository) RevokeCalendarToken(ctx context.Context, userID uuid.UUID) error {
+	return m.RevokeCalendarTokenFn(ctx, userID)
+}
+
+func (m *MockEventRepository) GetActiveCalendarToken(ctx context.Context, tokenHash string) (*model.CalendarToken, error) {
+	return m.GetActiveCalendarTokenFn(ctx, tokenHash)
+}
+
+func TestEventService_RSVP(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name              string
+		startsAt          time.Time
+		rsvpDeadline      *time.Time
+		cancelledAt       *time.Time
+		req               *RSVPRequest
+		saveErr           error
+		expectedHeadcount int
+		expectedErr       error
+	}{
+		{
+			name:              "going defaults to one person",
+			startsAt:          now.Add(48 * time.Hour),
+			req:               &RSVPRequest{Status: constants.RSVPGoing},
+			expectedHeadcount: 1,
+		},
+		{
+			name:              "going with guests",
+			startsAt:          now.Add(48 * time.Hour),
+			rsvpDeadline:      &future,
+			req:               &RSVPRequest{Status: constants.RSVPGoing, Headcount: 4},
+			expectedHeadcount: 4,
+		},
+		{
+			name:     "not going",
+			startsAt: now.Add(48 * time.Hour),
+			req:      &RSVPRequest{Status: constants.RSVPNotGoing},
+		},
+		{
+			name:        "not going with a headcount",
+			startsAt:    now.Add(48 * time.Hour),
+			req:         &RSVPRequest{Status: constants.RSVPNotGoing, Headcount: 2},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:         "after the deadline",
+			startsAt:     now.Add(48 * time.Hour),
+			rsvpDeadline: &past,
+			req:          &RSVPRequest{Status: constants.RSVPGoing},
+			expectedErr:  constants.ErrInvalidInput,
+		},
+		{
+			name:        "event already started",
+			startsAt:    past,
+			req:         &RSVPRequest{Status: constants.RSVPGoing},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "cancelled event",
+			startsAt:    now.Add(48 * time.Hour),
+			cancelledAt: &past,
+			req:         &RSVPRequest{Status: constants.RSVPGoing},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "event full",
+			startsAt:    now.Add(48 * time.Hour),
+			req:         &RSVPRequest{Status: constants.RSVPGoing, Headcount: 3},
+			saveErr:     constants.ErrEventFull,
+			expectedErr: constants.ErrEventFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &model.Event{
+				ID:           uuid.New(),
+				Title:        "Clubhouse potluck",
+				StartsAt:     tt.startsAt,
+				EndsAt:       tt.startsAt.Add(3 * time.Hour),
+				RSVPDeadline: tt.rsvpDeadline,
+				CancelledAt:  tt.cancelledAt,
+			}
+			var saved *model.EventRSVP
+			eventRepo := &MockEventRepository{
+				GetEventByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+					return event, nil
+				},
+				SaveRSVPFn: func(ctx context.Context, rsvp *model.EventRSVP) error {
+					saved = rsvp
+					return tt.saveErr
+				},
+			}
+
+			service := NewEventService(eventRepo, &MockAmenityRepository{}, &MockUserRepository{}, &MockNotificationRepository{}, "http://localhost:8080")
+			resp, err := service.RSVP(context.Background(), uuid.New(), event.ID, tt.req)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if saved.Headcount != tt.expectedHeadcount {
+				t.Errorf("expected headcount %d, got %d", tt.expectedHeadcount, saved.Headcount)
+			}
+			if resp.Status != tt.req.Status {
+				t.Errorf("expected status %s, got %s", tt.req.Status, resp.Status)
+			}
+		})
+	}
+}
+
+func TestEventService_CalendarFeed(t *testing.T) {
+	userID := uuid.New()
+	var stored *model.CalendarToken
+	eventRepo := &MockEventRepository{
+		ReplaceCalendarTokenFn: func(ctx context.Context, token *model.CalendarToken) error {
+			stored = token
+			return nil
+		},
+		GetActiveCalendarTokenFn: func(ctx context.Context, tokenHash string) (*model.CalendarToken, error) {
+			if stored == nil || tokenHash != stored.TokenHash {
+				return nil, constants.ErrRecordNotFound
+			}
+			return stored, nil
+		},
+		ListEventsFn: func(ctx context.Context, filter repository.EventFilter) ([]model.Event, error) {
+			if !filter.IncludeCancelled {
+				t.Errorf("expected cancelled events in the feed")
+			}
+			return nil, nil
+		},
+	}
+	amenityRepo := &MockAmenityRepository{
+		ListReservationsFn: func(ctx context.Context, filter repository.ReservationFilter) ([]model.AmenityReservation, error) {
+			if filter.UserID == nil || *filter.UserID != userID || !filter.ActiveOnly {
+				t.Errorf("expected the user's active reservations, got %+v", filter)
+			}
+			return nil, nil
+		},
+	}
+	status := constants.ActiveStatus
+	userRepo := &MockUserRepository{
+		GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, Status: status}, nil
+		},
+	}
+
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "greenfield", Name: "Greenfield HOA"})
+	service := NewEventService(eventRepo, amenityRepo, userRepo, &MockNotificationRepository{}, "https://hoa.example.com/")
+	resp, err := service.CreateCalendarFeed(ctx, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prefix := "https://hoa.example.com/v1/calendar/greenfield/"
+	if !strings.HasPrefix(resp.URL, prefix) || !strings.HasSuffix(resp.URL, ".ics") {
+		t.Fatalf("unexpected feed url %s", resp.URL)
+	}
+	token := strings.TrimSuffix(strings.TrimPrefix(resp.URL, prefix), ".ics")
+	if stored.TokenHash == token || len(stored.TokenHash) != 64 {
+		t.Errorf("expected only a hash of the token to be stored")
+	}
+
+	feed, err := service.GetCalendarFeed(ctx, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(feed), "BEGIN:VCALENDAR\r\n") {
+		t.Errorf("expected an iCalendar document, got %q", feed)
+	}
+
+	if _, err := service.GetCalendarFeed(ctx, token+"x"); !errors.Is(err, constants.ErrRecordNotFound) {
+		t.Errorf("expected unknown token to be not found, got %v", err)
+	}
+
+	status = constants.SuspendedStatus
+	if _, err := service.GetCalendarFeed(ctx, token); !errors.Is(err, constants.ErrRecordNotFound) {
+		t.Errorf("expected suspended user's feed to be not found, got %v", err)
+	}
+}
+
+func TestRenderCalendar(t *testing.T) {
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	cancelledAt := now
+	description := "Bring a dish; drinks, plates and cups are provided.\nParking at the back."
+	events := []model.Event{
+		{
+			ID:          uuid.New(),
+			Title:       "Summer potluck",
+			Description: &description,
+			Category:    constants.EventSocial,
+			Location:    "Clubhouse",
+			StartsAt:    time.Date(2025, 6, 14, 10, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2025, 6, 14, 13, 0, 0, 0, time.UTC),
+			UpdatedAt:   now,
+		},
+		{
+			ID:          uuid.New(),
+			Title:       "Pool cleanup",
+			Category:    constants.EventCleanup,
+			Location:    "Pool",
+			StartsAt:    time.Date(2025, 6, 21, 7, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2025, 6, 21, 9, 0, 0, 0, time.UTC),
+			CancelledAt: &cancelledAt,
+			UpdatedAt:   now,
+		},
+	}
+	reservations := []model.AmenityReservation{
+		{
+			ID:          uuid.New(),
+			AmenityName: "Tennis court",
+			StartAt:     time.Date(2025, 6, 15, 16, 0, 0, 0, time.UTC),
+			EndAt:       time.Date(2025, 6, 15, 17, 0, 0, 0, time.UTC),
+			GuestCount:  1,
+			Status:      constants.ReservationPending,
+			UpdatedAt:   now,
+		},
+	}
+
+	feed := string(renderCalendar("Greenfield HOA", events, reservations, now))
+
+	for _, expected := range []string{
+		"UID:event-" + events[0].ID.String() + "@hoa-hub\r\n",
+		"DTSTART:20250614T100000Z\r\n",
+		"STATUS:CANCELLED\r\n",
+		"UID:reservation-" + reservations[0].ID.String() + "@hoa-hub\r\n",
+		"STATUS:TENTATIVE\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(feed, expected) {
+			t.Errorf("expected feed to contain %q", expected)
+		}
+	}
+	if strings.Count(feed, "BEGIN:VEVENT") != 3 {
+		t.Errorf("expected 3 entries, got %d", strings.Count(feed, "BEGIN:VEVENT"))
+	}
+
+	unfolded := strings.ReplaceAll(feed, "\r\n ", "")
+	if !strings.Contains(unfolded, `DESCRIPTION:Bring a dish\; drinks\, plates and cups are provided.\nParking at the back.`) {
+		t.Errorf("expected escaped description, got %q", unfolded)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(feed, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+func TestWriteICSLine_FoldsOnRuneBoundaries(t *testing.T) {
+	content := "SUMMARY:" + strings.Repeat("Fiesta ñ ", 20)
+
+	var buf bytes.Buffer
+	writeICSLine(&buf, content)
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a multi-byte character: %q", line)
+		}
+	}
+	if strings.ReplaceAll(buf.String(), "\r\n ", "") != content+"\r\n" {
+		t.Errorf("unfolded line does not match the original")
+	}
+}
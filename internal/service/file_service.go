@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"mime"
+	"path/filepath"
+
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+)
+
+type FileServiceImpl struct {
+	store storage.Storage
+}
+
+func NewFileService(store storage.Storage) FileService {
+	return &FileServiceImpl{
+		store: store,
+	}
+}
+
+// OpenSignedFile serves a link made by the store's SignedURL. Only stores
+// that sign links back to this API can verify them; for any other store
+// the route does not exist.
+func (s *FileServiceImpl) OpenSignedFile(ctx context.Context, req *SignedFileRequest) (*StoredFile, error) {
+	verifier, ok := s.store.(storage.URLVerifier)
+	if !ok {
+		return nil, constants.ErrRecordNotFound
+	}
+	if err := verifier.VerifySignedURL(req.Key, req.Name, req.Expires, req.Signature); err != nil {
+		return nil, err
+	}
+
+	body, err := s.store.Get(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(req.Name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &StoredFile{
+		FileName:    req.Name,
+		ContentType: contentType,
+		SizeBytes:   -1,
+		Body:        body,
+	}, nil
+}
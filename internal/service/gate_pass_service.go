@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type GatePassServiceImpl struct {
+	gatePassRepo  repository.GatePassRepository
+	occupancyRepo repository.OccupancyRepository
+	propertyRepo  repository.PropertyRepository
+	jwt           auth.IJWTAuth
+}
+
+func NewGatePassService(gatePassRepo repository.GatePassRepository, occupancyRepo repository.OccupancyRepository, propertyRepo repository.PropertyRepository, jwt auth.IJWTAuth) GatePassService {
+	return &GatePassServiceImpl{
+		gatePassRepo:  gatePassRepo,
+		occupancyRepo: occupancyRepo,
+		propertyRepo:  propertyRepo,
+		jwt:           jwt,
+	}
+}
+
+func (s *GatePassServiceImpl) CreateGatePass(ctx context.Context, userID uuid.UUID, req *GatePassRequest) (*GatePassResponse, error) {
+	visitorName := strings.TrimSpace(req.VisitorName)
+	if visitorName == "" {
+		return nil, invalidInput("visitorName is required")
+	}
+
+	now := time.Now()
+	validFrom := now
+	if req.ValidFrom != nil {
+		validFrom = *req.ValidFrom
+	}
+	if !req.ValidUntil.After(validFrom) {
+		return nil, invalidInput("validUntil must be after validFrom")
+	}
+	if !req.ValidUntil.After(now) {
+		return nil, invalidInput("validUntil must be in the future")
+	}
+	if req.ValidUntil.Sub(validFrom) > constants.MaxGatePassDays*24*time.Hour {
+		return nil, invalidInput("gate passes can be valid for at most %d days", constants.MaxGatePassDays)
+	}
+
+	maxUses := req.MaxUses
+	if !req.MultiUse {
+		single := 1
+		maxUses = &single
+	}
+
+	property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.gatePassRepo.CreateGatePass(ctx, &model.GatePass{
+		PropertyID:   property.ID,
+		HostUserID:   userID,
+		VisitorName:  visitorName,
+		VisitorType:  req.VisitorType,
+		VehiclePlate: normalizePlate(req.VehiclePlate),
+		Purpose:      trimmedOrNil(req.Purpose),
+		ValidFrom:    validFrom,
+		ValidUntil:   req.ValidUntil,
+		MaxUses:      maxUses,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toHostResponse(created)
+}
+
+func (s *GatePassServiceImpl) ListUserGatePasses(ctx context.Context, userID uuid.UUID, req *ListGatePassesRequest) ([]GatePassResponse, error) {
+	passes, err := s.gatePassRepo.ListGatePasses(ctx, repository.GatePassFilter{
+		HostUserID:     &userID,
+		IncludeRevoked: req.IncludeRevoked,
+		Limit:          pageSize(req.Limit),
+		Offset:         req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toGatePassResponses(passes, time.Now()), nil
+}
+
+func (s *GatePassServiceImpl) GetUserGatePass(ctx context.Context, userID, id uuid.UUID) (*GatePassResponse, error) {
+	pass, err := s.userGatePass(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toHostResponse(pass)
+}
+
+func (s *GatePassServiceImpl) RevokeUserGatePass(ctx context.Context, userID, id uuid.UUID, req *RevokeGatePassRequest) (*GatePassResponse, error) {
+	if _, err := s.userGatePass(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	return s.RevokeGatePass(ctx, id, userID, req)
+}
+
+func (s *GatePassServiceImpl) RevokeGatePass(ctx context.Context, id, revokedBy uuid.UUID, req *RevokeGatePassRequest) (*GatePassResponse, error) {
+	if err := s.gatePassRepo.RevokeGatePass(ctx, id, trimmedOrNil(req.Reason), revokedBy); err != nil {
+		return nil, err
+	}
+
+	pass, err := s.gatePassRepo.GetGatePassByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGatePassResponse(pass, time.Now()), nil
+}
+
+// VerifyGatePass checks a scanned QR payload without consuming a use. An
+// unreadable or forged payload is reported as invalid rather than as an
+// error so the guard always gets an answer to show.
+func (s *GatePassServiceImpl) VerifyGatePass(ctx context.Context, req *VerifyGatePassRequest) (*GatePassVerification, error) {
+	pass, err := s.scannedGatePass(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) || errors.Is(err, constants.ErrRecordNotFound) {
+			return &GatePassVerification{Valid: false, Reason: "pass is not recognised or has expired"}, nil
+		}
+		return nil, err
+	}
+
+	resp := toGatePassResponse(pass, time.Now())
+	verification := &GatePassVerification{Valid: resp.Status == constants.GatePassActive, Pass: resp}
+	if !verification.Valid {
+		verification.Reason = gatePassDenialReason(resp.Status)
+	}
+
+	return verification, nil
+}
+
+func (s *GatePassServiceImpl) RecordEntry(ctx context.Context, guardID uuid.UUID, req *GateEntryRequest) (*GateEntryResponse, error) {
+	pass, err := s.scannedGatePass(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) || errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, constants.ErrGatePassDenied
+		}
+		return nil, err
+	}
+
+	if status := gatePassStatus(pass, time.Now()); status != constants.GatePassActive {
+		return nil, fmt.Errorf("%w: %s", constants.ErrGatePassDenied, gatePassDenialReason(status))
+	}
+
+	entry := &model.GateEntry{
+		EntryType:     constants.GateEntryPass,
+		GatePassID:    &pass.ID,
+		VisitorName:   pass.VisitorName,
+		VehiclePlate:  pass.VehiclePlate,
+		PropertyID:    pass.PropertyID,
+		VisitedUserID: &pass.HostUserID,
+		Purpose:       pass.Purpose,
+		EntryGuardID:  &guardID,
+		Notes:         trimmedOrNil(req.Notes),
+	}
+	if err := s.gatePassRepo.RecordEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return toGateEntryResponse(entry), nil
+}
+
+func (s *GatePassServiceImpl) RecordWalkIn(ctx context.Context, guardID uuid.UUID, req *WalkInRequest) (*GateEntryResponse, error) {
+	visitorName := strings.TrimSpace(req.VisitorName)
+	if visitorName == "" {
+		return nil, invalidInput("visitorName is required")
+	}
+
+	propertyID, err := uuid.Parse(req.PropertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	var visitedUserID *uuid.UUID
+	if req.VisitedUserID != "" {
+		userID, err := uuid.Parse(req.VisitedUserID)
+		if err != nil {
+			return nil, invalidInput("visited user id must be a uuid")
+		}
+
+		occupants, err := s.occupancyRepo.ListOccupantsOnDate(ctx, propertyID, today(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, occupant := range occupants {
+			if occupant.UserID == userID {
+				visitedUserID = &userID
+				break
+			}
+		}
+		if visitedUserID == nil {
+			return nil, invalidInput("the visited resident does not live at this property")
+		}
+	}
+
+	entry := &model.GateEntry{
+		EntryType:     constants.GateEntryWalkIn,
+		VisitorName:   visitorName,
+		VehiclePlate:  normalizePlate(req.VehiclePlate),
+		PropertyID:    propertyID,
+		VisitedUserID: visitedUserID,
+		Purpose:       trimmedOrNil(req.Purpose),
+		EntryGuardID:  &guardID,
+		Notes:         trimmedOrNil(req.Notes),
+	}
+	if err := s.gatePassRepo.CreateWalkInEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return toGateEntryResponse(entry), nil
+}
+
+func (s *GatePassServiceImpl) RecordExit(ctx context.Context, guardID, entryID uuid.UUID) (*GateEntryResponse, error) {
+	if err := s.gatePassRepo.RecordExit(ctx, entryID, guardID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.gatePassRepo.GetGateEntryByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGateEntryResponse(entry), nil
+}
+
+// ListExpectedVisitors returns the passes that can be used at some point
+// during the given local day, defaulting to today.
+func (s *GatePassServiceImpl) ListExpectedVisitors(ctx context.Context, req *ExpectedVisitorsRequest) ([]GatePassResponse, error) {
+	from, to, err := s.localDay(ctx, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	passes, err := s.gatePassRepo.ListGatePasses(ctx, repository.GatePassFilter{From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	return toGatePassResponses(passes, time.Now()), nil
+}
+
+// ListGateEntries shows one day of the logbook, defaulting to today. Asking
+// only for visitors still inside without a date searches every day, so
+// overnight stays are not missed.
+func (s *GatePassServiceImpl) ListGateEntries(ctx context.Context, req *ListGateEntriesRequest) ([]GateEntryResponse, error) {
+	filter := repository.GateEntryFilter{
+		InsideOnly: req.Inside,
+		Limit:      pageSize(req.Limit),
+		Offset:     req.Offset,
+	}
+	if req.Date != "" || !req.Inside {
+		from, to, err := s.localDay(ctx, req.Date)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = &from
+		filter.To = &to
+	}
+
+	return s.listGateEntries(ctx, filter)
+}
+
+func (s *GatePassServiceImpl) SearchLogbook(ctx context.Context, req *SearchLogbookRequest) ([]GateEntryResponse, error) {
+	from, _, err := s.localDay(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	_, to, err := s.localDay(ctx, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, invalidInput("from must not be after to")
+	}
+
+	filter := repository.GateEntryFilter{
+		EntryType: req.EntryType,
+		From:      &from,
+		To:        &to,
+		Limit:     pageSize(req.Limit),
+		Offset:    req.Offset,
+	}
+	if plate := normalizePlate(&req.VehiclePlate); plate != nil {
+		filter.VehiclePlate = *plate
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return nil, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+
+	return s.listGateEntries(ctx, filter)
+}
+
+func (s *GatePassServiceImpl) listGateEntries(ctx context.Context, filter repository.GateEntryFilter) ([]GateEntryResponse, error) {
+	entries, err := s.gatePassRepo.ListGateEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]GateEntryResponse, 0, len(entries))
+	for i := range entries {
+		resp = append(resp, *toGateEntryResponse(&entries[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *GatePassServiceImpl) userGatePass(ctx context.Context, userID, id uuid.UUID) (*model.GatePass, error) {
+	pass, err := s.gatePassRepo.GetGatePassByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pass.HostUserID != userID {
+		return nil, constants.ErrRecordNotFound
+	}
+	return pass, nil
+}
+
+func (s *GatePassServiceImpl) scannedGatePass(ctx context.Context, token string) (*model.GatePass, error) {
+	claims, err := s.jwt.ParseGatePass(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+
+	return s.gatePassRepo.GetGatePassByID(ctx, id)
+}
+
+func (s *GatePassServiceImpl) toHostResponse(pass *model.GatePass) (*GatePassResponse, error) {
+	token, err := s.jwt.GenerateGatePass(pass.ID, pass.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toGatePassResponse(pass, time.Now())
+	resp.QRPayload = token
+	return resp, nil
+}
+
+// localDay returns the bounds of a calendar day in the association's time
+// zone, defaulting to the current local day.
+func (s *GatePassServiceImpl) localDay(ctx context.Context, value string) (time.Time, time.Time, error) {
+	location := tenant.Location(ctx)
+	var date time.Time
+	if value == "" {
+		date = time.Now().In(location)
+	} else {
+		parsed, err := parseDate(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		date = parsed
+	}
+
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	return from, from.AddDate(0, 0, 1), nil
+}
+
+// normalizePlate upper-cases a plate number and drops spaces and dashes so
+// "abc 1234" and "ABC-1234" are logged and searched the same way.
+func normalizePlate(plate *string) *string {
+	if plate == nil {
+		return nil
+	}
+	normalized := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(*plate))
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+func gatePassStatus(pass *model.GatePass, now time.Time) string {
+	switch {
+	case pass.RevokedAt != nil:
+		return constants.GatePassRevoked
+	case pass.MaxUses != nil && pass.UseCount >= *pass.MaxUses:
+		return constants.GatePassUsed
+	case !now.Before(pass.ValidUntil):
+		return constants.GatePassExpired
+	case now.Before(pass.ValidFrom):
+		return constants.GatePassScheduled
+	default:
+		return constants.GatePassActive
+	}
+}
+
+func gatePassDenialReason(status string) string {
+	switch status {
+	case constants.GatePassRevoked:
+		return "pass was revoked by the host"
+	case constants.GatePassUsed:
+		return "pass has no entries left"
+	case constants.GatePassExpired:
+		return "pass has expired"
+	case constants.GatePassScheduled:
+		return "pass is not valid yet"
+	default:
+		return "pass is not valid"
+	}
+}
+
+func toGatePassResponses(passes []model.GatePass, now time.Time) []GatePassResponse {
+	resp := make([]GatePassResponse, 0, len(passes))
+	for i := range passes {
+		resp = append(resp, *toGatePassResponse(&passes[i], now))
+	}
+	return resp
+}
+
+func toGatePassResponse(pass *model.GatePass, now time.Time) *GatePassResponse {
+	return &GatePassResponse{
+		ID:           pass.ID.String(),
+		PropertyID:   pass.PropertyID.String(),
+		HostUserID:   pass.HostUserID.String(),
+		VisitorName:  pass.VisitorName,
+		VisitorType:  pass.VisitorType,
+		VehiclePlate: pass.VehiclePlate,
+		Purpose:      pass.Purpose,
+		ValidFrom:    pass.ValidFrom,
+		ValidUntil:   pass.ValidUntil,
+		MaxUses:      pass.MaxUses,
+		UseCount:     pass.UseCount,
+		Status:       gatePassStatus(pass, now),
+		RevokedAt:    pass.RevokedAt,
+		RevokeReason: pass.RevokeReason,
+		CreatedAt:    pass.CreatedAt,
+	}
+}
+
+func toGateEntryResponse(entry *model.GateEntry) *GateEntryResponse {
+	return &GateEntryResponse{
+		ID:            entry.ID.String(),
+		EntryType:     entry.EntryType,
+		GatePassID:    optionalUUID(entry.GatePassID),
+		VisitorName:   entry.VisitorName,
+		VehiclePlate:  entry.VehiclePlate,
+		PropertyID:    entry.PropertyID.String(),
+		VisitedUserID: optionalUUID(entry.VisitedUserID),
+		Purpose:       entry.Purpose,
+		EnteredAt:     entry.EnteredAt,
+		EntryGuardID:  optionalUUID(entry.EntryGuardID),
+		ExitedAt:      entry.ExitedAt,
+		ExitGuardID:   optionalUUID(entry.ExitGuardID),
+		Notes:         entry.Notes,
+	}
+}
+
+func optionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockGatePassRepository struct {
+	CreateGatePassFn    func(ctx context.Context, pass *model.GatePass) (*model.GatePass, error)
+	GetGatePassByIDFn   func(ctx context.Context, id uuid.UUID) (*model.GatePass, error)
+	ListGatePassesFn    func(ctx context.Context, filter repository.GatePassFilter) ([]model.GatePass, error)
+	RevokeGatePassFn    func(ctx context.Context, id uuid.UUID, reason *string, revokedBy uuid.UUID) error
+	RecordEntryFn       func(ctx context.Context, entry *model.GateEntry) error
+	CreateWalkInEntryFn func(ctx context.Context, entry *model.GateEntry) error
+	RecordExitFn        func(ctx context.Context, entryID, guardID uuid.UUID) error
+	GetGateEntryByIDFn  func(ctx context.Context, id uuid.UUID) (*model.GateEntry, error)
+	ListGateEntriesFn   func(ctx context.Context, filter repository.GateEntryFilter) ([]model.GateEntry, error)
+}
+
+func (m *MockGatePassRepository) CreateGatePass(ctx context.Context, pass *model.GatePass) (*model.GatePass, error) {
+	return m.CreateGatePassFn(ctx, pass)
+}
+
+func (m *MockGatePassRepository) GetGatePassByID(ctx context.Context, id uuid.UUID) (*model.GatePass, error) {
+	return m.GetGatePassByIDFn(ctx, id)
+}
+
+func (m *MockGatePassRepository) ListGatePasses(ctx context.Context, filter repository.GatePassFilter) ([]model.GatePass, error) {
+	return m.ListGatePassesFn(ctx, filter)
+}
+
+func (m *MockGatePassRepository) RevokeGatePass(ctx context.Context, id uuid.UUID, reason *string, revokedBy uuid.UUID) error {
+	return m.RevokeGatePassFn(ctx, id, reason, revokedBy)
+}
+
+func (m *MockGatePassRepository) RecordEntry(ctx context.Context, entry *model.GateEntry) error {
+	return m.RecordEntryFn(ctx, entry)
+}
+
+func (m *MockGatePassRepository) CreateWalkInEntry(ctx context.Context, entry *model.GateEntry) error {
+	return m.CreateWalkInEntryFn(ctx, entry)
+}
+
+func (m *MockGatePassRepository) RecordExit(ctx context.Context, entryID, guardID uuid.UUID) error {
+	return m.RecordExitFn(ctx, entryID, guardID)
+}
+
+func (m *MockGatePassRepository) GetGateEntryByID(ctx context.Context, id uuid.UUID) (*model.GateEntry, error) {
+	return m.GetGateEntryByIDFn(ctx, id)
+}
+
+func (m *MockGatePassRepository) ListGateEntries(ctx context.Context, filter repository.GateEntryFilter) ([]model.GateEntry, error) {
+	return m.ListGateEntriesFn(ctx, filter)
+}
+
+func TestGatePassStatus(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	one := 1
+	revokedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		pass     model.GatePass
+		expected string
+	}{
+		{
+			name:     "within window",
+			pass:     model.GatePass{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(time.Hour), MaxUses: &one},
+			expected: constants.GatePassActive,
+		},
+		{
+			name:     "not started",
+			pass:     model.GatePass{ValidFrom: now.Add(time.Hour), ValidUntil: now.Add(2 * time.Hour)},
+			expected: constants.GatePassScheduled,
+		},
+		{
+			name:     "expired",
+			pass:     model.GatePass{ValidFrom: now.Add(-2 * time.Hour), ValidUntil: now},
+			expected: constants.GatePassExpired,
+		},
+		{
+			name:     "single use already used",
+			pass:     model.GatePass{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(time.Hour), MaxUses: &one, UseCount: 1},
+			expected: constants.GatePassUsed,
+		},
+		{
+			name:     "unlimited multi use",
+			pass:     model.GatePass{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(time.Hour), UseCount: 12},
+			expected: constants.GatePassActive,
+		},
+		{
+			name:     "revoked",
+			pass:     model.GatePass{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(time.Hour), RevokedAt: &revokedAt},
+			expected: constants.GatePassRevoked,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if status := gatePassStatus(&tc.pass, now); status != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestGatePassService_RecordEntry(t *testing.T) {
+	passID := uuid.New()
+	one := 1
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		token       string
+		pass        *model.GatePass
+		expectedErr error
+	}{
+		{
+			name:  "valid pass",
+			token: "good",
+			pass:  &model.GatePass{ID: passID, ValidFrom: time.Now().Add(-time.Hour), ValidUntil: time.Now().Add(time.Hour), MaxUses: &one},
+		},
+		{
+			name:        "forged token",
+			token:       "forged",
+			expectedErr: constants.ErrGatePassDenied,
+		},
+		{
+			name:        "revoked pass",
+			token:       "good",
+			pass:        &model.GatePass{ID: passID, ValidFrom: time.Now().Add(-time.Hour), ValidUntil: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+			expectedErr: constants.ErrGatePassDenied,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorded := false
+			jwt := &MockJWTAuth{
+				ParseGatePassFn: func(tokenStr string) (*auth.GatePassClaims, error) {
+					if tokenStr != "good" {
+						return nil, constants.ErrInvalidToken
+					}
+					claims := &auth.GatePassClaims{}
+					claims.ID = passID.String()
+					return claims, nil
+				},
+			}
+			gatePassRepo := &MockGatePassRepository{
+				GetGatePassByIDFn: func(ctx context.Context, id uuid.UUID) (*model.GatePass, error) {
+					return tc.pass, nil
+				},
+				RecordEntryFn: func(ctx context.Context, entry *model.GateEntry) error {
+					recorded = true
+					entry.ID = uuid.New()
+					entry.EnteredAt = time.Now()
+					return nil
+				},
+			}
+
+			service := NewGatePassService(gatePassRepo, &MockOccupancyRepository{}, &MockPropertyRepository{}, jwt)
+			resp, err := service.RecordEntry(context.Background(), uuid.New(), &GateEntryRequest{Token: tc.token})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if recorded {
+					t.Error("expected no entry to be logged")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !recorded || resp.GatePassID == nil || *resp.GatePassID != passID.String() {
+				t.Errorf("expected entry for pass %s", passID)
+			}
+		})
+	}
+}
+
+func TestGatePassService_RecordWalkIn(t *testing.T) {
+	propertyID := uuid.New()
+	residentID := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           *WalkInRequest
+		expectedPlate *string
+		expectedErr   error
+	}{
+		{
+			name:          "visiting a resident",
+			req:           &WalkInRequest{VisitorName: "Juan Dela Cruz", VehiclePlate: stringPtr("abc 1234"), PropertyID: propertyID.String(), VisitedUserID: residentID.String()},
+			expectedPlate: stringPtr("ABC1234"),
+		},
+		{
+			name: "on foot",
+			req:  &WalkInRequest{VisitorName: "Courier", PropertyID: propertyID.String()},
+		},
+		{
+			name:        "resident does not live there",
+			req:         &WalkInRequest{VisitorName: "Juan Dela Cruz", PropertyID: propertyID.String(), VisitedUserID: uuid.NewString()},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown property",
+			req:         &WalkInRequest{VisitorName: "Juan Dela Cruz", PropertyID: uuid.NewString()},
+			expectedErr: constants.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var logged *model.GateEntry
+			gatePassRepo := &MockGatePassRepository{
+				CreateWalkInEntryFn: func(ctx context.Context, entry *model.GateEntry) error {
+					logged = entry
+					return nil
+				},
+			}
+			propertyRepo := &MockPropertyRepository{
+				GetPropertyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+					if id != propertyID {
+						return nil, constants.ErrRecordNotFound
+					}
+					return &model.Property{ID: id}, nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListOccupantsOnDateFn: func(ctx context.Context, id uuid.UUID, date time.Time) ([]model.PropertyOccupant, error) {
+					return []model.PropertyOccupant{{Occupancy: model.Occupancy{PropertyID: id, UserID: residentID}}}, nil
+				},
+			}
+
+			service := NewGatePassService(gatePassRepo, occupancyRepo, propertyRepo, &MockJWTAuth{})
+			_, err := service.RecordWalkIn(context.Background(), uuid.New(), tc.req)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if logged == nil || logged.EntryType != constants.GateEntryWalkIn {
+				t.Fatal("expected a walk-in entry to be logged")
+			}
+			if (logged.VehiclePlate == nil) != (tc.expectedPlate == nil) ||
+				(tc.expectedPlate != nil && *logged.VehiclePlate != *tc.expectedPlate) {
+				t.Errorf("expected plate %v, got %v", tc.expectedPlate, logged.VehiclePlate)
+			}
+		})
+	}
+}
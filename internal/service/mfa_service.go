@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+// recoveryCodeAlphabet avoids 0/O and 1/l so codes survive being written
+// down; its 32 letters map evenly onto random bytes.
+const recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+type MFAServiceImpl struct {
+	jwt      auth.IJWTAuth
+	mfaRepo  repository.MFARepository
+	userRepo repository.UserRepository
+	roleRepo repository.RoleRepository
+	// requiredRoles cannot sign in without a second factor
+	requiredRoles []string
+}
+
+func NewMFAService(jwt auth.IJWTAuth, mfaRepo repository.MFARepository, userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository, requiredRoles []string) MFAService {
+	return &MFAServiceImpl{
+		jwt:           jwt,
+		mfaRepo:       mfaRepo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		requiredRoles: requiredRoles,
+	}
+}
+
+// ChallengeLogin decides whether a password-verified user also needs a
+// second factor. It returns nil when they can be signed in right away, and
+// otherwise a short-lived challenge to redeem with a code. Users whose role
+// requires MFA but who have not enrolled yet must enroll with the challenge
+// first.
+func (s *MFAServiceImpl) ChallengeLogin(ctx context.Context, user *model.User) (*MFAChallengeResponse, error) {
+	enabled, err := s.isEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		required, err := s.isRequired(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return nil, nil
+		}
+	}
+
+	expiresAt := time.Now().Add(constants.MFAChallengeTTL)
+	token, err := s.jwt.GenerateMFAChallenge(user, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAChallengeResponse{
+		MFARequired:        true,
+		ChallengeToken:     token,
+		ExpiresAt:          expiresAt,
+		EnrollmentRequired: !enabled,
+	}, nil
+}
+
+// EnrollWithChallenge starts enrollment for a user whose role requires MFA
+// and who is signing in without it.
+func (s *MFAServiceImpl) EnrollWithChallenge(ctx context.Context, req *MFAChallengeRequest) (*MFAEnrollmentResponse, error) {
+	user, err := s.challengeUser(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.enroll(ctx, user)
+}
+
+// CompleteLogin redeems a challenge with a TOTP or recovery code. When the
+// challenge was used to enroll, the code confirms the enrollment and the
+// new recovery codes are returned alongside the user.
+func (s *MFAServiceImpl) CompleteLogin(ctx context.Context, req *MFALoginRequest) (*model.User, []string, error) {
+	user, err := s.challengeUser(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mfa, err := s.mfaRepo.GetUserMFA(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, nil, invalidInput("set up two-factor authentication first")
+		}
+		return nil, nil, err
+	}
+
+	if mfa.EnabledAt == nil {
+		recoveryCodes, err := s.confirm(ctx, mfa, req.Code)
+		if err != nil {
+			return nil, nil, err
+		}
+		return user, recoveryCodes, nil
+	}
+
+	if err := s.verify(ctx, mfa, req.Code, req.RecoveryCode); err != nil {
+		return nil, nil, err
+	}
+	return user, nil, nil
+}
+
+func (s *MFAServiceImpl) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatusResponse, error) {
+	required, err := s.isRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &MFAStatusResponse{Required: required}
+
+	enabled, err := s.isEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		resp.Enabled = true
+		resp.RecoveryCodesRemaining, err = s.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// StartEnrollment issues a new secret for the authenticator app. Starting
+// again before confirming replaces the previous secret.
+func (s *MFAServiceImpl) StartEnrollment(ctx context.Context, userID uuid.UUID) (*MFAEnrollmentResponse, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.enroll(ctx, user)
+}
+
+func (s *MFAServiceImpl) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	mfa, err := s.mfaRepo.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, invalidInput("start enrollment first")
+		}
+		return nil, err
+	}
+	if mfa.EnabledAt != nil {
+		return nil, invalidInput("two-factor authentication is already enabled")
+	}
+
+	recoveryCodes, err := s.confirm(ctx, mfa, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code after a fresh code
+// proves the user still holds the second factor.
+func (s *MFAServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	mfa, err := s.enabledMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(ctx, mfa, req.Code, req.RecoveryCode); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableMFA turns the second factor off, which roles that require it
+// cannot do.
+func (s *MFAServiceImpl) DisableMFA(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) error {
+	required, err := s.isRequired(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return invalidInput("two-factor authentication is required for your role")
+	}
+
+	mfa, err := s.enabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verify(ctx, mfa, req.Code, req.RecoveryCode); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.DeleteMFA(ctx, userID)
+}
+
+// ResetMFA lets an admin clear the second factor of a user who lost both
+// their device and recovery codes. Roles that require MFA enroll again at
+// their next sign in.
+func (s *MFAServiceImpl) ResetMFA(ctx context.Context, userID uuid.UUID) error {
+	return s.mfaRepo.DeleteMFA(ctx, userID)
+}
+
+// challengeUser resolves a challenge token to the still active user it was
+// issued to, within the association of the request.
+func (s *MFAServiceImpl) challengeUser(ctx context.Context, token string) (*model.User, error) {
+	claims, err := s.jwt.ParseMFAChallenge(token)
+	if err != nil {
+		return nil, err
+	}
+	associationID, ok := tenant.AssociationID(ctx)
+	if !ok || claims.AssociationID != associationID.String() {
+		return nil, constants.ErrInvalidToken
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil, constants.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if user.Status != constants.ActiveStatus {
+		return nil, constants.ErrAccountInactive
+	}
+
+	return user, nil
+}
+
+func (s *MFAServiceImpl) enroll(ctx context.Context, user *model.User) (*MFAEnrollmentResponse, error) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.SaveMFASecret(ctx, user.ID, secret); err != nil {
+		if errors.Is(err, constants.ErrRecordExists) {
+			return nil, invalidInput("two-factor authentication is already enabled")
+		}
+		return nil, err
+	}
+
+	return &MFAEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(tenant.Name(ctx), user.Email, secret),
+	}, nil
+}
+
+// confirm enables a pending enrollment with the first code from the app and
+// returns the recovery codes, which are only ever shown here.
+func (s *MFAServiceImpl) confirm(ctx context.Context, mfa *model.UserMFA, code string) ([]string, error) {
+	if mfa.LockedUntil != nil && time.Now().Before(*mfa.LockedUntil) {
+		return nil, constants.ErrMFALocked
+	}
+	if code == "" {
+		return nil, invalidInput("code is required")
+	}
+
+	step, ok := auth.ValidateTOTP(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, s.recordFailure(ctx, mfa.UserID)
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.EnableMFA(ctx, mfa.UserID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// verify accepts a TOTP code, each step only once, or an unused recovery
+// code. Wrong codes count towards a temporary lockout.
+func (s *MFAServiceImpl) verify(ctx context.Context, mfa *model.UserMFA, code, recoveryCode string) error {
+	if mfa.LockedUntil != nil && time.Now().Before(*mfa.LockedUntil) {
+		return constants.ErrMFALocked
+	}
+
+	var err error
+	switch {
+	case code != "":
+		step, ok := auth.ValidateTOTP(mfa.Secret, code, time.Now())
+		if !ok {
+			return s.recordFailure(ctx, mfa.UserID)
+		}
+		err = s.mfaRepo.UseTOTPStep(ctx, mfa.UserID, step)
+	case recoveryCode != "":
+		err = s.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(recoveryCode)))
+	default:
+		return invalidInput("a code or recovery code is required")
+	}
+	if errors.Is(err, constants.ErrInvalidToken) {
+		return s.recordFailure(ctx, mfa.UserID)
+	}
+	return err
+}
+
+func (s *MFAServiceImpl) recordFailure(ctx context.Context, userID uuid.UUID) error {
+	lockedUntil := time.Now().Add(constants.MFALockout)
+	if err := s.mfaRepo.RecordFailedMFAAttempt(ctx, userID, constants.MaxMFAAttempts, lockedUntil); err != nil {
+		return err
+	}
+	return constants.ErrInvalidMFACode
+}
+
+func (s *MFAServiceImpl) enabledMFA(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+	mfa, err := s.mfaRepo.GetUserMFA(ctx, userID)
+	if err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
+		return nil, err
+	}
+	if mfa == nil || mfa.EnabledAt == nil {
+		return nil, invalidInput("two-factor authentication is not enabled")
+	}
+	return mfa, nil
+}
+
+func (s *MFAServiceImpl) isEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.mfaRepo.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return mfa.EnabledAt != nil, nil
+}
+
+func (s *MFAServiceImpl) isRequired(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if len(s.requiredRoles) == 0 {
+		return false, nil
+	}
+
+	roles, err := s.roleRepo.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		for _, required := range s.requiredRoles {
+			if role.Name == required {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// newRecoveryCodes returns a fresh set of codes formatted like
+// "k7m2p-x9qra", and the hashes to store in their place.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	raw := make([]byte, 10*constants.MFARecoveryCodeCount)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, nil, err
+	}
+
+	for i := 0; i < constants.MFARecoveryCodeCount; i++ {
+		var code strings.Builder
+		for j, b := range raw[i*10 : (i+1)*10] {
+			if j == 5 {
+				code.WriteByte('-')
+			}
+			code.WriteByte(recoveryCodeAlphabet[b&31])
+		}
+		codes = append(codes, code.String())
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code.String())))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes as typed.
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
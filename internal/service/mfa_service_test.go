@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockMFARepository struct {
+	SaveMFASecretFn            func(ctx context.Context, userID uuid.UUID, secret string) error
+	GetUserMFAFn               func(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error)
+	EnableMFAFn                func(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error
+	UseTOTPStepFn              func(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCodeFn          func(ctx context.Context, userID uuid.UUID, codeHash string) error
+	RecordFailedMFAAttemptFn   func(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) error
+	CountUnusedRecoveryCodesFn func(ctx context.Context, userID uuid.UUID) (int, error)
+	ReplaceRecoveryCodesFn     func(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	DeleteMFAFn                func(ctx context.Context, userID uuid.UUID) error
+}
+
+func (m *MockMFARepository) SaveMFASecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	return m.SaveMFASecretFn(ctx, userID, secret)
+}
+
+func (m *MockMFARepository) GetUserMFA(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+	return m.GetUserMFAFn(ctx, userID)
+}
+
+func (m *MockMFARepository) EnableMFA(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	return m.EnableMFAFn(ctx, userID, step, recoveryCodeHashes)
+}
+
+func (m *MockMFARepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	return m.UseTOTPStepFn(ctx, userID, step)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	return m.UseRecoveryCodeFn(ctx, userID, codeHash)
+}
+
+func (m *MockMFARepository) RecordFailedMFAAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) error {
+	return m.RecordFailedMFAAttemptFn(ctx, userID, maxAttempts, lockedUntil)
+}
+
+func (m *MockMFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	return m.CountUnusedRecoveryCodesFn(ctx, userID)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	return m.ReplaceRecoveryCodesFn(ctx, userID, codeHashes)
+}
+
+func (m *MockMFARepository) DeleteMFA(ctx context.Context, userID uuid.UUID) error {
+	return m.DeleteMFAFn(ctx, userID)
+}
+
+func rolesRepo(names ...string) *MockRoleRepository {
+	return &MockRoleRepository{
+		GetRolesByUserIDFn: func(ctx context.Context, userID uuid.UUID) ([]model.Role, error) {
+			var roles []model.Role
+			for _, name := range names {
+				roles = append(roles, model.Role{Name: name})
+			}
+			return roles, nil
+		},
+	}
+}
+
+func TestMFAService_ChallengeLogin(t *testing.T) {
+	enabledAt := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		mfa              *model.UserMFA
+		roles            []string
+		expectChallenge  bool
+		expectEnrollment bool
+	}{
+		{
+			name:  "resident without mfa",
+			roles: []string{"member"},
+		},
+		{
+			name:             "treasurer without mfa must enroll",
+			roles:            []string{"member", "treasurer"},
+			expectChallenge:  true,
+			expectEnrollment: true,
+		},
+		{
+			name:            "resident who opted in",
+			mfa:             &model.UserMFA{EnabledAt: &enabledAt},
+			roles:           []string{"member"},
+			expectChallenge: true,
+		},
+		{
+			name:             "treasurer with an unconfirmed enrollment",
+			mfa:              &model.UserMFA{},
+			roles:            []string{"treasurer"},
+			expectChallenge:  true,
+			expectEnrollment: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mfaRepo := &MockMFARepository{
+				GetUserMFAFn: func(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+					if tc.mfa == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.mfa, nil
+				},
+			}
+			jwtAuth := &MockJWTAuth{
+				GenerateMFAChallengeFn: func(user *model.User, expiresAt time.Time) (string, error) {
+					return "challenge12345", nil
+				},
+			}
+
+			service := NewMFAService(jwtAuth, mfaRepo, &MockUserRepository{}, rolesRepo(tc.roles...), []string{"admin", "treasurer"})
+			challenge, err := service.ChallengeLogin(context.Background(), &model.User{ID: uuid.New()})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if (challenge != nil) != tc.expectChallenge {
+				t.Fatalf("expected challenge %v, got %+v", tc.expectChallenge, challenge)
+			}
+			if challenge != nil && challenge.EnrollmentRequired != tc.expectEnrollment {
+				t.Errorf("expected enrollment required %v, got %v", tc.expectEnrollment, challenge.EnrollmentRequired)
+			}
+		})
+	}
+}
+
+func TestMFAService_CompleteLogin(t *testing.T) {
+	associationID := uuid.New()
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: associationID, Name: "Acacia Heights"})
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validCode, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongCode := "000000"
+	if validCode == wrongCode {
+		wrongCode = "111111"
+	}
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name                string
+		challengeAssocID    uuid.UUID
+		mfa                 model.UserMFA
+		req                 MFALoginRequest
+		stepErr             error
+		expectErr           error
+		expectFailure       bool
+		expectEnabled       bool
+		expectRecoveryCodes int
+	}{
+		{
+			name: "valid code",
+			mfa:  model.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+			req:  MFALoginRequest{Code: validCode},
+		},
+		{
+			name:          "wrong code",
+			mfa:           model.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+			req:           MFALoginRequest{Code: wrongCode},
+			expectErr:     constants.ErrInvalidMFACode,
+			expectFailure: true,
+		},
+		{
+			name:          "replayed code",
+			mfa:           model.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+			req:           MFALoginRequest{Code: validCode},
+			stepErr:       constants.ErrInvalidToken,
+			expectErr:     constants.ErrInvalidMFACode,
+			expectFailure: true,
+		},
+		{
+			name:      "locked out",
+			mfa:       model.UserMFA{Secret: secret, EnabledAt: &enabledAt, LockedUntil: &lockedUntil},
+			req:       MFALoginRequest{Code: validCode},
+			expectErr: constants.ErrMFALocked,
+		},
+		{
+			name: "recovery code typed loosely",
+			mfa:  model.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+			req:  MFALoginRequest{RecoveryCode: " K7M2P X9QRA "},
+		},
+		{
+			name:                "first code confirms a forced enrollment",
+			mfa:                 model.UserMFA{Secret: secret},
+			req:                 MFALoginRequest{Code: validCode},
+			expectEnabled:       true,
+			expectRecoveryCodes: constants.MFARecoveryCodeCount,
+		},
+		{
+			name:             "challenge from another association",
+			challengeAssocID: uuid.New(),
+			mfa:              model.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+			req:              MFALoginRequest{Code: validCode},
+			expectErr:        constants.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			user := &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus}
+			challengeAssocID := associationID
+			if tc.challengeAssocID != uuid.Nil {
+				challengeAssocID = tc.challengeAssocID
+			}
+
+			jwtAuth := &MockJWTAuth{
+				ParseMFAChallengeFn: func(tokenStr string) (*auth.MFAChallengeClaims, error) {
+					return &auth.MFAChallengeClaims{UserID: user.ID.String(), AssociationID: challengeAssocID.String()}, nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return user, nil
+				},
+			}
+			mfa := tc.mfa
+			mfa.UserID = user.ID
+			failed, enabled := false, false
+			mfaRepo := &MockMFARepository{
+				GetUserMFAFn: func(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+					return &mfa, nil
+				},
+				UseTOTPStepFn: func(ctx context.Context, userID uuid.UUID, step int64) error {
+					return tc.stepErr
+				},
+				UseRecoveryCodeFn: func(ctx context.Context, userID uuid.UUID, codeHash string) error {
+					if codeHash != hashToken("k7m2px9qra") {
+						return constants.ErrInvalidToken
+					}
+					return nil
+				},
+				RecordFailedMFAAttemptFn: func(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) error {
+					failed = true
+					return nil
+				},
+				EnableMFAFn: func(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+					enabled = len(recoveryCodeHashes) == constants.MFARecoveryCodeCount
+					return nil
+				},
+			}
+
+			service := NewMFAService(jwtAuth, mfaRepo, userRepo, rolesRepo("treasurer"), []string{"treasurer"})
+			tc.req.ChallengeToken = "challenge12345"
+			got, recoveryCodes, err := service.CompleteLogin(ctx, &tc.req)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr == nil && got != user {
+				t.Errorf("expected the challenged user, got %+v", got)
+			}
+			if failed != tc.expectFailure {
+				t.Errorf("expected failed attempt recorded %v, got %v", tc.expectFailure, failed)
+			}
+			if enabled != tc.expectEnabled {
+				t.Errorf("expected mfa enabled %v, got %v", tc.expectEnabled, enabled)
+			}
+			if len(recoveryCodes) != tc.expectRecoveryCodes {
+				t.Errorf("expected %d recovery codes, got %d", tc.expectRecoveryCodes, len(recoveryCodes))
+			}
+		})
+	}
+}
+
+func TestMFAService_DisableMFA(t *testing.T) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabledAt := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		roles         []string
+		expectErr     error
+		expectDeleted bool
+	}{
+		{
+			name:          "resident turns it off",
+			roles:         []string{"member"},
+			expectDeleted: true,
+		},
+		{
+			name:      "treasurer cannot",
+			roles:     []string{"member", "treasurer"},
+			expectErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted := false
+			mfaRepo := &MockMFARepository{
+				GetUserMFAFn: func(ctx context.Context, userID uuid.UUID) (*model.UserMFA, error) {
+					return &model.UserMFA{UserID: userID, Secret: secret, EnabledAt: &enabledAt}, nil
+				},
+				UseTOTPStepFn: func(ctx context.Context, userID uuid.UUID, step int64) error {
+					return nil
+				},
+				DeleteMFAFn: func(ctx context.Context, userID uuid.UUID) error {
+					deleted = true
+					return nil
+				},
+			}
+
+			service := NewMFAService(&MockJWTAuth{}, mfaRepo, &MockUserRepository{}, rolesRepo(tc.roles...), []string{"admin", "treasurer"})
+			err := service.DisableMFA(context.Background(), uuid.New(), &MFACodeRequest{Code: code})
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if deleted != tc.expectDeleted {
+				t.Errorf("expected deleted %v, got %v", tc.expectDeleted, deleted)
+			}
+		})
+	}
+}
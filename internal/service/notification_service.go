@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type NotificationServiceImpl struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &NotificationServiceImpl{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *NotificationServiceImpl) ListNotifications(ctx context.Context, userID uuid.UUID, req *ListNotificationsRequest) ([]NotificationResponse, error) {
+	notifications, err := s.notificationRepo.ListNotifications(ctx, userID, repository.NotificationFilter{
+		UnreadOnly: req.Unread,
+		Limit:      pageSize(req.Limit),
+		Offset:     req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]NotificationResponse, 0, len(notifications))
+	for i := range notifications {
+		resp = append(resp, toNotificationResponse(&notifications[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *NotificationServiceImpl) CountUnread(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error) {
+	count, err := s.notificationRepo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnreadCountResponse{Unread: count}, nil
+}
+
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	return s.notificationRepo.MarkNotificationRead(ctx, id, userID)
+}
+
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.notificationRepo.MarkAllNotificationsRead(ctx, userID)
+}
+
+func toNotificationResponse(notification *model.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:            notification.ID.String(),
+		Kind:          notification.Kind,
+		Title:         notification.Title,
+		Body:          notification.Body,
+		ReferenceType: notification.ReferenceType,
+		ReferenceID:   optionalUUID(notification.ReferenceID),
+		ReadAt:        notification.ReadAt,
+		CreatedAt:     notification.CreatedAt,
+	}
+}
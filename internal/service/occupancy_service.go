@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type OccupancyServiceImpl struct {
+	occupancyRepo repository.OccupancyRepository
+	propertyRepo  repository.PropertyRepository
+	userRepo      repository.UserRepository
+}
+
+func NewOccupancyService(occupancyRepo repository.OccupancyRepository, propertyRepo repository.PropertyRepository, userRepo repository.UserRepository) OccupancyService {
+	return &OccupancyServiceImpl{
+		occupancyRepo: occupancyRepo,
+		propertyRepo:  propertyRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// AddOccupant records a co-owner, tenant or representative. The primary
+// owner only changes through TransferOwnership, which keeps exactly one open
+// owner and properties.owner_id in step.
+func (s *OccupancyServiceImpl) AddOccupant(ctx context.Context, propertyID uuid.UUID, req *AddOccupantRequest) (*OccupantResponse, error) {
+	if req.OccupancyType == constants.OccupancyOwner {
+		return nil, invalidInput("the owner can only be changed by transferring ownership")
+	}
+
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, invalidInput("user id must be a uuid")
+	}
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy := &model.Occupancy{
+		PropertyID:    propertyID,
+		UserID:        userID,
+		OccupancyType: req.OccupancyType,
+		StartDate:     startDate,
+		Notes:         req.Notes,
+	}
+	if req.EndDate != "" {
+		endDate, err := parseDate(req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		if endDate.Before(startDate) {
+			return nil, invalidInput("end date must not be before start date")
+		}
+		occupancy.EndDate = &endDate
+	}
+
+	created, err := s.occupancyRepo.CreateOccupancy(ctx, occupancy)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOccupantResponse(&model.PropertyOccupant{
+		Occupancy: *created,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}), nil
+}
+
+func (s *OccupancyServiceImpl) EndOccupancy(ctx context.Context, propertyID, occupancyID uuid.UUID, req *EndOccupancyRequest) error {
+	occupancy, err := s.occupancyRepo.GetOccupancyByID(ctx, occupancyID)
+	if err != nil {
+		return err
+	}
+	if occupancy.PropertyID != propertyID {
+		return constants.ErrRecordNotFound
+	}
+
+	endDate, err := parseDate(req.EndDate)
+	if err != nil {
+		return err
+	}
+	if endDate.Before(occupancy.StartDate) {
+		return invalidInput("end date must not be before start date")
+	}
+
+	return s.occupancyRepo.EndOccupancy(ctx, occupancyID, endDate)
+}
+
+func (s *OccupancyServiceImpl) GetOccupants(ctx context.Context, propertyID uuid.UUID, date string) ([]OccupantResponse, error) {
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	onDate, err := parseDateOrToday(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	occupants, err := s.occupancyRepo.ListOccupantsOnDate(ctx, propertyID, onDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOccupantResponses(occupants), nil
+}
+
+func (s *OccupancyServiceImpl) GetOccupancyHistory(ctx context.Context, propertyID uuid.UUID) ([]OccupantResponse, error) {
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	occupants, err := s.occupancyRepo.ListOccupancyHistory(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOccupantResponses(occupants), nil
+}
+
+// LookupOccupants answers "who lived at Phase/Block/Lot on a given date".
+func (s *OccupancyServiceImpl) LookupOccupants(ctx context.Context, req *OccupancyLookupRequest) (*OccupancyLookupResponse, error) {
+	onDate, err := parseDateOrToday(ctx, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.propertyRepo.GetPropertyByAddress(ctx, strings.TrimSpace(req.Phase), strings.TrimSpace(req.Block), strings.TrimSpace(req.Lot))
+	if err != nil {
+		return nil, err
+	}
+
+	occupants, err := s.occupancyRepo.ListOccupantsOnDate(ctx, property.ID, onDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OccupancyLookupResponse{
+		Property:  *toPropertyResponse(property),
+		Date:      onDate.Format(constants.DateFormat),
+		Occupants: toOccupantResponses(occupants),
+	}, nil
+}
+
+func (s *OccupancyServiceImpl) TransferOwnership(ctx context.Context, propertyID uuid.UUID, req *TransferOwnershipRequest) error {
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return err
+	}
+
+	transferDate, err := parseDate(req.TransferDate)
+	if err != nil {
+		return err
+	}
+
+	primaryOwners := 0
+	seen := map[uuid.UUID]bool{}
+	owners := make([]model.Occupancy, 0, len(req.Owners))
+	for _, owner := range req.Owners {
+		userID, err := uuid.Parse(owner.UserID)
+		if err != nil {
+			return invalidInput("user id must be a uuid")
+		}
+		if seen[userID] {
+			return invalidInput("user %s is listed more than once", userID)
+		}
+		seen[userID] = true
+
+		if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
+
+		if owner.OccupancyType == constants.OccupancyOwner {
+			primaryOwners++
+		}
+		owners = append(owners, model.Occupancy{UserID: userID, OccupancyType: owner.OccupancyType})
+	}
+	if primaryOwners != 1 {
+		return invalidInput("exactly one owner is required, the rest must be co-owners")
+	}
+
+	// the current owners' occupancies end on the transfer date, which may not
+	// come before they started
+	history, err := s.occupancyRepo.ListOccupancyHistory(ctx, propertyID)
+	if err != nil {
+		return err
+	}
+	for _, occupant := range history {
+		isOwner := occupant.OccupancyType == constants.OccupancyOwner || occupant.OccupancyType == constants.OccupancyCoOwner
+		if isOwner && occupant.EndDate == nil && transferDate.Before(occupant.StartDate) {
+			return invalidInput("transfer date must not be before the current owners started on %s", occupant.StartDate.Format(constants.DateFormat))
+		}
+	}
+
+	return s.occupancyRepo.TransferOwnership(ctx, propertyID, owners, transferDate)
+}
+
+func (s *OccupancyServiceImpl) GetUserProperties(ctx context.Context, userID uuid.UUID) ([]UserPropertyResponse, error) {
+	properties, err := s.occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]UserPropertyResponse, 0, len(properties))
+	for i := range properties {
+		resp = append(resp, UserPropertyResponse{
+			Property:      *toPropertyResponse(&properties[i].Property),
+			OccupancyID:   properties[i].OccupancyID.String(),
+			OccupancyType: properties[i].OccupancyType,
+			StartDate:     properties[i].StartDate.Format(constants.DateFormat),
+			EndDate:       formatOptionalDate(properties[i].EndDate),
+		})
+	}
+
+	return resp, nil
+}
+
+func toOccupantResponses(occupants []model.PropertyOccupant) []OccupantResponse {
+	resp := make([]OccupantResponse, 0, len(occupants))
+	for i := range occupants {
+		resp = append(resp, *toOccupantResponse(&occupants[i]))
+	}
+	return resp
+}
+
+func toOccupantResponse(occupant *model.PropertyOccupant) *OccupantResponse {
+	return &OccupantResponse{
+		ID:            occupant.ID.String(),
+		PropertyID:    occupant.PropertyID.String(),
+		UserID:        occupant.UserID.String(),
+		FirstName:     occupant.FirstName,
+		LastName:      occupant.LastName,
+		Email:         occupant.Email,
+		OccupancyType: occupant.OccupancyType,
+		StartDate:     occupant.StartDate.Format(constants.DateFormat),
+		EndDate:       formatOptionalDate(occupant.EndDate),
+		Notes:         occupant.Notes,
+	}
+}
+
+// resolveUserProperty returns the property the user currently occupies that
+// the request refers to. propertyID may be empty when the user is linked to a
+// single property; unknown and foreign properties both read as not found.
+func resolveUserProperty(ctx context.Context, occupancyRepo repository.OccupancyRepository, userID uuid.UUID, propertyID string) (*model.Property, error) {
+	properties, err := occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if propertyID == "" {
+		switch len(properties) {
+		case 0:
+			return nil, constants.ErrRecordNotFound
+		case 1:
+			return &properties[0].Property, nil
+		default:
+			return nil, invalidInput("propertyId is required when you are linked to several properties")
+		}
+	}
+
+	id, err := uuid.Parse(propertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+	for i := range properties {
+		if properties[i].ID == id {
+			return &properties[i].Property, nil
+		}
+	}
+
+	return nil, constants.ErrRecordNotFound
+}
+
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(constants.DateFormat, value)
+	if err != nil {
+		return time.Time{}, invalidInput("dates must use the %s format", constants.DateFormat)
+	}
+	return date, nil
+}
+
+func parseDateOrToday(ctx context.Context, value string) (time.Time, error) {
+	if value == "" {
+		return today(ctx), nil
+	}
+	return parseDate(value)
+}
+
+// today returns the current date in the association's time zone.
+func today(ctx context.Context) time.Time {
+	return localDate(ctx, time.Now())
+}
+
+// localDate returns the association's calendar date at the instant t. Like
+// every other date it is stored as midnight UTC.
+func localDate(ctx context.Context, t time.Time) time.Time {
+	local := t.In(tenant.Location(ctx))
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(constants.DateFormat)
+	return &formatted
+}
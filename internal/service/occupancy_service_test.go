@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type MockOccupancyRepository struct {
+	CreateOccupancyFn      func(ctx context.Context, occupancy *model.Occupancy) (*model.Occupancy, error)
+	GetOccupancyByIDFn     func(ctx context.Context, id uuid.UUID) (*model.Occupancy, error)
+	EndOccupancyFn         func(ctx context.Context, id uuid.UUID, endDate time.Time) error
+	ListOccupantsOnDateFn  func(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error)
+	ListOccupancyHistoryFn func(ctx context.Context, propertyID uuid.UUID) ([]model.PropertyOccupant, error)
+	ListUserPropertiesFn   func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error)
+	TransferOwnershipFn    func(ctx context.Context, propertyID uuid.UUID, owners []model.Occupancy, transferDate time.Time) error
+}
+
+func (m *MockOccupancyRepository) CreateOccupancy(ctx context.Context, occupancy *model.Occupancy) (*model.Occupancy, error) {
+	return m.CreateOccupancyFn(ctx, occupancy)
+}
+
+func (m *MockOccupancyRepository) GetOccupancyByID(ctx context.Context, id uuid.UUID) (*model.Occupancy, error) {
+	return m.GetOccupancyByIDFn(ctx, id)
+}
+
+func (m *MockOccupancyRepository) EndOccupancy(ctx context.Context, id uuid.UUID, endDate time.Time) error {
+	return m.EndOccupancyFn(ctx, id, endDate)
+}
+
+func (m *MockOccupancyRepository) ListOccupantsOnDate(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error) {
+	return m.ListOccupantsOnDateFn(ctx, propertyID, date)
+}
+
+func (m *MockOccupancyRepository) ListOccupancyHistory(ctx context.Context, propertyID uuid.UUID) ([]model.PropertyOccupant, error) {
+	return m.ListOccupancyHistoryFn(ctx, propertyID)
+}
+
+func (m *MockOccupancyRepository) ListUserProperties(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+	return m.ListUserPropertiesFn(ctx, userID, date)
+}
+
+func (m *MockOccupancyRepository) TransferOwnership(ctx context.Context, propertyID uuid.UUID, owners []model.Occupancy, transferDate time.Time) error {
+	return m.TransferOwnershipFn(ctx, propertyID, owners, transferDate)
+}
+
+func TestOccupancyService_TransferOwnership(t *testing.T) {
+	ownerID := uuid.New().String()
+	coOwnerID := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		req            *TransferOwnershipRequest
+		ownerSince     time.Time
+		expectedErr    error
+		expectTransfer bool
+	}{
+		{
+			name: "owner and co-owner",
+			req: &TransferOwnershipRequest{
+				TransferDate: "2026-03-01",
+				Owners: []TransferOwnerRequest{
+					{UserID: ownerID, OccupancyType: constants.OccupancyOwner},
+					{UserID: coOwnerID, OccupancyType: constants.OccupancyCoOwner},
+				},
+			},
+			expectTransfer: true,
+		},
+		{
+			name: "on the day the current owner started",
+			req: &TransferOwnershipRequest{
+				TransferDate: "2026-03-01",
+				Owners:       []TransferOwnerRequest{{UserID: ownerID, OccupancyType: constants.OccupancyOwner}},
+			},
+			ownerSince:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectTransfer: true,
+		},
+		{
+			name: "before the current owner started",
+			req: &TransferOwnershipRequest{
+				TransferDate: "2026-03-01",
+				Owners:       []TransferOwnerRequest{{UserID: ownerID, OccupancyType: constants.OccupancyOwner}},
+			},
+			ownerSince:  time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name: "missing primary owner",
+			req: &TransferOwnershipRequest{
+				TransferDate: "2026-03-01",
+				Owners:       []TransferOwnerRequest{{UserID: coOwnerID, OccupancyType: constants.OccupancyCoOwner}},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name: "duplicate user",
+			req: &TransferOwnershipRequest{
+				TransferDate: "2026-03-01",
+				Owners: []TransferOwnerRequest{
+					{UserID: ownerID, OccupancyType: constants.OccupancyOwner},
+					{UserID: ownerID, OccupancyType: constants.OccupancyCoOwner},
+				},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name: "invalid transfer date",
+			req: &TransferOwnershipRequest{
+				TransferDate: "03/01/2026",
+				Owners:       []TransferOwnerRequest{{UserID: ownerID, OccupancyType: constants.OccupancyOwner}},
+			},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transferred := false
+			occupancyRepo := &MockOccupancyRepository{
+				ListOccupancyHistoryFn: func(ctx context.Context, propertyID uuid.UUID) ([]model.PropertyOccupant, error) {
+					history := []model.PropertyOccupant{
+						{Occupancy: model.Occupancy{OccupancyType: constants.OccupancyTenant, StartDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}},
+					}
+					if !tc.ownerSince.IsZero() {
+						history = append(history, model.PropertyOccupant{
+							Occupancy: model.Occupancy{OccupancyType: constants.OccupancyOwner, StartDate: tc.ownerSince},
+						})
+					}
+					return history, nil
+				},
+				TransferOwnershipFn: func(ctx context.Context, propertyID uuid.UUID, owners []model.Occupancy, transferDate time.Time) error {
+					transferred = true
+					if len(owners) != len(tc.req.Owners) {
+						t.Errorf("expected %d owners, got %d", len(tc.req.Owners), len(owners))
+					}
+					if transferDate.Format(constants.DateFormat) != tc.req.TransferDate {
+						t.Errorf("expected transfer date %s, got %s", tc.req.TransferDate, transferDate)
+					}
+					return nil
+				},
+			}
+			propertyRepo := &MockPropertyRepository{
+				GetPropertyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+					return &model.Property{ID: id}, nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return &model.User{ID: id}, nil
+				},
+			}
+
+			service := NewOccupancyService(occupancyRepo, propertyRepo, userRepo)
+			err := service.TransferOwnership(context.Background(), uuid.New(), tc.req)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if transferred != tc.expectTransfer {
+				t.Errorf("expected transfer %v, got %v", tc.expectTransfer, transferred)
+			}
+		})
+	}
+}
+
+func TestOccupancyService_AddOccupantRejectsOwner(t *testing.T) {
+	service := NewOccupancyService(&MockOccupancyRepository{}, &MockPropertyRepository{}, &MockUserRepository{})
+	_, err := service.AddOccupant(context.Background(), uuid.New(), &AddOccupantRequest{
+		UserID:        uuid.New().String(),
+		OccupancyType: constants.OccupancyOwner,
+		StartDate:     "2026-03-01",
+	})
+	if !errors.Is(err, constants.ErrInvalidInput) {
+		t.Errorf("expected error %v, got %v", constants.ErrInvalidInput, err)
+	}
+}
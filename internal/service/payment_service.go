@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type PaymentServiceImpl struct {
+	paymentRepo  repository.PaymentRepository
+	propertyRepo repository.PropertyRepository
+}
+
+func NewPaymentService(paymentRepo repository.PaymentRepository, propertyRepo repository.PropertyRepository) PaymentService {
+	return &PaymentServiceImpl{
+		paymentRepo:  paymentRepo,
+		propertyRepo: propertyRepo,
+	}
+}
+
+// RecordPayment stores the payment and allocates it to the property's unpaid
+// invoices oldest first. Whatever is left over stays on the account as credit
+// for future invoices.
+func (s *PaymentServiceImpl) RecordPayment(ctx context.Context, req *RecordPaymentRequest, recordedBy *uuid.UUID) (*PaymentResponse, error) {
+	propertyID, err := uuid.Parse(req.PropertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+
+	paidAt, err := parseDateOrToday(ctx, req.PaidAt)
+	if err != nil {
+		return nil, err
+	}
+	if paidAt.After(today(ctx)) {
+		return nil, invalidInput("payment date must not be in the future")
+	}
+
+	var reference *string
+	if req.ReferenceNumber != nil && strings.TrimSpace(*req.ReferenceNumber) != "" {
+		trimmed := strings.TrimSpace(*req.ReferenceNumber)
+		reference = &trimmed
+	}
+	if reference == nil && req.Method != constants.PaymentMethodCash {
+		return nil, invalidInput("a reference number is required for %s payments", req.Method)
+	}
+
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepo.CreatePayment(ctx, &model.Payment{
+		PropertyID:      propertyID,
+		Method:          req.Method,
+		ReferenceNumber: reference,
+		AmountCents:     req.AmountCents,
+		PaidAt:          paidAt,
+		Notes:           req.Notes,
+		RecordedBy:      recordedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentResponse(payment), nil
+}
+
+func (s *PaymentServiceImpl) GetPayment(ctx context.Context, id uuid.UUID) (*PaymentResponse, error) {
+	payment, err := s.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentResponse(payment), nil
+}
+
+func (s *PaymentServiceImpl) ListPayments(ctx context.Context, req *ListPaymentsRequest) ([]PaymentResponse, error) {
+	filter := repository.PaymentFilter{
+		Method: req.Method,
+		Limit:  pageSize(req.Limit),
+		Offset: req.Offset,
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return nil, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+	if req.From != "" {
+		from, err := parseDate(req.From)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := parseDate(req.To)
+		if err != nil {
+			return nil, err
+		}
+		filter.To = &to
+	}
+
+	payments, err := s.paymentRepo.ListPayments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]PaymentResponse, 0, len(payments))
+	for i := range payments {
+		resp = append(resp, *toPaymentResponse(&payments[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *PaymentServiceImpl) GetPropertyCredit(ctx context.Context, propertyID uuid.UUID) (*PropertyCreditResponse, error) {
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	credit, err := s.paymentRepo.GetPropertyCredit(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PropertyCreditResponse{
+		PropertyID:  propertyID.String(),
+		CreditCents: credit,
+	}, nil
+}
+
+func toPaymentResponse(payment *model.Payment) *PaymentResponse {
+	resp := &PaymentResponse{
+		ID:               payment.ID.String(),
+		ReceiptNumber:    payment.ReceiptNumber,
+		PropertyID:       payment.PropertyID.String(),
+		Method:           payment.Method,
+		ReferenceNumber:  payment.ReferenceNumber,
+		AmountCents:      payment.AmountCents,
+		AllocatedCents:   payment.AmountCents - payment.UnallocatedCents,
+		UnallocatedCents: payment.UnallocatedCents,
+		PaidAt:           payment.PaidAt.Format(constants.DateFormat),
+		Notes:            payment.Notes,
+		CreatedAt:        payment.CreatedAt,
+	}
+	if payment.RecordedBy != nil {
+		recordedBy := payment.RecordedBy.String()
+		resp.RecordedBy = &recordedBy
+	}
+	if payment.Allocations != nil {
+		resp.Allocations = make([]PaymentAllocationResponse, 0, len(payment.Allocations))
+		for _, allocation := range payment.Allocations {
+			resp.Allocations = append(resp.Allocations, PaymentAllocationResponse{
+				InvoiceID:     allocation.InvoiceID.String(),
+				InvoiceNumber: allocation.InvoiceNumber,
+				AmountCents:   allocation.AmountCents,
+			})
+		}
+	}
+	return resp
+}
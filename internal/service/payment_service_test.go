@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockPaymentRepository struct {
+	CreatePaymentFn     func(ctx context.Context, payment *model.Payment) (*model.Payment, error)
+	GetPaymentByIDFn    func(ctx context.Context, id uuid.UUID) (*model.Payment, error)
+	ListPaymentsFn      func(ctx context.Context, filter repository.PaymentFilter) ([]model.Payment, error)
+	GetPropertyCreditFn func(ctx context.Context, propertyID uuid.UUID) (int64, error)
+}
+
+func (m *MockPaymentRepository) CreatePayment(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	return m.CreatePaymentFn(ctx, payment)
+}
+
+func (m *MockPaymentRepository) GetPaymentByID(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	return m.GetPaymentByIDFn(ctx, id)
+}
+
+func (m *MockPaymentRepository) ListPayments(ctx context.Context, filter repository.PaymentFilter) ([]model.Payment, error) {
+	return m.ListPaymentsFn(ctx, filter)
+}
+
+func (m *MockPaymentRepository) GetPropertyCredit(ctx context.Context, propertyID uuid.UUID) (int64, error) {
+	return m.GetPropertyCreditFn(ctx, propertyID)
+}
+
+func TestPaymentService_RecordPayment(t *testing.T) {
+	propertyID := uuid.New()
+	recordedBy := uuid.New()
+	reference := " BT-123 "
+	blank := "  "
+
+	tests := []struct {
+		name        string
+		req         *RecordPaymentRequest
+		propertyErr error
+		createErr   error
+		expectedErr error
+	}{
+		{
+			name: "cash without reference",
+			req:  &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodCash, AmountCents: 250000},
+		},
+		{
+			name: "bank transfer with reference",
+			req:  &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodBankTransfer, ReferenceNumber: &reference, AmountCents: 250000, PaidAt: "2026-03-02"},
+		},
+		{
+			name:        "check without reference",
+			req:         &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodCheck, ReferenceNumber: &blank, AmountCents: 250000},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "future payment date",
+			req:         &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodCash, AmountCents: 250000, PaidAt: "2999-01-01"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown property",
+			req:         &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodCash, AmountCents: 250000},
+			propertyErr: constants.ErrRecordNotFound,
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:        "duplicate reference",
+			req:         &RecordPaymentRequest{PropertyID: propertyID.String(), Method: constants.PaymentMethodEWallet, ReferenceNumber: &reference, AmountCents: 250000},
+			createErr:   constants.ErrRecordExists,
+			expectedErr: constants.ErrRecordExists,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyRepo := &MockPropertyRepository{
+				GetPropertyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+					if tc.propertyErr != nil {
+						return nil, tc.propertyErr
+					}
+					return &model.Property{ID: id}, nil
+				},
+			}
+			paymentRepo := &MockPaymentRepository{
+				CreatePaymentFn: func(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					if payment.ReferenceNumber != nil && *payment.ReferenceNumber != "BT-123" {
+						t.Errorf("expected trimmed reference, got %q", *payment.ReferenceNumber)
+					}
+					payment.ID = uuid.New()
+					payment.ReceiptNumber = "OR-00000001"
+					payment.UnallocatedCents = 50000
+					return payment, nil
+				},
+			}
+
+			service := NewPaymentService(paymentRepo, propertyRepo)
+			resp, err := service.RecordPayment(context.Background(), tc.req, &recordedBy)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.AllocatedCents != 200000 || resp.UnallocatedCents != 50000 {
+				t.Errorf("unexpected allocation %d/%d", resp.AllocatedCents, resp.UnallocatedCents)
+			}
+			if resp.RecordedBy == nil || *resp.RecordedBy != recordedBy.String() {
+				t.Errorf("expected recorded by %s", recordedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type PenaltyServiceImpl struct {
+	penaltyRepo repository.PenaltyRepository
+	invoiceRepo repository.InvoiceRepository
+}
+
+func NewPenaltyService(penaltyRepo repository.PenaltyRepository, invoiceRepo repository.InvoiceRepository) PenaltyService {
+	return &PenaltyServiceImpl{
+		penaltyRepo: penaltyRepo,
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+func (s *PenaltyServiceImpl) CreatePenaltyRule(ctx context.Context, req *PenaltyRuleRequest) (*PenaltyRuleResponse, error) {
+	if req.FixedCents == 0 && req.MonthlyInterestBps == 0 {
+		return nil, invalidInput("a penalty rule needs a fixed amount, an interest rate or both")
+	}
+
+	effectiveFrom, err := parseDate(req.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &model.PenaltyRule{
+		Name:               strings.TrimSpace(req.Name),
+		GraceDays:          req.GraceDays,
+		FixedCents:         req.FixedCents,
+		MonthlyInterestBps: req.MonthlyInterestBps,
+		EffectiveFrom:      effectiveFrom,
+	}
+	if req.EffectiveTo != "" {
+		effectiveTo, err := parseDate(req.EffectiveTo)
+		if err != nil {
+			return nil, err
+		}
+		if effectiveTo.Before(effectiveFrom) {
+			return nil, invalidInput("effective to must not be before effective from")
+		}
+		rule.EffectiveTo = &effectiveTo
+	}
+
+	created, err := s.penaltyRepo.CreatePenaltyRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPenaltyRuleResponse(created), nil
+}
+
+func (s *PenaltyServiceImpl) ListPenaltyRules(ctx context.Context) ([]PenaltyRuleResponse, error) {
+	rules, err := s.penaltyRepo.ListPenaltyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]PenaltyRuleResponse, 0, len(rules))
+	for i := range rules {
+		resp = append(resp, *toPenaltyRuleResponse(&rules[i]))
+	}
+
+	return resp, nil
+}
+
+// ApplyPenalties is called daily by the job scheduler. It charges every
+// penalty that has accrued on overdue invoices up to the association's
+// current date; charges recorded on an earlier run are skipped by the
+// repository.
+func (s *PenaltyServiceImpl) ApplyPenalties(ctx context.Context, now time.Time) error {
+	asOf := localDate(ctx, now)
+
+	rules, err := s.penaltyRepo.ListPenaltyRules(ctx)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	invoices, err := s.penaltyRepo.ListOverdueInvoices(ctx, asOf)
+	if err != nil {
+		return err
+	}
+
+	applied := 0
+	for i := range invoices {
+		rule := selectPenaltyRule(rules, invoices[i].DueDate)
+		if rule == nil {
+			continue
+		}
+
+		// interest for each month is charged on the dues unpaid on that day,
+		// so catching up on missed months needs the payment history
+		var allocations []model.PaymentAllocation
+		if rule.MonthlyInterestBps > 0 && invoices[i].PaidCents > 0 {
+			allocations, err = s.penaltyRepo.ListInvoiceAllocations(ctx, invoices[i].ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, penalty := range accruedPenalties(rule, &invoices[i], allocations, asOf) {
+			ok, err := s.penaltyRepo.ApplyPenalty(ctx, &penalty)
+			if err != nil {
+				return err
+			}
+			if ok {
+				applied++
+			}
+		}
+	}
+
+	if applied > 0 {
+		log.Printf("applied %d penalties to overdue invoices as of %s\n", applied, asOf.Format(constants.DateFormat))
+	}
+
+	return nil
+}
+
+func (s *PenaltyServiceImpl) ListInvoicePenalties(ctx context.Context, invoiceID uuid.UUID) ([]InvoicePenaltyResponse, error) {
+	if _, err := s.invoiceRepo.GetInvoiceByID(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+
+	penalties, err := s.penaltyRepo.ListInvoicePenalties(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]InvoicePenaltyResponse, 0, len(penalties))
+	for i := range penalties {
+		resp = append(resp, *toInvoicePenaltyResponse(&penalties[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *PenaltyServiceImpl) WaivePenalty(ctx context.Context, id uuid.UUID, req *WaivePenaltyRequest, waivedBy uuid.UUID) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return invalidInput("a reason is required to waive a penalty")
+	}
+
+	penalty, err := s.penaltyRepo.GetPenaltyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if penalty.Status == constants.PenaltyStatusWaived {
+		return invalidInput("penalty is already waived")
+	}
+
+	return s.penaltyRepo.WaivePenalty(ctx, id, reason, waivedBy)
+}
+
+// selectPenaltyRule returns the rule in force on the invoice due date,
+// preferring the most recent effective_from.
+func selectPenaltyRule(rules []model.PenaltyRule, dueDate time.Time) *model.PenaltyRule {
+	var best *model.PenaltyRule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.EffectiveFrom.After(dueDate) || (rule.EffectiveTo != nil && rule.EffectiveTo.Before(dueDate)) {
+			continue
+		}
+		if best == nil || rule.EffectiveFrom.After(best.EffectiveFrom) {
+			best = rule
+		}
+	}
+	return best
+}
+
+// accruedPenalties lists every charge the rule has produced for the invoice
+// up to asOf. The fixed penalty accrues once on the first day after the grace
+// period; interest accrues on that day and every month after it, on the dues
+// still unpaid on the accrual date, never on earlier penalties. Payments count
+// from the day they were made, so months the daily job missed and payments
+// posted late are both charged on the balance on the accrual date.
+func accruedPenalties(rule *model.PenaltyRule, invoice *model.Invoice, allocations []model.PaymentAllocation, asOf time.Time) []model.InvoicePenalty {
+	start := invoice.DueDate.AddDate(0, 0, rule.GraceDays+1)
+	if start.After(asOf) {
+		return nil
+	}
+
+	penalties := []model.InvoicePenalty{}
+	if rule.FixedCents > 0 {
+		penalties = append(penalties, model.InvoicePenalty{
+			InvoiceID:   invoice.ID,
+			RuleID:      rule.ID,
+			PenaltyType: constants.PenaltyTypeFixed,
+			PeriodKey:   start,
+			AmountCents: rule.FixedCents,
+		})
+	}
+
+	if rule.MonthlyInterestBps == 0 {
+		return penalties
+	}
+
+	dues := invoice.TotalCents - invoice.PenaltyCents
+	for month := 0; ; month++ {
+		accrual := start.AddDate(0, month, 0)
+		if accrual.After(asOf) {
+			break
+		}
+
+		unpaidDues := dues - paidBefore(invoice, allocations, accrual)
+		if unpaidDues <= 0 {
+			break
+		}
+		interest := int64(math.Round(float64(unpaidDues) * float64(rule.MonthlyInterestBps) / 10000))
+		if interest <= 0 {
+			continue
+		}
+		penalties = append(penalties, model.InvoicePenalty{
+			InvoiceID:   invoice.ID,
+			RuleID:      rule.ID,
+			PenaltyType: constants.PenaltyTypeInterest,
+			PeriodKey:   accrual,
+			AmountCents: interest,
+		})
+	}
+
+	return penalties
+}
+
+// paidBefore sums the payments that count against the invoice before the
+// date, going by when they were paid rather than when they were recorded.
+// Without allocations the invoice's paid amount is used as is.
+func paidBefore(invoice *model.Invoice, allocations []model.PaymentAllocation, date time.Time) int64 {
+	if allocations == nil {
+		return invoice.PaidCents
+	}
+
+	var paid int64
+	for _, allocation := range allocations {
+		if allocation.AppliesFrom.Before(date) {
+			paid += allocation.AmountCents
+		}
+	}
+	return paid
+}
+
+func toPenaltyRuleResponse(rule *model.PenaltyRule) *PenaltyRuleResponse {
+	return &PenaltyRuleResponse{
+		ID:                 rule.ID.String(),
+		Name:               rule.Name,
+		GraceDays:          rule.GraceDays,
+		FixedCents:         rule.FixedCents,
+		MonthlyInterestBps: rule.MonthlyInterestBps,
+		EffectiveFrom:      rule.EffectiveFrom.Format(constants.DateFormat),
+		EffectiveTo:        formatOptionalDate(rule.EffectiveTo),
+	}
+}
+
+func toInvoicePenaltyResponse(penalty *model.InvoicePenalty) *InvoicePenaltyResponse {
+	resp := &InvoicePenaltyResponse{
+		ID:          penalty.ID.String(),
+		InvoiceID:   penalty.InvoiceID.String(),
+		RuleID:      penalty.RuleID.String(),
+		PenaltyType: penalty.PenaltyType,
+		AccruedOn:   penalty.PeriodKey.Format(constants.DateFormat),
+		AmountCents: penalty.AmountCents,
+		Status:      penalty.Status,
+		WaiveReason: penalty.WaiveReason,
+		WaivedAt:    penalty.WaivedAt,
+	}
+	if penalty.WaivedBy != nil {
+		waivedBy := penalty.WaivedBy.String()
+		resp.WaivedBy = &waivedBy
+	}
+	return resp
+}
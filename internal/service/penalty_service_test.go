@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockPenaltyRepository struct {
+	CreatePenaltyRuleFn      func(ctx context.Context, rule *model.PenaltyRule) (*model.PenaltyRule, error)
+	ListPenaltyRulesFn       func(ctx context.Context) ([]model.PenaltyRule, error)
+	ListOverdueInvoicesFn    func(ctx context.Context, asOf time.Time) ([]model.Invoice, error)
+	ListInvoiceAllocationsFn func(ctx context.Context, invoiceID uuid.UUID) ([]model.PaymentAllocation, error)
+	GetPenaltyByIDFn         func(ctx context.Context, id uuid.UUID) (*model.InvoicePenalty, error)
+	ListInvoicePenaltiesFn   func(ctx context.Context, invoiceID uuid.UUID) ([]model.InvoicePenalty, error)
+	ApplyPenaltyFn           func(ctx context.Context, penalty *model.InvoicePenalty) (bool, error)
+	WaivePenaltyFn           func(ctx context.Context, id uuid.UUID, reason string, waivedBy uuid.UUID) error
+}
+
+func (m *MockPenaltyRepository) CreatePenaltyRule(ctx context.Context, rule *model.PenaltyRule) (*model.PenaltyRule, error) {
+	return m.CreatePenaltyRuleFn(ctx, rule)
+}
+
+func (m *MockPenaltyRepository) ListPenaltyRules(ctx context.Context) ([]model.PenaltyRule, error) {
+	return m.ListPenaltyRulesFn(ctx)
+}
+
+func (m *MockPenaltyRepository) ListOverdueInvoices(ctx context.Context, asOf time.Time) ([]model.Invoice, error) {
+	return m.ListOverdueInvoicesFn(ctx, asOf)
+}
+
+func (m *MockPenaltyRepository) ListInvoiceAllocations(ctx context.Context, invoiceID uuid.UUID) ([]model.PaymentAllocation, error) {
+	return m.ListInvoiceAllocationsFn(ctx, invoiceID)
+}
+
+func (m *MockPenaltyRepository) GetPenaltyByID(ctx context.Context, id uuid.UUID) (*model.InvoicePenalty, error) {
+	return m.GetPenaltyByIDFn(ctx, id)
+}
+
+func (m *MockPenaltyRepository) ListInvoicePenalties(ctx context.Context, invoiceID uuid.UUID) ([]model.InvoicePenalty, error) {
+	return m.ListInvoicePenaltiesFn(ctx, invoiceID)
+}
+
+func (m *MockPenaltyRepository) ApplyPenalty(ctx context.Context, penalty *model.InvoicePenalty) (bool, error) {
+	return m.ApplyPenaltyFn(ctx, penalty)
+}
+
+func (m *MockPenaltyRepository) WaivePenalty(ctx context.Context, id uuid.UUID, reason string, waivedBy uuid.UUID) error {
+	return m.WaivePenaltyFn(ctx, id, reason, waivedBy)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAccruedPenalties(t *testing.T) {
+	rule := &model.PenaltyRule{ID: uuid.New(), GraceDays: 5, FixedCents: 10000, MonthlyInterestBps: 200}
+	invoice := &model.Invoice{ID: uuid.New(), DueDate: date(2026, 3, 15), TotalCents: 160000, PenaltyCents: 10000, PaidCents: 50000}
+
+	tests := []struct {
+		name     string
+		asOf     time.Time
+		expected int
+	}{
+		{name: "within grace period", asOf: date(2026, 3, 20), expected: 0},
+		{name: "first day after grace", asOf: date(2026, 3, 21), expected: 2},
+		{name: "two months later", asOf: date(2026, 5, 21), expected: 4},
+		{name: "just before third month", asOf: date(2026, 5, 20), expected: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			penalties := accruedPenalties(rule, invoice, nil, tc.asOf)
+			if len(penalties) != tc.expected {
+				t.Fatalf("expected %d penalties, got %d", tc.expected, len(penalties))
+			}
+			for _, penalty := range penalties {
+				switch penalty.PenaltyType {
+				case constants.PenaltyTypeFixed:
+					if penalty.AmountCents != 10000 || !penalty.PeriodKey.Equal(date(2026, 3, 21)) {
+						t.Errorf("unexpected fixed penalty %+v", penalty)
+					}
+				case constants.PenaltyTypeInterest:
+					// 2% of the 100000 unpaid dues, excluding the earlier penalty
+					if penalty.AmountCents != 2000 {
+						t.Errorf("expected interest 2000, got %d", penalty.AmountCents)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAccruedPenalties_InterestOnBalanceAtAccrual(t *testing.T) {
+	rule := &model.PenaltyRule{ID: uuid.New(), GraceDays: 5, MonthlyInterestBps: 200}
+	invoice := &model.Invoice{ID: uuid.New(), DueDate: date(2026, 3, 15), TotalCents: 150000, PaidCents: 150000}
+	allocations := []model.PaymentAllocation{
+		{InvoiceID: invoice.ID, AmountCents: 50000, CreatedAt: date(2026, 4, 10), AppliesFrom: date(2026, 4, 10)},
+		// a cheque paid before the May accrual but posted after it
+		{InvoiceID: invoice.ID, AmountCents: 100000, CreatedAt: date(2026, 5, 30), AppliesFrom: date(2026, 5, 18)},
+	}
+
+	// the job first runs after the invoice is settled and catches up
+	penalties := accruedPenalties(rule, invoice, allocations, date(2026, 6, 1))
+
+	expected := map[time.Time]int64{
+		date(2026, 3, 21): 3000,
+		date(2026, 4, 21): 2000,
+	}
+	if len(penalties) != len(expected) {
+		t.Fatalf("expected %d penalties, got %d", len(expected), len(penalties))
+	}
+	for _, penalty := range penalties {
+		if penalty.AmountCents != expected[penalty.PeriodKey] {
+			t.Errorf("expected interest %d on %s, got %d", expected[penalty.PeriodKey],
+				penalty.PeriodKey.Format(constants.DateFormat), penalty.AmountCents)
+		}
+	}
+}
+
+func TestPenaltyService_ApplyPenalties(t *testing.T) {
+	rule := model.PenaltyRule{ID: uuid.New(), FixedCents: 10000, EffectiveFrom: date(2026, 1, 1)}
+	invoices := []model.Invoice{
+		{ID: uuid.New(), DueDate: date(2026, 3, 15), TotalCents: 150000},
+		{ID: uuid.New(), DueDate: date(2025, 12, 15), TotalCents: 150000},
+	}
+
+	applied := []uuid.UUID{}
+	repo := &MockPenaltyRepository{
+		ListPenaltyRulesFn: func(ctx context.Context) ([]model.PenaltyRule, error) {
+			return []model.PenaltyRule{rule}, nil
+		},
+		ListOverdueInvoicesFn: func(ctx context.Context, asOf time.Time) ([]model.Invoice, error) {
+			if !asOf.Equal(date(2026, 4, 1)) {
+				t.Errorf("expected as of date 2026-04-01, got %s", asOf)
+			}
+			return invoices, nil
+		},
+		ApplyPenaltyFn: func(ctx context.Context, penalty *model.InvoicePenalty) (bool, error) {
+			applied = append(applied, penalty.InvoiceID)
+			return true, nil
+		},
+	}
+
+	service := NewPenaltyService(repo, &MockInvoiceRepository{})
+	if err := service.ApplyPenalties(context.Background(), time.Date(2026, 4, 1, 2, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the December invoice predates the only rule
+	if len(applied) != 1 || applied[0] != invoices[0].ID {
+		t.Errorf("expected a penalty on %s only, got %v", invoices[0].ID, applied)
+	}
+}
+
+func TestPenaltyService_ApplyPenaltiesOnLocalDate(t *testing.T) {
+	rule := model.PenaltyRule{ID: uuid.New(), FixedCents: 10000, EffectiveFrom: date(2026, 1, 1)}
+
+	var listedAsOf time.Time
+	repo := &MockPenaltyRepository{
+		ListPenaltyRulesFn: func(ctx context.Context) ([]model.PenaltyRule, error) {
+			return []model.PenaltyRule{rule}, nil
+		},
+		ListOverdueInvoicesFn: func(ctx context.Context, asOf time.Time) ([]model.Invoice, error) {
+			listedAsOf = asOf
+			return []model.Invoice{{ID: uuid.New(), DueDate: date(2026, 3, 31), TotalCents: 150000}}, nil
+		},
+		ApplyPenaltyFn: func(ctx context.Context, penalty *model.InvoicePenalty) (bool, error) {
+			return true, nil
+		},
+	}
+
+	// 16:30 UTC on March 31 is already April 1 in Manila
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{Timezone: "Asia/Manila"})
+	service := NewPenaltyService(repo, &MockInvoiceRepository{})
+	if err := service.ApplyPenalties(ctx, time.Date(2026, 3, 31, 16, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !listedAsOf.Equal(date(2026, 4, 1)) {
+		t.Errorf("expected as of date 2026-04-01, got %s", listedAsOf)
+	}
+}
+
+func TestPenaltyService_WaivePenalty(t *testing.T) {
+	waivedBy := uuid.New()
+
+	tests := []struct {
+		name        string
+		reason      string
+		status      string
+		getErr      error
+		expectedErr error
+	}{
+		{name: "success", reason: "first offense", status: constants.PenaltyStatusApplied},
+		{name: "blank reason", reason: "  ", status: constants.PenaltyStatusApplied, expectedErr: constants.ErrInvalidInput},
+		{name: "already waived", reason: "duplicate", status: constants.PenaltyStatusWaived, expectedErr: constants.ErrInvalidInput},
+		{name: "unknown penalty", reason: "typo", getErr: constants.ErrRecordNotFound, expectedErr: constants.ErrRecordNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			waived := false
+			repo := &MockPenaltyRepository{
+				GetPenaltyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.InvoicePenalty, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &model.InvoicePenalty{ID: id, Status: tc.status}, nil
+				},
+				WaivePenaltyFn: func(ctx context.Context, id uuid.UUID, reason string, by uuid.UUID) error {
+					waived = true
+					if reason != tc.reason || by != waivedBy {
+						t.Errorf("unexpected waiver %q by %s", reason, by)
+					}
+					return nil
+				},
+			}
+
+			service := NewPenaltyService(repo, &MockInvoiceRepository{})
+			err := service.WaivePenalty(context.Background(), uuid.New(), &WaivePenaltyRequest{Reason: tc.reason}, waivedBy)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if waived {
+					t.Error("expected no waiver")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !waived {
+				t.Error("expected the penalty to be waived")
+			}
+		})
+	}
+}
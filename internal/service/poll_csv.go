@@ -0,0 +1,64 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RenderResultsCSV writes the results as a spreadsheet-friendly summary,
+// the tally and, for open polls, the list of voters.
+func (s *PollServiceImpl) RenderResultsCSV(results *PollResultsResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	closedAt := ""
+	if results.ClosedAt != nil {
+		closedAt = results.ClosedAt.Format(time.RFC3339)
+	}
+	reached := "no"
+	if results.Turnout.Reached {
+		reached = "yes"
+	}
+	rows := [][]string{
+		{"Poll", csvCell(results.Title)},
+		{"Kind", results.Kind},
+		{"Threshold", results.Threshold},
+		{"Closed at", closedAt},
+		{"Eligible voters", strconv.Itoa(results.Turnout.EligibleVoters)},
+		{"Ballots cast", strconv.Itoa(results.Turnout.BallotsCast)},
+		{"Quorum", fmt.Sprintf("%d%% (%d ballots)", results.Turnout.QuorumPercent, results.Turnout.RequiredBallots)},
+		{"Quorum reached", reached},
+		{"Outcome", results.Outcome},
+		{},
+		{"Option", "Votes"},
+	}
+	for _, option := range results.Options {
+		rows = append(rows, []string{csvCell(option.Label), strconv.Itoa(option.Votes)})
+	}
+	rows = append(rows, []string{"Abstain", strconv.Itoa(results.Abstentions)})
+
+	if !results.Secret {
+		rows = append(rows, []string{}, []string{"Voter", "Choice", "Cast at"})
+		for _, voter := range results.Voters {
+			rows = append(rows, []string{csvCell(voter.Name), csvCell(voter.Choice), voter.CastAt.Format(time.RFC3339)})
+		}
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write poll results csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// csvCell keeps spreadsheets from evaluating user-entered text as a
+// formula.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,633 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+const maxPollOptions = 20
+
+type PollServiceImpl struct {
+	pollRepo      repository.PollRepository
+	occupancyRepo repository.OccupancyRepository
+	userRepo      repository.UserRepository
+	roleRepo      repository.RoleRepository
+}
+
+func NewPollService(pollRepo repository.PollRepository, occupancyRepo repository.OccupancyRepository, userRepo repository.UserRepository, roleRepo repository.RoleRepository) PollService {
+	return &PollServiceImpl{
+		pollRepo:      pollRepo,
+		occupancyRepo: occupancyRepo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+	}
+}
+
+func (s *PollServiceImpl) CreatePoll(ctx context.Context, createdBy uuid.UUID, req *PollRequest) (*PollDetailResponse, error) {
+	poll := &model.Poll{CreatedBy: &createdBy}
+	options, err := applyPollRequest(poll, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.pollRepo.CreatePoll(ctx, poll, options)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordExists) {
+			return nil, invalidInput("options must be unique")
+		}
+		return nil, err
+	}
+
+	return toPollDetailResponse(created, options, time.Now()), nil
+}
+
+func (s *PollServiceImpl) UpdatePoll(ctx context.Context, id uuid.UUID, req *PollRequest) (*PollDetailResponse, error) {
+	poll, err := s.pollRepo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Status != constants.PollDraft {
+		return nil, invalidInput("only draft polls can be changed")
+	}
+	options, err := applyPollRequest(poll, req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.pollRepo.UpdatePoll(ctx, poll, options)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordExists) {
+			return nil, invalidInput("options must be unique")
+		}
+		return nil, err
+	}
+
+	return toPollDetailResponse(updated, options, time.Now()), nil
+}
+
+// PublishPoll makes a draft visible to members. Voting opens on its own
+// once the window starts.
+func (s *PollServiceImpl) PublishPoll(ctx context.Context, id uuid.UUID) (*PollResponse, error) {
+	poll, err := s.pollRepo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Status != constants.PollDraft {
+		return nil, invalidInput("poll is already %s", poll.Status)
+	}
+	if !poll.ClosesAt.After(time.Now()) {
+		return nil, invalidInput("the voting window has already ended")
+	}
+
+	if err := s.pollRepo.ChangePollStatus(ctx, poll.ID, constants.PollDraft, constants.PollPublished); err != nil {
+		return nil, err
+	}
+	poll.Status = constants.PollPublished
+
+	return toPollResponse(poll, time.Now()), nil
+}
+
+func (s *PollServiceImpl) CancelPoll(ctx context.Context, id uuid.UUID) (*PollResponse, error) {
+	poll, err := s.pollRepo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch pollPhase(poll, time.Now()) {
+	case constants.PollPhaseDraft, constants.PollPhaseUpcoming, constants.PollPhaseOpen:
+	default:
+		return nil, invalidInput("poll is already %s", pollPhase(poll, time.Now()))
+	}
+
+	if err := s.pollRepo.ChangePollStatus(ctx, poll.ID, poll.Status, constants.PollCancelled); err != nil {
+		return nil, err
+	}
+	poll.Status = constants.PollCancelled
+
+	return toPollResponse(poll, time.Now()), nil
+}
+
+// ClosePoll ends voting now, even if the window is still open, and
+// returns the final results.
+func (s *PollServiceImpl) ClosePoll(ctx context.Context, id uuid.UUID) (*PollResultsResponse, error) {
+	poll, err := s.pollRepo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch pollPhase(poll, time.Now()) {
+	case constants.PollPhaseOpen, constants.PollPhaseClosed:
+	default:
+		return nil, invalidInput("a poll that is %s cannot be closed", pollPhase(poll, time.Now()))
+	}
+
+	poll, err = s.finalize(ctx, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.results(ctx, poll)
+}
+
+func (s *PollServiceImpl) ListAllPolls(ctx context.Context, req *ListPollsRequest) ([]PollResponse, error) {
+	return s.listPolls(ctx, req, true)
+}
+
+func (s *PollServiceImpl) ListPolls(ctx context.Context, req *ListPollsRequest) ([]PollResponse, error) {
+	return s.listPolls(ctx, req, false)
+}
+
+// GetPoll returns the poll with its options, turnout so far and whether
+// the user has voted or still can.
+func (s *PollServiceImpl) GetPoll(ctx context.Context, userID, id uuid.UUID) (*PollDetailResponse, error) {
+	poll, err := s.visiblePoll(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	options, err := s.pollRepo.ListPollOptions(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toPollDetailResponse(poll, options, time.Now())
+	if poll.Status == constants.PollDraft {
+		return resp, nil
+	}
+
+	turnout, err := s.turnout(ctx, poll)
+	if err != nil {
+		return nil, err
+	}
+	resp.Turnout = turnout
+
+	if _, err := s.pollRepo.GetPollBallot(ctx, poll.ID, userID); err == nil {
+		resp.Voted = true
+		return resp, nil
+	} else if !errors.Is(err, constants.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	reason, err := s.ineligibleReason(ctx, poll, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		resp.CanVote = true
+	} else {
+		resp.Reason = &reason
+	}
+
+	return resp, nil
+}
+
+func (s *PollServiceImpl) CastVote(ctx context.Context, userID, id uuid.UUID, req *PollVoteRequest) (*PollVoteReceiptResponse, error) {
+	poll, err := s.visiblePoll(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var optionID *uuid.UUID
+	switch {
+	case req.Abstain && req.OptionID != nil:
+		return nil, invalidInput("choose an option or abstain, not both")
+	case !req.Abstain && req.OptionID == nil:
+		return nil, invalidInput("optionId is required unless abstaining")
+	case req.OptionID != nil:
+		parsed, err := uuid.Parse(*req.OptionID)
+		if err != nil {
+			return nil, invalidInput("option id must be a uuid")
+		}
+		options, err := s.pollRepo.ListPollOptions(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+		if findPollOption(options, parsed) == nil {
+			return nil, invalidInput("option %s is not on this poll", parsed)
+		}
+		optionID = &parsed
+	}
+
+	reason, err := s.ineligibleReason(ctx, poll, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, invalidInput("%s", reason)
+	}
+
+	ballot := &model.PollBallot{
+		PollID: poll.ID,
+		UserID: userID,
+	}
+	if err := s.pollRepo.CastPollVote(ctx, ballot, optionID, poll.Secret); err != nil {
+		return nil, err
+	}
+
+	return &PollVoteReceiptResponse{
+		PollID:    poll.ID.String(),
+		Abstained: optionID == nil,
+		CastAt:    ballot.CastAt,
+	}, nil
+}
+
+// GetResults returns the tally once voting has closed. A poll whose window
+// ran out is finalized on first read, freezing its eligible voter count.
+func (s *PollServiceImpl) GetResults(ctx context.Context, userID, id uuid.UUID) (*PollResultsResponse, error) {
+	poll, err := s.visiblePoll(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if phase := pollPhase(poll, time.Now()); phase != constants.PollPhaseClosed {
+		return nil, invalidInput("results are only available after voting closes (poll is %s)", phase)
+	}
+
+	if poll.Status == constants.PollPublished {
+		poll, err = s.finalize(ctx, poll)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.results(ctx, poll)
+}
+
+func (s *PollServiceImpl) listPolls(ctx context.Context, req *ListPollsRequest, includeDrafts bool) ([]PollResponse, error) {
+	polls, err := s.pollRepo.ListPolls(ctx, repository.PollFilter{
+		IncludeDrafts: includeDrafts,
+		Limit:         pageSize(req.Limit),
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := make([]PollResponse, 0, len(polls))
+	for i := range polls {
+		resp = append(resp, *toPollResponse(&polls[i], now))
+	}
+
+	return resp, nil
+}
+
+// visiblePoll hides drafts from members who cannot manage polls.
+func (s *PollServiceImpl) visiblePoll(ctx context.Context, userID, id uuid.UUID) (*model.Poll, error) {
+	poll, err := s.pollRepo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Status != constants.PollDraft {
+		return poll, nil
+	}
+
+	manager, err := s.roleRepo.UserHasPermission(ctx, userID, constants.PermManagePolls)
+	if err != nil {
+		return nil, err
+	}
+	if !manager {
+		return nil, constants.ErrRecordNotFound
+	}
+	return poll, nil
+}
+
+// finalize closes a published poll and freezes its eligible voter count.
+// When the window already ran out, the count is taken as of its end.
+func (s *PollServiceImpl) finalize(ctx context.Context, poll *model.Poll) (*model.Poll, error) {
+	closedAt := time.Now()
+	if poll.ClosesAt.Before(closedAt) {
+		closedAt = poll.ClosesAt
+	}
+
+	turnout, err := s.pollRepo.GetPollTurnout(ctx, poll.ID, poll.Audience, closedAt)
+	if err != nil {
+		return nil, err
+	}
+	// a concurrent request may have closed it first; either way reload
+	if err := s.pollRepo.ClosePoll(ctx, poll.ID, turnout.EligibleVoters, closedAt); err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	closed, err := s.pollRepo.GetPollByID(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	if closed.Status != constants.PollClosed {
+		return nil, invalidInput("poll is %s", closed.Status)
+	}
+	return closed, nil
+}
+
+// ineligibleReason explains why the user cannot vote on the poll, or
+// returns "" when they can.
+func (s *PollServiceImpl) ineligibleReason(ctx context.Context, poll *model.Poll, userID uuid.UUID) (string, error) {
+	if phase := pollPhase(poll, time.Now()); phase != constants.PollPhaseOpen {
+		return "voting is " + phase, nil
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.Status != constants.ActiveStatus {
+		return "account is not active", nil
+	}
+
+	if poll.Audience == constants.PollAudienceOwners {
+		properties, err := s.occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+		if err != nil {
+			return "", err
+		}
+		for _, property := range properties {
+			if property.ArchivedAt == nil && (property.OccupancyType == constants.OccupancyOwner || property.OccupancyType == constants.OccupancyCoOwner) {
+				return "", nil
+			}
+		}
+		return "only property owners can vote on this poll", nil
+	}
+
+	roles, err := s.roleRepo.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roles {
+		if role.Name == constants.RoleMember {
+			return "", nil
+		}
+	}
+	return "only members can vote on this poll", nil
+}
+
+func (s *PollServiceImpl) turnout(ctx context.Context, poll *model.Poll) (*PollTurnoutResponse, error) {
+	asOf := today(ctx)
+	if !time.Now().Before(poll.ClosesAt) {
+		asOf = poll.ClosesAt
+	}
+
+	turnout, err := s.pollRepo.GetPollTurnout(ctx, poll.ID, poll.Audience, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if poll.EligibleVoters != nil {
+		turnout.EligibleVoters = *poll.EligibleVoters
+	}
+
+	return toPollTurnoutResponse(turnout, poll.QuorumPercent), nil
+}
+
+func (s *PollServiceImpl) results(ctx context.Context, poll *model.Poll) (*PollResultsResponse, error) {
+	turnout, err := s.turnout(ctx, poll)
+	if err != nil {
+		return nil, err
+	}
+	options, err := s.pollRepo.ListPollOptions(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	tallies, err := s.pollRepo.TallyPollVotes(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := pollResult(poll, options, tallies, *turnout)
+	if poll.Secret {
+		return resp, nil
+	}
+
+	voters, err := s.pollRepo.ListPollVoters(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Voters = make([]PollVoterResponse, 0, len(voters))
+	for _, voter := range voters {
+		entry := PollVoterResponse{
+			UserID: voter.UserID.String(),
+			Name:   strings.TrimSpace(voter.FirstName + " " + voter.LastName),
+			Choice: "Abstain",
+			CastAt: voter.CastAt,
+		}
+		if voter.OptionID != nil {
+			if option := findPollOption(options, *voter.OptionID); option != nil {
+				entry.Choice = option.Label
+			}
+		}
+		resp.Voters = append(resp.Voters, entry)
+	}
+
+	return resp, nil
+}
+
+// pollResult tallies the options and decides the outcome. Abstentions
+// count towards the quorum but not towards the threshold. A yes/no poll
+// passes when Yes clears the threshold; a multiple choice poll is decided
+// when a single leading option does.
+func pollResult(poll *model.Poll, options []model.PollOption, tallies []model.PollTally, turnout PollTurnoutResponse) *PollResultsResponse {
+	votes := make(map[uuid.UUID]int, len(tallies))
+	abstentions := 0
+	for _, tally := range tallies {
+		if tally.OptionID == nil {
+			abstentions += tally.Votes
+			continue
+		}
+		votes[*tally.OptionID] = tally.Votes
+	}
+
+	resp := &PollResultsResponse{
+		PollID:      poll.ID.String(),
+		Title:       poll.Title,
+		Kind:        poll.Kind,
+		Threshold:   poll.Threshold,
+		Secret:      poll.Secret,
+		Status:      poll.Status,
+		ClosedAt:    poll.ClosedAt,
+		Turnout:     turnout,
+		Abstentions: abstentions,
+		Options:     make([]PollOptionResultResponse, 0, len(options)),
+	}
+
+	var leader *model.PollOption
+	tiedLead := false
+	for i := range options {
+		option := &options[i]
+		count := votes[option.ID]
+		resp.VotesCast += count
+		resp.Options = append(resp.Options, PollOptionResultResponse{
+			OptionID: option.ID.String(),
+			Label:    option.Label,
+			Votes:    count,
+		})
+
+		switch {
+		case leader == nil || count > votes[leader.ID]:
+			leader = option
+			tiedLead = false
+		case count == votes[leader.ID]:
+			tiedLead = true
+		}
+	}
+
+	if !turnout.Reached {
+		resp.Outcome = constants.PollOutcomeNoQuorum
+		return resp
+	}
+
+	if poll.Kind == constants.PollYesNo {
+		resp.Outcome = constants.PollOutcomeRejected
+		for i := range options {
+			if options[i].Label == constants.PollOptionYes && meetsThreshold(votes[options[i].ID], resp.VotesCast, poll.Threshold) {
+				resp.Outcome = constants.PollOutcomePassed
+				winner := options[i].ID.String()
+				resp.WinningOptionID = &winner
+			}
+		}
+		return resp
+	}
+
+	resp.Outcome = constants.PollOutcomeUndecided
+	if leader != nil && !tiedLead && meetsThreshold(votes[leader.ID], resp.VotesCast, poll.Threshold) {
+		resp.Outcome = constants.PollOutcomeDecided
+		winner := leader.ID.String()
+		resp.WinningOptionID = &winner
+	}
+
+	return resp
+}
+
+func meetsThreshold(votes, cast int, threshold string) bool {
+	if cast == 0 {
+		return false
+	}
+	if threshold == constants.PollTwoThirds {
+		return votes*3 >= cast*2
+	}
+	return votes*2 > cast
+}
+
+func applyPollRequest(poll *model.Poll, req *PollRequest) ([]model.PollOption, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, invalidInput("title is required")
+	}
+	if !req.ClosesAt.After(req.OpensAt) {
+		return nil, invalidInput("closesAt must be after opensAt")
+	}
+
+	options := []model.PollOption{}
+	if req.Kind == constants.PollYesNo {
+		if len(req.Options) > 0 {
+			return nil, invalidInput("yes/no polls do not take options")
+		}
+		options = append(options,
+			model.PollOption{Label: constants.PollOptionYes, SortOrder: 0},
+			model.PollOption{Label: constants.PollOptionNo, SortOrder: 1},
+		)
+	} else {
+		if len(req.Options) < 2 || len(req.Options) > maxPollOptions {
+			return nil, invalidInput("multiple choice polls need between 2 and %d options", maxPollOptions)
+		}
+		for i, value := range req.Options {
+			label := strings.TrimSpace(value)
+			if label == "" {
+				return nil, invalidInput("options cannot be blank")
+			}
+			options = append(options, model.PollOption{Label: label, SortOrder: i})
+		}
+	}
+
+	poll.Title = title
+	poll.Description = trimmedOrNil(req.Description)
+	poll.Kind = req.Kind
+	poll.Audience = req.Audience
+	poll.Threshold = req.Threshold
+	poll.QuorumPercent = req.QuorumPercent
+	poll.Secret = req.Secret
+	poll.OpensAt = req.OpensAt
+	poll.ClosesAt = req.ClosesAt
+
+	return options, nil
+}
+
+func pollPhase(poll *model.Poll, now time.Time) string {
+	switch poll.Status {
+	case constants.PollDraft:
+		return constants.PollPhaseDraft
+	case constants.PollCancelled:
+		return constants.PollPhaseCancelled
+	case constants.PollClosed:
+		return constants.PollPhaseClosed
+	}
+
+	switch {
+	case now.Before(poll.OpensAt):
+		return constants.PollPhaseUpcoming
+	case now.Before(poll.ClosesAt):
+		return constants.PollPhaseOpen
+	default:
+		return constants.PollPhaseClosed
+	}
+}
+
+func findPollOption(options []model.PollOption, id uuid.UUID) *model.PollOption {
+	for i := range options {
+		if options[i].ID == id {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+func toPollResponse(poll *model.Poll, now time.Time) *PollResponse {
+	resp := &PollResponse{
+		ID:            poll.ID.String(),
+		Title:         poll.Title,
+		Description:   poll.Description,
+		Kind:          poll.Kind,
+		Audience:      poll.Audience,
+		Threshold:     poll.Threshold,
+		QuorumPercent: poll.QuorumPercent,
+		Secret:        poll.Secret,
+		OpensAt:       poll.OpensAt,
+		ClosesAt:      poll.ClosesAt,
+		Status:        poll.Status,
+		Phase:         pollPhase(poll, now),
+		ClosedAt:      poll.ClosedAt,
+		CreatedAt:     poll.CreatedAt,
+		UpdatedAt:     poll.UpdatedAt,
+	}
+	if poll.CreatedBy != nil {
+		createdBy := poll.CreatedBy.String()
+		resp.CreatedBy = &createdBy
+	}
+	return resp
+}
+
+func toPollDetailResponse(poll *model.Poll, options []model.PollOption, now time.Time) *PollDetailResponse {
+	resp := &PollDetailResponse{
+		PollResponse: *toPollResponse(poll, now),
+		Options:      make([]PollOptionResponse, 0, len(options)),
+	}
+	for _, option := range options {
+		resp.Options = append(resp.Options, PollOptionResponse{
+			ID:        option.ID.String(),
+			Label:     option.Label,
+			SortOrder: option.SortOrder,
+		})
+	}
+	return resp
+}
+
+func toPollTurnoutResponse(turnout *model.PollTurnout, percent int) *PollTurnoutResponse {
+	// round up: a 50% quorum of 7 voters needs 4 ballots
+	required := (turnout.EligibleVoters*percent + 99) / 100
+	return &PollTurnoutResponse{
+		EligibleVoters:  turnout.EligibleVoters,
+		BallotsCast:     turnout.BallotsCast,
+		QuorumPercent:   percent,
+		RequiredBallots: required,
+		Reached:         turnout.EligibleVoters > 0 && turnout.BallotsCast >= required,
+	}
+}
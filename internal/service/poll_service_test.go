@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockPollRepository struct {
+	CreatePollFn       func(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error)
+	GetPollByIDFn      func(ctx context.Context, id uuid.UUID) (*model.Poll, error)
+	ListPollsFn        func(ctx context.Context, filter repository.PollFilter) ([]model.Poll, error)
+	UpdatePollFn       func(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error)
+	ChangePollStatusFn func(ctx context.Context, id uuid.UUID, from, to string) error
+	ClosePollFn        func(ctx context.Context, id uuid.UUID, eligibleVoters int, closedAt time.Time) error
+	ListPollOptionsFn  func(ctx context.Context, pollID uuid.UUID) ([]model.PollOption, error)
+	CastPollVoteFn     func(ctx context.Context, ballot *model.PollBallot, optionID *uuid.UUID, secret bool) error
+	GetPollBallotFn    func(ctx context.Context, pollID, userID uuid.UUID) (*model.PollBallot, error)
+	GetPollTurnoutFn   func(ctx context.Context, pollID uuid.UUID, audience string, asOf time.Time) (*model.PollTurnout, error)
+	TallyPollVotesFn   func(ctx context.Context, pollID uuid.UUID) ([]model.PollTally, error)
+	ListPollVotersFn   func(ctx context.Context, pollID uuid.UUID) ([]model.PollVoter, error)
+}
+
+func (m *MockPollRepository) CreatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error) {
+	return m.CreatePollFn(ctx, poll, options)
+}
+
+func (m *MockPollRepository) GetPollByID(ctx context.Context, id uuid.UUID) (*model.Poll, error) {
+	return m.GetPollByIDFn(ctx, id)
+}
+
+func (m *MockPollRepository) ListPolls(ctx context.Context, filter repository.PollFilter) ([]model.Poll, error) {
+	return m.ListPollsFn(ctx, filter)
+}
+
+func (m *MockPollRepository) UpdatePoll(ctx context.Context, poll *model.Poll, options []model.PollOption) (*model.Poll, error) {
+	return m.UpdatePollFn(ctx, poll, options)
+}
+
+func (m *MockPollRepository) ChangePollStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	return m.ChangePollStatusFn(ctx, id, from, to)
+}
+
+func (m *MockPollRepository) ClosePoll(ctx context.Context, id uuid.UUID, eligibleVoters int, closedAt time.Time) error {
+	return m.ClosePollFn(ctx, id, eligibleVoters, closedAt)
+}
+
+func (m *MockPollRepository) ListPollOptions(ctx context.Context, pollID uuid.UUID) ([]model.PollOption, error) {
+	return m.ListPollOptionsFn(ctx, pollID)
+}
+
+func (m *MockPollRepository) CastPollVote(ctx context.Context, ballot *model.PollBallot, optionID *uuid.UUID, secret bool) error {
+	return m.CastPollVoteFn(ctx, ballot, optionID, secret)
+}
+
+func (m *MockPollRepository) GetPollBallot(ctx context.Context, pollID, userID uuid.UUID) (*model.PollBallot, error) {
+	return m.GetPollBallotFn(ctx, pollID, userID)
+}
+
+func (m *MockPollRepository) GetPollTurnout(ctx context.Context, pollID uuid.UUID, audience string, asOf time.Time) (*model.PollTurnout, error) {
+	return m.GetPollTurnoutFn(ctx, pollID, audience, asOf)
+}
+
+func (m *MockPollRepository) TallyPollVotes(ctx context.Context, pollID uuid.UUID) ([]model.PollTally, error) {
+	return m.TallyPollVotesFn(ctx, pollID)
+}
+
+func (m *MockPollRepository) ListPollVoters(ctx context.Context, pollID uuid.UUID) ([]model.PollVoter, error) {
+	return m.ListPollVotersFn(ctx, pollID)
+}
+
+func TestPollResult(t *testing.T) {
+	yes, no := uuid.New(), uuid.New()
+	yesNo := []model.PollOption{
+		{ID: yes, Label: constants.PollOptionYes, SortOrder: 0},
+		{ID: no, Label: constants.PollOptionNo, SortOrder: 1},
+	}
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	choices := []model.PollOption{
+		{ID: a, Label: "Repaint the clubhouse"},
+		{ID: b, Label: "Resurface the court"},
+		{ID: c, Label: "Do nothing"},
+	}
+	reached := PollTurnoutResponse{EligibleVoters: 20, BallotsCast: 15, QuorumPercent: 50, RequiredBallots: 10, Reached: true}
+
+	tests := []struct {
+		name            string
+		kind            string
+		threshold       string
+		options         []model.PollOption
+		tallies         []model.PollTally
+		turnout         PollTurnoutResponse
+		expectedOutcome string
+		expectedWinner  *uuid.UUID
+	}{
+		{
+			name:            "simple majority passes",
+			kind:            constants.PollYesNo,
+			threshold:       constants.PollSimpleMajority,
+			options:         yesNo,
+			tallies:         []model.PollTally{{OptionID: &yes, Votes: 8}, {OptionID: &no, Votes: 7}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomePassed,
+			expectedWinner:  &yes,
+		},
+		{
+			name:            "even split is not a majority",
+			kind:            constants.PollYesNo,
+			threshold:       constants.PollSimpleMajority,
+			options:         yesNo,
+			tallies:         []model.PollTally{{OptionID: &yes, Votes: 7}, {OptionID: &no, Votes: 7}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomeRejected,
+		},
+		{
+			name:            "abstentions do not count against the threshold",
+			kind:            constants.PollYesNo,
+			threshold:       constants.PollTwoThirds,
+			options:         yesNo,
+			tallies:         []model.PollTally{{OptionID: &yes, Votes: 6}, {OptionID: &no, Votes: 3}, {Votes: 6}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomePassed,
+			expectedWinner:  &yes,
+		},
+		{
+			name:            "short of two thirds",
+			kind:            constants.PollYesNo,
+			threshold:       constants.PollTwoThirds,
+			options:         yesNo,
+			tallies:         []model.PollTally{{OptionID: &yes, Votes: 9}, {OptionID: &no, Votes: 6}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomeRejected,
+		},
+		{
+			name:            "no quorum",
+			kind:            constants.PollYesNo,
+			threshold:       constants.PollSimpleMajority,
+			options:         yesNo,
+			tallies:         []model.PollTally{{OptionID: &yes, Votes: 5}},
+			turnout:         PollTurnoutResponse{EligibleVoters: 20, BallotsCast: 5, QuorumPercent: 50, RequiredBallots: 10},
+			expectedOutcome: constants.PollOutcomeNoQuorum,
+		},
+		{
+			name:            "multiple choice decided",
+			kind:            constants.PollMultipleChoice,
+			threshold:       constants.PollSimpleMajority,
+			options:         choices,
+			tallies:         []model.PollTally{{OptionID: &a, Votes: 3}, {OptionID: &b, Votes: 9}, {OptionID: &c, Votes: 3}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomeDecided,
+			expectedWinner:  &b,
+		},
+		{
+			name:            "multiple choice plurality is not a majority",
+			kind:            constants.PollMultipleChoice,
+			threshold:       constants.PollSimpleMajority,
+			options:         choices,
+			tallies:         []model.PollTally{{OptionID: &a, Votes: 5}, {OptionID: &b, Votes: 6}, {OptionID: &c, Votes: 4}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomeUndecided,
+		},
+		{
+			name:            "multiple choice tied lead",
+			kind:            constants.PollMultipleChoice,
+			threshold:       constants.PollSimpleMajority,
+			options:         choices[:2],
+			tallies:         []model.PollTally{{OptionID: &a, Votes: 5}, {OptionID: &b, Votes: 5}, {Votes: 5}},
+			turnout:         reached,
+			expectedOutcome: constants.PollOutcomeUndecided,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &model.Poll{ID: uuid.New(), Kind: tt.kind, Threshold: tt.threshold, Status: constants.PollClosed}
+			resp := pollResult(poll, tt.options, tt.tallies, tt.turnout)
+
+			if resp.Outcome != tt.expectedOutcome {
+				t.Errorf("expected outcome %s, got %s", tt.expectedOutcome, resp.Outcome)
+			}
+			switch {
+			case tt.expectedWinner == nil && resp.WinningOptionID != nil:
+				t.Errorf("expected no winner, got %s", *resp.WinningOptionID)
+			case tt.expectedWinner != nil && (resp.WinningOptionID == nil || *resp.WinningOptionID != tt.expectedWinner.String()):
+				t.Errorf("expected winner %s, got %v", tt.expectedWinner, resp.WinningOptionID)
+			}
+		})
+	}
+}
+
+func TestPollService_CastVote(t *testing.T) {
+	userID := uuid.New()
+	yes, no := uuid.New(), uuid.New()
+	yesID, otherID := yes.String(), uuid.New().String()
+
+	tests := []struct {
+		name           string
+		audience       string
+		secret         bool
+		opensIn        time.Duration
+		userStatus     string
+		roles          []model.Role
+		occupancyType  string
+		req            *PollVoteRequest
+		castErr        error
+		expectedOption *uuid.UUID
+		expectedErr    error
+	}{
+		{
+			name:           "member votes",
+			audience:       constants.PollAudienceMembers,
+			roles:          []model.Role{{Name: constants.RoleMember}},
+			req:            &PollVoteRequest{OptionID: &yesID},
+			expectedOption: &yes,
+		},
+		{
+			name:     "member abstains on a secret poll",
+			audience: constants.PollAudienceMembers,
+			secret:   true,
+			roles:    []model.Role{{Name: constants.RoleMember}},
+			req:      &PollVoteRequest{Abstain: true},
+		},
+		{
+			name:        "guard is not a member",
+			audience:    constants.PollAudienceMembers,
+			roles:       []model.Role{{Name: constants.RoleGuard}},
+			req:         &PollVoteRequest{OptionID: &yesID},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "inactive account",
+			audience:    constants.PollAudienceMembers,
+			userStatus:  "suspended",
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{OptionID: &yesID},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:           "owner votes on an owners-only poll",
+			audience:       constants.PollAudienceOwners,
+			occupancyType:  constants.OccupancyCoOwner,
+			req:            &PollVoteRequest{OptionID: &yesID},
+			expectedOption: &yes,
+		},
+		{
+			name:          "tenant on an owners-only poll",
+			audience:      constants.PollAudienceOwners,
+			occupancyType: constants.OccupancyTenant,
+			req:           &PollVoteRequest{OptionID: &yesID},
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:        "option and abstain together",
+			audience:    constants.PollAudienceMembers,
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{OptionID: &yesID, Abstain: true},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "neither option nor abstain",
+			audience:    constants.PollAudienceMembers,
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "option from another poll",
+			audience:    constants.PollAudienceMembers,
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{OptionID: &otherID},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "not open yet",
+			audience:    constants.PollAudienceMembers,
+			opensIn:     time.Hour,
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{OptionID: &yesID},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "already voted",
+			audience:    constants.PollAudienceMembers,
+			roles:       []model.Role{{Name: constants.RoleMember}},
+			req:         &PollVoteRequest{OptionID: &yesID},
+			castErr:     constants.ErrRecordExists,
+			expectedErr: constants.ErrRecordExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opensIn := tt.opensIn
+			if opensIn == 0 {
+				opensIn = -time.Hour
+			}
+			poll := &model.Poll{
+				ID:            uuid.New(),
+				Title:         "Adopt the revised pet policy",
+				Kind:          constants.PollYesNo,
+				Audience:      tt.audience,
+				Threshold:     constants.PollSimpleMajority,
+				QuorumPercent: 25,
+				Secret:        tt.secret,
+				OpensAt:       time.Now().Add(opensIn),
+				ClosesAt:      time.Now().Add(opensIn + 2*time.Hour),
+				Status:        constants.PollPublished,
+			}
+			status := tt.userStatus
+			if status == "" {
+				status = constants.ActiveStatus
+			}
+
+			var castOption *uuid.UUID
+			var castSecret bool
+			pollRepo := &MockPollRepository{
+				GetPollByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Poll, error) {
+					return poll, nil
+				},
+				ListPollOptionsFn: func(ctx context.Context, pollID uuid.UUID) ([]model.PollOption, error) {
+					return []model.PollOption{{ID: yes, Label: constants.PollOptionYes}, {ID: no, Label: constants.PollOptionNo, SortOrder: 1}}, nil
+				},
+				CastPollVoteFn: func(ctx context.Context, ballot *model.PollBallot, optionID *uuid.UUID, secret bool) error {
+					castOption = optionID
+					castSecret = secret
+					ballot.CastAt = time.Now()
+					return tt.castErr
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					if tt.occupancyType == "" {
+						return []model.UserProperty{}, nil
+					}
+					return []model.UserProperty{{Property: model.Property{ID: uuid.New()}, OccupancyType: tt.occupancyType}}, nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return &model.User{ID: id, Status: status}, nil
+				},
+			}
+			roleRepo := &MockRoleRepository{
+				GetRolesByUserIDFn: func(ctx context.Context, userID uuid.UUID) ([]model.Role, error) {
+					return tt.roles, nil
+				},
+			}
+
+			service := NewPollService(pollRepo, occupancyRepo, userRepo, roleRepo)
+			resp, err := service.CastVote(context.Background(), userID, poll.ID, tt.req)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if (castOption == nil) != (tt.expectedOption == nil) || (castOption != nil && *castOption != *tt.expectedOption) {
+				t.Errorf("expected option %v, got %v", tt.expectedOption, castOption)
+			}
+			if resp.Abstained != (tt.expectedOption == nil) {
+				t.Errorf("expected abstained %v, got %v", tt.expectedOption == nil, resp.Abstained)
+			}
+			if castSecret != tt.secret {
+				t.Errorf("expected secret %v, got %v", tt.secret, castSecret)
+			}
+		})
+	}
+}
+
+func TestPollService_RenderResultsCSV(t *testing.T) {
+	results := &PollResultsResponse{
+		PollID:    uuid.New().String(),
+		Title:     "=HYPERLINK(\"http://example.com\")",
+		Kind:      constants.PollYesNo,
+		Threshold: constants.PollSimpleMajority,
+		Turnout:   PollTurnoutResponse{EligibleVoters: 4, BallotsCast: 3, QuorumPercent: 50, RequiredBallots: 2, Reached: true},
+		Options: []PollOptionResultResponse{
+			{Label: constants.PollOptionYes, Votes: 2},
+			{Label: constants.PollOptionNo, Votes: 0},
+		},
+		Abstentions: 1,
+		Outcome:     constants.PollOutcomePassed,
+		Voters: []PollVoterResponse{
+			{Name: "Juan Dela Cruz", Choice: constants.PollOptionYes, CastAt: time.Now()},
+		},
+	}
+
+	service := NewPollService(&MockPollRepository{}, &MockOccupancyRepository{}, &MockUserRepository{}, &MockRoleRepository{})
+	document, err := service.RenderResultsCSV(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(document)
+	for _, want := range []string{"'=HYPERLINK", "Yes,2", "Abstain,1", "Outcome,passed", "Juan Dela Cruz,Yes"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected csv to contain %q:\n%s", want, out)
+		}
+	}
+
+	results.Secret = true
+	document, err = service.RenderResultsCSV(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(document), "Voter") {
+		t.Errorf("secret poll export should not list voters:\n%s", document)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+type PropertyServiceImpl struct {
+	propertyRepo repository.PropertyRepository
+	userRepo     repository.UserRepository
+}
+
+func NewPropertyService(propertyRepo repository.PropertyRepository, userRepo repository.UserRepository) PropertyService {
+	return &PropertyServiceImpl{
+		propertyRepo: propertyRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *PropertyServiceImpl) CreateProperty(ctx context.Context, req *PropertyRequest) (*PropertyResponse, error) {
+	property, err := s.propertyFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.propertyRepo.CreateProperty(ctx, property)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPropertyResponse(created), nil
+}
+
+func (s *PropertyServiceImpl) GetProperty(ctx context.Context, id uuid.UUID) (*PropertyResponse, error) {
+	property, err := s.propertyRepo.GetPropertyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPropertyResponse(property), nil
+}
+
+func (s *PropertyServiceImpl) ListProperties(ctx context.Context, req *ListPropertiesRequest) ([]PropertyResponse, error) {
+	filter := repository.PropertyFilter{
+		Phase:           strings.TrimSpace(req.Phase),
+		Block:           strings.TrimSpace(req.Block),
+		Road:            strings.TrimSpace(req.Road),
+		Type:            strings.TrimSpace(req.Type),
+		IncludeArchived: req.IncludeArchived,
+		Limit:           pageSize(req.Limit),
+		Offset:          req.Offset,
+	}
+	if req.OwnerID != "" {
+		ownerID, err := uuid.Parse(req.OwnerID)
+		if err != nil {
+			return nil, invalidInput("owner id must be a uuid")
+		}
+		filter.OwnerID = &ownerID
+	}
+
+	properties, err := s.propertyRepo.ListProperties(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]PropertyResponse, 0, len(properties))
+	for i := range properties {
+		resp = append(resp, *toPropertyResponse(&properties[i]))
+	}
+
+	return resp, nil
+}
+
+// UpdateProperty edits the registry entry only. Owners are changed through
+// OccupancyService.TransferOwnership so the ownership history is kept.
+func (s *PropertyServiceImpl) UpdateProperty(ctx context.Context, id uuid.UUID, req *PropertyRequest) (*PropertyResponse, error) {
+	existing, err := s.propertyRepo.GetPropertyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.propertyFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.OwnerID != nil && !sameOwner(existing.OwnerID, property.OwnerID) {
+		return nil, invalidInput("owners can only be changed through an ownership transfer")
+	}
+	property.ID = id
+	property.OwnerID = existing.OwnerID
+
+	updated, err := s.propertyRepo.UpdateProperty(ctx, property)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProperty(ctx, updated.ID)
+}
+
+func (s *PropertyServiceImpl) ArchiveProperty(ctx context.Context, id uuid.UUID) error {
+	return s.propertyRepo.ArchiveProperty(ctx, id)
+}
+
+func (s *PropertyServiceImpl) propertyFromRequest(ctx context.Context, req *PropertyRequest) (*model.Property, error) {
+	property := &model.Property{
+		Block:      strings.TrimSpace(req.Block),
+		Lot:        strings.TrimSpace(req.Lot),
+		Phase:      strings.TrimSpace(req.Phase),
+		Road:       req.Road,
+		Type:       req.Type,
+		LotAreaSqm: req.LotAreaSqm,
+	}
+
+	if req.OwnerID != nil && *req.OwnerID != "" {
+		ownerID, err := uuid.Parse(*req.OwnerID)
+		if err != nil {
+			return nil, invalidInput("owner id must be a uuid")
+		}
+		if _, err := s.userRepo.GetUserByID(ctx, ownerID); err != nil {
+			return nil, err
+		}
+		property.OwnerID = &ownerID
+	}
+
+	return property, nil
+}
+
+func sameOwner(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func toPropertyResponse(property *model.Property) *PropertyResponse {
+	resp := &PropertyResponse{
+		ID:         property.ID.String(),
+		Block:      property.Block,
+		Lot:        property.Lot,
+		Road:       property.Road,
+		Phase:      property.Phase,
+		Type:       property.Type,
+		LotAreaSqm: property.LotAreaSqm,
+		ArchivedAt: property.ArchivedAt,
+		CreatedAt:  property.CreatedAt,
+		UpdatedAt:  property.UpdatedAt,
+	}
+	if property.OwnerID != nil {
+		ownerID := property.OwnerID.String()
+		resp.OwnerID = &ownerID
+	}
+
+	return resp
+}
+
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockPropertyRepository struct {
+	CreatePropertyFn       func(ctx context.Context, property *model.Property) (*model.Property, error)
+	GetPropertyByIDFn      func(ctx context.Context, id uuid.UUID) (*model.Property, error)
+	GetPropertyByAddressFn func(ctx context.Context, phase, block, lot string) (*model.Property, error)
+	ListPropertiesFn       func(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error)
+	UpdatePropertyFn       func(ctx context.Context, property *model.Property) (*model.Property, error)
+	ArchivePropertyFn      func(ctx context.Context, id uuid.UUID) error
+}
+
+func (m *MockPropertyRepository) CreateProperty(ctx context.Context, property *model.Property) (*model.Property, error) {
+	return m.CreatePropertyFn(ctx, property)
+}
+
+func (m *MockPropertyRepository) GetPropertyByID(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+	return m.GetPropertyByIDFn(ctx, id)
+}
+
+func (m *MockPropertyRepository) GetPropertyByAddress(ctx context.Context, phase, block, lot string) (*model.Property, error) {
+	return m.GetPropertyByAddressFn(ctx, phase, block, lot)
+}
+
+func (m *MockPropertyRepository) ListProperties(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error) {
+	return m.ListPropertiesFn(ctx, filter)
+}
+
+func (m *MockPropertyRepository) UpdateProperty(ctx context.Context, property *model.Property) (*model.Property, error) {
+	return m.UpdatePropertyFn(ctx, property)
+}
+
+func (m *MockPropertyRepository) ArchiveProperty(ctx context.Context, id uuid.UUID) error {
+	return m.ArchivePropertyFn(ctx, id)
+}
+
+func TestPropertyService_CreateProperty(t *testing.T) {
+	ownerID := uuid.New().String()
+	invalidOwnerID := "not-a-uuid"
+
+	tests := []struct {
+		name        string
+		req         *PropertyRequest
+		ownerErr    error
+		createErr   error
+		expectedErr error
+	}{
+		{
+			name: "success",
+			req:  &PropertyRequest{OwnerID: &ownerID, Phase: " 1 ", Block: "4", Lot: "12"},
+		},
+		{
+			name:        "duplicate phase block lot",
+			req:         &PropertyRequest{Phase: "1", Block: "4", Lot: "12"},
+			createErr:   constants.ErrRecordExists,
+			expectedErr: constants.ErrRecordExists,
+		},
+		{
+			name:        "unknown owner",
+			req:         &PropertyRequest{OwnerID: &ownerID, Phase: "1", Block: "4", Lot: "12"},
+			ownerErr:    constants.ErrRecordNotFound,
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:        "invalid owner id",
+			req:         &PropertyRequest{OwnerID: &invalidOwnerID, Phase: "1", Block: "4", Lot: "12"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyRepo := &MockPropertyRepository{
+				CreatePropertyFn: func(ctx context.Context, property *model.Property) (*model.Property, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					if property.Phase != "1" {
+						t.Errorf("expected trimmed phase, got %q", property.Phase)
+					}
+					property.ID = uuid.New()
+					return property, nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					if tc.ownerErr != nil {
+						return nil, tc.ownerErr
+					}
+					return &model.User{ID: id}, nil
+				},
+			}
+
+			service := NewPropertyService(propertyRepo, userRepo)
+			resp, err := service.CreateProperty(context.Background(), tc.req)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && tc.req.OwnerID != nil && *resp.OwnerID != *tc.req.OwnerID {
+				t.Errorf("expected owner %s, got %v", *tc.req.OwnerID, resp.OwnerID)
+			}
+		})
+	}
+}
+
+func TestPropertyService_ListProperties(t *testing.T) {
+	var got repository.PropertyFilter
+	propertyRepo := &MockPropertyRepository{
+		ListPropertiesFn: func(ctx context.Context, filter repository.PropertyFilter) ([]model.Property, error) {
+			got = filter
+			return []model.Property{{ID: uuid.New(), Phase: "2", Block: "4", Lot: "12"}}, nil
+		},
+	}
+
+	service := NewPropertyService(propertyRepo, &MockUserRepository{})
+	resp, err := service.ListProperties(context.Background(), &ListPropertiesRequest{Phase: "2", Limit: 1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp) != 1 {
+		t.Fatalf("expected 1 property, got %d", len(resp))
+	}
+	if got.Phase != "2" || got.Limit != maxPageSize {
+		t.Errorf("unexpected filter %+v", got)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+// registrationProofTypes are the sniffed content types accepted as a copy of
+// a title or lease.
+var registrationProofTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+type RegistrationServiceImpl struct {
+	registrationRepo repository.RegistrationRepository
+	propertyRepo     repository.PropertyRepository
+	store            storage.Storage
+	mailer           mailer.Mailer
+}
+
+func NewRegistrationService(registrationRepo repository.RegistrationRepository, propertyRepo repository.PropertyRepository,
+	store storage.Storage, mailer mailer.Mailer) RegistrationService {
+	return &RegistrationServiceImpl{
+		registrationRepo: registrationRepo,
+		propertyRepo:     propertyRepo,
+		store:            store,
+		mailer:           mailer,
+	}
+}
+
+func (s *RegistrationServiceImpl) ListRegistrations(ctx context.Context, req *ListRegistrationsRequest) ([]RegistrationResponse, error) {
+	registrations, err := s.registrationRepo.ListRegistrations(ctx, repository.RegistrationFilter{
+		Status: req.Status,
+		Limit:  pageSize(req.Limit),
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]RegistrationResponse, 0, len(registrations))
+	for i := range registrations {
+		resp = append(resp, *toRegistrationResponse(&registrations[i]))
+	}
+	return resp, nil
+}
+
+func (s *RegistrationServiceImpl) GetRegistration(ctx context.Context, id uuid.UUID) (*RegistrationResponse, error) {
+	registration, err := s.registrationRepo.GetRegistrationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRegistrationResponse(registration), nil
+}
+
+func (s *RegistrationServiceImpl) GetRegistrationProof(ctx context.Context, id uuid.UUID) (*StoredFile, error) {
+	registration, err := s.registrationRepo.GetRegistrationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if registration.ProofKey == nil {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	body, err := s.store.Get(ctx, *registration.ProofKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredFile{
+		FileName:    *registration.ProofFileName,
+		ContentType: *registration.ProofContentType,
+		SizeBytes:   *registration.ProofSizeBytes,
+		Body:        body,
+	}, nil
+}
+
+// ApproveRegistration makes the registrant a member and, when they claimed a
+// property, links them to it. A title makes them the owner, or a co-owner
+// when the property already has one; a lease makes them a tenant.
+func (s *RegistrationServiceImpl) ApproveRegistration(ctx context.Context, id, reviewedBy uuid.UUID, req *ApproveRegistrationRequest) (*RegistrationResponse, error) {
+	registration, err := s.pendingRegistration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var occupancy *model.Occupancy
+	if registration.PropertyID != nil {
+		property, err := s.propertyRepo.GetPropertyByID(ctx, *registration.PropertyID)
+		if err != nil {
+			return nil, err
+		}
+		if property.ArchivedAt != nil {
+			return nil, invalidInput("the claimed property has been archived")
+		}
+
+		occupancyType := req.OccupancyType
+		if occupancyType == "" {
+			switch {
+			case *registration.ProofType == constants.ProofLease:
+				occupancyType = constants.OccupancyTenant
+			case property.OwnerID != nil:
+				occupancyType = constants.OccupancyCoOwner
+			default:
+				occupancyType = constants.OccupancyOwner
+			}
+		}
+		if occupancyType == constants.OccupancyOwner && property.OwnerID != nil {
+			return nil, invalidInput("the property already has an owner; approve as co-owner or transfer ownership")
+		}
+
+		startDate, err := parseDateOrToday(ctx, req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		occupancy = &model.Occupancy{
+			PropertyID:    property.ID,
+			OccupancyType: occupancyType,
+			StartDate:     startDate,
+		}
+	} else if req.OccupancyType != "" || req.StartDate != "" {
+		return nil, invalidInput("the registrant did not claim a property")
+	}
+
+	if err := s.registrationRepo.ApproveRegistration(ctx, id, reviewedBy, occupancy); err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      registration.Email,
+		Subject: "Your " + tenant.Name(ctx) + " registration was approved",
+		Body: fmt.Sprintf("Hi %s,\n\nYour registration with %s was approved. You can now sign in.\n",
+			registration.FirstName, tenant.Name(ctx)),
+	})
+	if err != nil {
+		log.Printf("failed to send registration approved email: %v\n", err)
+	}
+
+	return s.GetRegistration(ctx, id)
+}
+
+// RejectRegistration turns the registrant away with a reason, which is
+// emailed to them and shown when they try to sign in.
+func (s *RegistrationServiceImpl) RejectRegistration(ctx context.Context, id, reviewedBy uuid.UUID, req *RejectRegistrationRequest) (*RegistrationResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, invalidInput("a reason is required")
+	}
+
+	registration, err := s.pendingRegistration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.registrationRepo.RejectRegistration(ctx, id, reason, reviewedBy); err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      registration.Email,
+		Subject: "Your " + tenant.Name(ctx) + " registration was not approved",
+		Body: fmt.Sprintf("Hi %s,\n\nYour registration with %s was not approved for the following reason:\n\n%s\n\nContact your association office if you have questions.\n",
+			registration.FirstName, tenant.Name(ctx), reason),
+	})
+	if err != nil {
+		log.Printf("failed to send registration rejected email: %v\n", err)
+	}
+
+	return s.GetRegistration(ctx, id)
+}
+
+// pendingRegistration loads a registration that is ready for review: still
+// pending, with the registrant's email confirmed.
+func (s *RegistrationServiceImpl) pendingRegistration(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error) {
+	registration, err := s.registrationRepo.GetRegistrationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status != constants.RegistrationPending {
+		return nil, invalidInput("the registration was already %s", registration.Status)
+	}
+	if registration.UserStatus != constants.PendingApprovalStatus {
+		return nil, invalidInput("the registrant has not confirmed their email yet")
+	}
+	return registration, nil
+}
+
+func proofFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "proof"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func toRegistrationResponse(registration *model.RegistrationDetail) *RegistrationResponse {
+	resp := &RegistrationResponse{
+		ID:               registration.ID.String(),
+		UserID:           registration.UserID.String(),
+		FirstName:        registration.FirstName,
+		LastName:         registration.LastName,
+		Email:            registration.Email,
+		MobileNumber:     registration.MobileNumber,
+		UserStatus:       registration.UserStatus,
+		Phase:            registration.Phase,
+		Block:            registration.Block,
+		Lot:              registration.Lot,
+		ProofType:        registration.ProofType,
+		ProofFileName:    registration.ProofFileName,
+		ProofContentType: registration.ProofContentType,
+		ProofSizeBytes:   registration.ProofSizeBytes,
+		Status:           registration.Status,
+		RejectionReason:  registration.RejectionReason,
+		ReviewedAt:       registration.ReviewedAt,
+		CreatedAt:        registration.CreatedAt,
+	}
+	if registration.PropertyID != nil {
+		propertyID := registration.PropertyID.String()
+		resp.PropertyID = &propertyID
+	}
+	if registration.ReviewedBy != nil {
+		reviewedBy := registration.ReviewedBy.String()
+		resp.ReviewedBy = &reviewedBy
+	}
+	return resp
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type MockRegistrationRepository struct {
+	CreateRegistrationFn  func(ctx context.Context, user *model.User, registration *model.Registration) error
+	GetRegistrationByIDFn func(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error)
+	ListRegistrationsFn   func(ctx context.Context, filter repository.RegistrationFilter) ([]model.RegistrationDetail, error)
+	ApproveRegistrationFn func(ctx context.Context, id, reviewedBy uuid.UUID, occupancy *model.Occupancy) error
+	RejectRegistrationFn  func(ctx context.Context, id uuid.UUID, reason string, reviewedBy uuid.UUID) error
+}
+
+func (m *MockRegistrationRepository) CreateRegistration(ctx context.Context, user *model.User, registration *model.Registration) error {
+	return m.CreateRegistrationFn(ctx, user, registration)
+}
+
+func (m *MockRegistrationRepository) GetRegistrationByID(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error) {
+	return m.GetRegistrationByIDFn(ctx, id)
+}
+
+func (m *MockRegistrationRepository) ListRegistrations(ctx context.Context, filter repository.RegistrationFilter) ([]model.RegistrationDetail, error) {
+	return m.ListRegistrationsFn(ctx, filter)
+}
+
+func (m *MockRegistrationRepository) ApproveRegistration(ctx context.Context, id, reviewedBy uuid.UUID, occupancy *model.Occupancy) error {
+	return m.ApproveRegistrationFn(ctx, id, reviewedBy, occupancy)
+}
+
+func (m *MockRegistrationRepository) RejectRegistration(ctx context.Context, id uuid.UUID, reason string, reviewedBy uuid.UUID) error {
+	return m.RejectRegistrationFn(ctx, id, reason, reviewedBy)
+}
+
+var pdfProof = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+func TestUserService_CreateUser_PropertyClaim(t *testing.T) {
+	property := &model.Property{ID: uuid.New(), Phase: "1", Block: "2", Lot: "3"}
+
+	tests := []struct {
+		name         string
+		req          CreateUserRequest
+		proof        []byte
+		createErr    error
+		expectedErr  error
+		expectStored bool
+	}{
+		{
+			name:         "title with pdf",
+			req:          CreateUserRequest{Phase: "1", Block: "2", Lot: "3", ProofType: constants.ProofTitle},
+			proof:        pdfProof,
+			expectStored: true,
+		},
+		{
+			name:        "missing proof",
+			req:         CreateUserRequest{Phase: "1", Block: "2", Lot: "3", ProofType: constants.ProofLease},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "incomplete address",
+			req:         CreateUserRequest{Phase: "1", Block: "2", ProofType: constants.ProofTitle},
+			proof:       pdfProof,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown address",
+			req:         CreateUserRequest{Phase: "9", Block: "9", Lot: "9", ProofType: constants.ProofTitle},
+			proof:       pdfProof,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unsupported file",
+			req:         CreateUserRequest{Phase: "1", Block: "2", Lot: "3", ProofType: constants.ProofTitle},
+			proof:       []byte("just some text"),
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:         "insert fails",
+			req:          CreateUserRequest{Phase: "1", Block: "2", Lot: "3", ProofType: constants.ProofLease},
+			proof:        pdfProof,
+			createErr:    constants.ErrRecordExists,
+			expectedErr:  constants.ErrRecordExists,
+			expectStored: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := &MockUserRepository{
+				GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+					return nil, constants.ErrRecordNotFound
+				},
+			}
+			propertyRepo := &MockPropertyRepository{
+				GetPropertyByAddressFn: func(ctx context.Context, phase, block, lot string) (*model.Property, error) {
+					if phase == property.Phase && block == property.Block && lot == property.Lot {
+						return property, nil
+					}
+					return nil, constants.ErrRecordNotFound
+				},
+			}
+			var created *model.Registration
+			registrationRepo := &MockRegistrationRepository{
+				CreateRegistrationFn: func(ctx context.Context, user *model.User, registration *model.Registration) error {
+					created = registration
+					return tc.createErr
+				},
+			}
+			var storedKey, deletedKey string
+			var storedBody []byte
+			store := &MockStorage{
+				PutFn: func(ctx context.Context, key string, body io.Reader, contentType string) error {
+					storedKey = key
+					storedBody, _ = io.ReadAll(body)
+					return nil
+				},
+				DeleteFn: func(ctx context.Context, key string) error {
+					deletedKey = key
+					return nil
+				},
+			}
+			verificationRepo := &MockEmailVerificationRepository{
+				CreateEmailVerificationTokenFn: func(ctx context.Context, token *model.EmailVerificationToken) error { return nil },
+			}
+			mail := &MockMailer{SendFn: func(ctx context.Context, message mailer.Message) error { return nil }}
+			service := NewUserService(userRepo, verificationRepo, registrationRepo, propertyRepo, store, mail, job.NewBackground(), "")
+
+			req := tc.req
+			req.FirstName, req.LastName, req.Email, req.Password = "Ana", "Cruz", "ana@example.com", "password12345"
+			var proof *FileUpload
+			if tc.proof != nil {
+				proof = &FileUpload{FileName: "../title.pdf", SizeBytes: int64(len(tc.proof)), Body: bytes.NewReader(tc.proof)}
+			}
+
+			ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "acacia", Name: "Acacia Heights"})
+			resp, err := service.CreateUser(ctx, &req, proof)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if tc.expectStored {
+				if storedKey != "registrations/"+created.ID.String() || !bytes.Equal(storedBody, tc.proof) {
+					t.Errorf("expected the whole proof stored under the registration, got %q", storedKey)
+				}
+				if *created.ProofFileName != "title.pdf" || *created.ProofContentType != "application/pdf" {
+					t.Errorf("unexpected proof metadata %q %q", *created.ProofFileName, *created.ProofContentType)
+				}
+			} else if storedKey != "" {
+				t.Errorf("expected nothing stored, got %q", storedKey)
+			}
+
+			if tc.createErr != nil {
+				if deletedKey != storedKey {
+					t.Errorf("expected the orphaned proof to be removed, got %q", deletedKey)
+				}
+			} else if tc.expectedErr == nil && (resp.PropertyID == nil || *resp.PropertyID != property.ID.String()) {
+				t.Errorf("expected the claimed property in the response, got %v", resp.PropertyID)
+			}
+		})
+	}
+}
+
+func TestRegistrationService_ApproveRegistration(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name          string
+		proofType     string
+		userStatus    string
+		status        string
+		propertyOwner *uuid.UUID
+		req           ApproveRegistrationRequest
+		expectedErr   error
+		expectedType  string
+	}{
+		{
+			name:         "title without owner",
+			proofType:    constants.ProofTitle,
+			expectedType: constants.OccupancyOwner,
+		},
+		{
+			name:          "title with owner",
+			proofType:     constants.ProofTitle,
+			propertyOwner: &ownerID,
+			expectedType:  constants.OccupancyCoOwner,
+		},
+		{
+			name:         "lease",
+			proofType:    constants.ProofLease,
+			expectedType: constants.OccupancyTenant,
+		},
+		{
+			name:         "override occupancy type",
+			proofType:    constants.ProofTitle,
+			req:          ApproveRegistrationRequest{OccupancyType: constants.OccupancyRepresentative},
+			expectedType: constants.OccupancyRepresentative,
+		},
+		{
+			name:          "owner when property is owned",
+			proofType:     constants.ProofTitle,
+			propertyOwner: &ownerID,
+			req:           ApproveRegistrationRequest{OccupancyType: constants.OccupancyOwner},
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name: "no claim",
+		},
+		{
+			name:        "email not confirmed",
+			userStatus:  constants.PendingVerificationStatus,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "already reviewed",
+			status:      constants.RegistrationRejected,
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			registration := &model.RegistrationDetail{
+				Registration: model.Registration{ID: uuid.New(), UserID: uuid.New(), Status: constants.RegistrationPending},
+				FirstName:    "Ana",
+				Email:        "ana@example.com",
+				UserStatus:   constants.PendingApprovalStatus,
+			}
+			if tc.status != "" {
+				registration.Status = tc.status
+			}
+			if tc.userStatus != "" {
+				registration.UserStatus = tc.userStatus
+			}
+			property := &model.Property{ID: uuid.New(), OwnerID: tc.propertyOwner}
+			if tc.proofType != "" {
+				registration.PropertyID = &property.ID
+				registration.ProofType = &tc.proofType
+			}
+
+			var approved bool
+			var occupancy *model.Occupancy
+			registrationRepo := &MockRegistrationRepository{
+				GetRegistrationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error) {
+					return registration, nil
+				},
+				ApproveRegistrationFn: func(ctx context.Context, id, reviewedBy uuid.UUID, o *model.Occupancy) error {
+					approved = true
+					occupancy = o
+					return nil
+				},
+			}
+			propertyRepo := &MockPropertyRepository{
+				GetPropertyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+					return property, nil
+				},
+			}
+			var sent *mailer.Message
+			mail := &MockMailer{
+				SendFn: func(ctx context.Context, message mailer.Message) error {
+					sent = &message
+					return nil
+				},
+			}
+
+			service := NewRegistrationService(registrationRepo, propertyRepo, &MockStorage{}, mail)
+			_, err := service.ApproveRegistration(context.Background(), registration.ID, uuid.New(), &tc.req)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr != nil {
+				if approved {
+					t.Error("expected the registration not to be approved")
+				}
+				return
+			}
+
+			if tc.expectedType == "" {
+				if occupancy != nil {
+					t.Errorf("expected no occupancy, got %+v", occupancy)
+				}
+			} else if occupancy == nil || occupancy.PropertyID != property.ID || occupancy.OccupancyType != tc.expectedType {
+				t.Errorf("expected a %s occupancy of the claimed property, got %+v", tc.expectedType, occupancy)
+			}
+			if sent == nil || sent.To != registration.Email {
+				t.Error("expected the registrant to be emailed")
+			}
+		})
+	}
+}
+
+func TestRegistrationService_RejectRegistration(t *testing.T) {
+	registration := &model.RegistrationDetail{
+		Registration: model.Registration{ID: uuid.New(), UserID: uuid.New(), Status: constants.RegistrationPending},
+		FirstName:    "Ana",
+		Email:        "ana@example.com",
+		UserStatus:   constants.PendingApprovalStatus,
+	}
+
+	var reason string
+	registrationRepo := &MockRegistrationRepository{
+		GetRegistrationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.RegistrationDetail, error) {
+			return registration, nil
+		},
+		RejectRegistrationFn: func(ctx context.Context, id uuid.UUID, r string, reviewedBy uuid.UUID) error {
+			reason = r
+			return nil
+		},
+	}
+	var sent *mailer.Message
+	mail := &MockMailer{
+		SendFn: func(ctx context.Context, message mailer.Message) error {
+			sent = &message
+			return nil
+		},
+	}
+	service := NewRegistrationService(registrationRepo, &MockPropertyRepository{}, &MockStorage{}, mail)
+
+	if _, err := service.RejectRegistration(context.Background(), registration.ID, uuid.New(), &RejectRegistrationRequest{Reason: "  "}); !errors.Is(err, constants.ErrInvalidInput) {
+		t.Fatalf("expected a blank reason to be rejected, got %v", err)
+	}
+
+	_, err := service.RejectRegistration(context.Background(), registration.ID, uuid.New(), &RejectRegistrationRequest{Reason: " Title is not in your name "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reason != "Title is not in your name" {
+		t.Errorf("expected the trimmed reason to be stored, got %q", reason)
+	}
+	if sent == nil || !strings.Contains(sent.Body, "Title is not in your name") {
+		t.Error("expected the reason to be emailed to the registrant")
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type RoleServiceImpl struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+}
+
+func NewRoleService(roleRepo repository.RoleRepository, userRepo repository.UserRepository) RoleService {
+	return &RoleServiceImpl{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *RoleServiceImpl) ListRoles(ctx context.Context) ([]model.Role, error) {
+	return s.roleRepo.ListRoles(ctx)
+}
+
+func (s *RoleServiceImpl) GetUserAccess(ctx context.Context, userID uuid.UUID) (*UserAccessResponse, error) {
+	roles, err := s.roleRepo.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.roleRepo.GetPermissionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &UserAccessResponse{
+		UserID:      userID.String(),
+		Roles:       make([]string, 0, len(roles)),
+		Permissions: make([]string, 0, len(permissions)),
+	}
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, role.Name)
+	}
+	for _, permission := range permissions {
+		resp.Permissions = append(resp.Permissions, permission.Name)
+	}
+
+	return resp, nil
+}
+
+func (s *RoleServiceImpl) HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+	return s.roleRepo.UserHasPermission(ctx, userID, permission)
+}
+
+func (s *RoleServiceImpl) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+
+	role, err := s.roleRepo.GetRoleByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	return s.roleRepo.AssignRoleToUser(ctx, userID, role.ID)
+}
+
+func (s *RoleServiceImpl) RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	role, err := s.roleRepo.GetRoleByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	return s.roleRepo.RemoveRoleFromUser(ctx, userID, role.ID)
+}
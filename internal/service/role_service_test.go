@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type MockRoleRepository struct {
+	ListRolesFn              func(ctx context.Context) ([]model.Role, error)
+	GetRoleByNameFn          func(ctx context.Context, name string) (*model.Role, error)
+	GetRolesByUserIDFn       func(ctx context.Context, userID uuid.UUID) ([]model.Role, error)
+	GetPermissionsByUserIDFn func(ctx context.Context, userID uuid.UUID) ([]model.Permission, error)
+	UserHasPermissionFn      func(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+	AssignRoleToUserFn       func(ctx context.Context, userID, roleID uuid.UUID) error
+	RemoveRoleFromUserFn     func(ctx context.Context, userID, roleID uuid.UUID) error
+}
+
+func (m *MockRoleRepository) ListRoles(ctx context.Context) ([]model.Role, error) {
+	return m.ListRolesFn(ctx)
+}
+
+func (m *MockRoleRepository) GetRoleByName(ctx context.Context, name string) (*model.Role, error) {
+	return m.GetRoleByNameFn(ctx, name)
+}
+
+func (m *MockRoleRepository) GetRolesByUserID(ctx context.Context, userID uuid.UUID) ([]model.Role, error) {
+	return m.GetRolesByUserIDFn(ctx, userID)
+}
+
+func (m *MockRoleRepository) GetPermissionsByUserID(ctx context.Context, userID uuid.UUID) ([]model.Permission, error) {
+	return m.GetPermissionsByUserIDFn(ctx, userID)
+}
+
+func (m *MockRoleRepository) UserHasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+	return m.UserHasPermissionFn(ctx, userID, permission)
+}
+
+func (m *MockRoleRepository) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	return m.AssignRoleToUserFn(ctx, userID, roleID)
+}
+
+func (m *MockRoleRepository) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	return m.RemoveRoleFromUserFn(ctx, userID, roleID)
+}
+
+func TestRoleService_GetUserAccess(t *testing.T) {
+	userID := uuid.New()
+	roleRepo := &MockRoleRepository{
+		GetRolesByUserIDFn: func(ctx context.Context, id uuid.UUID) ([]model.Role, error) {
+			return []model.Role{{Name: constants.RoleTreasurer}}, nil
+		},
+		GetPermissionsByUserIDFn: func(ctx context.Context, id uuid.UUID) ([]model.Permission, error) {
+			return []model.Permission{{Name: constants.PermManageFinances}, {Name: constants.PermViewReports}}, nil
+		},
+	}
+
+	service := NewRoleService(roleRepo, &MockUserRepository{})
+	resp, err := service.GetUserAccess(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.UserID != userID.String() {
+		t.Errorf("expected user id %s, got %s", userID, resp.UserID)
+	}
+	if !reflect.DeepEqual(resp.Roles, []string{constants.RoleTreasurer}) {
+		t.Errorf("unexpected roles %v", resp.Roles)
+	}
+	if !reflect.DeepEqual(resp.Permissions, []string{constants.PermManageFinances, constants.PermViewReports}) {
+		t.Errorf("unexpected permissions %v", resp.Permissions)
+	}
+}
+
+func TestRoleService_AssignRole(t *testing.T) {
+	roleID := uuid.New()
+
+	tests := []struct {
+		name        string
+		roleName    string
+		userErr     error
+		expectedErr error
+		expectAdded bool
+	}{
+		{
+			name:        "success",
+			roleName:    constants.RoleTreasurer,
+			expectAdded: true,
+		},
+		{
+			name:        "user not found",
+			roleName:    constants.RoleTreasurer,
+			userErr:     constants.ErrRecordNotFound,
+			expectedErr: constants.ErrRecordNotFound,
+		},
+		{
+			name:        "role not found",
+			roleName:    "superuser",
+			expectedErr: constants.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			added := false
+			roleRepo := &MockRoleRepository{
+				GetRoleByNameFn: func(ctx context.Context, name string) (*model.Role, error) {
+					if name != constants.RoleTreasurer {
+						return nil, constants.ErrRecordNotFound
+					}
+					return &model.Role{ID: roleID, Name: name}, nil
+				},
+				AssignRoleToUserFn: func(ctx context.Context, userID, id uuid.UUID) error {
+					if id != roleID {
+						t.Errorf("expected role id %s, got %s", roleID, id)
+					}
+					added = true
+					return nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					if tc.userErr != nil {
+						return nil, tc.userErr
+					}
+					return &model.User{ID: id}, nil
+				},
+			}
+
+			service := NewRoleService(roleRepo, userRepo)
+			err := service.AssignRole(context.Background(), uuid.New(), tc.roleName)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if added != tc.expectAdded {
+				t.Errorf("expected added %v, got %v", tc.expectAdded, added)
+			}
+		})
+	}
+}
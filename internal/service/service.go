@@ -2,30 +2,333 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/auth"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/config"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
 )
 
+type AssociationService interface {
+	GetAssociationBySlug(ctx context.Context, slug string) (*model.Association, error)
+	GetAssociationByID(ctx context.Context, id uuid.UUID) (*model.Association, error)
+	GetCurrentAssociation(ctx context.Context) (*AssociationResponse, error)
+	SaveAssociation(ctx context.Context, req *AssociationRequest) (*AssociationResponse, error)
+	ForEachAssociation(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserService interface {
-	CreateUser(ctx context.Context, user *CreateUserRequest) (*CreatUserResponse, error)
+	CreateUser(ctx context.Context, user *CreateUserRequest, proof *FileUpload) (*CreatUserResponse, error)
 	LoginUser(ctx context.Context, req *LoginUserRequest) (*model.User, error)
+	SuspendUser(ctx context.Context, userID uuid.UUID) error
+	ReactivateUser(ctx context.Context, userID uuid.UUID) error
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest) error
+	ExpireUnverifiedUsers(ctx context.Context, now time.Time) error
+}
+
+type RegistrationService interface {
+	ListRegistrations(ctx context.Context, req *ListRegistrationsRequest) ([]RegistrationResponse, error)
+	GetRegistration(ctx context.Context, id uuid.UUID) (*RegistrationResponse, error)
+	GetRegistrationProof(ctx context.Context, id uuid.UUID) (*StoredFile, error)
+	ApproveRegistration(ctx context.Context, id, reviewedBy uuid.UUID, req *ApproveRegistrationRequest) (*RegistrationResponse, error)
+	RejectRegistration(ctx context.Context, id, reviewedBy uuid.UUID, req *RejectRegistrationRequest) (*RegistrationResponse, error)
+}
+
+type MFAService interface {
+	ChallengeLogin(ctx context.Context, user *model.User) (*MFAChallengeResponse, error)
+	EnrollWithChallenge(ctx context.Context, req *MFAChallengeRequest) (*MFAEnrollmentResponse, error)
+	CompleteLogin(ctx context.Context, req *MFALoginRequest) (*model.User, []string, error)
+	GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatusResponse, error)
+	StartEnrollment(ctx context.Context, userID uuid.UUID) (*MFAEnrollmentResponse, error)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, req *MFACodeRequest) error
+	ResetMFA(ctx context.Context, userID uuid.UUID) error
+}
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]model.Role, error)
+	GetUserAccess(ctx context.Context, userID uuid.UUID) (*UserAccessResponse, error)
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error
+}
+
+type AuthService interface {
+	IssueTokens(ctx context.Context, user *model.User) (auth.TokenPairs, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*model.User, auth.TokenPairs, error)
+	Logout(ctx context.Context, refreshToken string) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+	PurgeRevokedSessions(ctx context.Context, now time.Time) error
+	RequestPasswordReset(ctx context.Context, req *ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
+}
+
+type PropertyService interface {
+	CreateProperty(ctx context.Context, req *PropertyRequest) (*PropertyResponse, error)
+	GetProperty(ctx context.Context, id uuid.UUID) (*PropertyResponse, error)
+	ListProperties(ctx context.Context, req *ListPropertiesRequest) ([]PropertyResponse, error)
+	UpdateProperty(ctx context.Context, id uuid.UUID, req *PropertyRequest) (*PropertyResponse, error)
+	ArchiveProperty(ctx context.Context, id uuid.UUID) error
+}
+
+type OccupancyService interface {
+	AddOccupant(ctx context.Context, propertyID uuid.UUID, req *AddOccupantRequest) (*OccupantResponse, error)
+	EndOccupancy(ctx context.Context, propertyID, occupancyID uuid.UUID, req *EndOccupancyRequest) error
+	GetOccupants(ctx context.Context, propertyID uuid.UUID, date string) ([]OccupantResponse, error)
+	GetOccupancyHistory(ctx context.Context, propertyID uuid.UUID) ([]OccupantResponse, error)
+	LookupOccupants(ctx context.Context, req *OccupancyLookupRequest) (*OccupancyLookupResponse, error)
+	TransferOwnership(ctx context.Context, propertyID uuid.UUID, req *TransferOwnershipRequest) error
+	GetUserProperties(ctx context.Context, userID uuid.UUID) ([]UserPropertyResponse, error)
+}
+
+type BillingService interface {
+	CreateDuesSchedule(ctx context.Context, req *DuesScheduleRequest) (*DuesScheduleResponse, error)
+	ListDuesSchedules(ctx context.Context) ([]DuesScheduleResponse, error)
+	PreviewBillingRun(ctx context.Context, req *BillingRunRequest) (*BillingRunPreview, error)
+	RunBilling(ctx context.Context, req *BillingRunRequest, runBy *uuid.UUID) (*BillingRunResponse, error)
+	RunScheduledBilling(ctx context.Context, now time.Time) error
+	ListBillingRuns(ctx context.Context) ([]BillingRunResponse, error)
+	ListInvoices(ctx context.Context, req *ListInvoicesRequest) ([]InvoiceResponse, error)
+	GetInvoice(ctx context.Context, id uuid.UUID) (*InvoiceResponse, error)
+	VoidInvoice(ctx context.Context, id uuid.UUID, req *VoidInvoiceRequest) error
+}
+
+type PaymentService interface {
+	RecordPayment(ctx context.Context, req *RecordPaymentRequest, recordedBy *uuid.UUID) (*PaymentResponse, error)
+	GetPayment(ctx context.Context, id uuid.UUID) (*PaymentResponse, error)
+	ListPayments(ctx context.Context, req *ListPaymentsRequest) ([]PaymentResponse, error)
+	GetPropertyCredit(ctx context.Context, propertyID uuid.UUID) (*PropertyCreditResponse, error)
+}
+
+type PenaltyService interface {
+	CreatePenaltyRule(ctx context.Context, req *PenaltyRuleRequest) (*PenaltyRuleResponse, error)
+	ListPenaltyRules(ctx context.Context) ([]PenaltyRuleResponse, error)
+	ApplyPenalties(ctx context.Context, now time.Time) error
+	ListInvoicePenalties(ctx context.Context, invoiceID uuid.UUID) ([]InvoicePenaltyResponse, error)
+	WaivePenalty(ctx context.Context, id uuid.UUID, req *WaivePenaltyRequest, waivedBy uuid.UUID) error
+}
+
+type StatementService interface {
+	GetPropertyStatement(ctx context.Context, propertyID uuid.UUID, req *StatementRequest) (*StatementResponse, error)
+	GetUserStatement(ctx context.Context, userID uuid.UUID, req *StatementRequest) (*StatementResponse, error)
+	RenderStatementPDF(statement *StatementResponse) ([]byte, error)
+}
+
+type AnnouncementService interface {
+	CreateAnnouncement(ctx context.Context, req *AnnouncementRequest, createdBy uuid.UUID) (*AnnouncementResponse, error)
+	GetAnnouncement(ctx context.Context, id uuid.UUID) (*AnnouncementResponse, error)
+	ListAnnouncements(ctx context.Context, req *ListAnnouncementsRequest) ([]AnnouncementResponse, error)
+	UpdateAnnouncement(ctx context.Context, id uuid.UUID, req *AnnouncementRequest) (*AnnouncementResponse, error)
+	DeleteAnnouncement(ctx context.Context, id uuid.UUID) error
+	GetFeed(ctx context.Context, userID uuid.UUID, req *AnnouncementFeedRequest) ([]FeedAnnouncementResponse, error)
+	GetFeedAnnouncement(ctx context.Context, userID, id uuid.UUID) (*FeedAnnouncementResponse, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	ListReadReceipts(ctx context.Context, id uuid.UUID) ([]AnnouncementReadResponse, error)
+}
+
+type AmenityService interface {
+	CreateAmenity(ctx context.Context, req *AmenityRequest) (*AmenityResponse, error)
+	GetAmenity(ctx context.Context, id uuid.UUID) (*AmenityResponse, error)
+	ListAmenities(ctx context.Context, includeInactive bool) ([]AmenityResponse, error)
+	UpdateAmenity(ctx context.Context, id uuid.UUID, req *AmenityRequest) (*AmenityResponse, error)
+	GetAvailability(ctx context.Context, id uuid.UUID, req *AvailabilityRequest) (*AvailabilityResponse, error)
+	CreateReservation(ctx context.Context, userID, amenityID uuid.UUID, req *ReservationRequest) (*ReservationResponse, error)
+	ListReservations(ctx context.Context, req *ListReservationsRequest) ([]ReservationResponse, error)
+	ListUserReservations(ctx context.Context, userID uuid.UUID, req *ListReservationsRequest) ([]ReservationResponse, error)
+	ApproveReservation(ctx context.Context, id, decidedBy uuid.UUID, req *ReservationDecisionRequest) (*ReservationResponse, error)
+	RejectReservation(ctx context.Context, id, decidedBy uuid.UUID, req *ReservationDecisionRequest) (*ReservationResponse, error)
+	CancelReservation(ctx context.Context, id uuid.UUID, req *CancelReservationRequest) (*ReservationResponse, error)
+	CancelUserReservation(ctx context.Context, userID, id uuid.UUID, req *CancelReservationRequest) (*ReservationResponse, error)
+}
+
+type GatePassService interface {
+	CreateGatePass(ctx context.Context, userID uuid.UUID, req *GatePassRequest) (*GatePassResponse, error)
+	ListUserGatePasses(ctx context.Context, userID uuid.UUID, req *ListGatePassesRequest) ([]GatePassResponse, error)
+	GetUserGatePass(ctx context.Context, userID, id uuid.UUID) (*GatePassResponse, error)
+	RevokeUserGatePass(ctx context.Context, userID, id uuid.UUID, req *RevokeGatePassRequest) (*GatePassResponse, error)
+	RevokeGatePass(ctx context.Context, id, revokedBy uuid.UUID, req *RevokeGatePassRequest) (*GatePassResponse, error)
+	VerifyGatePass(ctx context.Context, req *VerifyGatePassRequest) (*GatePassVerification, error)
+	RecordEntry(ctx context.Context, guardID uuid.UUID, req *GateEntryRequest) (*GateEntryResponse, error)
+	RecordWalkIn(ctx context.Context, guardID uuid.UUID, req *WalkInRequest) (*GateEntryResponse, error)
+	RecordExit(ctx context.Context, guardID, entryID uuid.UUID) (*GateEntryResponse, error)
+	ListExpectedVisitors(ctx context.Context, req *ExpectedVisitorsRequest) ([]GatePassResponse, error)
+	ListGateEntries(ctx context.Context, req *ListGateEntriesRequest) ([]GateEntryResponse, error)
+	SearchLogbook(ctx context.Context, req *SearchLogbookRequest) ([]GateEntryResponse, error)
+}
+
+type VehicleService interface {
+	RegisterVehicle(ctx context.Context, userID uuid.UUID, req *VehicleRequest) (*VehicleResponse, error)
+	ListUserVehicles(ctx context.Context, userID uuid.UUID) ([]VehicleResponse, error)
+	RemoveUserVehicle(ctx context.Context, userID, id uuid.UUID) error
+	GetVehicle(ctx context.Context, id uuid.UUID) (*VehicleResponse, error)
+	ListVehicles(ctx context.Context, req *ListVehiclesRequest) ([]VehicleResponse, error)
+	RemoveVehicle(ctx context.Context, id uuid.UUID) error
+	IssueSticker(ctx context.Context, vehicleID, issuedBy uuid.UUID, req *IssueStickerRequest) (*StickerResponse, error)
+	ListStickers(ctx context.Context, req *ListStickersRequest) ([]StickerResponse, error)
+	MarkStickerLost(ctx context.Context, id uuid.UUID, req *StickerStatusRequest) (*StickerResponse, error)
+	RevokeSticker(ctx context.Context, id uuid.UUID, req *StickerStatusRequest) (*StickerResponse, error)
+	LookupPlate(ctx context.Context, plateNumber string) (*PlateLookupResponse, error)
+}
+
+type TicketService interface {
+	CreateTicket(ctx context.Context, userID uuid.UUID, req *TicketRequest) (*TicketResponse, error)
+	ListUserTickets(ctx context.Context, userID uuid.UUID, req *ListTicketsRequest) ([]TicketResponse, error)
+	GetUserTicket(ctx context.Context, userID, id uuid.UUID) (*TicketDetailResponse, error)
+	AddUserTicketComment(ctx context.Context, userID, id uuid.UUID, req *TicketCommentRequest) (*TicketCommentResponse, error)
+	AddUserTicketPhoto(ctx context.Context, userID, id uuid.UUID, upload *FileUpload) (*TicketPhotoResponse, error)
+	GetUserTicketPhoto(ctx context.Context, userID, ticketID, photoID uuid.UUID) (*StoredFile, error)
+	ReopenUserTicket(ctx context.Context, userID, id uuid.UUID, req *TicketStatusRequest) (*TicketResponse, error)
+	ListTickets(ctx context.Context, req *ListTicketsRequest) ([]TicketResponse, error)
+	GetTicket(ctx context.Context, id uuid.UUID) (*TicketDetailResponse, error)
+	AssignTicket(ctx context.Context, id uuid.UUID, req *AssignTicketRequest) (*TicketResponse, error)
+	ChangeTicketStatus(ctx context.Context, id, changedBy uuid.UUID, req *TicketStatusRequest) (*TicketResponse, error)
+	AddTicketComment(ctx context.Context, id, authorID uuid.UUID, req *TicketCommentRequest) (*TicketCommentResponse, error)
+	GetTicketPhoto(ctx context.Context, ticketID, photoID uuid.UUID) (*StoredFile, error)
+}
+
+type NotificationService interface {
+	ListNotifications(ctx context.Context, userID uuid.UUID, req *ListNotificationsRequest) ([]NotificationResponse, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
+
+type ViolationService interface {
+	RecordViolation(ctx context.Context, reportedBy uuid.UUID, req *ViolationRequest) (*ViolationResponse, error)
+	ListViolations(ctx context.Context, req *ListViolationsRequest) ([]ViolationResponse, error)
+	GetViolation(ctx context.Context, id uuid.UUID) (*ViolationDetailResponse, error)
+	GetPropertyViolationHistory(ctx context.Context, propertyID uuid.UUID) ([]ViolationDetailResponse, error)
+	AddEvidence(ctx context.Context, id, uploadedBy uuid.UUID, upload *FileUpload) (*ViolationAttachmentResponse, error)
+	GetEvidence(ctx context.Context, violationID, attachmentID uuid.UUID) (*StoredFile, error)
+	IssueNotice(ctx context.Context, id, issuedBy uuid.UUID, req *ViolationNoticeRequest) (*ViolationNoticeResponse, error)
+	IssueFine(ctx context.Context, id, issuedBy uuid.UUID, req *ViolationFineRequest) (*ViolationFineResponse, error)
+	DecideAppeal(ctx context.Context, id, appealID, decidedBy uuid.UUID, req *AppealDecisionRequest) (*ViolationResponse, error)
+	ResolveViolation(ctx context.Context, id, actorID uuid.UUID, req *ViolationStatusRequest) (*ViolationResponse, error)
+	DismissViolation(ctx context.Context, id, actorID uuid.UUID, req *ViolationStatusRequest) (*ViolationResponse, error)
+	ListUserViolations(ctx context.Context, userID uuid.UUID, req *ListViolationsRequest) ([]ViolationResponse, error)
+	GetUserViolation(ctx context.Context, userID, id uuid.UUID) (*ViolationDetailResponse, error)
+	GetUserEvidence(ctx context.Context, userID, violationID, attachmentID uuid.UUID) (*StoredFile, error)
+	SubmitAppeal(ctx context.Context, userID, id uuid.UUID, req *AppealRequest) (*ViolationAppealResponse, error)
+}
+
+type DocumentService interface {
+	CreateDocument(ctx context.Context, uploadedBy uuid.UUID, req *DocumentRequest, upload *FileUpload) (*DocumentDetailResponse, error)
+	UpdateDocument(ctx context.Context, id uuid.UUID, req *DocumentRequest) (*DocumentResponse, error)
+	AddVersion(ctx context.Context, id, uploadedBy uuid.UUID, req *DocumentVersionRequest, upload *FileUpload) (*DocumentVersionResponse, error)
+	ArchiveDocument(ctx context.Context, id uuid.UUID) error
+	ListDocuments(ctx context.Context, userID uuid.UUID, req *ListDocumentsRequest) ([]DocumentResponse, error)
+	GetDocument(ctx context.Context, userID, id uuid.UUID) (*DocumentDetailResponse, error)
+	GetDownloadURL(ctx context.Context, userID, id uuid.UUID, req *DocumentDownloadRequest) (*DocumentDownloadResponse, error)
+}
+
+type FileService interface {
+	OpenSignedFile(ctx context.Context, req *SignedFileRequest) (*StoredFile, error)
+}
+
+type ElectionService interface {
+	CreateElection(ctx context.Context, createdBy uuid.UUID, req *ElectionRequest) (*ElectionResponse, error)
+	UpdateElection(ctx context.Context, id uuid.UUID, req *ElectionRequest) (*ElectionResponse, error)
+	AddPosition(ctx context.Context, electionID uuid.UUID, req *ElectionPositionRequest) (*ElectionPositionResponse, error)
+	RemovePosition(ctx context.Context, electionID, positionID uuid.UUID) error
+	AddCandidate(ctx context.Context, electionID, positionID uuid.UUID, req *CandidateRequest) (*CandidateResponse, error)
+	RemoveCandidate(ctx context.Context, electionID, candidateID uuid.UUID) error
+	ScheduleElection(ctx context.Context, id uuid.UUID) (*ElectionResponse, error)
+	CancelElection(ctx context.Context, id uuid.UUID) (*ElectionResponse, error)
+	PublishResults(ctx context.Context, id uuid.UUID) (*ElectionResultsResponse, error)
+	ListAllElections(ctx context.Context, req *ListElectionsRequest) ([]ElectionResponse, error)
+	ListProxies(ctx context.Context, electionID uuid.UUID, req *ListProxiesRequest) ([]ProxyResponse, error)
+	GetProxyAuthorization(ctx context.Context, electionID, proxyID uuid.UUID) (*StoredFile, error)
+	ReviewProxy(ctx context.Context, electionID, proxyID, reviewedBy uuid.UUID, req *ProxyReviewRequest) (*ProxyResponse, error)
+	ListElections(ctx context.Context, req *ListElectionsRequest) ([]ElectionResponse, error)
+	GetElection(ctx context.Context, userID, id uuid.UUID) (*ElectionDetailResponse, error)
+	GetQuorum(ctx context.Context, userID, id uuid.UUID) (*QuorumResponse, error)
+	GetEligibility(ctx context.Context, userID, id uuid.UUID) ([]BallotEligibilityResponse, error)
+	CastBallot(ctx context.Context, userID, id uuid.UUID, req *BallotRequest) (*BallotReceiptResponse, error)
+	GetResults(ctx context.Context, userID, id uuid.UUID) (*ElectionResultsResponse, error)
+	GrantProxy(ctx context.Context, userID, id uuid.UUID, req *ProxyRequest, upload *FileUpload) (*ProxyResponse, error)
+	RevokeProxy(ctx context.Context, userID, electionID, proxyID uuid.UUID) (*ProxyResponse, error)
+	ListUserProxies(ctx context.Context, userID uuid.UUID) ([]ProxyResponse, error)
+}
+
+type PollService interface {
+	CreatePoll(ctx context.Context, createdBy uuid.UUID, req *PollRequest) (*PollDetailResponse, error)
+	UpdatePoll(ctx context.Context, id uuid.UUID, req *PollRequest) (*PollDetailResponse, error)
+	PublishPoll(ctx context.Context, id uuid.UUID) (*PollResponse, error)
+	CancelPoll(ctx context.Context, id uuid.UUID) (*PollResponse, error)
+	ClosePoll(ctx context.Context, id uuid.UUID) (*PollResultsResponse, error)
+	ListAllPolls(ctx context.Context, req *ListPollsRequest) ([]PollResponse, error)
+	ListPolls(ctx context.Context, req *ListPollsRequest) ([]PollResponse, error)
+	GetPoll(ctx context.Context, userID, id uuid.UUID) (*PollDetailResponse, error)
+	CastVote(ctx context.Context, userID, id uuid.UUID, req *PollVoteRequest) (*PollVoteReceiptResponse, error)
+	GetResults(ctx context.Context, userID, id uuid.UUID) (*PollResultsResponse, error)
+	RenderResultsCSV(results *PollResultsResponse) ([]byte, error)
+}
+
+type EventService interface {
+	CreateEvent(ctx context.Context, createdBy uuid.UUID, req *EventRequest) (*EventResponse, error)
+	UpdateEvent(ctx context.Context, id uuid.UUID, req *EventRequest) (*EventResponse, error)
+	CancelEvent(ctx context.Context, id uuid.UUID, req *CancelEventRequest) (*EventResponse, error)
+	ListAttendees(ctx context.Context, id uuid.UUID) (*EventAttendeesResponse, error)
+	ListEvents(ctx context.Context, req *ListEventsRequest) ([]EventResponse, error)
+	GetEvent(ctx context.Context, userID, id uuid.UUID) (*EventDetailResponse, error)
+	RSVP(ctx context.Context, userID, id uuid.UUID, req *RSVPRequest) (*RSVPResponse, error)
+	CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (*CalendarFeedResponse, error)
+	RevokeCalendarFeed(ctx context.Context, userID uuid.UUID) error
+	GetCalendarFeed(ctx context.Context, token string) ([]byte, error)
 }
 
 type Service struct {
-	UserService UserService
+	AssociationService  AssociationService
+	UserService         UserService
+	RegistrationService RegistrationService
+	MFAService          MFAService
+	RoleService         RoleService
+	AuthService         AuthService
+	PropertyService     PropertyService
+	OccupancyService    OccupancyService
+	BillingService      BillingService
+	PaymentService      PaymentService
+	PenaltyService      PenaltyService
+	StatementService    StatementService
+	AnnouncementService AnnouncementService
+	AmenityService      AmenityService
+	GatePassService     GatePassService
+	VehicleService      VehicleService
+	TicketService       TicketService
+	NotificationService NotificationService
+	ViolationService    ViolationService
+	DocumentService     DocumentService
+	FileService         FileService
+	ElectionService     ElectionService
+	PollService         PollService
+	EventService        EventService
 }
 
+// CreateUserRequest is sent as JSON or, when claiming a property, as a
+// multipart form with the proof file alongside.
 type CreateUserRequest struct {
-	FirstName    string  `json:"firstName" binding:"required"`
-	LastName     string  `json:"lastName" binding:"required"`
-	MiddleName   *string `json:"middleName"`
-	Email        string  `json:"email" binding:"required,email"`
-	Password     string  `json:"password" binding:"required,min=8"`
-	DateOfBirth  string  `json:"dateOfBirth"`
-	MobileNumber string  `json:"mobileNumber"`
-	Gender       string  `json:"gender"`
-	Status       string  `json:"status"`
+	FirstName    string  `json:"firstName" form:"firstName" binding:"required"`
+	LastName     string  `json:"lastName" form:"lastName" binding:"required"`
+	MiddleName   *string `json:"middleName" form:"middleName"`
+	Email        string  `json:"email" form:"email" binding:"required,email"`
+	Password     string  `json:"password" form:"password" binding:"required,min=8"`
+	DateOfBirth  string  `json:"dateOfBirth" form:"dateOfBirth"`
+	MobileNumber string  `json:"mobileNumber" form:"mobileNumber"`
+	Gender       string  `json:"gender" form:"gender"`
+	Status       string  `json:"status" form:"status"`
+	Phase        string  `json:"phase" form:"phase"`
+	Block        string  `json:"block" form:"block"`
+	Lot          string  `json:"lot" form:"lot"`
+	ProofType    string  `json:"proofType" form:"proofType" binding:"omitempty,oneof=title lease"`
 	//RoleID       string  `json:"roleId" binding:"required"`
 }
 
@@ -35,6 +338,49 @@ type CreatUserResponse struct {
 	MiddleName *string `json:"middleName"`
 	Email      string  `json:"email"`
 	UserType   string  `json:"userType"`
+	Status     string  `json:"status"`
+	PropertyID *string `json:"propertyId"`
+}
+
+type ListRegistrationsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ApproveRegistrationRequest may override how the claimed property is
+// linked. The occupancy type defaults to owner for a title and tenant for a
+// lease, and starts today unless a start date is given.
+type ApproveRegistrationRequest struct {
+	OccupancyType string `json:"occupancyType" binding:"omitempty,oneof=owner co_owner tenant representative"`
+	StartDate     string `json:"startDate"`
+}
+
+type RejectRegistrationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type RegistrationResponse struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"userId"`
+	FirstName        string     `json:"firstName"`
+	LastName         string     `json:"lastName"`
+	Email            string     `json:"email"`
+	MobileNumber     string     `json:"mobileNumber"`
+	UserStatus       string     `json:"userStatus"`
+	PropertyID       *string    `json:"propertyId"`
+	Phase            *string    `json:"phase"`
+	Block            *string    `json:"block"`
+	Lot              *string    `json:"lot"`
+	ProofType        *string    `json:"proofType"`
+	ProofFileName    *string    `json:"proofFileName"`
+	ProofContentType *string    `json:"proofContentType"`
+	ProofSizeBytes   *int64     `json:"proofSizeBytes"`
+	Status           string     `json:"status"`
+	RejectionReason  *string    `json:"rejectionReason"`
+	ReviewedBy       *string    `json:"reviewedBy"`
+	ReviewedAt       *time.Time `json:"reviewedAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
 }
 
 type LoginUserRequest struct {
@@ -42,8 +388,1389 @@ type LoginUserRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-func NewService(repos *repository.Repository) *Service {
+// MFAChallengeResponse is returned by login in place of the tokens when a
+// second factor is needed. Like the token response it is snake_case.
+type MFAChallengeResponse struct {
+	MFARequired        bool      `json:"mfa_required"`
+	ChallengeToken     string    `json:"challenge_token"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+}
+
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+// MFALoginRequest redeems a challenge with either a code from the
+// authenticator app or a recovery code.
+type MFALoginRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+type MFACodeRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MFAStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type UserAccessResponse struct {
+	UserID      string   `json:"userId"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type PropertyRequest struct {
+	OwnerID    *string  `json:"ownerId" binding:"omitempty,uuid"`
+	Block      string   `json:"block" binding:"required"`
+	Lot        string   `json:"lot" binding:"required"`
+	Road       *string  `json:"road"`
+	Phase      string   `json:"phase" binding:"required"`
+	Type       *string  `json:"type"`
+	LotAreaSqm *float64 `json:"lotAreaSqm" binding:"omitempty,gt=0"`
+}
+
+type ListPropertiesRequest struct {
+	Phase           string `form:"phase"`
+	Block           string `form:"block"`
+	Road            string `form:"road"`
+	Type            string `form:"type"`
+	OwnerID         string `form:"ownerId" binding:"omitempty,uuid"`
+	IncludeArchived bool   `form:"includeArchived"`
+	Limit           int    `form:"limit" binding:"omitempty,min=1"`
+	Offset          int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type PropertyResponse struct {
+	ID         string     `json:"id"`
+	OwnerID    *string    `json:"ownerId"`
+	Block      string     `json:"block"`
+	Lot        string     `json:"lot"`
+	Road       *string    `json:"road"`
+	Phase      string     `json:"phase"`
+	Type       *string    `json:"type"`
+	LotAreaSqm *float64   `json:"lotAreaSqm"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// AddOccupantRequest covers everyone except the owner; ownership changes go
+// through TransferOwnershipRequest so the history stays consistent.
+type AddOccupantRequest struct {
+	UserID        string  `json:"userId" binding:"required,uuid"`
+	OccupancyType string  `json:"occupancyType" binding:"required,oneof=co_owner tenant representative"`
+	StartDate     string  `json:"startDate" binding:"required"`
+	EndDate       string  `json:"endDate"`
+	Notes         *string `json:"notes"`
+}
+
+type EndOccupancyRequest struct {
+	EndDate string `json:"endDate" binding:"required"`
+}
+
+type TransferOwnerRequest struct {
+	UserID        string `json:"userId" binding:"required,uuid"`
+	OccupancyType string `json:"occupancyType" binding:"required,oneof=owner co_owner"`
+}
+
+type TransferOwnershipRequest struct {
+	TransferDate string                 `json:"transferDate" binding:"required"`
+	Owners       []TransferOwnerRequest `json:"owners" binding:"required,min=1,dive"`
+}
+
+type OccupancyLookupRequest struct {
+	Phase string `form:"phase" binding:"required"`
+	Block string `form:"block" binding:"required"`
+	Lot   string `form:"lot" binding:"required"`
+	Date  string `form:"date"`
+}
+
+type OccupantResponse struct {
+	ID            string  `json:"id"`
+	PropertyID    string  `json:"propertyId"`
+	UserID        string  `json:"userId"`
+	FirstName     string  `json:"firstName"`
+	LastName      string  `json:"lastName"`
+	Email         string  `json:"email"`
+	OccupancyType string  `json:"occupancyType"`
+	StartDate     string  `json:"startDate"`
+	EndDate       *string `json:"endDate"`
+	Notes         *string `json:"notes"`
+}
+
+type OccupancyLookupResponse struct {
+	Property  PropertyResponse   `json:"property"`
+	Date      string             `json:"date"`
+	Occupants []OccupantResponse `json:"occupants"`
+}
+
+type UserPropertyResponse struct {
+	Property      PropertyResponse `json:"property"`
+	OccupancyID   string           `json:"occupancyId"`
+	OccupancyType string           `json:"occupancyType"`
+	StartDate     string           `json:"startDate"`
+	EndDate       *string          `json:"endDate"`
+}
+
+type DuesScheduleRequest struct {
+	Name          string  `json:"name" binding:"required"`
+	PropertyType  *string `json:"propertyType"`
+	Phase         *string `json:"phase"`
+	RateType      string  `json:"rateType" binding:"required,oneof=flat per_sqm"`
+	AmountCents   int64   `json:"amountCents" binding:"min=0"`
+	EffectiveFrom string  `json:"effectiveFrom" binding:"required"`
+	EffectiveTo   string  `json:"effectiveTo"`
+}
+
+type DuesScheduleResponse struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	PropertyType  *string `json:"propertyType"`
+	Phase         *string `json:"phase"`
+	RateType      string  `json:"rateType"`
+	AmountCents   int64   `json:"amountCents"`
+	EffectiveFrom string  `json:"effectiveFrom"`
+	EffectiveTo   *string `json:"effectiveTo"`
+}
+
+type BillingRunRequest struct {
+	Period string `json:"period" binding:"required"`
+}
+
+type BillingRunPreview struct {
+	Period       string           `json:"period"`
+	InvoiceCount int              `json:"invoiceCount"`
+	TotalCents   int64            `json:"totalCents"`
+	Invoices     []InvoicePreview `json:"invoices"`
+	Skipped      []BillingSkip    `json:"skipped"`
+}
+
+type InvoicePreview struct {
+	PropertyID string                    `json:"propertyId"`
+	Phase      string                    `json:"phase"`
+	Block      string                    `json:"block"`
+	Lot        string                    `json:"lot"`
+	DueDate    string                    `json:"dueDate"`
+	TotalCents int64                     `json:"totalCents"`
+	LineItems  []InvoiceLineItemResponse `json:"lineItems"`
+}
+
+type BillingSkip struct {
+	PropertyID string `json:"propertyId"`
+	Phase      string `json:"phase"`
+	Block      string `json:"block"`
+	Lot        string `json:"lot"`
+	Reason     string `json:"reason"`
+}
+
+type BillingRunResponse struct {
+	ID           string    `json:"id"`
+	Period       string    `json:"period"`
+	InvoiceCount int       `json:"invoiceCount"`
+	TotalCents   int64     `json:"totalCents"`
+	RunBy        *string   `json:"runBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type ListInvoicesRequest struct {
+	PropertyID string `form:"propertyId" binding:"omitempty,uuid"`
+	Status     string `form:"status" binding:"omitempty,oneof=open partially_paid paid void"`
+	Kind       string `form:"kind" binding:"omitempty,oneof=dues fine"`
+	Period     string `form:"period"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type VoidInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type InvoiceResponse struct {
+	ID            string                    `json:"id"`
+	InvoiceNumber string                    `json:"invoiceNumber"`
+	PropertyID    string                    `json:"propertyId"`
+	Kind          string                    `json:"kind"`
+	Period        string                    `json:"period"`
+	IssueDate     string                    `json:"issueDate"`
+	DueDate       string                    `json:"dueDate"`
+	Status        string                    `json:"status"`
+	TotalCents    int64                     `json:"totalCents"`
+	PaidCents     int64                     `json:"paidCents"`
+	PenaltyCents  int64                     `json:"penaltyCents"`
+	BalanceCents  int64                     `json:"balanceCents"`
+	VoidReason    *string                   `json:"voidReason,omitempty"`
+	LineItems     []InvoiceLineItemResponse `json:"lineItems"`
+}
+
+type InvoiceLineItemResponse struct {
+	Description     string  `json:"description"`
+	Quantity        float64 `json:"quantity"`
+	UnitAmountCents int64   `json:"unitAmountCents"`
+	AmountCents     int64   `json:"amountCents"`
+}
+
+type RecordPaymentRequest struct {
+	PropertyID      string  `json:"propertyId" binding:"required,uuid"`
+	Method          string  `json:"method" binding:"required,oneof=cash check bank_transfer e_wallet"`
+	ReferenceNumber *string `json:"referenceNumber"`
+	AmountCents     int64   `json:"amountCents" binding:"required,min=1"`
+	PaidAt          string  `json:"paidAt"`
+	Notes           *string `json:"notes"`
+}
+
+type ListPaymentsRequest struct {
+	PropertyID string `form:"propertyId" binding:"omitempty,uuid"`
+	Method     string `form:"method" binding:"omitempty,oneof=cash check bank_transfer e_wallet"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type PaymentResponse struct {
+	ID               string                      `json:"id"`
+	ReceiptNumber    string                      `json:"receiptNumber"`
+	PropertyID       string                      `json:"propertyId"`
+	Method           string                      `json:"method"`
+	ReferenceNumber  *string                     `json:"referenceNumber"`
+	AmountCents      int64                       `json:"amountCents"`
+	AllocatedCents   int64                       `json:"allocatedCents"`
+	UnallocatedCents int64                       `json:"unallocatedCents"`
+	PaidAt           string                      `json:"paidAt"`
+	Notes            *string                     `json:"notes"`
+	RecordedBy       *string                     `json:"recordedBy"`
+	CreatedAt        time.Time                   `json:"createdAt"`
+	Allocations      []PaymentAllocationResponse `json:"allocations,omitempty"`
+}
+
+type PaymentAllocationResponse struct {
+	InvoiceID     string `json:"invoiceId"`
+	InvoiceNumber string `json:"invoiceNumber"`
+	AmountCents   int64  `json:"amountCents"`
+}
+
+type PropertyCreditResponse struct {
+	PropertyID  string `json:"propertyId"`
+	CreditCents int64  `json:"creditCents"`
+}
+
+type PenaltyRuleRequest struct {
+	Name               string `json:"name" binding:"required"`
+	GraceDays          int    `json:"graceDays" binding:"min=0"`
+	FixedCents         int64  `json:"fixedCents" binding:"min=0"`
+	MonthlyInterestBps int    `json:"monthlyInterestBps" binding:"min=0,max=10000"`
+	EffectiveFrom      string `json:"effectiveFrom" binding:"required"`
+	EffectiveTo        string `json:"effectiveTo"`
+}
+
+type PenaltyRuleResponse struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	GraceDays          int     `json:"graceDays"`
+	FixedCents         int64   `json:"fixedCents"`
+	MonthlyInterestBps int     `json:"monthlyInterestBps"`
+	EffectiveFrom      string  `json:"effectiveFrom"`
+	EffectiveTo        *string `json:"effectiveTo"`
+}
+
+type WaivePenaltyRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type InvoicePenaltyResponse struct {
+	ID          string     `json:"id"`
+	InvoiceID   string     `json:"invoiceId"`
+	RuleID      string     `json:"ruleId"`
+	PenaltyType string     `json:"penaltyType"`
+	AccruedOn   string     `json:"accruedOn"`
+	AmountCents int64      `json:"amountCents"`
+	Status      string     `json:"status"`
+	WaiveReason *string    `json:"waiveReason,omitempty"`
+	WaivedBy    *string    `json:"waivedBy,omitempty"`
+	WaivedAt    *time.Time `json:"waivedAt,omitempty"`
+}
+
+// StatementRequest selects the statement range; PropertyID is only read by
+// the member endpoint, the admin endpoint takes it from the path.
+type StatementRequest struct {
+	PropertyID string `form:"propertyId" binding:"omitempty,uuid"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+}
+
+type StatementResponse struct {
+	AssociationName     string                   `json:"associationName"`
+	Property            PropertyResponse         `json:"property"`
+	From                string                   `json:"from"`
+	To                  string                   `json:"to"`
+	OpeningBalanceCents int64                    `json:"openingBalanceCents"`
+	TotalChargesCents   int64                    `json:"totalChargesCents"`
+	TotalCreditsCents   int64                    `json:"totalCreditsCents"`
+	ClosingBalanceCents int64                    `json:"closingBalanceCents"`
+	Entries             []StatementEntryResponse `json:"entries"`
+	GeneratedAt         time.Time                `json:"generatedAt"`
+}
+
+type StatementEntryResponse struct {
+	Date         string `json:"date"`
+	Type         string `json:"type"`
+	Reference    string `json:"reference"`
+	Description  string `json:"description"`
+	ChargeCents  int64  `json:"chargeCents"`
+	CreditCents  int64  `json:"creditCents"`
+	BalanceCents int64  `json:"balanceCents"`
+}
+
+type AnnouncementRequest struct {
+	Title         string     `json:"title" binding:"required"`
+	Body          string     `json:"body" binding:"required"`
+	AudienceType  string     `json:"audienceType" binding:"required,oneof=all phase block role"`
+	AudiencePhase *string    `json:"audiencePhase"`
+	AudienceBlock *string    `json:"audienceBlock"`
+	AudienceRole  *string    `json:"audienceRole"`
+	Pinned        bool       `json:"pinned"`
+	PublishAt     *time.Time `json:"publishAt"`
+	ExpireAt      *time.Time `json:"expireAt"`
+}
+
+type ListAnnouncementsRequest struct {
+	IncludeExpired bool `form:"includeExpired"`
+	Limit          int  `form:"limit" binding:"omitempty,min=1"`
+	Offset         int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AnnouncementFeedRequest struct {
+	Unread bool `form:"unread"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AnnouncementResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	AudienceType  string     `json:"audienceType"`
+	AudiencePhase *string    `json:"audiencePhase"`
+	AudienceBlock *string    `json:"audienceBlock"`
+	AudienceRole  *string    `json:"audienceRole"`
+	Pinned        bool       `json:"pinned"`
+	PublishAt     time.Time  `json:"publishAt"`
+	ExpireAt      *time.Time `json:"expireAt"`
+	CreatedBy     *string    `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+type FeedAnnouncementResponse struct {
+	AnnouncementResponse
+	Read   bool       `json:"read"`
+	ReadAt *time.Time `json:"readAt"`
+}
+
+type UnreadCountResponse struct {
+	Unread int `json:"unread"`
+}
+
+type AnnouncementReadResponse struct {
+	UserID    string    `json:"userId"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Email     string    `json:"email"`
+	ReadAt    time.Time `json:"readAt"`
+}
+
+// AmenityRequest takes opening hours as HH:MM in the association's time zone.
+// RequiresApproval defaults to true for amenities that charge a fee.
+type AmenityRequest struct {
+	Name              string  `json:"name" binding:"required"`
+	Description       *string `json:"description"`
+	Capacity          int     `json:"capacity" binding:"required,min=1"`
+	OpensAt           string  `json:"opensAt" binding:"required"`
+	ClosesAt          string  `json:"closesAt" binding:"required"`
+	SlotMinutes       int     `json:"slotMinutes" binding:"required,min=1"`
+	FeeCents          int64   `json:"feeCents" binding:"min=0"`
+	RequiresApproval  *bool   `json:"requiresApproval"`
+	CancellationHours int     `json:"cancellationHours" binding:"min=0"`
+	Active            *bool   `json:"active"`
+}
+
+type AmenityResponse struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Description       *string   `json:"description"`
+	Capacity          int       `json:"capacity"`
+	OpensAt           string    `json:"opensAt"`
+	ClosesAt          string    `json:"closesAt"`
+	SlotMinutes       int       `json:"slotMinutes"`
+	FeeCents          int64     `json:"feeCents"`
+	RequiresApproval  bool      `json:"requiresApproval"`
+	CancellationHours int       `json:"cancellationHours"`
+	Active            bool      `json:"active"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type AvailabilityRequest struct {
+	Date string `form:"date"`
+}
+
+type AvailabilityResponse struct {
+	AmenityID   string             `json:"amenityId"`
+	Date        string             `json:"date"`
+	SlotMinutes int                `json:"slotMinutes"`
+	Slots       []AvailabilitySlot `json:"slots"`
+}
+
+type AvailabilitySlot struct {
+	StartAt   time.Time `json:"startAt"`
+	EndAt     time.Time `json:"endAt"`
+	Available bool      `json:"available"`
+}
+
+// ReservationRequest books a block of consecutive slots. PropertyID may be
+// omitted when the member is linked to a single property.
+type ReservationRequest struct {
+	PropertyID string    `json:"propertyId" binding:"omitempty,uuid"`
+	StartAt    time.Time `json:"startAt" binding:"required"`
+	EndAt      time.Time `json:"endAt" binding:"required"`
+	GuestCount int       `json:"guestCount" binding:"required,min=1"`
+	Notes      *string   `json:"notes"`
+}
+
+type ListReservationsRequest struct {
+	AmenityID string `form:"amenityId" binding:"omitempty,uuid"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+	From      string `form:"from"`
+	To        string `form:"to"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1"`
+	Offset    int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type ReservationDecisionRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type CancelReservationRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type ReservationResponse struct {
+	ID             string     `json:"id"`
+	AmenityID      string     `json:"amenityId"`
+	AmenityName    string     `json:"amenityName"`
+	PropertyID     string     `json:"propertyId"`
+	UserID         string     `json:"userId"`
+	StartAt        time.Time  `json:"startAt"`
+	EndAt          time.Time  `json:"endAt"`
+	GuestCount     int        `json:"guestCount"`
+	Status         string     `json:"status"`
+	FeeCents       int64      `json:"feeCents"`
+	Notes          *string    `json:"notes"`
+	DecisionReason *string    `json:"decisionReason,omitempty"`
+	DecidedBy      *string    `json:"decidedBy,omitempty"`
+	DecidedAt      *time.Time `json:"decidedAt,omitempty"`
+	CancelReason   *string    `json:"cancelReason,omitempty"`
+	CancelledAt    *time.Time `json:"cancelledAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// GatePassRequest pre-registers a visitor. Passes are single-use unless
+// MultiUse is set; a multi-use pass without MaxUses can be used any number
+// of times within its validity window.
+type GatePassRequest struct {
+	PropertyID   string     `json:"propertyId" binding:"omitempty,uuid"`
+	VisitorName  string     `json:"visitorName" binding:"required"`
+	VisitorType  string     `json:"visitorType" binding:"required,oneof=guest delivery service"`
+	VehiclePlate *string    `json:"vehiclePlate"`
+	Purpose      *string    `json:"purpose"`
+	ValidFrom    *time.Time `json:"validFrom"`
+	ValidUntil   time.Time  `json:"validUntil" binding:"required"`
+	MultiUse     bool       `json:"multiUse"`
+	MaxUses      *int       `json:"maxUses" binding:"omitempty,min=1"`
+}
+
+type ListGatePassesRequest struct {
+	IncludeRevoked bool `form:"includeRevoked"`
+	Limit          int  `form:"limit" binding:"omitempty,min=1"`
+	Offset         int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type RevokeGatePassRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type VerifyGatePassRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type GateEntryRequest struct {
+	Token string  `json:"token" binding:"required"`
+	Notes *string `json:"notes"`
+}
+
+// WalkInRequest logs a visitor without a gate pass. VisitedUserID, when
+// given, must be a current occupant of the property.
+type WalkInRequest struct {
+	VisitorName   string  `json:"visitorName" binding:"required"`
+	VehiclePlate  *string `json:"vehiclePlate"`
+	PropertyID    string  `json:"propertyId" binding:"required,uuid"`
+	VisitedUserID string  `json:"visitedUserId" binding:"omitempty,uuid"`
+	Purpose       *string `json:"purpose"`
+	Notes         *string `json:"notes"`
+}
+
+type ExpectedVisitorsRequest struct {
+	Date string `form:"date"`
+}
+
+type ListGateEntriesRequest struct {
+	Date   string `form:"date"`
+	Inside bool   `form:"inside"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SearchLogbookRequest searches entries between two local dates, inclusive.
+// Both default to today.
+type SearchLogbookRequest struct {
+	From         string `form:"from"`
+	To           string `form:"to"`
+	VehiclePlate string `form:"plate"`
+	PropertyID   string `form:"propertyId" binding:"omitempty,uuid"`
+	EntryType    string `form:"entryType" binding:"omitempty,oneof=pass walk_in"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1"`
+	Offset       int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// GatePassResponse only carries the signed QR payload when returned to the
+// host who created the pass.
+type GatePassResponse struct {
+	ID           string     `json:"id"`
+	PropertyID   string     `json:"propertyId"`
+	HostUserID   string     `json:"hostUserId"`
+	VisitorName  string     `json:"visitorName"`
+	VisitorType  string     `json:"visitorType"`
+	VehiclePlate *string    `json:"vehiclePlate"`
+	Purpose      *string    `json:"purpose"`
+	ValidFrom    time.Time  `json:"validFrom"`
+	ValidUntil   time.Time  `json:"validUntil"`
+	MaxUses      *int       `json:"maxUses"`
+	UseCount     int        `json:"useCount"`
+	Status       string     `json:"status"`
+	RevokedAt    *time.Time `json:"revokedAt,omitempty"`
+	RevokeReason *string    `json:"revokeReason,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	QRPayload    string     `json:"qrPayload,omitempty"`
+}
+
+type GatePassVerification struct {
+	Valid  bool              `json:"valid"`
+	Reason string            `json:"reason,omitempty"`
+	Pass   *GatePassResponse `json:"pass,omitempty"`
+}
+
+type GateEntryResponse struct {
+	ID            string     `json:"id"`
+	EntryType     string     `json:"entryType"`
+	GatePassID    *string    `json:"gatePassId"`
+	VisitorName   string     `json:"visitorName"`
+	VehiclePlate  *string    `json:"vehiclePlate"`
+	PropertyID    string     `json:"propertyId"`
+	VisitedUserID *string    `json:"visitedUserId"`
+	Purpose       *string    `json:"purpose"`
+	EnteredAt     time.Time  `json:"enteredAt"`
+	EntryGuardID  *string    `json:"entryGuardId"`
+	ExitedAt      *time.Time `json:"exitedAt"`
+	ExitGuardID   *string    `json:"exitGuardId"`
+	Notes         *string    `json:"notes"`
+}
+
+type VehicleRequest struct {
+	PropertyID  string `json:"propertyId" binding:"omitempty,uuid"`
+	PlateNumber string `json:"plateNumber" binding:"required"`
+	Make        string `json:"make" binding:"required"`
+	Model       string `json:"model" binding:"required"`
+	Color       string `json:"color" binding:"required"`
+}
+
+type ListVehiclesRequest struct {
+	PropertyID      string `form:"propertyId" binding:"omitempty,uuid"`
+	PlateNumber     string `form:"plate"`
+	IncludeArchived bool   `form:"includeArchived"`
+	Limit           int    `form:"limit" binding:"omitempty,min=1"`
+	Offset          int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// IssueStickerRequest defaults Year to the current year.
+type IssueStickerRequest struct {
+	Year int `json:"year" binding:"omitempty,min=2000,max=2100"`
+}
+
+type ListStickersRequest struct {
+	Year   int    `form:"year" binding:"omitempty,min=2000,max=2100"`
+	Status string `form:"status" binding:"omitempty,oneof=issued lost revoked"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type StickerStatusRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type VehicleResponse struct {
+	ID          string            `json:"id"`
+	PropertyID  string            `json:"propertyId"`
+	UserID      string            `json:"userId"`
+	PlateNumber string            `json:"plateNumber"`
+	Make        string            `json:"make"`
+	Model       string            `json:"model"`
+	Color       string            `json:"color"`
+	ArchivedAt  *time.Time        `json:"archivedAt,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Stickers    []StickerResponse `json:"stickers,omitempty"`
+}
+
+type StickerResponse struct {
+	ID              string     `json:"id"`
+	VehicleID       string     `json:"vehicleId"`
+	PlateNumber     string     `json:"plateNumber"`
+	Year            int        `json:"year"`
+	StickerNumber   string     `json:"stickerNumber"`
+	Status          string     `json:"status"`
+	StatusReason    *string    `json:"statusReason,omitempty"`
+	IssuedBy        *string    `json:"issuedBy"`
+	IssuedAt        time.Time  `json:"issuedAt"`
+	StatusChangedAt *time.Time `json:"statusChangedAt,omitempty"`
+}
+
+// PlateLookupResponse tells a guard whose vehicle it is and whether it
+// carries a valid sticker for the current year.
+type PlateLookupResponse struct {
+	Vehicle         VehicleResponse  `json:"vehicle"`
+	Property        PropertyResponse `json:"property"`
+	HasValidSticker bool             `json:"hasValidSticker"`
+	CurrentSticker  *StickerResponse `json:"currentSticker"`
+}
+
+// TicketRequest takes either a PropertyID (defaulting to the reporter's
+// property when both are empty) or a CommonArea such as "Phase 2 park".
+type TicketRequest struct {
+	Category    string `json:"category" binding:"required"`
+	Priority    string `json:"priority"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description" binding:"required"`
+	PropertyID  string `json:"propertyId" binding:"omitempty,uuid"`
+	CommonArea  string `json:"commonArea"`
+}
+
+type ListTicketsRequest struct {
+	Status         string `form:"status"`
+	Category       string `form:"category"`
+	Priority       string `form:"priority"`
+	PropertyID     string `form:"propertyId" binding:"omitempty,uuid"`
+	AssignedUserID string `form:"assignedTo" binding:"omitempty,uuid"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1"`
+	Offset         int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AssignTicketRequest assigns a ticket to a staff user, an outside vendor, or
+// both. Sending neither unassigns it.
+type AssignTicketRequest struct {
+	UserID string  `json:"userId" binding:"omitempty,uuid"`
+	Vendor *string `json:"vendor"`
+}
+
+type TicketStatusRequest struct {
+	Status string  `json:"status"`
+	Note   *string `json:"note"`
+}
+
+type TicketCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// FileUpload is an uploaded file as received by a handler.
+type FileUpload struct {
+	FileName  string
+	SizeBytes int64
+	Body      io.Reader
+}
+
+// StoredFile is a stored file ready to stream; the caller closes Body.
+// FileName is set when the file should be saved rather than displayed, and
+// SizeBytes is -1 when unknown.
+type StoredFile struct {
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	Body        io.ReadCloser
+}
+
+type TicketResponse struct {
+	ID             string     `json:"id"`
+	TicketNumber   string     `json:"ticketNumber"`
+	ReporterID     string     `json:"reporterId"`
+	Category       string     `json:"category"`
+	Priority       string     `json:"priority"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	PropertyID     *string    `json:"propertyId"`
+	CommonArea     *string    `json:"commonArea"`
+	Status         string     `json:"status"`
+	AssignedUserID *string    `json:"assignedUserId"`
+	AssignedVendor *string    `json:"assignedVendor"`
+	ResolvedAt     *time.Time `json:"resolvedAt"`
+	ClosedAt       *time.Time `json:"closedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type TicketDetailResponse struct {
+	TicketResponse
+	Photos   []TicketPhotoResponse        `json:"photos"`
+	Comments []TicketCommentResponse      `json:"comments"`
+	History  []TicketStatusChangeResponse `json:"history"`
+}
+
+type TicketPhotoResponse struct {
+	ID          string    `json:"id"`
+	ContentType string    `json:"contentType"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type TicketCommentResponse struct {
+	ID         string    `json:"id"`
+	AuthorID   *string   `json:"authorId"`
+	AuthorName string    `json:"authorName"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type TicketStatusChangeResponse struct {
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	Note       *string   `json:"note"`
+	ChangedBy  *string   `json:"changedBy"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+type ListNotificationsRequest struct {
+	Unread bool `form:"unread"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type NotificationResponse struct {
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	ReferenceType *string    `json:"referenceType"`
+	ReferenceID   *string    `json:"referenceId"`
+	ReadAt        *time.Time `json:"readAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+type ViolationRequest struct {
+	PropertyID  string     `json:"propertyId" binding:"required,uuid"`
+	Category    string     `json:"category" binding:"required"`
+	Description string     `json:"description" binding:"required"`
+	ObservedAt  *time.Time `json:"observedAt"`
+}
+
+type ListViolationsRequest struct {
+	PropertyID string `form:"propertyId" binding:"omitempty,uuid"`
+	Status     string `form:"status"`
+	Category   string `form:"category"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type ViolationNoticeRequest struct {
+	Message      string `json:"message" binding:"required"`
+	CureDeadline string `json:"cureDeadline" binding:"required"`
+}
+
+type ViolationFineRequest struct {
+	AmountCents int64  `json:"amountCents" binding:"required,min=1"`
+	DueDate     string `json:"dueDate"`
+}
+
+type AppealRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type AppealDecisionRequest struct {
+	Accept bool    `json:"accept"`
+	Note   *string `json:"note"`
+}
+
+type ViolationStatusRequest struct {
+	Note *string `json:"note"`
+}
+
+type ViolationResponse struct {
+	ID              string     `json:"id"`
+	ViolationNumber string     `json:"violationNumber"`
+	PropertyID      string     `json:"propertyId"`
+	Category        string     `json:"category"`
+	Description     string     `json:"description"`
+	ObservedAt      time.Time  `json:"observedAt"`
+	Status          string     `json:"status"`
+	ReportedBy      *string    `json:"reportedBy"`
+	ClosedAt        *time.Time `json:"closedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+type ViolationDetailResponse struct {
+	ViolationResponse
+	Attachments []ViolationAttachmentResponse `json:"attachments"`
+	Notices     []ViolationNoticeResponse     `json:"notices"`
+	Fines       []ViolationFineResponse       `json:"fines"`
+	Appeals     []ViolationAppealResponse     `json:"appeals"`
+	History     []ViolationEventResponse      `json:"history"`
+}
+
+type ViolationAttachmentResponse struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ViolationNoticeResponse struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	CureDeadline string    `json:"cureDeadline"`
+	IssuedBy     *string   `json:"issuedBy"`
+	IssuedAt     time.Time `json:"issuedAt"`
+}
+
+type ViolationFineResponse struct {
+	ID            string    `json:"id"`
+	InvoiceID     string    `json:"invoiceId"`
+	InvoiceNumber string    `json:"invoiceNumber"`
+	InvoiceStatus string    `json:"invoiceStatus"`
+	AmountCents   int64     `json:"amountCents"`
+	IssuedBy      *string   `json:"issuedBy"`
+	IssuedAt      time.Time `json:"issuedAt"`
+}
+
+type ViolationAppealResponse struct {
+	ID           string     `json:"id"`
+	SubmittedBy  string     `json:"submittedBy"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	DecisionNote *string    `json:"decisionNote"`
+	DecidedBy    *string    `json:"decidedBy"`
+	DecidedAt    *time.Time `json:"decidedAt"`
+	SubmittedAt  time.Time  `json:"submittedAt"`
+}
+
+type ViolationEventResponse struct {
+	EventType string    `json:"eventType"`
+	Note      *string   `json:"note"`
+	ActorID   *string   `json:"actorId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DocumentRequest arrives as JSON on update and as multipart form fields
+// alongside the file on create.
+type DocumentRequest struct {
+	Title       string  `json:"title" form:"title" binding:"required"`
+	Description *string `json:"description" form:"description"`
+	Category    string  `json:"category" form:"category" binding:"required"`
+	VisibleRole *string `json:"visibleRole" form:"visibleRole"`
+	Notes       *string `json:"-" form:"notes"`
+}
+
+type DocumentVersionRequest struct {
+	Notes *string `form:"notes"`
+}
+
+type ListDocumentsRequest struct {
+	Q               string `form:"q"`
+	Category        string `form:"category"`
+	IncludeArchived bool   `form:"includeArchived"`
+	Limit           int    `form:"limit" binding:"omitempty,min=1"`
+	Offset          int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type DocumentDownloadRequest struct {
+	Version int `form:"version" binding:"omitempty,min=1"`
+}
+
+type SignedFileRequest struct {
+	Key       string
+	Name      string `form:"name" binding:"required"`
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+type DocumentResponse struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    *string    `json:"description"`
+	Category       string     `json:"category"`
+	VisibleRole    *string    `json:"visibleRole"`
+	CurrentVersion int        `json:"currentVersion"`
+	CreatedBy      *string    `json:"createdBy"`
+	ArchivedAt     *time.Time `json:"archivedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type DocumentDetailResponse struct {
+	DocumentResponse
+	Versions []DocumentVersionResponse `json:"versions"`
+}
+
+type DocumentVersionResponse struct {
+	ID            string    `json:"id"`
+	VersionNumber int       `json:"versionNumber"`
+	FileName      string    `json:"fileName"`
+	ContentType   string    `json:"contentType"`
+	SizeBytes     int64     `json:"sizeBytes"`
+	Notes         *string   `json:"notes"`
+	UploadedBy    *string   `json:"uploadedBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type DocumentDownloadResponse struct {
+	URL           string    `json:"url"`
+	FileName      string    `json:"fileName"`
+	VersionNumber int       `json:"versionNumber"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+type ElectionRequest struct {
+	Title          string    `json:"title" binding:"required"`
+	Description    *string   `json:"description"`
+	VotingStartsAt time.Time `json:"votingStartsAt" binding:"required"`
+	VotingEndsAt   time.Time `json:"votingEndsAt" binding:"required"`
+	QuorumPercent  int       `json:"quorumPercent" binding:"required,min=1,max=100"`
+}
+
+type ListElectionsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type ElectionPositionRequest struct {
+	Title     string `json:"title" binding:"required"`
+	Seats     int    `json:"seats" binding:"omitempty,min=1"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type CandidateRequest struct {
+	Name   string  `json:"name" binding:"required"`
+	UserID *string `json:"userId"`
+	Bio    *string `json:"bio"`
+}
+
+// BallotRequest votes for the property. Each selection lists the chosen
+// candidates for one position, up to its seats; omitted positions are
+// abstentions.
+type BallotRequest struct {
+	PropertyID string            `json:"propertyId" binding:"required,uuid"`
+	Selections []BallotSelection `json:"selections" binding:"dive"`
+}
+
+type BallotSelection struct {
+	PositionID   string   `json:"positionId" binding:"required,uuid"`
+	CandidateIDs []string `json:"candidateIds"`
+}
+
+// ProxyRequest arrives as multipart form fields alongside the signed
+// authorization.
+type ProxyRequest struct {
+	PropertyID string `form:"propertyId" binding:"required,uuid"`
+	ProxyEmail string `form:"proxyEmail" binding:"required,email"`
+}
+
+type ProxyReviewRequest struct {
+	Approve bool    `json:"approve"`
+	Note    *string `json:"note"`
+}
+
+type ListProxiesRequest struct {
+	Status string `form:"status"`
+}
+
+type ElectionResponse struct {
+	ID                 string     `json:"id"`
+	Title              string     `json:"title"`
+	Description        *string    `json:"description"`
+	VotingStartsAt     time.Time  `json:"votingStartsAt"`
+	VotingEndsAt       time.Time  `json:"votingEndsAt"`
+	QuorumPercent      int        `json:"quorumPercent"`
+	Status             string     `json:"status"`
+	Phase              string     `json:"phase"`
+	ResultsPublishedAt *time.Time `json:"resultsPublishedAt"`
+	CreatedBy          *string    `json:"createdBy"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+type ElectionDetailResponse struct {
+	ElectionResponse
+	Positions []ElectionPositionResponse `json:"positions"`
+}
+
+type ElectionPositionResponse struct {
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Seats      int                 `json:"seats"`
+	SortOrder  int                 `json:"sortOrder"`
+	Candidates []CandidateResponse `json:"candidates"`
+}
+
+type CandidateResponse struct {
+	ID         string  `json:"id"`
+	PositionID string  `json:"positionId"`
+	UserID     *string `json:"userId"`
+	Name       string  `json:"name"`
+	Bio        *string `json:"bio"`
+}
+
+type QuorumResponse struct {
+	EligibleProperties int  `json:"eligibleProperties"`
+	BallotsCast        int  `json:"ballotsCast"`
+	QuorumPercent      int  `json:"quorumPercent"`
+	RequiredBallots    int  `json:"requiredBallots"`
+	Reached            bool `json:"reached"`
+}
+
+type BallotEligibilityResponse struct {
+	PropertyID string  `json:"propertyId"`
+	Phase      string  `json:"phase"`
+	Block      string  `json:"block"`
+	Lot        string  `json:"lot"`
+	ViaProxy   bool    `json:"viaProxy"`
+	Voted      bool    `json:"voted"`
+	CanVote    bool    `json:"canVote"`
+	Reason     *string `json:"reason"`
+}
+
+type BallotReceiptResponse struct {
+	ElectionID string    `json:"electionId"`
+	PropertyID string    `json:"propertyId"`
+	ViaProxy   bool      `json:"viaProxy"`
+	CastAt     time.Time `json:"castAt"`
+}
+
+type ElectionResultsResponse struct {
+	ElectionID  string                   `json:"electionId"`
+	Title       string                   `json:"title"`
+	Status      string                   `json:"status"`
+	PublishedAt *time.Time               `json:"publishedAt"`
+	Quorum      QuorumResponse           `json:"quorum"`
+	Positions   []PositionResultResponse `json:"positions"`
+}
+
+type PositionResultResponse struct {
+	PositionID string                    `json:"positionId"`
+	Title      string                    `json:"title"`
+	Seats      int                       `json:"seats"`
+	Tied       bool                      `json:"tied"`
+	Candidates []CandidateResultResponse `json:"candidates"`
+}
+
+type CandidateResultResponse struct {
+	CandidateID string `json:"candidateId"`
+	Name        string `json:"name"`
+	Votes       int    `json:"votes"`
+	Elected     bool   `json:"elected"`
+}
+
+type ProxyResponse struct {
+	ID          string     `json:"id"`
+	ElectionID  string     `json:"electionId"`
+	PropertyID  string     `json:"propertyId"`
+	GrantedBy   string     `json:"grantedBy"`
+	ProxyUserID string     `json:"proxyUserId"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	SizeBytes   int64      `json:"sizeBytes"`
+	Status      string     `json:"status"`
+	ReviewNote  *string    `json:"reviewNote"`
+	ReviewedBy  *string    `json:"reviewedBy"`
+	ReviewedAt  *time.Time `json:"reviewedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// PollRequest describes a poll or resolution. Options are only given for
+// multiple choice polls; yes/no polls get Yes and No.
+type PollRequest struct {
+	Title         string    `json:"title" binding:"required"`
+	Description   *string   `json:"description"`
+	Kind          string    `json:"kind" binding:"required,oneof=yes_no multiple_choice"`
+	Audience      string    `json:"audience" binding:"required,oneof=members owners"`
+	Threshold     string    `json:"threshold" binding:"required,oneof=simple_majority two_thirds"`
+	QuorumPercent int       `json:"quorumPercent" binding:"required,min=1,max=100"`
+	Secret        bool      `json:"secret"`
+	OpensAt       time.Time `json:"opensAt" binding:"required"`
+	ClosesAt      time.Time `json:"closesAt" binding:"required"`
+	Options       []string  `json:"options"`
+}
+
+type ListPollsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type PollVoteRequest struct {
+	OptionID *string `json:"optionId"`
+	Abstain  bool    `json:"abstain"`
+}
+
+type PollResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Description   *string    `json:"description"`
+	Kind          string     `json:"kind"`
+	Audience      string     `json:"audience"`
+	Threshold     string     `json:"threshold"`
+	QuorumPercent int        `json:"quorumPercent"`
+	Secret        bool       `json:"secret"`
+	OpensAt       time.Time  `json:"opensAt"`
+	ClosesAt      time.Time  `json:"closesAt"`
+	Status        string     `json:"status"`
+	Phase         string     `json:"phase"`
+	ClosedAt      *time.Time `json:"closedAt"`
+	CreatedBy     *string    `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+type PollDetailResponse struct {
+	PollResponse
+	Options []PollOptionResponse `json:"options"`
+	Turnout *PollTurnoutResponse `json:"turnout"`
+	Voted   bool                 `json:"voted"`
+	CanVote bool                 `json:"canVote"`
+	Reason  *string              `json:"reason"`
+}
+
+type PollOptionResponse struct {
+	ID        string `json:"id"`
+	Label     string `json:"label"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type PollTurnoutResponse struct {
+	EligibleVoters  int  `json:"eligibleVoters"`
+	BallotsCast     int  `json:"ballotsCast"`
+	QuorumPercent   int  `json:"quorumPercent"`
+	RequiredBallots int  `json:"requiredBallots"`
+	Reached         bool `json:"reached"`
+}
+
+type PollVoteReceiptResponse struct {
+	PollID    string    `json:"pollId"`
+	Abstained bool      `json:"abstained"`
+	CastAt    time.Time `json:"castAt"`
+}
+
+// PollResultsResponse lists who voted and how only for polls that are not
+// secret.
+type PollResultsResponse struct {
+	PollID          string                     `json:"pollId"`
+	Title           string                     `json:"title"`
+	Kind            string                     `json:"kind"`
+	Threshold       string                     `json:"threshold"`
+	Secret          bool                       `json:"secret"`
+	Status          string                     `json:"status"`
+	ClosedAt        *time.Time                 `json:"closedAt"`
+	Turnout         PollTurnoutResponse        `json:"turnout"`
+	Options         []PollOptionResultResponse `json:"options"`
+	VotesCast       int                        `json:"votesCast"`
+	Abstentions     int                        `json:"abstentions"`
+	Outcome         string                     `json:"outcome"`
+	WinningOptionID *string                    `json:"winningOptionId"`
+	Voters          []PollVoterResponse        `json:"voters,omitempty"`
+}
+
+type PollOptionResultResponse struct {
+	OptionID string `json:"optionId"`
+	Label    string `json:"label"`
+	Votes    int    `json:"votes"`
+}
+
+type PollVoterResponse struct {
+	UserID string    `json:"userId"`
+	Name   string    `json:"name"`
+	Choice string    `json:"choice"`
+	CastAt time.Time `json:"castAt"`
+}
+
+type EventRequest struct {
+	Title        string     `json:"title" binding:"required"`
+	Description  *string    `json:"description"`
+	Category     string     `json:"category" binding:"omitempty,oneof=general_assembly meeting social cleanup other"`
+	Location     string     `json:"location" binding:"required"`
+	StartsAt     time.Time  `json:"startsAt" binding:"required"`
+	EndsAt       time.Time  `json:"endsAt" binding:"required"`
+	Capacity     *int       `json:"capacity" binding:"omitempty,min=1"`
+	RSVPDeadline *time.Time `json:"rsvpDeadline"`
+}
+
+type CancelEventRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type ListEventsRequest struct {
+	From             string `form:"from"`
+	To               string `form:"to"`
+	Category         string `form:"category" binding:"omitempty,oneof=general_assembly meeting social cleanup other"`
+	IncludeCancelled bool   `form:"includeCancelled"`
+	Limit            int    `form:"limit" binding:"omitempty,min=1"`
+	Offset           int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// RSVPRequest records whether the member is going. Headcount includes the
+// member and defaults to 1 when going.
+type RSVPRequest struct {
+	Status    string `json:"status" binding:"required,oneof=going not_going"`
+	Headcount int    `json:"headcount" binding:"omitempty,min=1,max=20"`
+}
+
+type EventResponse struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description"`
+	Category     string     `json:"category"`
+	Location     string     `json:"location"`
+	StartsAt     time.Time  `json:"startsAt"`
+	EndsAt       time.Time  `json:"endsAt"`
+	Capacity     *int       `json:"capacity"`
+	SpotsLeft    *int       `json:"spotsLeft"`
+	Headcount    int        `json:"headcount"`
+	RSVPDeadline *time.Time `json:"rsvpDeadline"`
+	RSVPOpen     bool       `json:"rsvpOpen"`
+	CancelReason *string    `json:"cancelReason"`
+	CancelledAt  *time.Time `json:"cancelledAt"`
+	CreatedBy    *string    `json:"createdBy"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type EventDetailResponse struct {
+	EventResponse
+	MyRSVP *RSVPResponse `json:"myRsvp"`
+}
+
+type RSVPResponse struct {
+	ID        string    `json:"id"`
+	EventID   string    `json:"eventId"`
+	UserID    string    `json:"userId"`
+	Status    string    `json:"status"`
+	Headcount int       `json:"headcount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type EventAttendeesResponse struct {
+	EventID   string                  `json:"eventId"`
+	Headcount int                     `json:"headcount"`
+	Capacity  *int                    `json:"capacity"`
+	Attendees []EventAttendeeResponse `json:"attendees"`
+}
+
+type EventAttendeeResponse struct {
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	Headcount int       `json:"headcount"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CalendarFeedResponse carries the subscription URL. It is only returned
+// when the feed is created; the token is not stored in readable form.
+type CalendarFeedResponse struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type AssociationRequest struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"`
+}
+
+type AssociationResponse struct {
+	ID       string `json:"id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"`
+}
+
+func NewService(repos *repository.Repository, jwt auth.IJWTAuth, store storage.Storage, mail mailer.Mailer, background *job.Background, cfg *config.Config) *Service {
 	return &Service{
-		UserService: NewUserService(repos.UserRepository),
+		AssociationService:  NewAssociationService(repos.AssociationRepository),
+		UserService:         NewUserService(repos.UserRepository, repos.EmailVerificationRepository, repos.RegistrationRepository, repos.PropertyRepository, store, mail, background, cfg.AppURL),
+		RegistrationService: NewRegistrationService(repos.RegistrationRepository, repos.PropertyRepository, store, mail),
+		MFAService:          NewMFAService(jwt, repos.MFARepository, repos.UserRepository, repos.RoleRepository, cfg.MFARequiredRoles),
+		RoleService:         NewRoleService(repos.RoleRepository, repos.UserRepository),
+		AuthService:         NewAuthService(jwt, repos.UserRepository, repos.RefreshTokenRepository, repos.PasswordResetRepository, mail, background, cfg.AppURL),
+		PropertyService:     NewPropertyService(repos.PropertyRepository, repos.UserRepository),
+		OccupancyService:    NewOccupancyService(repos.OccupancyRepository, repos.PropertyRepository, repos.UserRepository),
+		BillingService:      NewBillingService(repos.BillingRepository, repos.InvoiceRepository, repos.PropertyRepository, cfg.BillingDay, cfg.BillingDueDay),
+		PaymentService:      NewPaymentService(repos.PaymentRepository, repos.PropertyRepository),
+		PenaltyService:      NewPenaltyService(repos.PenaltyRepository, repos.InvoiceRepository),
+		StatementService:    NewStatementService(repos.StatementRepository, repos.PropertyRepository, repos.OccupancyRepository),
+		AnnouncementService: NewAnnouncementService(repos.AnnouncementRepository, repos.RoleRepository),
+		AmenityService:      NewAmenityService(repos.AmenityRepository, repos.InvoiceRepository, repos.OccupancyRepository),
+		GatePassService:     NewGatePassService(repos.GatePassRepository, repos.OccupancyRepository, repos.PropertyRepository, jwt),
+		VehicleService:      NewVehicleService(repos.VehicleRepository, repos.InvoiceRepository, repos.OccupancyRepository, repos.PropertyRepository, cfg.MaxStickers),
+		TicketService:       NewTicketService(repos.TicketRepository, repos.NotificationRepository, repos.OccupancyRepository, repos.UserRepository, store),
+		NotificationService: NewNotificationService(repos.NotificationRepository),
+		ViolationService:    NewViolationService(repos.ViolationRepository, repos.NotificationRepository, repos.OccupancyRepository, repos.PropertyRepository, store),
+		DocumentService:     NewDocumentService(repos.DocumentRepository, repos.RoleRepository, store),
+		FileService:         NewFileService(store),
+		ElectionService:     NewElectionService(repos.ElectionRepository, repos.OccupancyRepository, repos.InvoiceRepository, repos.PropertyRepository, repos.UserRepository, repos.RoleRepository, store),
+		PollService:         NewPollService(repos.PollRepository, repos.OccupancyRepository, repos.UserRepository, repos.RoleRepository),
+		EventService:        NewEventService(repos.EventRepository, repos.AmenityRepository, repos.UserRepository, repos.NotificationRepository, cfg.PublicURL),
 	}
 }
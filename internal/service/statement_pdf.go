@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// column widths in millimetres; they add up to the printable A4 width
+var statementColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 22, "L"},
+	{"Reference", 32, "L"},
+	{"Description", 70, "L"},
+	{"Charges", 22, "R"},
+	{"Credits", 22, "R"},
+	{"Balance", 22, "R"},
+}
+
+// RenderStatementPDF lays the statement out as a printable A4 document.
+func (s *StatementServiceImpl) RenderStatementPDF(statement *StatementResponse) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Generated %s - page %d of {nb}", statement.GeneratedAt.Format("2006-01-02 15:04 MST"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 7, tr(statement.AssociationName), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 7, "Statement of Account", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, tr(propertyAddress(&statement.Property)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Period: %s to %s", statement.From, statement.To), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for _, column := range statementColumns {
+		pdf.CellFormat(column.width, 6, column.title, "1", 0, column.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	writeRow := func(values ...string) {
+		for i, column := range statementColumns {
+			text := tr(values[i])
+			for len(text) > 0 && pdf.GetStringWidth(text) > column.width-2 {
+				text = text[:len(text)-1]
+			}
+			pdf.CellFormat(column.width, 6, text, "1", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	writeRow(statement.From, "", "Opening balance", "", "", formatCents(statement.OpeningBalanceCents))
+	for _, entry := range statement.Entries {
+		writeRow(entry.Date, entry.Reference, entry.Description, optionalCents(entry.ChargeCents), optionalCents(entry.CreditCents), formatCents(entry.BalanceCents))
+	}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	writeRow(statement.To, "", "Closing balance", formatCents(statement.TotalChargesCents), formatCents(statement.TotalCreditsCents), formatCents(statement.ClosingBalanceCents))
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "", 10)
+	if statement.ClosingBalanceCents < 0 {
+		pdf.CellFormat(0, 5, fmt.Sprintf("Account credit: PHP %s", formatCents(-statement.ClosingBalanceCents)), "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 5, fmt.Sprintf("Amount due: PHP %s", formatCents(statement.ClosingBalanceCents)), "", 1, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render statement pdf: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func propertyAddress(property *PropertyResponse) string {
+	address := fmt.Sprintf("Phase %s, Block %s, Lot %s", property.Phase, property.Block, property.Lot)
+	if property.Road != nil && *property.Road != "" {
+		address += ", " + *property.Road
+	}
+	return address
+}
+
+func optionalCents(cents int64) string {
+	if cents == 0 {
+		return ""
+	}
+	return formatCents(cents)
+}
+
+// formatCents renders centavos as pesos with thousands separators.
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	whole := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s%s.%02d", sign, grouped.String(), cents%100)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type StatementServiceImpl struct {
+	statementRepo repository.StatementRepository
+	propertyRepo  repository.PropertyRepository
+	occupancyRepo repository.OccupancyRepository
+}
+
+func NewStatementService(statementRepo repository.StatementRepository, propertyRepo repository.PropertyRepository, occupancyRepo repository.OccupancyRepository) StatementService {
+	return &StatementServiceImpl{
+		statementRepo: statementRepo,
+		propertyRepo:  propertyRepo,
+		occupancyRepo: occupancyRepo,
+	}
+}
+
+func (s *StatementServiceImpl) GetPropertyStatement(ctx context.Context, propertyID uuid.UUID, req *StatementRequest) (*StatementResponse, error) {
+	property, err := s.propertyRepo.GetPropertyByID(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildStatement(ctx, property, req)
+}
+
+// GetUserStatement builds the statement for a property the user currently
+// occupies. Users linked to a single property do not need to name it.
+func (s *StatementServiceImpl) GetUserStatement(ctx context.Context, userID uuid.UUID, req *StatementRequest) (*StatementResponse, error) {
+	property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildStatement(ctx, property, req)
+}
+
+// buildStatement folds everything posted before the range into the opening
+// balance and lists the rest with a running balance. A negative balance is
+// credit in the homeowner's favour.
+func (s *StatementServiceImpl) buildStatement(ctx context.Context, property *model.Property, req *StatementRequest) (*StatementResponse, error) {
+	to, err := parseDateOrToday(ctx, req.To)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(to.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	if req.From != "" {
+		if from, err = parseDate(req.From); err != nil {
+			return nil, err
+		}
+	}
+	if from.After(to) {
+		return nil, invalidInput("from must not be after to")
+	}
+
+	entries, err := s.statementRepo.ListStatementEntries(ctx, property.ID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &StatementResponse{
+		AssociationName: tenant.Name(ctx),
+		Property:        *toPropertyResponse(property),
+		From:            from.Format(constants.DateFormat),
+		To:              to.Format(constants.DateFormat),
+		Entries:         []StatementEntryResponse{},
+		GeneratedAt:     time.Now(),
+	}
+
+	balance := int64(0)
+	for _, entry := range entries {
+		balance += entry.ChargeCents - entry.CreditCents
+		if entry.EntryDate.Before(from) {
+			statement.OpeningBalanceCents = balance
+			continue
+		}
+
+		statement.TotalChargesCents += entry.ChargeCents
+		statement.TotalCreditsCents += entry.CreditCents
+		statement.Entries = append(statement.Entries, StatementEntryResponse{
+			Date:         entry.EntryDate.Format(constants.DateFormat),
+			Type:         entry.EntryType,
+			Reference:    entry.Reference,
+			Description:  entry.Description,
+			ChargeCents:  entry.ChargeCents,
+			CreditCents:  entry.CreditCents,
+			BalanceCents: balance,
+		})
+	}
+	statement.ClosingBalanceCents = balance
+
+	return statement, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type MockStatementRepository struct {
+	ListStatementEntriesFn func(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error)
+}
+
+func (m *MockStatementRepository) ListStatementEntries(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error) {
+	return m.ListStatementEntriesFn(ctx, propertyID, to)
+}
+
+func TestStatementService_GetPropertyStatement(t *testing.T) {
+	property := model.Property{ID: uuid.New(), Phase: "2", Block: "4", Lot: "12"}
+	entries := []model.StatementEntry{
+		{EntryDate: date(2026, 1, 1), EntryType: constants.StatementEntryInvoice, Reference: "INV-202601-000001", ChargeCents: 150000},
+		{EntryDate: date(2026, 1, 20), EntryType: constants.StatementEntryPayment, Reference: "OR-00000001", CreditCents: 100000},
+		{EntryDate: date(2026, 2, 1), EntryType: constants.StatementEntryInvoice, Reference: "INV-202602-000001", ChargeCents: 150000},
+		{EntryDate: date(2026, 2, 21), EntryType: constants.StatementEntryPenalty, Reference: "INV-202601-000001", ChargeCents: 10000},
+		{EntryDate: date(2026, 2, 25), EntryType: constants.StatementEntryPayment, Reference: "OR-00000002", CreditCents: 300000},
+	}
+
+	statementRepo := &MockStatementRepository{
+		ListStatementEntriesFn: func(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error) {
+			if !to.Equal(date(2026, 2, 28)) {
+				t.Errorf("unexpected end date %s", to)
+			}
+			return entries, nil
+		},
+	}
+	propertyRepo := &MockPropertyRepository{
+		GetPropertyByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Property, error) {
+			return &property, nil
+		},
+	}
+
+	service := NewStatementService(statementRepo, propertyRepo, &MockOccupancyRepository{})
+	statement, err := service.GetPropertyStatement(context.Background(), property.ID, &StatementRequest{From: "2026-02-01", To: "2026-02-28"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if statement.OpeningBalanceCents != 50000 {
+		t.Errorf("expected opening balance 50000, got %d", statement.OpeningBalanceCents)
+	}
+	if len(statement.Entries) != 3 {
+		t.Fatalf("expected 3 entries in range, got %d", len(statement.Entries))
+	}
+	if statement.Entries[1].BalanceCents != 210000 {
+		t.Errorf("expected running balance 210000, got %d", statement.Entries[1].BalanceCents)
+	}
+	if statement.TotalChargesCents != 160000 || statement.TotalCreditsCents != 300000 {
+		t.Errorf("unexpected totals %d/%d", statement.TotalChargesCents, statement.TotalCreditsCents)
+	}
+	if statement.ClosingBalanceCents != -90000 {
+		t.Errorf("expected closing credit of 90000, got %d", statement.ClosingBalanceCents)
+	}
+
+	document, err := service.RenderStatementPDF(statement)
+	if err != nil {
+		t.Fatalf("unexpected pdf error: %v", err)
+	}
+	if !bytes.HasPrefix(document, []byte("%PDF")) {
+		t.Error("expected a pdf document")
+	}
+}
+
+func TestStatementService_GetUserStatement(t *testing.T) {
+	first := model.UserProperty{Property: model.Property{ID: uuid.New()}}
+	second := model.UserProperty{Property: model.Property{ID: uuid.New()}}
+
+	tests := []struct {
+		name        string
+		properties  []model.UserProperty
+		propertyID  string
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{name: "single property", properties: []model.UserProperty{first}, expected: first.ID},
+		{name: "chosen property", properties: []model.UserProperty{first, second}, propertyID: second.ID.String(), expected: second.ID},
+		{name: "ambiguous", properties: []model.UserProperty{first, second}, expectedErr: constants.ErrInvalidInput},
+		{name: "not linked", properties: []model.UserProperty{first}, propertyID: uuid.New().String(), expectedErr: constants.ErrRecordNotFound},
+		{name: "no properties", expectedErr: constants.ErrRecordNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					return tc.properties, nil
+				},
+			}
+			statementRepo := &MockStatementRepository{
+				ListStatementEntriesFn: func(ctx context.Context, propertyID uuid.UUID, to time.Time) ([]model.StatementEntry, error) {
+					if propertyID != tc.expected {
+						t.Errorf("expected property %s, got %s", tc.expected, propertyID)
+					}
+					return nil, nil
+				},
+			}
+
+			service := NewStatementService(statementRepo, &MockPropertyRepository{}, occupancyRepo)
+			_, err := service.GetUserStatement(context.Background(), uuid.New(), &StatementRequest{PropertyID: tc.propertyID})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := map[int64]string{
+		0:         "0.00",
+		5:         "0.05",
+		150000:    "1,500.00",
+		123456789: "1,234,567.89",
+		-90000:    "-900.00",
+	}
+
+	for cents, expected := range tests {
+		if got := formatCents(cents); got != expected {
+			t.Errorf("formatCents(%d) = %q, expected %q", cents, got, expected)
+		}
+	}
+}
@@ -0,0 +1,521 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+)
+
+var ticketCategories = map[string]bool{
+	constants.TicketCategoryStreetlight: true,
+	constants.TicketCategoryDrainage:    true,
+	constants.TicketCategoryGarbage:     true,
+	constants.TicketCategoryRoad:        true,
+	constants.TicketCategoryWater:       true,
+	constants.TicketCategoryElectrical:  true,
+	constants.TicketCategoryLandscaping: true,
+	constants.TicketCategorySecurity:    true,
+	constants.TicketCategoryOther:       true,
+}
+
+var ticketPriorities = map[string]bool{
+	constants.TicketPriorityLow:    true,
+	constants.TicketPriorityNormal: true,
+	constants.TicketPriorityHigh:   true,
+	constants.TicketPriorityUrgent: true,
+}
+
+// ticketTransitions lists the statuses each status may move to.
+var ticketTransitions = map[string][]string{
+	constants.TicketOpen:       {constants.TicketTriaged, constants.TicketInProgress, constants.TicketResolved, constants.TicketClosed},
+	constants.TicketTriaged:    {constants.TicketInProgress, constants.TicketResolved, constants.TicketClosed},
+	constants.TicketInProgress: {constants.TicketResolved, constants.TicketClosed},
+	constants.TicketResolved:   {constants.TicketClosed, constants.TicketReopened},
+	constants.TicketClosed:     {constants.TicketReopened},
+	constants.TicketReopened:   {constants.TicketTriaged, constants.TicketInProgress, constants.TicketResolved, constants.TicketClosed},
+}
+
+var ticketPhotoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type TicketServiceImpl struct {
+	ticketRepo       repository.TicketRepository
+	notificationRepo repository.NotificationRepository
+	occupancyRepo    repository.OccupancyRepository
+	userRepo         repository.UserRepository
+	store            storage.Storage
+}
+
+func NewTicketService(ticketRepo repository.TicketRepository, notificationRepo repository.NotificationRepository, occupancyRepo repository.OccupancyRepository, userRepo repository.UserRepository, store storage.Storage) TicketService {
+	return &TicketServiceImpl{
+		ticketRepo:       ticketRepo,
+		notificationRepo: notificationRepo,
+		occupancyRepo:    occupancyRepo,
+		userRepo:         userRepo,
+		store:            store,
+	}
+}
+
+func (s *TicketServiceImpl) CreateTicket(ctx context.Context, userID uuid.UUID, req *TicketRequest) (*TicketResponse, error) {
+	if !ticketCategories[req.Category] {
+		return nil, invalidInput("unknown category %q", req.Category)
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = constants.TicketPriorityNormal
+	}
+	if !ticketPriorities[priority] {
+		return nil, invalidInput("unknown priority %q", priority)
+	}
+
+	title := strings.TrimSpace(req.Title)
+	description := strings.TrimSpace(req.Description)
+	if title == "" || description == "" {
+		return nil, invalidInput("title and description are required")
+	}
+
+	ticket := &model.Ticket{
+		ReporterID:  userID,
+		Category:    req.Category,
+		Priority:    priority,
+		Title:       title,
+		Description: description,
+	}
+
+	commonArea := trimmedOrNil(&req.CommonArea)
+	switch {
+	case commonArea != nil && req.PropertyID != "":
+		return nil, invalidInput("a ticket is either about a property or a common area, not both")
+	case commonArea != nil:
+		ticket.CommonArea = commonArea
+	default:
+		property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+		if err != nil {
+			if errors.Is(err, constants.ErrRecordNotFound) && req.PropertyID == "" {
+				return nil, invalidInput("commonArea is required when you are not linked to a property")
+			}
+			return nil, err
+		}
+		ticket.PropertyID = &property.ID
+	}
+
+	created, err := s.ticketRepo.CreateTicket(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketResponse(created), nil
+}
+
+func (s *TicketServiceImpl) ListUserTickets(ctx context.Context, userID uuid.UUID, req *ListTicketsRequest) ([]TicketResponse, error) {
+	filter, err := ticketFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.ReporterID = &userID
+
+	return s.listTickets(ctx, filter)
+}
+
+func (s *TicketServiceImpl) GetUserTicket(ctx context.Context, userID, id uuid.UUID) (*TicketDetailResponse, error) {
+	ticket, err := s.userTicket(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticketDetail(ctx, ticket)
+}
+
+func (s *TicketServiceImpl) AddUserTicketComment(ctx context.Context, userID, id uuid.UUID, req *TicketCommentRequest) (*TicketCommentResponse, error) {
+	if _, err := s.userTicket(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	return s.addComment(ctx, id, userID, req)
+}
+
+// AddUserTicketPhoto stores a photo of the problem. The type is sniffed from
+// the content rather than trusted from the upload.
+func (s *TicketServiceImpl) AddUserTicketPhoto(ctx context.Context, userID, id uuid.UUID, upload *FileUpload) (*TicketPhotoResponse, error) {
+	ticket, err := s.userTicket(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == constants.TicketClosed {
+		return nil, invalidInput("ticket is closed")
+	}
+	if upload.SizeBytes <= 0 || upload.SizeBytes > constants.MaxTicketPhotoBytes {
+		return nil, invalidInput("photos must be at most %d MB", constants.MaxTicketPhotoBytes>>20)
+	}
+
+	photos, err := s.ticketRepo.ListTicketPhotos(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(photos) >= constants.MaxTicketPhotos {
+		return nil, invalidInput("a ticket can have at most %d photos", constants.MaxTicketPhotos)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read photo: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !ticketPhotoTypes[contentType] {
+		return nil, invalidInput("photos must be JPEG, PNG or WebP images")
+	}
+
+	photo := &model.TicketPhoto{
+		ID:          uuid.New(),
+		TicketID:    ticket.ID,
+		ContentType: contentType,
+		SizeBytes:   upload.SizeBytes,
+		UploadedBy:  &userID,
+	}
+	photo.StorageKey = fmt.Sprintf("tickets/%s/%s", ticket.ID, photo.ID)
+
+	body := io.MultiReader(bytes.NewReader(head), upload.Body)
+	if err := s.store.Put(ctx, photo.StorageKey, body, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.ticketRepo.AddTicketPhoto(ctx, photo); err != nil {
+		if dErr := s.store.Delete(ctx, photo.StorageKey); dErr != nil {
+			log.Printf("failed to remove orphaned photo %s: %v\n", photo.StorageKey, dErr)
+		}
+		return nil, err
+	}
+
+	return toTicketPhotoResponse(photo), nil
+}
+
+func (s *TicketServiceImpl) GetUserTicketPhoto(ctx context.Context, userID, ticketID, photoID uuid.UUID) (*StoredFile, error) {
+	if _, err := s.userTicket(ctx, userID, ticketID); err != nil {
+		return nil, err
+	}
+
+	return s.GetTicketPhoto(ctx, ticketID, photoID)
+}
+
+// ReopenUserTicket lets the reporter reopen a ticket that was resolved or
+// closed without the problem being fixed.
+func (s *TicketServiceImpl) ReopenUserTicket(ctx context.Context, userID, id uuid.UUID, req *TicketStatusRequest) (*TicketResponse, error) {
+	ticket, err := s.userTicket(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeStatus(ctx, ticket, constants.TicketReopened, userID, trimmedOrNil(req.Note))
+}
+
+func (s *TicketServiceImpl) ListTickets(ctx context.Context, req *ListTicketsRequest) ([]TicketResponse, error) {
+	filter, err := ticketFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listTickets(ctx, filter)
+}
+
+func (s *TicketServiceImpl) GetTicket(ctx context.Context, id uuid.UUID) (*TicketDetailResponse, error) {
+	ticket, err := s.ticketRepo.GetTicketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticketDetail(ctx, ticket)
+}
+
+func (s *TicketServiceImpl) AssignTicket(ctx context.Context, id uuid.UUID, req *AssignTicketRequest) (*TicketResponse, error) {
+	var assignedUserID *uuid.UUID
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return nil, invalidInput("user id must be a uuid")
+		}
+		if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+			if errors.Is(err, constants.ErrRecordNotFound) {
+				return nil, invalidInput("assigned user does not exist")
+			}
+			return nil, err
+		}
+		assignedUserID = &userID
+	}
+
+	if err := s.ticketRepo.AssignTicket(ctx, id, assignedUserID, trimmedOrNil(req.Vendor)); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.GetTicketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketResponse(ticket), nil
+}
+
+func (s *TicketServiceImpl) ChangeTicketStatus(ctx context.Context, id, changedBy uuid.UUID, req *TicketStatusRequest) (*TicketResponse, error) {
+	if _, ok := ticketTransitions[req.Status]; !ok {
+		return nil, invalidInput("unknown status %q", req.Status)
+	}
+
+	ticket, err := s.ticketRepo.GetTicketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changeStatus(ctx, ticket, req.Status, changedBy, trimmedOrNil(req.Note))
+}
+
+func (s *TicketServiceImpl) AddTicketComment(ctx context.Context, id, authorID uuid.UUID, req *TicketCommentRequest) (*TicketCommentResponse, error) {
+	if _, err := s.ticketRepo.GetTicketByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.addComment(ctx, id, authorID, req)
+}
+
+func (s *TicketServiceImpl) GetTicketPhoto(ctx context.Context, ticketID, photoID uuid.UUID) (*StoredFile, error) {
+	photo, err := s.ticketRepo.GetTicketPhoto(ctx, ticketID, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.store.Get(ctx, photo.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredFile{
+		ContentType: photo.ContentType,
+		SizeBytes:   photo.SizeBytes,
+		Body:        body,
+	}, nil
+}
+
+// changeStatus applies a transition allowed by ticketTransitions and tells
+// the reporter about it, unless they made the change themselves. A failed
+// notification is logged rather than undoing the change.
+func (s *TicketServiceImpl) changeStatus(ctx context.Context, ticket *model.Ticket, status string, changedBy uuid.UUID, note *string) (*TicketResponse, error) {
+	if !canTransitionTicket(ticket.Status, status) {
+		return nil, invalidInput("a %s ticket cannot be moved to %s", ticketStatusLabel(ticket.Status), ticketStatusLabel(status))
+	}
+
+	err := s.ticketRepo.ChangeTicketStatus(ctx, &model.TicketStatusChange{
+		TicketID:   ticket.ID,
+		FromStatus: ticket.Status,
+		ToStatus:   status,
+		Note:       note,
+		ChangedBy:  &changedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.ticketRepo.GetTicketByID(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if changedBy != ticket.ReporterID {
+		body := updated.Title
+		if note != nil {
+			body += "\n\n" + *note
+		}
+		referenceType := constants.ReferenceTicket
+		notification := &model.Notification{
+			UserID:        ticket.ReporterID,
+			Kind:          constants.NotificationTicketStatus,
+			Title:         fmt.Sprintf("Ticket %s is now %s", updated.TicketNumber, ticketStatusLabel(status)),
+			Body:          body,
+			ReferenceType: &referenceType,
+			ReferenceID:   &updated.ID,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			log.Printf("failed to notify reporter of ticket %s: %v\n", updated.TicketNumber, err)
+		}
+	}
+
+	return toTicketResponse(updated), nil
+}
+
+func (s *TicketServiceImpl) addComment(ctx context.Context, ticketID, authorID uuid.UUID, req *TicketCommentRequest) (*TicketCommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, invalidInput("comment body is required")
+	}
+
+	comment, err := s.ticketRepo.AddTicketComment(ctx, &model.TicketComment{
+		TicketID: ticketID,
+		AuthorID: &authorID,
+		Body:     body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketCommentResponse(comment), nil
+}
+
+// userTicket returns the ticket only when userID reported it.
+func (s *TicketServiceImpl) userTicket(ctx context.Context, userID, id uuid.UUID) (*model.Ticket, error) {
+	ticket, err := s.ticketRepo.GetTicketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.ReporterID != userID {
+		return nil, constants.ErrRecordNotFound
+	}
+
+	return ticket, nil
+}
+
+func (s *TicketServiceImpl) listTickets(ctx context.Context, filter repository.TicketFilter) ([]TicketResponse, error) {
+	tickets, err := s.ticketRepo.ListTickets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]TicketResponse, 0, len(tickets))
+	for i := range tickets {
+		resp = append(resp, *toTicketResponse(&tickets[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *TicketServiceImpl) ticketDetail(ctx context.Context, ticket *model.Ticket) (*TicketDetailResponse, error) {
+	photos, err := s.ticketRepo.ListTicketPhotos(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.ticketRepo.ListTicketComments(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.ticketRepo.ListTicketStatusChanges(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &TicketDetailResponse{
+		TicketResponse: *toTicketResponse(ticket),
+		Photos:         make([]TicketPhotoResponse, 0, len(photos)),
+		Comments:       make([]TicketCommentResponse, 0, len(comments)),
+		History:        make([]TicketStatusChangeResponse, 0, len(changes)),
+	}
+	for i := range photos {
+		resp.Photos = append(resp.Photos, *toTicketPhotoResponse(&photos[i]))
+	}
+	for i := range comments {
+		resp.Comments = append(resp.Comments, *toTicketCommentResponse(&comments[i]))
+	}
+	for _, change := range changes {
+		resp.History = append(resp.History, TicketStatusChangeResponse{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Note:       change.Note,
+			ChangedBy:  optionalUUID(change.ChangedBy),
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+func ticketFilter(req *ListTicketsRequest) (repository.TicketFilter, error) {
+	filter := repository.TicketFilter{
+		Status:   req.Status,
+		Category: req.Category,
+		Priority: req.Priority,
+		Limit:    pageSize(req.Limit),
+		Offset:   req.Offset,
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return filter, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+	if req.AssignedUserID != "" {
+		assignedUserID, err := uuid.Parse(req.AssignedUserID)
+		if err != nil {
+			return filter, invalidInput("assigned user id must be a uuid")
+		}
+		filter.AssignedUserID = &assignedUserID
+	}
+
+	return filter, nil
+}
+
+func canTransitionTicket(from, to string) bool {
+	for _, next := range ticketTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func ticketStatusLabel(status string) string {
+	return strings.ReplaceAll(status, "_", " ")
+}
+
+func toTicketResponse(ticket *model.Ticket) *TicketResponse {
+	return &TicketResponse{
+		ID:             ticket.ID.String(),
+		TicketNumber:   ticket.TicketNumber,
+		ReporterID:     ticket.ReporterID.String(),
+		Category:       ticket.Category,
+		Priority:       ticket.Priority,
+		Title:          ticket.Title,
+		Description:    ticket.Description,
+		PropertyID:     optionalUUID(ticket.PropertyID),
+		CommonArea:     ticket.CommonArea,
+		Status:         ticket.Status,
+		AssignedUserID: optionalUUID(ticket.AssignedUserID),
+		AssignedVendor: ticket.AssignedVendor,
+		ResolvedAt:     ticket.ResolvedAt,
+		ClosedAt:       ticket.ClosedAt,
+		CreatedAt:      ticket.CreatedAt,
+		UpdatedAt:      ticket.UpdatedAt,
+	}
+}
+
+func toTicketPhotoResponse(photo *model.TicketPhoto) *TicketPhotoResponse {
+	return &TicketPhotoResponse{
+		ID:          photo.ID.String(),
+		ContentType: photo.ContentType,
+		SizeBytes:   photo.SizeBytes,
+		CreatedAt:   photo.CreatedAt,
+	}
+}
+
+func toTicketCommentResponse(comment *model.TicketComment) *TicketCommentResponse {
+	resp := &TicketCommentResponse{
+		ID:        comment.ID.String(),
+		AuthorID:  optionalUUID(comment.AuthorID),
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	}
+	if comment.FirstName != nil && comment.LastName != nil {
+		resp.AuthorName = *comment.FirstName + " " + *comment.LastName
+	}
+	return resp
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockTicketRepository struct {
+	CreateTicketFn            func(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error)
+	GetTicketByIDFn           func(ctx context.Context, id uuid.UUID) (*model.Ticket, error)
+	ListTicketsFn             func(ctx context.Context, filter repository.TicketFilter) ([]model.Ticket, error)
+	AssignTicketFn            func(ctx context.Context, id uuid.UUID, assignedUserID *uuid.UUID, assignedVendor *string) error
+	ChangeTicketStatusFn      func(ctx context.Context, change *model.TicketStatusChange) error
+	ListTicketStatusChangesFn func(ctx context.Context, ticketID uuid.UUID) ([]model.TicketStatusChange, error)
+	AddTicketCommentFn        func(ctx context.Context, comment *model.TicketComment) (*model.TicketComment, error)
+	ListTicketCommentsFn      func(ctx context.Context, ticketID uuid.UUID) ([]model.TicketComment, error)
+	AddTicketPhotoFn          func(ctx context.Context, photo *model.TicketPhoto) error
+	GetTicketPhotoFn          func(ctx context.Context, ticketID, photoID uuid.UUID) (*model.TicketPhoto, error)
+	ListTicketPhotosFn        func(ctx context.Context, ticketID uuid.UUID) ([]model.TicketPhoto, error)
+}
+
+func (m *MockTicketRepository) CreateTicket(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error) {
+	return m.CreateTicketFn(ctx, ticket)
+}
+
+func (m *MockTicketRepository) GetTicketByID(ctx context.Context, id uuid.UUID) (*model.Ticket, error) {
+	return m.GetTicketByIDFn(ctx, id)
+}
+
+func (m *MockTicketRepository) ListTickets(ctx context.Context, filter repository.TicketFilter) ([]model.Ticket, error) {
+	return m.ListTicketsFn(ctx, filter)
+}
+
+func (m *MockTicketRepository) AssignTicket(ctx context.Context, id uuid.UUID, assignedUserID *uuid.UUID, assignedVendor *string) error {
+	return m.AssignTicketFn(ctx, id, assignedUserID, assignedVendor)
+}
+
+func (m *MockTicketRepository) ChangeTicketStatus(ctx context.Context, change *model.TicketStatusChange) error {
+	return m.ChangeTicketStatusFn(ctx, change)
+}
+
+func (m *MockTicketRepository) ListTicketStatusChanges(ctx context.Context, ticketID uuid.UUID) ([]model.TicketStatusChange, error) {
+	return m.ListTicketStatusChangesFn(ctx, ticketID)
+}
+
+func (m *MockTicketRepository) AddTicketComment(ctx context.Context, comment *model.TicketComment) (*model.TicketComment, error) {
+	return m.AddTicketCommentFn(ctx, comment)
+}
+
+func (m *MockTicketRepository) ListTicketComments(ctx context.Context, ticketID uuid.UUID) ([]model.TicketComment, error) {
+	return m.ListTicketCommentsFn(ctx, ticketID)
+}
+
+func (m *MockTicketRepository) AddTicketPhoto(ctx context.Context, photo *model.TicketPhoto) error {
+	return m.AddTicketPhotoFn(ctx, photo)
+}
+
+func (m *MockTicketRepository) GetTicketPhoto(ctx context.Context, ticketID, photoID uuid.UUID) (*model.TicketPhoto, error) {
+	return m.GetTicketPhotoFn(ctx, ticketID, photoID)
+}
+
+func (m *MockTicketRepository) ListTicketPhotos(ctx context.Context, ticketID uuid.UUID) ([]model.TicketPhoto, error) {
+	return m.ListTicketPhotosFn(ctx, ticketID)
+}
+
+type MockNotificationRepository struct {
+	CreateNotificationFn       func(ctx context.Context, notification *model.Notification) error
+	ListNotificationsFn        func(ctx context.Context, userID uuid.UUID, filter repository.NotificationFilter) ([]model.Notification, error)
+	CountUnreadNotificationsFn func(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkNotificationReadFn     func(ctx context.Context, id, userID uuid.UUID) error
+	MarkAllNotificationsReadFn func(ctx context.Context, userID uuid.UUID) error
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	return m.CreateNotificationFn(ctx, notification)
+}
+
+func (m *MockNotificationRepository) ListNotifications(ctx context.Context, userID uuid.UUID, filter repository.NotificationFilter) ([]model.Notification, error) {
+	return m.ListNotificationsFn(ctx, userID, filter)
+}
+
+func (m *MockNotificationRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	return m.CountUnreadNotificationsFn(ctx, userID)
+}
+
+func (m *MockNotificationRepository) MarkNotificationRead(ctx context.Context, id, userID uuid.UUID) error {
+	return m.MarkNotificationReadFn(ctx, id, userID)
+}
+
+func (m *MockNotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	return m.MarkAllNotificationsReadFn(ctx, userID)
+}
+
+func TestTicketService_CreateTicket(t *testing.T) {
+	property := model.Property{ID: uuid.New()}
+
+	tests := []struct {
+		name               string
+		req                *TicketRequest
+		properties         []model.UserProperty
+		expectedCommonArea bool
+		expectedErr        error
+	}{
+		{
+			name:       "defaults to the reporter's property",
+			req:        &TicketRequest{Category: constants.TicketCategoryDrainage, Title: "Clogged drain", Description: "Water backs up after rain"},
+			properties: []model.UserProperty{{Property: property}},
+		},
+		{
+			name:               "common area",
+			req:                &TicketRequest{Category: constants.TicketCategoryStreetlight, Title: "Streetlight out", Description: "Corner of Narra St.", CommonArea: "Phase 1 gate"},
+			expectedCommonArea: true,
+		},
+		{
+			name:        "property and common area",
+			req:         &TicketRequest{Category: constants.TicketCategoryGarbage, Title: "Missed pickup", Description: "Not collected", PropertyID: property.ID.String(), CommonArea: "Phase 1"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "no property to default to",
+			req:         &TicketRequest{Category: constants.TicketCategoryGarbage, Title: "Missed pickup", Description: "Not collected"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown category",
+			req:         &TicketRequest{Category: "potholes", Title: "Pothole", Description: "Deep", CommonArea: "Main road"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown priority",
+			req:         &TicketRequest{Category: constants.TicketCategoryRoad, Priority: "asap", Title: "Pothole", Description: "Deep", CommonArea: "Main road"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticketRepo := &MockTicketRepository{
+				CreateTicketFn: func(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error) {
+					ticket.ID = uuid.New()
+					ticket.Status = constants.TicketOpen
+					return ticket, nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					return tc.properties, nil
+				},
+			}
+
+			service := NewTicketService(ticketRepo, &MockNotificationRepository{}, occupancyRepo, &MockUserRepository{}, nil)
+			resp, err := service.CreateTicket(context.Background(), uuid.New(), tc.req)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Priority != constants.TicketPriorityNormal {
+				t.Errorf("expected normal priority, got %s", resp.Priority)
+			}
+			if tc.expectedCommonArea {
+				if resp.CommonArea == nil || resp.PropertyID != nil {
+					t.Errorf("expected a common area ticket, got %+v", resp)
+				}
+			} else if resp.PropertyID == nil || *resp.PropertyID != property.ID.String() {
+				t.Errorf("expected property %s, got %v", property.ID, resp.PropertyID)
+			}
+		})
+	}
+}
+
+func TestTicketService_ChangeTicketStatus(t *testing.T) {
+	reporterID := uuid.New()
+	adminID := uuid.New()
+
+	tests := []struct {
+		name           string
+		from           string
+		to             string
+		changedBy      uuid.UUID
+		expectedNotify bool
+		expectedErr    error
+	}{
+		{
+			name:           "admin starts work",
+			from:           constants.TicketTriaged,
+			to:             constants.TicketInProgress,
+			changedBy:      adminID,
+			expectedNotify: true,
+		},
+		{
+			name:           "admin resolves",
+			from:           constants.TicketInProgress,
+			to:             constants.TicketResolved,
+			changedBy:      adminID,
+			expectedNotify: true,
+		},
+		{
+			name:      "reporter reopens",
+			from:      constants.TicketResolved,
+			to:        constants.TicketReopened,
+			changedBy: reporterID,
+		},
+		{
+			name:        "closed tickets only reopen",
+			from:        constants.TicketClosed,
+			to:          constants.TicketInProgress,
+			changedBy:   adminID,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "open tickets cannot be reopened",
+			from:        constants.TicketOpen,
+			to:          constants.TicketReopened,
+			changedBy:   adminID,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "unknown status",
+			from:        constants.TicketOpen,
+			to:          "done",
+			changedBy:   adminID,
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticket := &model.Ticket{ID: uuid.New(), TicketNumber: "TKT-000001", ReporterID: reporterID, Title: "Streetlight out", Status: tc.from}
+			var change *model.TicketStatusChange
+			var notified *model.Notification
+			ticketRepo := &MockTicketRepository{
+				GetTicketByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Ticket, error) {
+					updated := *ticket
+					if change != nil {
+						updated.Status = change.ToStatus
+					}
+					return &updated, nil
+				},
+				ChangeTicketStatusFn: func(ctx context.Context, c *model.TicketStatusChange) error {
+					change = c
+					return nil
+				},
+			}
+			notificationRepo := &MockNotificationRepository{
+				CreateNotificationFn: func(ctx context.Context, notification *model.Notification) error {
+					notified = notification
+					return nil
+				},
+			}
+
+			service := NewTicketService(ticketRepo, notificationRepo, &MockOccupancyRepository{}, &MockUserRepository{}, nil)
+			resp, err := service.ChangeTicketStatus(context.Background(), ticket.ID, tc.changedBy, &TicketStatusRequest{Status: tc.to})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if change != nil {
+					t.Error("expected status to be unchanged")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != tc.to || change.FromStatus != tc.from {
+				t.Errorf("expected %s -> %s, got %s -> %s", tc.from, tc.to, change.FromStatus, resp.Status)
+			}
+			if (notified != nil) != tc.expectedNotify {
+				t.Fatalf("expected notification %v, got %v", tc.expectedNotify, notified)
+			}
+			if notified != nil && (notified.UserID != reporterID || *notified.ReferenceID != ticket.ID) {
+				t.Errorf("expected reporter to be notified about ticket %s", ticket.ID)
+			}
+		})
+	}
+}
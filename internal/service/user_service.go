@@ -1,29 +1,61 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/util"
 )
 
 type UserServiceImpl struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	verificationRepo repository.EmailVerificationRepository
+	registrationRepo repository.RegistrationRepository
+	propertyRepo     repository.PropertyRepository
+	store            storage.Storage
+	mailer           mailer.Mailer
+	background       *job.Background
+	// appURL is the web app that hosts the verify email page
+	appURL string
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
+func NewUserService(repo repository.UserRepository, verificationRepo repository.EmailVerificationRepository,
+	registrationRepo repository.RegistrationRepository, propertyRepo repository.PropertyRepository, store storage.Storage,
+	mailer mailer.Mailer, background *job.Background, appURL string) UserService {
 	return &UserServiceImpl{
-		userRepo: repo,
+		userRepo:         repo,
+		verificationRepo: verificationRepo,
+		registrationRepo: registrationRepo,
+		propertyRepo:     propertyRepo,
+		store:            store,
+		mailer:           mailer,
+		background:       background,
+		appURL:           strings.TrimSuffix(appURL, "/"),
 	}
 }
 
-func (s *UserServiceImpl) CreateUser(ctx context.Context, req *CreateUserRequest) (*CreatUserResponse, error) {
+// CreateUser registers an account that must confirm its email and then be
+// approved by an admin. A registrant may claim a property by its address,
+// backed by an uploaded title or lease that the admin reviews.
+func (s *UserServiceImpl) CreateUser(ctx context.Context, req *CreateUserRequest, proof *FileUpload) (*CreatUserResponse, error) {
 	result, err := s.userRepo.GetUserByEmail(ctx, req.Email)
-	if err != nil {
+	if err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
 		return nil, err
 	}
 
@@ -54,21 +86,95 @@ func (s *UserServiceImpl) CreateUser(ctx context.Context, req *CreateUserRequest
 		Gender:       req.Gender,
 		Email:        req.Email,
 		PasswordHash: hashPassword,
-		Status:       constants.ActiveStatus,
+		Status:       constants.PendingVerificationStatus,
 		CreatedAt:    time.Now(),
 	}
 
-	resp, err := s.userRepo.CreateUser(ctx, user)
-	if err != nil {
+	registration := &model.Registration{ID: uuid.New()}
+	if proof != nil || req.Phase != "" || req.Block != "" || req.Lot != "" || req.ProofType != "" {
+		if err := s.claimProperty(ctx, registration, req, proof); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.registrationRepo.CreateRegistration(ctx, user, registration); err != nil {
+		if registration.ProofKey != nil {
+			if dErr := s.store.Delete(ctx, *registration.ProofKey); dErr != nil {
+				log.Printf("failed to remove orphaned proof %s: %v\n", *registration.ProofKey, dErr)
+			}
+		}
 		return nil, err
 	}
 
-	return &CreatUserResponse{
-		FirstName:  resp.FirstName,
-		LastName:   resp.LastName,
-		MiddleName: resp.MiddleName,
-		Email:      resp.Email,
-	}, nil
+	// the account exists either way; a lost email can be resent
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		log.Printf("failed to send verification email: %v\n", err)
+	}
+
+	resp := &CreatUserResponse{
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		MiddleName: user.MiddleName,
+		Email:      user.Email,
+		Status:     user.Status,
+	}
+	if registration.PropertyID != nil {
+		propertyID := registration.PropertyID.String()
+		resp.PropertyID = &propertyID
+	}
+	return resp, nil
+}
+
+// claimProperty resolves the claimed address and stores the proof under the
+// registration's key.
+func (s *UserServiceImpl) claimProperty(ctx context.Context, registration *model.Registration, req *CreateUserRequest, proof *FileUpload) error {
+	phase, block, lot := strings.TrimSpace(req.Phase), strings.TrimSpace(req.Block), strings.TrimSpace(req.Lot)
+	if phase == "" || block == "" || lot == "" {
+		return invalidInput("phase, block and lot are required to claim a property")
+	}
+	if req.ProofType != constants.ProofTitle && req.ProofType != constants.ProofLease {
+		return invalidInput("proof type must be title or lease")
+	}
+	if proof == nil {
+		return invalidInput("a copy of the title or lease is required to claim a property")
+	}
+
+	property, err := s.propertyRepo.GetPropertyByAddress(ctx, phase, block, lot)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return invalidInput("no property is registered at phase %s, block %s, lot %s", phase, block, lot)
+		}
+		return err
+	}
+	if property.ArchivedAt != nil {
+		return invalidInput("no property is registered at phase %s, block %s, lot %s", phase, block, lot)
+	}
+
+	if proof.SizeBytes <= 0 || proof.SizeBytes > constants.MaxRegistrationProofBytes {
+		return invalidInput("proofs must be at most %d MB", constants.MaxRegistrationProofBytes>>20)
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(proof.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("failed to read proof: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !registrationProofTypes[contentType] {
+		return invalidInput("the proof must be a PDF or a JPEG or PNG image")
+	}
+
+	key := fmt.Sprintf("registrations/%s", registration.ID)
+	fileName := proofFileName(proof.FileName)
+	registration.PropertyID = &property.ID
+	registration.ProofType = &req.ProofType
+	registration.ProofKey = &key
+	registration.ProofFileName = &fileName
+	registration.ProofContentType = &contentType
+	registration.ProofSizeBytes = &proof.SizeBytes
+
+	body := io.MultiReader(bytes.NewReader(head), proof.Body)
+	return s.store.Put(ctx, key, body, contentType)
 }
 
 func (s *UserServiceImpl) LoginUser(ctx context.Context, req *LoginUserRequest) (*model.User, error) {
@@ -86,5 +192,136 @@ func (s *UserServiceImpl) LoginUser(ctx context.Context, req *LoginUserRequest)
 		return nil, constants.ErrInvalidPassword
 	}
 
+	switch user.Status {
+	case constants.PendingVerificationStatus:
+		return nil, constants.ErrEmailNotVerified
+	case constants.PendingApprovalStatus:
+		return nil, constants.ErrPendingApproval
+	case constants.RejectedStatus:
+		return nil, constants.ErrRegistrationRejected
+	}
+	if user.Status != constants.ActiveStatus {
+		return nil, constants.ErrAccountInactive
+	}
+
 	return user, nil
 }
+
+// SuspendUser blocks the account and kills all of its sessions, including
+// access tokens that have not expired yet.
+func (s *UserServiceImpl) SuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return s.userRepo.SuspendUser(ctx, userID)
+}
+
+// ReactivateUser lifts a suspension. Registrations still pending or rejected
+// go through verification and approval instead.
+func (s *UserServiceImpl) ReactivateUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Status != constants.SuspendedStatus && user.Status != constants.InactiveStatus {
+		return invalidInput("only suspended or inactive accounts can be reactivated")
+	}
+
+	return s.userRepo.ReactivateUser(ctx, userID)
+}
+
+// VerifyEmail confirms the address behind an emailed token and hands the
+// registration to the admins for approval.
+func (s *UserServiceImpl) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error {
+	token, err := s.verificationRepo.GetEmailVerificationTokenByHash(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return constants.ErrInvalidToken
+		}
+		return err
+	}
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return constants.ErrInvalidToken
+	}
+
+	return s.verificationRepo.VerifyEmail(ctx, token.ID, constants.PendingApprovalStatus)
+}
+
+// ResendVerification emails a fresh link to a registration that is still
+// pending. Like password resets the lookup and the email happen in the
+// background, so neither the response nor its timing tells unknown, already
+// verified and throttled accounts apart from pending ones.
+func (s *UserServiceImpl) ResendVerification(ctx context.Context, req *ResendVerificationRequest) error {
+	email := strings.TrimSpace(req.Email)
+	s.background.Go(ctx, "resend verification email", func(ctx context.Context) error {
+		return s.resendVerification(ctx, email)
+	})
+
+	return nil
+}
+
+func (s *UserServiceImpl) resendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, constants.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Status != constants.PendingVerificationStatus {
+		return nil
+	}
+
+	latest, err := s.verificationRepo.GetLatestEmailVerificationToken(ctx, user.ID)
+	if err != nil && !errors.Is(err, constants.ErrRecordNotFound) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < constants.EmailVerificationCooldown {
+		return nil
+	}
+
+	return s.sendVerificationEmail(ctx, user)
+}
+
+// ExpireUnverifiedUsers deletes registrations that were never confirmed, so
+// their email and mobile number can be registered again.
+func (s *UserServiceImpl) ExpireUnverifiedUsers(ctx context.Context, now time.Time) error {
+	deleted, proofKeys, err := s.userRepo.DeleteUnverifiedUsers(ctx, now.Add(-constants.UnverifiedAccountTTL))
+	if err != nil {
+		return err
+	}
+	for _, key := range proofKeys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("failed to remove proof %s: %v\n", key, err)
+		}
+	}
+	if deleted > 0 {
+		log.Printf("expired %d unverified accounts\n", deleted)
+	}
+	return nil
+}
+
+func (s *UserServiceImpl) sendVerificationEmail(ctx context.Context, user *model.User) error {
+	association, ok := tenant.FromContext(ctx)
+	if !ok {
+		return constants.ErrRecordNotFound
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	err = s.verificationRepo.CreateEmailVerificationToken(ctx, &model.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(constants.EmailVerificationTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := s.appURL + "/verify-email?" + url.Values{"association": {association.Slug}, "token": {token}}.Encode()
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email for " + association.Name,
+		Body: fmt.Sprintf("Hi %s,\n\nThanks for registering with %s. Open the link below within %d hours to confirm your email; an administrator will then review your registration:\n\n%s\n\nIf you did not register, you can ignore this email.\n",
+			user.FirstName, association.Name, int(constants.EmailVerificationTokenTTL.Hours()), link),
+	})
+}
@@ -3,16 +3,26 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/job"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/mailer"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
 	"github.com/ivanpaghubasan/hoa-hub-api/internal/util"
 )
 
 type MockUserRepository struct {
-	GetUserByEmailFn func(ctx context.Context, email string) (*model.User, error)
-	CreateUserFn     func(ctx context.Context, user *model.User) (*model.User, error)
+	GetUserByEmailFn        func(ctx context.Context, email string) (*model.User, error)
+	CreateUserFn            func(ctx context.Context, user *model.User) (*model.User, error)
+	GetUserByIDFn           func(ctx context.Context, id uuid.UUID) (*model.User, error)
+	ReactivateUserFn        func(ctx context.Context, id uuid.UUID) error
+	SuspendUserFn           func(ctx context.Context, id uuid.UUID) error
+	DeleteUnverifiedUsersFn func(ctx context.Context, cutoff time.Time) (int64, []string, error)
 }
 
 func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
@@ -23,6 +33,45 @@ func (m *MockUserRepository) CreateUser(ctx context.Context, user *model.User) (
 	return m.CreateUserFn(ctx, user)
 }
 
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	return m.GetUserByIDFn(ctx, id)
+}
+
+func (m *MockUserRepository) ReactivateUser(ctx context.Context, id uuid.UUID) error {
+	return m.ReactivateUserFn(ctx, id)
+}
+
+func (m *MockUserRepository) SuspendUser(ctx context.Context, id uuid.UUID) error {
+	return m.SuspendUserFn(ctx, id)
+}
+
+func (m *MockUserRepository) DeleteUnverifiedUsers(ctx context.Context, cutoff time.Time) (int64, []string, error) {
+	return m.DeleteUnverifiedUsersFn(ctx, cutoff)
+}
+
+type MockEmailVerificationRepository struct {
+	CreateEmailVerificationTokenFn    func(ctx context.Context, token *model.EmailVerificationToken) error
+	GetLatestEmailVerificationTokenFn func(ctx context.Context, userID uuid.UUID) (*model.EmailVerificationToken, error)
+	GetEmailVerificationTokenByHashFn func(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error)
+	VerifyEmailFn                     func(ctx context.Context, tokenID uuid.UUID, status string) error
+}
+
+func (m *MockEmailVerificationRepository) CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error {
+	return m.CreateEmailVerificationTokenFn(ctx, token)
+}
+
+func (m *MockEmailVerificationRepository) GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (*model.EmailVerificationToken, error) {
+	return m.GetLatestEmailVerificationTokenFn(ctx, userID)
+}
+
+func (m *MockEmailVerificationRepository) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error) {
+	return m.GetEmailVerificationTokenByHashFn(ctx, tokenHash)
+}
+
+func (m *MockEmailVerificationRepository) VerifyEmail(ctx context.Context, tokenID uuid.UUID, status string) error {
+	return m.VerifyEmailFn(ctx, tokenID, status)
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -51,9 +100,6 @@ func TestUserService_CreateUser(t *testing.T) {
 					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
 						return nil, nil
 					},
-					CreateUserFn: func(ctx context.Context, user *model.User) (*model.User, error) {
-						return user, nil
-					},
 				}
 			},
 			expectErr:   false,
@@ -79,9 +125,6 @@ func TestUserService_CreateUser(t *testing.T) {
 					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
 						return &model.User{Email: email}, nil
 					},
-					CreateUserFn: func(ctx context.Context, user *model.User) (*model.User, error) {
-						return nil, errors.New("should not be called")
-					},
 				}
 			},
 			expectErr: true,
@@ -106,9 +149,6 @@ func TestUserService_CreateUser(t *testing.T) {
 					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
 						return nil, constants.ErrRecordNotFound
 					},
-					CreateUserFn: func(ctx context.Context, user *model.User) (*model.User, error) {
-						return user, nil
-					},
 				}
 			},
 			expectErr: true,
@@ -118,9 +158,32 @@ func TestUserService_CreateUser(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockRepo := tc.setupMock
-			service := NewUserService(mockRepo())
+			var stored *model.EmailVerificationToken
+			verificationRepo := &MockEmailVerificationRepository{
+				CreateEmailVerificationTokenFn: func(ctx context.Context, token *model.EmailVerificationToken) error {
+					stored = token
+					return nil
+				},
+			}
+			var sent *mailer.Message
+			mail := &MockMailer{
+				SendFn: func(ctx context.Context, message mailer.Message) error {
+					sent = &message
+					return nil
+				},
+			}
+			registrationRepo := &MockRegistrationRepository{
+				CreateRegistrationFn: func(ctx context.Context, user *model.User, registration *model.Registration) error {
+					if registration.PropertyID != nil {
+						t.Error("expected no property claim")
+					}
+					return nil
+				},
+			}
+			service := NewUserService(mockRepo(), verificationRepo, registrationRepo, &MockPropertyRepository{}, &MockStorage{}, mail, job.NewBackground(), "https://app.example.com")
 
-			resp, err := service.CreateUser(context.Background(), tc.req)
+			ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "acacia", Name: "Acacia Heights"})
+			resp, err := service.CreateUser(ctx, tc.req, nil)
 			if tc.expectErr {
 				if err == nil {
 					t.Error("expected error, got none")
@@ -132,6 +195,15 @@ func TestUserService_CreateUser(t *testing.T) {
 				if resp.Email != tc.expectEmail {
 					t.Errorf("expected email %s, got %s", tc.expectEmail, resp.Email)
 				}
+				if resp.Status != constants.PendingVerificationStatus {
+					t.Errorf("expected status %s, got %s", constants.PendingVerificationStatus, resp.Status)
+				}
+				if sent == nil || sent.To != tc.expectEmail {
+					t.Fatalf("expected a verification email to %s", tc.expectEmail)
+				}
+				if stored == nil || !strings.Contains(sent.Body, "https://app.example.com/verify-email?association=acacia&token=") {
+					t.Errorf("expected a stored token and a verification link in %q", sent.Body)
+				}
 			}
 
 		})
@@ -158,7 +230,7 @@ func TestUserService_LoginUser(t *testing.T) {
 			setupMock: func() *MockUserRepository {
 				return &MockUserRepository{
 					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
-						return &model.User{Email: email, PasswordHash: hashPassword}, nil
+						return &model.User{Email: email, PasswordHash: hashPassword, Status: constants.ActiveStatus}, nil
 					},
 				}
 			},
@@ -197,12 +269,76 @@ func TestUserService_LoginUser(t *testing.T) {
 			expectErr:   true,
 			expectedErr: constants.ErrInvalidPassword,
 		},
+		{
+			name: "suspended account",
+			req: &LoginUserRequest{
+				Email:    "john@test.com",
+				Password: password,
+			},
+			setupMock: func() *MockUserRepository {
+				return &MockUserRepository{
+					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{Email: email, PasswordHash: hashPassword, Status: constants.SuspendedStatus}, nil
+					},
+				}
+			},
+			expectErr:   true,
+			expectedErr: constants.ErrAccountInactive,
+		},
+		{
+			name: "unverified email",
+			req: &LoginUserRequest{
+				Email:    "john@test.com",
+				Password: password,
+			},
+			setupMock: func() *MockUserRepository {
+				return &MockUserRepository{
+					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{Email: email, PasswordHash: hashPassword, Status: constants.PendingVerificationStatus}, nil
+					},
+				}
+			},
+			expectErr:   true,
+			expectedErr: constants.ErrEmailNotVerified,
+		},
+		{
+			name: "awaiting approval",
+			req: &LoginUserRequest{
+				Email:    "john@test.com",
+				Password: password,
+			},
+			setupMock: func() *MockUserRepository {
+				return &MockUserRepository{
+					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{Email: email, PasswordHash: hashPassword, Status: constants.PendingApprovalStatus}, nil
+					},
+				}
+			},
+			expectErr:   true,
+			expectedErr: constants.ErrPendingApproval,
+		},
+		{
+			name: "rejected registration",
+			req: &LoginUserRequest{
+				Email:    "john@test.com",
+				Password: password,
+			},
+			setupMock: func() *MockUserRepository {
+				return &MockUserRepository{
+					GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{Email: email, PasswordHash: hashPassword, Status: constants.RejectedStatus}, nil
+					},
+				}
+			},
+			expectErr:   true,
+			expectedErr: constants.ErrRegistrationRejected,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockRepo := tc.setupMock()
-			service := NewUserService(mockRepo)
+			service := NewUserService(mockRepo, &MockEmailVerificationRepository{}, &MockRegistrationRepository{}, &MockPropertyRepository{}, &MockStorage{}, &MockMailer{}, job.NewBackground(), "")
 			user, err := service.LoginUser(context.Background(), tc.req)
 			if tc.expectErr {
 				if err == nil {
@@ -221,3 +357,229 @@ func TestUserService_LoginUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_SuspendUser(t *testing.T) {
+	userID := uuid.New()
+	suspended := false
+
+	// the status change and the session revocation are one repository call,
+	// so a failure cannot leave a suspended user with working tokens
+	userRepo := &MockUserRepository{
+		SuspendUserFn: func(ctx context.Context, id uuid.UUID) error {
+			suspended = id == userID
+			return nil
+		},
+	}
+
+	service := NewUserService(userRepo, &MockEmailVerificationRepository{}, &MockRegistrationRepository{}, &MockPropertyRepository{}, &MockStorage{}, &MockMailer{}, job.NewBackground(), "")
+	if err := service.SuspendUser(context.Background(), userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !suspended {
+		t.Error("expected the user to be suspended")
+	}
+}
+
+func TestUserService_ReactivateUser(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           string
+		expectedErr      error
+		expectReactivate bool
+	}{
+		{name: "suspended", status: constants.SuspendedStatus, expectReactivate: true},
+		{name: "inactive", status: constants.InactiveStatus, expectReactivate: true},
+		{name: "pending verification", status: constants.PendingVerificationStatus, expectedErr: constants.ErrInvalidInput},
+		{name: "pending approval", status: constants.PendingApprovalStatus, expectedErr: constants.ErrInvalidInput},
+		{name: "rejected", status: constants.RejectedStatus, expectedErr: constants.ErrInvalidInput},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reactivated := false
+			userRepo := &MockUserRepository{
+				GetUserByIDFn: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+					return &model.User{ID: id, Status: tc.status}, nil
+				},
+				ReactivateUserFn: func(ctx context.Context, id uuid.UUID) error {
+					reactivated = true
+					return nil
+				},
+			}
+
+			service := NewUserService(userRepo, &MockEmailVerificationRepository{}, &MockRegistrationRepository{}, &MockPropertyRepository{}, &MockStorage{}, &MockMailer{}, job.NewBackground(), "")
+			err := service.ReactivateUser(context.Background(), uuid.New())
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if reactivated != tc.expectReactivate {
+				t.Errorf("expected reactivate %v, got %v", tc.expectReactivate, reactivated)
+			}
+		})
+	}
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		token       *model.EmailVerificationToken
+		verifyErr   error
+		expectedErr error
+	}{
+		{
+			name:  "valid token",
+			token: &model.EmailVerificationToken{ExpiresAt: time.Now().Add(time.Hour)},
+		},
+		{
+			name:        "unknown token",
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "already used",
+			token:       &model.EmailVerificationToken{ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt},
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "expired",
+			token:       &model.EmailVerificationToken{ExpiresAt: time.Now().Add(-time.Minute)},
+			expectedErr: constants.ErrInvalidToken,
+		},
+		{
+			name:        "account no longer pending",
+			token:       &model.EmailVerificationToken{ExpiresAt: time.Now().Add(time.Hour)},
+			verifyErr:   constants.ErrInvalidToken,
+			expectedErr: constants.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var status string
+			verificationRepo := &MockEmailVerificationRepository{
+				GetEmailVerificationTokenByHashFn: func(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error) {
+					if tc.token == nil || tokenHash != hashToken("verify-token") {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.token, nil
+				},
+				VerifyEmailFn: func(ctx context.Context, tokenID uuid.UUID, s string) error {
+					status = s
+					return tc.verifyErr
+				},
+			}
+
+			service := NewUserService(&MockUserRepository{}, verificationRepo, &MockRegistrationRepository{}, &MockPropertyRepository{}, &MockStorage{}, &MockMailer{}, job.NewBackground(), "")
+			err := service.VerifyEmail(context.Background(), &VerifyEmailRequest{Token: "verify-token"})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && status != constants.PendingApprovalStatus {
+				t.Errorf("expected the account to become %s, got %q", constants.PendingApprovalStatus, status)
+			}
+		})
+	}
+}
+
+func TestUserService_ResendVerification(t *testing.T) {
+	ctx := tenant.WithAssociation(context.Background(), &model.Association{ID: uuid.New(), Slug: "acacia", Name: "Acacia Heights"})
+
+	tests := []struct {
+		name       string
+		user       *model.User
+		latest     *model.EmailVerificationToken
+		expectMail bool
+	}{
+		{
+			name:       "pending registration",
+			user:       &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.PendingVerificationStatus},
+			latest:     &model.EmailVerificationToken{CreatedAt: time.Now().Add(-constants.EmailVerificationCooldown - time.Second)},
+			expectMail: true,
+		},
+		{
+			name:   "resent within the cooldown",
+			user:   &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.PendingVerificationStatus},
+			latest: &model.EmailVerificationToken{CreatedAt: time.Now().Add(-10 * time.Second)},
+		},
+		{
+			name: "already verified",
+			user: &model.User{ID: uuid.New(), Email: "ana@example.com", Status: constants.ActiveStatus},
+		},
+		{
+			name: "unknown email",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo := &MockUserRepository{
+				GetUserByEmailFn: func(ctx context.Context, email string) (*model.User, error) {
+					if tc.user == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.user, nil
+				},
+			}
+			verificationRepo := &MockEmailVerificationRepository{
+				GetLatestEmailVerificationTokenFn: func(ctx context.Context, userID uuid.UUID) (*model.EmailVerificationToken, error) {
+					if tc.latest == nil {
+						return nil, constants.ErrRecordNotFound
+					}
+					return tc.latest, nil
+				},
+				CreateEmailVerificationTokenFn: func(ctx context.Context, token *model.EmailVerificationToken) error {
+					return nil
+				},
+			}
+			sent := false
+			mail := &MockMailer{
+				SendFn: func(ctx context.Context, message mailer.Message) error {
+					sent = true
+					return nil
+				},
+			}
+
+			background := job.NewBackground()
+			service := NewUserService(userRepo, verificationRepo, &MockRegistrationRepository{}, &MockPropertyRepository{}, &MockStorage{}, mail, background, "https://app.example.com")
+			if err := service.ResendVerification(ctx, &ResendVerificationRequest{Email: "ana@example.com"}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			background.Wait(ctx)
+			if sent != tc.expectMail {
+				t.Errorf("expected mail sent %v, got %v", tc.expectMail, sent)
+			}
+		})
+	}
+}
+
+func TestUserService_ExpireUnverifiedUsers(t *testing.T) {
+	now := time.Date(2026, 5, 8, 3, 0, 0, 0, time.UTC)
+	var cutoff time.Time
+	userRepo := &MockUserRepository{
+		DeleteUnverifiedUsersFn: func(ctx context.Context, c time.Time) (int64, []string, error) {
+			cutoff = c
+			return 2, []string{"registrations/proof"}, nil
+		},
+	}
+	var deletedKeys []string
+	store := &MockStorage{
+		DeleteFn: func(ctx context.Context, key string) error {
+			deletedKeys = append(deletedKeys, key)
+			return nil
+		},
+	}
+
+	service := NewUserService(userRepo, &MockEmailVerificationRepository{}, &MockRegistrationRepository{}, &MockPropertyRepository{}, store, &MockMailer{}, job.NewBackground(), "")
+	if err := service.ExpireUnverifiedUsers(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := now.Add(-constants.UnverifiedAccountTTL); !cutoff.Equal(expected) {
+		t.Errorf("expected cutoff %s, got %s", expected, cutoff)
+	}
+	if len(deletedKeys) != 1 || deletedKeys[0] != "registrations/proof" {
+		t.Errorf("expected the proof to be removed, got %v", deletedKeys)
+	}
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/tenant"
+)
+
+type VehicleServiceImpl struct {
+	vehicleRepo   repository.VehicleRepository
+	invoiceRepo   repository.InvoiceRepository
+	occupancyRepo repository.OccupancyRepository
+	propertyRepo  repository.PropertyRepository
+	maxStickers   int
+}
+
+func NewVehicleService(vehicleRepo repository.VehicleRepository, invoiceRepo repository.InvoiceRepository, occupancyRepo repository.OccupancyRepository, propertyRepo repository.PropertyRepository, maxStickers int) VehicleService {
+	return &VehicleServiceImpl{
+		vehicleRepo:   vehicleRepo,
+		invoiceRepo:   invoiceRepo,
+		occupancyRepo: occupancyRepo,
+		propertyRepo:  propertyRepo,
+		maxStickers:   maxStickers,
+	}
+}
+
+func (s *VehicleServiceImpl) RegisterVehicle(ctx context.Context, userID uuid.UUID, req *VehicleRequest) (*VehicleResponse, error) {
+	plate := normalizePlate(&req.PlateNumber)
+	vehicleMake := strings.TrimSpace(req.Make)
+	vehicleModel := strings.TrimSpace(req.Model)
+	color := strings.TrimSpace(req.Color)
+	if plate == nil || vehicleMake == "" || vehicleModel == "" || color == "" {
+		return nil, invalidInput("plateNumber, make, model and color are required")
+	}
+
+	property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.vehicleRepo.CreateVehicle(ctx, &model.Vehicle{
+		PropertyID:  property.ID,
+		UserID:      userID,
+		PlateNumber: *plate,
+		Make:        vehicleMake,
+		Model:       vehicleModel,
+		Color:       color,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVehicleResponse(created), nil
+}
+
+func (s *VehicleServiceImpl) ListUserVehicles(ctx context.Context, userID uuid.UUID) ([]VehicleResponse, error) {
+	vehicles, err := s.vehicleRepo.ListVehicles(ctx, repository.VehicleFilter{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVehicleResponses(vehicles), nil
+}
+
+func (s *VehicleServiceImpl) RemoveUserVehicle(ctx context.Context, userID, id uuid.UUID) error {
+	vehicle, err := s.vehicleRepo.GetVehicleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if vehicle.UserID != userID {
+		return constants.ErrRecordNotFound
+	}
+
+	return s.vehicleRepo.ArchiveVehicle(ctx, id)
+}
+
+func (s *VehicleServiceImpl) GetVehicle(ctx context.Context, id uuid.UUID) (*VehicleResponse, error) {
+	vehicle, err := s.vehicleRepo.GetVehicleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVehicleResponse(vehicle), nil
+}
+
+func (s *VehicleServiceImpl) ListVehicles(ctx context.Context, req *ListVehiclesRequest) ([]VehicleResponse, error) {
+	filter := repository.VehicleFilter{
+		IncludeArchived: req.IncludeArchived,
+		Limit:           pageSize(req.Limit),
+		Offset:          req.Offset,
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return nil, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+	if plate := normalizePlate(&req.PlateNumber); plate != nil {
+		filter.PlateNumber = *plate
+	}
+
+	vehicles, err := s.vehicleRepo.ListVehicles(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVehicleResponses(vehicles), nil
+}
+
+func (s *VehicleServiceImpl) RemoveVehicle(ctx context.Context, id uuid.UUID) error {
+	return s.vehicleRepo.ArchiveVehicle(ctx, id)
+}
+
+// IssueSticker issues the vehicle's sticker for a year. Stickers are only
+// issued to properties in good standing on their dues, and never beyond the
+// configured number of stickers per property.
+func (s *VehicleServiceImpl) IssueSticker(ctx context.Context, vehicleID, issuedBy uuid.UUID, req *IssueStickerRequest) (*StickerResponse, error) {
+	vehicle, err := s.vehicleRepo.GetVehicleByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	if vehicle.ArchivedAt != nil {
+		return nil, invalidInput("vehicle is no longer registered")
+	}
+
+	year := req.Year
+	if year == 0 {
+		year = time.Now().In(tenant.Location(ctx)).Year()
+	}
+
+	overdue, err := s.invoiceRepo.HasOverdueInvoices(ctx, vehicle.PropertyID, today(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if overdue {
+		return nil, constants.ErrOverdueDues
+	}
+
+	sticker := &model.VehicleSticker{
+		VehicleID:   vehicle.ID,
+		PlateNumber: vehicle.PlateNumber,
+		StickerYear: year,
+		IssuedBy:    &issuedBy,
+	}
+	if err := s.vehicleRepo.IssueSticker(ctx, sticker, vehicle.PropertyID, s.maxStickers); err != nil {
+		return nil, err
+	}
+
+	return toStickerResponse(sticker), nil
+}
+
+func (s *VehicleServiceImpl) ListStickers(ctx context.Context, req *ListStickersRequest) ([]StickerResponse, error) {
+	stickers, err := s.vehicleRepo.ListStickers(ctx, repository.StickerFilter{
+		Year:   req.Year,
+		Status: req.Status,
+		Limit:  pageSize(req.Limit),
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]StickerResponse, 0, len(stickers))
+	for i := range stickers {
+		resp = append(resp, *toStickerResponse(&stickers[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *VehicleServiceImpl) MarkStickerLost(ctx context.Context, id uuid.UUID, req *StickerStatusRequest) (*StickerResponse, error) {
+	return s.updateStickerStatus(ctx, id, constants.StickerLost, trimmedOrNil(req.Reason))
+}
+
+func (s *VehicleServiceImpl) RevokeSticker(ctx context.Context, id uuid.UUID, req *StickerStatusRequest) (*StickerResponse, error) {
+	reason := trimmedOrNil(req.Reason)
+	if reason == nil {
+		return nil, invalidInput("a reason is required to revoke a sticker")
+	}
+
+	return s.updateStickerStatus(ctx, id, constants.StickerRevoked, reason)
+}
+
+func (s *VehicleServiceImpl) LookupPlate(ctx context.Context, plateNumber string) (*PlateLookupResponse, error) {
+	plate := normalizePlate(&plateNumber)
+	if plate == nil {
+		return nil, invalidInput("plate number is required")
+	}
+
+	vehicle, err := s.vehicleRepo.GetVehicleByPlate(ctx, *plate)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.propertyRepo.GetPropertyByID(ctx, vehicle.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PlateLookupResponse{
+		Vehicle:  *toVehicleResponse(vehicle),
+		Property: *toPropertyResponse(property),
+	}
+	year := time.Now().In(tenant.Location(ctx)).Year()
+	for i := range vehicle.Stickers {
+		sticker := &vehicle.Stickers[i]
+		if sticker.StickerYear == year && sticker.Status == constants.StickerIssued {
+			resp.HasValidSticker = true
+			resp.CurrentSticker = toStickerResponse(sticker)
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *VehicleServiceImpl) updateStickerStatus(ctx context.Context, id uuid.UUID, status string, reason *string) (*StickerResponse, error) {
+	if err := s.vehicleRepo.UpdateStickerStatus(ctx, id, status, reason); err != nil {
+		return nil, err
+	}
+
+	sticker, err := s.vehicleRepo.GetStickerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStickerResponse(sticker), nil
+}
+
+func toVehicleResponses(vehicles []model.Vehicle) []VehicleResponse {
+	resp := make([]VehicleResponse, 0, len(vehicles))
+	for i := range vehicles {
+		resp = append(resp, *toVehicleResponse(&vehicles[i]))
+	}
+	return resp
+}
+
+func toVehicleResponse(vehicle *model.Vehicle) *VehicleResponse {
+	resp := &VehicleResponse{
+		ID:          vehicle.ID.String(),
+		PropertyID:  vehicle.PropertyID.String(),
+		UserID:      vehicle.UserID.String(),
+		PlateNumber: vehicle.PlateNumber,
+		Make:        vehicle.Make,
+		Model:       vehicle.Model,
+		Color:       vehicle.Color,
+		ArchivedAt:  vehicle.ArchivedAt,
+		CreatedAt:   vehicle.CreatedAt,
+	}
+	for i := range vehicle.Stickers {
+		resp.Stickers = append(resp.Stickers, *toStickerResponse(&vehicle.Stickers[i]))
+	}
+	return resp
+}
+
+func toStickerResponse(sticker *model.VehicleSticker) *StickerResponse {
+	return &StickerResponse{
+		ID:              sticker.ID.String(),
+		VehicleID:       sticker.VehicleID.String(),
+		PlateNumber:     sticker.PlateNumber,
+		Year:            sticker.StickerYear,
+		StickerNumber:   sticker.StickerNumber,
+		Status:          sticker.Status,
+		StatusReason:    sticker.StatusReason,
+		IssuedBy:        optionalUUID(sticker.IssuedBy),
+		IssuedAt:        sticker.IssuedAt,
+		StatusChangedAt: sticker.StatusChangedAt,
+	}
+}
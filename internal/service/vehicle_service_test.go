@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockVehicleRepository struct {
+	CreateVehicleFn       func(ctx context.Context, vehicle *model.Vehicle) (*model.Vehicle, error)
+	GetVehicleByIDFn      func(ctx context.Context, id uuid.UUID) (*model.Vehicle, error)
+	GetVehicleByPlateFn   func(ctx context.Context, plateNumber string) (*model.Vehicle, error)
+	ListVehiclesFn        func(ctx context.Context, filter repository.VehicleFilter) ([]model.Vehicle, error)
+	ArchiveVehicleFn      func(ctx context.Context, id uuid.UUID) error
+	IssueStickerFn        func(ctx context.Context, sticker *model.VehicleSticker, propertyID uuid.UUID, maxPerProperty int) error
+	GetStickerByIDFn      func(ctx context.Context, id uuid.UUID) (*model.VehicleSticker, error)
+	ListStickersFn        func(ctx context.Context, filter repository.StickerFilter) ([]model.VehicleSticker, error)
+	UpdateStickerStatusFn func(ctx context.Context, id uuid.UUID, status string, reason *string) error
+}
+
+func (m *MockVehicleRepository) CreateVehicle(ctx context.Context, vehicle *model.Vehicle) (*model.Vehicle, error) {
+	return m.CreateVehicleFn(ctx, vehicle)
+}
+
+func (m *MockVehicleRepository) GetVehicleByID(ctx context.Context, id uuid.UUID) (*model.Vehicle, error) {
+	return m.GetVehicleByIDFn(ctx, id)
+}
+
+func (m *MockVehicleRepository) GetVehicleByPlate(ctx context.Context, plateNumber string) (*model.Vehicle, error) {
+	return m.GetVehicleByPlateFn(ctx, plateNumber)
+}
+
+func (m *MockVehicleRepository) ListVehicles(ctx context.Context, filter repository.VehicleFilter) ([]model.Vehicle, error) {
+	return m.ListVehiclesFn(ctx, filter)
+}
+
+func (m *MockVehicleRepository) ArchiveVehicle(ctx context.Context, id uuid.UUID) error {
+	return m.ArchiveVehicleFn(ctx, id)
+}
+
+func (m *MockVehicleRepository) IssueSticker(ctx context.Context, sticker *model.VehicleSticker, propertyID uuid.UUID, maxPerProperty int) error {
+	return m.IssueStickerFn(ctx, sticker, propertyID, maxPerProperty)
+}
+
+func (m *MockVehicleRepository) GetStickerByID(ctx context.Context, id uuid.UUID) (*model.VehicleSticker, error) {
+	return m.GetStickerByIDFn(ctx, id)
+}
+
+func (m *MockVehicleRepository) ListStickers(ctx context.Context, filter repository.StickerFilter) ([]model.VehicleSticker, error) {
+	return m.ListStickersFn(ctx, filter)
+}
+
+func (m *MockVehicleRepository) UpdateStickerStatus(ctx context.Context, id uuid.UUID, status string, reason *string) error {
+	return m.UpdateStickerStatusFn(ctx, id, status, reason)
+}
+
+func TestVehicleService_RegisterVehicle(t *testing.T) {
+	property := model.Property{ID: uuid.New()}
+
+	tests := []struct {
+		name          string
+		req           *VehicleRequest
+		expectedPlate string
+		expectedErr   error
+	}{
+		{
+			name:          "plate is normalized",
+			req:           &VehicleRequest{PlateNumber: " abc-1234 ", Make: "Toyota", Model: "Vios", Color: "White"},
+			expectedPlate: "ABC1234",
+		},
+		{
+			name:        "blank plate",
+			req:         &VehicleRequest{PlateNumber: " - ", Make: "Toyota", Model: "Vios", Color: "White"},
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:        "plate already registered",
+			req:         &VehicleRequest{PlateNumber: "TAKEN1", Make: "Honda", Model: "City", Color: "Gray"},
+			expectedErr: constants.ErrRecordExists,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vehicleRepo := &MockVehicleRepository{
+				CreateVehicleFn: func(ctx context.Context, vehicle *model.Vehicle) (*model.Vehicle, error) {
+					if vehicle.PlateNumber == "TAKEN1" {
+						return nil, constants.ErrRecordExists
+					}
+					vehicle.ID = uuid.New()
+					return vehicle, nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					return []model.UserProperty{{Property: property}}, nil
+				},
+			}
+
+			service := NewVehicleService(vehicleRepo, &MockInvoiceRepository{}, occupancyRepo, &MockPropertyRepository{}, 4)
+			resp, err := service.RegisterVehicle(context.Background(), uuid.New(), tc.req)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.PlateNumber != tc.expectedPlate {
+				t.Errorf("expected plate %s, got %s", tc.expectedPlate, resp.PlateNumber)
+			}
+			if resp.PropertyID != property.ID.String() {
+				t.Errorf("expected property %s, got %s", property.ID, resp.PropertyID)
+			}
+		})
+	}
+}
+
+func TestVehicleService_IssueSticker(t *testing.T) {
+	archivedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		vehicle      *model.Vehicle
+		req          *IssueStickerRequest
+		overdue      bool
+		limitReached bool
+		expectedYear int
+		expectedErr  error
+	}{
+		{
+			name:         "defaults to the current year",
+			vehicle:      &model.Vehicle{ID: uuid.New(), PropertyID: uuid.New(), PlateNumber: "ABC1234"},
+			req:          &IssueStickerRequest{},
+			expectedYear: time.Now().UTC().Year(),
+		},
+		{
+			name:         "next year's sticker",
+			vehicle:      &model.Vehicle{ID: uuid.New(), PropertyID: uuid.New(), PlateNumber: "ABC1234"},
+			req:          &IssueStickerRequest{Year: time.Now().UTC().Year() + 1},
+			expectedYear: time.Now().UTC().Year() + 1,
+		},
+		{
+			name:        "overdue dues",
+			vehicle:     &model.Vehicle{ID: uuid.New(), PropertyID: uuid.New(), PlateNumber: "ABC1234"},
+			req:         &IssueStickerRequest{},
+			overdue:     true,
+			expectedErr: constants.ErrOverdueDues,
+		},
+		{
+			name:         "property at its limit",
+			vehicle:      &model.Vehicle{ID: uuid.New(), PropertyID: uuid.New(), PlateNumber: "ABC1234"},
+			req:          &IssueStickerRequest{},
+			limitReached: true,
+			expectedErr:  constants.ErrStickerLimit,
+		},
+		{
+			name:        "archived vehicle",
+			vehicle:     &model.Vehicle{ID: uuid.New(), PropertyID: uuid.New(), PlateNumber: "ABC1234", ArchivedAt: &archivedAt},
+			req:         &IssueStickerRequest{},
+			expectedErr: constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issued := false
+			vehicleRepo := &MockVehicleRepository{
+				GetVehicleByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Vehicle, error) {
+					return tc.vehicle, nil
+				},
+				IssueStickerFn: func(ctx context.Context, sticker *model.VehicleSticker, propertyID uuid.UUID, maxPerProperty int) error {
+					if propertyID != tc.vehicle.PropertyID || maxPerProperty != 2 {
+						t.Errorf("unexpected property %s or limit %d", propertyID, maxPerProperty)
+					}
+					if tc.limitReached {
+						return constants.ErrStickerLimit
+					}
+					issued = true
+					sticker.ID = uuid.New()
+					sticker.Status = constants.StickerIssued
+					return nil
+				},
+			}
+			invoiceRepo := &MockInvoiceRepository{
+				HasOverdueInvoicesFn: func(ctx context.Context, propertyID uuid.UUID, asOf time.Time) (bool, error) {
+					return tc.overdue, nil
+				},
+			}
+
+			service := NewVehicleService(vehicleRepo, invoiceRepo, &MockOccupancyRepository{}, &MockPropertyRepository{}, 2)
+			resp, err := service.IssueSticker(context.Background(), tc.vehicle.ID, uuid.New(), tc.req)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if issued {
+					t.Error("expected no sticker to be issued")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Year != tc.expectedYear {
+				t.Errorf("expected year %d, got %d", tc.expectedYear, resp.Year)
+			}
+			if resp.PlateNumber != tc.vehicle.PlateNumber {
+				t.Errorf("expected plate %s, got %s", tc.vehicle.PlateNumber, resp.PlateNumber)
+			}
+		})
+	}
+}
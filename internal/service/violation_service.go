@@ -0,0 +1,684 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/storage"
+)
+
+var violationCategories = map[string]bool{
+	constants.ViolationCategoryConstruction: true,
+	constants.ViolationCategoryNoise:        true,
+	constants.ViolationCategoryPets:         true,
+	constants.ViolationCategoryParking:      true,
+	constants.ViolationCategoryGarbage:      true,
+	constants.ViolationCategoryOther:        true,
+}
+
+var violationEvidenceTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// appealOccupancyTypes are the occupants answerable for the property's
+// violations; tenants can see them but not appeal.
+var appealOccupancyTypes = map[string]bool{
+	constants.OccupancyOwner:          true,
+	constants.OccupancyCoOwner:        true,
+	constants.OccupancyRepresentative: true,
+}
+
+type ViolationServiceImpl struct {
+	violationRepo    repository.ViolationRepository
+	notificationRepo repository.NotificationRepository
+	occupancyRepo    repository.OccupancyRepository
+	propertyRepo     repository.PropertyRepository
+	store            storage.Storage
+}
+
+func NewViolationService(violationRepo repository.ViolationRepository, notificationRepo repository.NotificationRepository, occupancyRepo repository.OccupancyRepository, propertyRepo repository.PropertyRepository, store storage.Storage) ViolationService {
+	return &ViolationServiceImpl{
+		violationRepo:    violationRepo,
+		notificationRepo: notificationRepo,
+		occupancyRepo:    occupancyRepo,
+		propertyRepo:     propertyRepo,
+		store:            store,
+	}
+}
+
+func (s *ViolationServiceImpl) RecordViolation(ctx context.Context, reportedBy uuid.UUID, req *ViolationRequest) (*ViolationResponse, error) {
+	if !violationCategories[req.Category] {
+		return nil, invalidInput("unknown category %q", req.Category)
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, invalidInput("description is required")
+	}
+
+	propertyID, err := uuid.Parse(req.PropertyID)
+	if err != nil {
+		return nil, invalidInput("property id must be a uuid")
+	}
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	observedAt := time.Now()
+	if req.ObservedAt != nil {
+		if req.ObservedAt.After(observedAt) {
+			return nil, invalidInput("observedAt cannot be in the future")
+		}
+		observedAt = *req.ObservedAt
+	}
+
+	violation, err := s.violationRepo.CreateViolation(ctx, &model.Violation{
+		PropertyID:  propertyID,
+		Category:    req.Category,
+		Description: description,
+		ObservedAt:  observedAt,
+		ReportedBy:  &reportedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toViolationResponse(violation), nil
+}
+
+func (s *ViolationServiceImpl) ListViolations(ctx context.Context, req *ListViolationsRequest) ([]ViolationResponse, error) {
+	filter := repository.ViolationFilter{
+		Status:   req.Status,
+		Category: req.Category,
+		Limit:    pageSize(req.Limit),
+		Offset:   req.Offset,
+	}
+	if req.PropertyID != "" {
+		propertyID, err := uuid.Parse(req.PropertyID)
+		if err != nil {
+			return nil, invalidInput("property id must be a uuid")
+		}
+		filter.PropertyID = &propertyID
+	}
+
+	return s.listViolations(ctx, filter)
+}
+
+func (s *ViolationServiceImpl) GetViolation(ctx context.Context, id uuid.UUID) (*ViolationDetailResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.violationDetail(ctx, violation)
+}
+
+// GetPropertyViolationHistory returns every violation recorded against the
+// property, newest first, each with its full trail.
+func (s *ViolationServiceImpl) GetPropertyViolationHistory(ctx context.Context, propertyID uuid.UUID) ([]ViolationDetailResponse, error) {
+	if _, err := s.propertyRepo.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	violations, err := s.violationRepo.ListViolations(ctx, repository.ViolationFilter{PropertyID: &propertyID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]ViolationDetailResponse, 0, len(violations))
+	for i := range violations {
+		detail, err := s.violationDetail(ctx, &violations[i])
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *detail)
+	}
+
+	return resp, nil
+}
+
+// AddEvidence stores a photo or PDF backing up the violation. The type is
+// sniffed from the content rather than trusted from the upload.
+func (s *ViolationServiceImpl) AddEvidence(ctx context.Context, id, uploadedBy uuid.UUID, upload *FileUpload) (*ViolationAttachmentResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if isViolationClosed(violation.Status) {
+		return nil, invalidInput("violation is %s", violation.Status)
+	}
+	if upload.SizeBytes <= 0 || upload.SizeBytes > constants.MaxViolationEvidenceBytes {
+		return nil, invalidInput("evidence files must be at most %d MB", constants.MaxViolationEvidenceBytes>>20)
+	}
+
+	attachments, err := s.violationRepo.ListViolationAttachments(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) >= constants.MaxViolationAttachments {
+		return nil, invalidInput("a violation can have at most %d evidence files", constants.MaxViolationAttachments)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read evidence: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !violationEvidenceTypes[contentType] {
+		return nil, invalidInput("evidence must be a JPEG, PNG or WebP image or a PDF")
+	}
+
+	attachment := &model.ViolationAttachment{
+		ID:          uuid.New(),
+		ViolationID: violation.ID,
+		FileName:    evidenceFileName(upload.FileName),
+		ContentType: contentType,
+		SizeBytes:   upload.SizeBytes,
+		UploadedBy:  &uploadedBy,
+	}
+	attachment.StorageKey = fmt.Sprintf("violations/%s/%s", violation.ID, attachment.ID)
+
+	body := io.MultiReader(bytes.NewReader(head), upload.Body)
+	if err := s.store.Put(ctx, attachment.StorageKey, body, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.violationRepo.AddViolationAttachment(ctx, attachment); err != nil {
+		if dErr := s.store.Delete(ctx, attachment.StorageKey); dErr != nil {
+			log.Printf("failed to remove orphaned evidence %s: %v\n", attachment.StorageKey, dErr)
+		}
+		return nil, err
+	}
+
+	return toViolationAttachmentResponse(attachment), nil
+}
+
+func (s *ViolationServiceImpl) GetEvidence(ctx context.Context, violationID, attachmentID uuid.UUID) (*StoredFile, error) {
+	attachment, err := s.violationRepo.GetViolationAttachment(ctx, violationID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredFile{
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		Body:        body,
+	}, nil
+}
+
+// IssueNotice sends the property a formal notice to cure the violation by
+// the deadline. A fined violation can be noticed again and stays fined.
+func (s *ViolationServiceImpl) IssueNotice(ctx context.Context, id, issuedBy uuid.UUID, req *ViolationNoticeRequest) (*ViolationNoticeResponse, error) {
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, invalidInput("message is required")
+	}
+	cureDeadline, err := parseDate(req.CureDeadline)
+	if err != nil {
+		return nil, err
+	}
+	if !cureDeadline.After(today(ctx)) {
+		return nil, invalidInput("cureDeadline must be in the future")
+	}
+
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	to := constants.ViolationNoticed
+	switch violation.Status {
+	case constants.ViolationOpen, constants.ViolationNoticed:
+	case constants.ViolationFined:
+		to = constants.ViolationFined
+	default:
+		return nil, invalidInput("a notice cannot be issued for a %s violation", violation.Status)
+	}
+
+	notice := &model.ViolationNotice{
+		ViolationID:  violation.ID,
+		Message:      message,
+		CureDeadline: cureDeadline,
+		IssuedBy:     &issuedBy,
+	}
+	if err := s.violationRepo.IssueViolationNotice(ctx, notice, violation.Status, to); err != nil {
+		return nil, err
+	}
+
+	s.notifyAccountable(ctx, violation, constants.NotificationViolationNotice,
+		fmt.Sprintf("Notice of violation %s", violation.ViolationNumber),
+		fmt.Sprintf("%s\n\nPlease cure the violation by %s.", message, cureDeadline.Format(constants.DateFormat)))
+
+	return toViolationNoticeResponse(notice), nil
+}
+
+// IssueFine posts a fine to the property's account once the cure deadline of
+// the latest notice has passed.
+func (s *ViolationServiceImpl) IssueFine(ctx context.Context, id, issuedBy uuid.UUID, req *ViolationFineRequest) (*ViolationFineResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if violation.Status != constants.ViolationNoticed && violation.Status != constants.ViolationFined {
+		return nil, invalidInput("a %s violation cannot be fined", violation.Status)
+	}
+
+	notices, err := s.violationRepo.ListViolationNotices(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(notices) == 0 {
+		return nil, invalidInput("a notice must be issued before a fine")
+	}
+	issueDate := today(ctx)
+	if latest := notices[len(notices)-1]; !latest.CureDeadline.Before(issueDate) {
+		return nil, invalidInput("the cure deadline of %s has not passed yet", latest.CureDeadline.Format(constants.DateFormat))
+	}
+
+	dueDate := issueDate.AddDate(0, 0, constants.DefaultFineDueDays)
+	if req.DueDate != "" {
+		dueDate, err = parseDate(req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		if dueDate.Before(issueDate) {
+			return nil, invalidInput("dueDate cannot be in the past")
+		}
+	}
+
+	invoice := &model.Invoice{
+		PropertyID:    violation.PropertyID,
+		BillingPeriod: time.Date(issueDate.Year(), issueDate.Month(), 1, 0, 0, 0, 0, time.UTC),
+		IssueDate:     issueDate,
+		DueDate:       dueDate,
+		Status:        constants.InvoiceStatusOpen,
+		TotalCents:    req.AmountCents,
+		LineItems: []model.InvoiceLineItem{{
+			Description:     fmt.Sprintf("Violation %s: %s fine", violation.ViolationNumber, violation.Category),
+			Quantity:        1,
+			UnitAmountCents: req.AmountCents,
+			AmountCents:     req.AmountCents,
+		}},
+	}
+	fine := &model.ViolationFine{
+		ViolationID: violation.ID,
+		AmountCents: req.AmountCents,
+		IssuedBy:    &issuedBy,
+	}
+	if err := s.violationRepo.IssueViolationFine(ctx, fine, invoice, violation.Status); err != nil {
+		return nil, err
+	}
+
+	s.notifyAccountable(ctx, violation, constants.NotificationViolationFine,
+		fmt.Sprintf("Fine issued for violation %s", violation.ViolationNumber),
+		fmt.Sprintf("Invoice %s was added to your account, due on %s.", invoice.InvoiceNumber, dueDate.Format(constants.DateFormat)))
+
+	return toViolationFineResponse(fine), nil
+}
+
+// DecideAppeal accepts or rejects the pending appeal. Accepting it dismisses
+// the violation; rejecting it returns the violation to where it was.
+func (s *ViolationServiceImpl) DecideAppeal(ctx context.Context, id, appealID, decidedBy uuid.UUID, req *AppealDecisionRequest) (*ViolationResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if violation.Status != constants.ViolationAppealed {
+		return nil, invalidInput("violation has no pending appeal")
+	}
+
+	appeal := &model.ViolationAppeal{
+		ID:           appealID,
+		ViolationID:  violation.ID,
+		Status:       constants.AppealRejected,
+		DecisionNote: trimmedOrNil(req.Note),
+		DecidedBy:    &decidedBy,
+	}
+	to := constants.ViolationDismissed
+	if req.Accept {
+		appeal.Status = constants.AppealAccepted
+	} else {
+		fines, err := s.violationRepo.ListViolationFines(ctx, violation.ID)
+		if err != nil {
+			return nil, err
+		}
+		to = constants.ViolationNoticed
+		if len(fines) > 0 {
+			to = constants.ViolationFined
+		}
+	}
+
+	if err := s.violationRepo.DecideViolationAppeal(ctx, appeal, to); err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("Your appeal was %s.", appeal.Status)
+	if appeal.DecisionNote != nil {
+		body += "\n\n" + *appeal.DecisionNote
+	}
+	s.notifyAccountable(ctx, violation, constants.NotificationViolationAppeal,
+		fmt.Sprintf("Appeal on violation %s %s", violation.ViolationNumber, appeal.Status), body)
+
+	return s.violationResponse(ctx, violation.ID)
+}
+
+func (s *ViolationServiceImpl) ResolveViolation(ctx context.Context, id, actorID uuid.UUID, req *ViolationStatusRequest) (*ViolationResponse, error) {
+	return s.closeViolation(ctx, id, actorID, constants.ViolationResolved, trimmedOrNil(req.Note))
+}
+
+// DismissViolation closes the violation as unfounded and voids its unpaid
+// fines.
+func (s *ViolationServiceImpl) DismissViolation(ctx context.Context, id, actorID uuid.UUID, req *ViolationStatusRequest) (*ViolationResponse, error) {
+	return s.closeViolation(ctx, id, actorID, constants.ViolationDismissed, trimmedOrNil(req.Note))
+}
+
+func (s *ViolationServiceImpl) ListUserViolations(ctx context.Context, userID uuid.UUID, req *ListViolationsRequest) ([]ViolationResponse, error) {
+	property, err := resolveUserProperty(ctx, s.occupancyRepo, userID, req.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listViolations(ctx, repository.ViolationFilter{
+		PropertyID: &property.ID,
+		Status:     req.Status,
+		Category:   req.Category,
+		Limit:      pageSize(req.Limit),
+		Offset:     req.Offset,
+	})
+}
+
+func (s *ViolationServiceImpl) GetUserViolation(ctx context.Context, userID, id uuid.UUID) (*ViolationDetailResponse, error) {
+	violation, _, err := s.userViolation(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.violationDetail(ctx, violation)
+}
+
+func (s *ViolationServiceImpl) GetUserEvidence(ctx context.Context, userID, violationID, attachmentID uuid.UUID) (*StoredFile, error) {
+	if _, _, err := s.userViolation(ctx, userID, violationID); err != nil {
+		return nil, err
+	}
+
+	return s.GetEvidence(ctx, violationID, attachmentID)
+}
+
+// SubmitAppeal lets an owner or representative of the property contest a
+// noticed or fined violation.
+func (s *ViolationServiceImpl) SubmitAppeal(ctx context.Context, userID, id uuid.UUID, req *AppealRequest) (*ViolationAppealResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, invalidInput("reason is required")
+	}
+
+	violation, property, err := s.userViolation(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !appealOccupancyTypes[property.OccupancyType] {
+		return nil, invalidInput("only owners and representatives can appeal a violation")
+	}
+	switch violation.Status {
+	case constants.ViolationNoticed, constants.ViolationFined:
+	case constants.ViolationAppealed:
+		return nil, constants.ErrRecordExists
+	default:
+		return nil, invalidInput("a %s violation cannot be appealed", violation.Status)
+	}
+
+	appeal := &model.ViolationAppeal{
+		ViolationID: violation.ID,
+		SubmittedBy: userID,
+		Reason:      reason,
+	}
+	if err := s.violationRepo.SubmitViolationAppeal(ctx, appeal, violation.Status); err != nil {
+		return nil, err
+	}
+
+	return toViolationAppealResponse(appeal), nil
+}
+
+func (s *ViolationServiceImpl) closeViolation(ctx context.Context, id, actorID uuid.UUID, to string, note *string) (*ViolationResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch violation.Status {
+	case constants.ViolationOpen, constants.ViolationNoticed, constants.ViolationFined:
+	case constants.ViolationAppealed:
+		return nil, invalidInput("decide the pending appeal first")
+	default:
+		return nil, invalidInput("violation is already %s", violation.Status)
+	}
+
+	if err := s.violationRepo.CloseViolation(ctx, violation.ID, violation.Status, to, note, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.violationResponse(ctx, violation.ID)
+}
+
+// userViolation returns the violation only when userID currently occupies
+// the property it was recorded against, along with that occupancy.
+func (s *ViolationServiceImpl) userViolation(ctx context.Context, userID, id uuid.UUID) (*model.Violation, *model.UserProperty, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	properties, err := s.occupancyRepo.ListUserProperties(ctx, userID, today(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range properties {
+		if properties[i].ID == violation.PropertyID {
+			return violation, &properties[i], nil
+		}
+	}
+
+	return nil, nil, constants.ErrRecordNotFound
+}
+
+// notifyAccountable tells the property's owners and representatives about
+// the violation. A failed notification is logged rather than undoing the
+// action that triggered it.
+func (s *ViolationServiceImpl) notifyAccountable(ctx context.Context, violation *model.Violation, kind, title, body string) {
+	occupants, err := s.occupancyRepo.ListOccupantsOnDate(ctx, violation.PropertyID, today(ctx))
+	if err != nil {
+		log.Printf("failed to list occupants to notify of violation %s: %v\n", violation.ViolationNumber, err)
+		return
+	}
+
+	referenceType := constants.ReferenceViolation
+	for _, occupant := range occupants {
+		if !appealOccupancyTypes[occupant.OccupancyType] {
+			continue
+		}
+		notification := &model.Notification{
+			UserID:        occupant.UserID,
+			Kind:          kind,
+			Title:         title,
+			Body:          body,
+			ReferenceType: &referenceType,
+			ReferenceID:   &violation.ID,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			log.Printf("failed to notify %s of violation %s: %v\n", occupant.UserID, violation.ViolationNumber, err)
+		}
+	}
+}
+
+func (s *ViolationServiceImpl) violationResponse(ctx context.Context, id uuid.UUID) (*ViolationResponse, error) {
+	violation, err := s.violationRepo.GetViolationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toViolationResponse(violation), nil
+}
+
+func (s *ViolationServiceImpl) listViolations(ctx context.Context, filter repository.ViolationFilter) ([]ViolationResponse, error) {
+	violations, err := s.violationRepo.ListViolations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]ViolationResponse, 0, len(violations))
+	for i := range violations {
+		resp = append(resp, *toViolationResponse(&violations[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *ViolationServiceImpl) violationDetail(ctx context.Context, violation *model.Violation) (*ViolationDetailResponse, error) {
+	attachments, err := s.violationRepo.ListViolationAttachments(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	notices, err := s.violationRepo.ListViolationNotices(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	fines, err := s.violationRepo.ListViolationFines(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	appeals, err := s.violationRepo.ListViolationAppeals(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.violationRepo.ListViolationEvents(ctx, violation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ViolationDetailResponse{
+		ViolationResponse: *toViolationResponse(violation),
+		Attachments:       make([]ViolationAttachmentResponse, 0, len(attachments)),
+		Notices:           make([]ViolationNoticeResponse, 0, len(notices)),
+		Fines:             make([]ViolationFineResponse, 0, len(fines)),
+		Appeals:           make([]ViolationAppealResponse, 0, len(appeals)),
+		History:           make([]ViolationEventResponse, 0, len(events)),
+	}
+	for i := range attachments {
+		resp.Attachments = append(resp.Attachments, *toViolationAttachmentResponse(&attachments[i]))
+	}
+	for i := range notices {
+		resp.Notices = append(resp.Notices, *toViolationNoticeResponse(&notices[i]))
+	}
+	for i := range fines {
+		resp.Fines = append(resp.Fines, *toViolationFineResponse(&fines[i]))
+	}
+	for i := range appeals {
+		resp.Appeals = append(resp.Appeals, *toViolationAppealResponse(&appeals[i]))
+	}
+	for _, event := range events {
+		resp.History = append(resp.History, ViolationEventResponse{
+			EventType: event.EventType,
+			Note:      event.Note,
+			ActorID:   optionalUUID(event.ActorID),
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+func isViolationClosed(status string) bool {
+	return status == constants.ViolationResolved || status == constants.ViolationDismissed
+}
+
+// evidenceFileName keeps only the base name of the uploaded file so it is
+// safe to echo back in a download.
+func evidenceFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "evidence"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func toViolationResponse(violation *model.Violation) *ViolationResponse {
+	return &ViolationResponse{
+		ID:              violation.ID.String(),
+		ViolationNumber: violation.ViolationNumber,
+		PropertyID:      violation.PropertyID.String(),
+		Category:        violation.Category,
+		Description:     violation.Description,
+		ObservedAt:      violation.ObservedAt,
+		Status:          violation.Status,
+		ReportedBy:      optionalUUID(violation.ReportedBy),
+		ClosedAt:        violation.ClosedAt,
+		CreatedAt:       violation.CreatedAt,
+		UpdatedAt:       violation.UpdatedAt,
+	}
+}
+
+func toViolationAttachmentResponse(attachment *model.ViolationAttachment) *ViolationAttachmentResponse {
+	return &ViolationAttachmentResponse{
+		ID:          attachment.ID.String(),
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func toViolationNoticeResponse(notice *model.ViolationNotice) *ViolationNoticeResponse {
+	return &ViolationNoticeResponse{
+		ID:           notice.ID.String(),
+		Message:      notice.Message,
+		CureDeadline: notice.CureDeadline.Format(constants.DateFormat),
+		IssuedBy:     optionalUUID(notice.IssuedBy),
+		IssuedAt:     notice.IssuedAt,
+	}
+}
+
+func toViolationFineResponse(fine *model.ViolationFine) *ViolationFineResponse {
+	return &ViolationFineResponse{
+		ID:            fine.ID.String(),
+		InvoiceID:     fine.InvoiceID.String(),
+		InvoiceNumber: fine.InvoiceNumber,
+		InvoiceStatus: fine.InvoiceStatus,
+		AmountCents:   fine.AmountCents,
+		IssuedBy:      optionalUUID(fine.IssuedBy),
+		IssuedAt:      fine.IssuedAt,
+	}
+}
+
+func toViolationAppealResponse(appeal *model.ViolationAppeal) *ViolationAppealResponse {
+	return &ViolationAppealResponse{
+		ID:           appeal.ID.String(),
+		SubmittedBy:  appeal.SubmittedBy.String(),
+		Reason:       appeal.Reason,
+		Status:       appeal.Status,
+		DecisionNote: appeal.DecisionNote,
+		DecidedBy:    optionalUUID(appeal.DecidedBy),
+		DecidedAt:    appeal.DecidedAt,
+		SubmittedAt:  appeal.SubmittedAt,
+	}
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/constants"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/repository"
+)
+
+type MockViolationRepository struct {
+	CreateViolationFn          func(ctx context.Context, violation *model.Violation) (*model.Violation, error)
+	GetViolationByIDFn         func(ctx context.Context, id uuid.UUID) (*model.Violation, error)
+	ListViolationsFn           func(ctx context.Context, filter repository.ViolationFilter) ([]model.Violation, error)
+	AddViolationAttachmentFn   func(ctx context.Context, attachment *model.ViolationAttachment) error
+	GetViolationAttachmentFn   func(ctx context.Context, violationID, id uuid.UUID) (*model.ViolationAttachment, error)
+	ListViolationAttachmentsFn func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAttachment, error)
+	IssueViolationNoticeFn     func(ctx context.Context, notice *model.ViolationNotice, from, to string) error
+	ListViolationNoticesFn     func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationNotice, error)
+	IssueViolationFineFn       func(ctx context.Context, fine *model.ViolationFine, invoice *model.Invoice, from string) error
+	ListViolationFinesFn       func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationFine, error)
+	SubmitViolationAppealFn    func(ctx context.Context, appeal *model.ViolationAppeal, from string) error
+	ListViolationAppealsFn     func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAppeal, error)
+	DecideViolationAppealFn    func(ctx context.Context, appeal *model.ViolationAppeal, to string) error
+	CloseViolationFn           func(ctx context.Context, id uuid.UUID, from, to string, note *string, actorID uuid.UUID) error
+	ListViolationEventsFn      func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationEvent, error)
+}
+
+func (m *MockViolationRepository) CreateViolation(ctx context.Context, violation *model.Violation) (*model.Violation, error) {
+	return m.CreateViolationFn(ctx, violation)
+}
+
+func (m *MockViolationRepository) GetViolationByID(ctx context.Context, id uuid.UUID) (*model.Violation, error) {
+	return m.GetViolationByIDFn(ctx, id)
+}
+
+func (m *MockViolationRepository) ListViolations(ctx context.Context, filter repository.ViolationFilter) ([]model.Violation, error) {
+	return m.ListViolationsFn(ctx, filter)
+}
+
+func (m *MockViolationRepository) AddViolationAttachment(ctx context.Context, attachment *model.ViolationAttachment) error {
+	return m.AddViolationAttachmentFn(ctx, attachment)
+}
+
+func (m *MockViolationRepository) GetViolationAttachment(ctx context.Context, violationID, id uuid.UUID) (*model.ViolationAttachment, error) {
+	return m.GetViolationAttachmentFn(ctx, violationID, id)
+}
+
+func (m *MockViolationRepository) ListViolationAttachments(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAttachment, error) {
+	return m.ListViolationAttachmentsFn(ctx, violationID)
+}
+
+func (m *MockViolationRepository) IssueViolationNotice(ctx context.Context, notice *model.ViolationNotice, from, to string) error {
+	return m.IssueViolationNoticeFn(ctx, notice, from, to)
+}
+
+func (m *MockViolationRepository) ListViolationNotices(ctx context.Context, violationID uuid.UUID) ([]model.ViolationNotice, error) {
+	return m.ListViolationNoticesFn(ctx, violationID)
+}
+
+func (m *MockViolationRepository) IssueViolationFine(ctx context.Context, fine *model.ViolationFine, invoice *model.Invoice, from string) error {
+	return m.IssueViolationFineFn(ctx, fine, invoice, from)
+}
+
+func (m *MockViolationRepository) ListViolationFines(ctx context.Context, violationID uuid.UUID) ([]model.ViolationFine, error) {
+	return m.ListViolationFinesFn(ctx, violationID)
+}
+
+func (m *MockViolationRepository) SubmitViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, from string) error {
+	return m.SubmitViolationAppealFn(ctx, appeal, from)
+}
+
+func (m *MockViolationRepository) ListViolationAppeals(ctx context.Context, violationID uuid.UUID) ([]model.ViolationAppeal, error) {
+	return m.ListViolationAppealsFn(ctx, violationID)
+}
+
+func (m *MockViolationRepository) DecideViolationAppeal(ctx context.Context, appeal *model.ViolationAppeal, to string) error {
+	return m.DecideViolationAppealFn(ctx, appeal, to)
+}
+
+func (m *MockViolationRepository) CloseViolation(ctx context.Context, id uuid.UUID, from, to string, note *string, actorID uuid.UUID) error {
+	return m.CloseViolationFn(ctx, id, from, to, note, actorID)
+}
+
+func (m *MockViolationRepository) ListViolationEvents(ctx context.Context, violationID uuid.UUID) ([]model.ViolationEvent, error) {
+	return m.ListViolationEventsFn(ctx, violationID)
+}
+
+func TestViolationService_IssueFine(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		status       string
+		cureDeadline time.Time
+		noNotice     bool
+		dueDate      string
+		expectedDue  time.Time
+		expectedErr  error
+	}{
+		{
+			name:         "cure deadline passed",
+			status:       constants.ViolationNoticed,
+			cureDeadline: today(ctx).AddDate(0, 0, -1),
+			expectedDue:  today(ctx).AddDate(0, 0, constants.DefaultFineDueDays),
+		},
+		{
+			name:         "fined again with a due date",
+			status:       constants.ViolationFined,
+			cureDeadline: today(ctx).AddDate(0, 0, -10),
+			dueDate:      today(ctx).AddDate(0, 0, 7).Format(constants.DateFormat),
+			expectedDue:  today(ctx).AddDate(0, 0, 7),
+		},
+		{
+			name:         "cure deadline is today",
+			status:       constants.ViolationNoticed,
+			cureDeadline: today(ctx),
+			expectedErr:  constants.ErrInvalidInput,
+		},
+		{
+			name:        "no notice issued",
+			status:      constants.ViolationNoticed,
+			noNotice:    true,
+			expectedErr: constants.ErrInvalidInput,
+		},
+		{
+			name:         "open violations are noticed first",
+			status:       constants.ViolationOpen,
+			cureDeadline: today(ctx).AddDate(0, 0, -1),
+			expectedErr:  constants.ErrInvalidInput,
+		},
+		{
+			name:         "appealed violations wait for the decision",
+			status:       constants.ViolationAppealed,
+			cureDeadline: today(ctx).AddDate(0, 0, -1),
+			expectedErr:  constants.ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violation := &model.Violation{ID: uuid.New(), ViolationNumber: "VIO-000001", PropertyID: uuid.New(), Category: constants.ViolationCategoryNoise, Status: tc.status}
+			var posted *model.Invoice
+			var notified int
+			violationRepo := &MockViolationRepository{
+				GetViolationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Violation, error) {
+					return violation, nil
+				},
+				ListViolationNoticesFn: func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationNotice, error) {
+					if tc.noNotice {
+						return []model.ViolationNotice{}, nil
+					}
+					return []model.ViolationNotice{
+						{CureDeadline: tc.cureDeadline.AddDate(0, 0, -30)},
+						{CureDeadline: tc.cureDeadline},
+					}, nil
+				},
+				IssueViolationFineFn: func(ctx context.Context, fine *model.ViolationFine, invoice *model.Invoice, from string) error {
+					if from != tc.status {
+						t.Errorf("expected fine from %s, got %s", tc.status, from)
+					}
+					posted = invoice
+					invoice.InvoiceNumber = "INV-000001"
+					fine.ID = uuid.New()
+					fine.InvoiceID = uuid.New()
+					return nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListOccupantsOnDateFn: func(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error) {
+					return []model.PropertyOccupant{
+						{Occupancy: model.Occupancy{UserID: uuid.New(), OccupancyType: constants.OccupancyOwner}},
+						{Occupancy: model.Occupancy{UserID: uuid.New(), OccupancyType: constants.OccupancyTenant}},
+					}, nil
+				},
+			}
+			notificationRepo := &MockNotificationRepository{
+				CreateNotificationFn: func(ctx context.Context, notification *model.Notification) error {
+					notified++
+					return nil
+				},
+			}
+
+			service := NewViolationService(violationRepo, notificationRepo, occupancyRepo, &MockPropertyRepository{}, nil)
+			resp, err := service.IssueFine(ctx, violation.ID, uuid.New(), &ViolationFineRequest{AmountCents: 250000, DueDate: tc.dueDate})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if posted != nil {
+					t.Error("expected no fine to be posted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.AmountCents != 250000 || posted.TotalCents != 250000 || len(posted.LineItems) != 1 {
+				t.Errorf("expected a single 250000 line item, got %+v", posted)
+			}
+			if posted.PropertyID != violation.PropertyID {
+				t.Errorf("expected fine on property %s, got %s", violation.PropertyID, posted.PropertyID)
+			}
+			if !posted.DueDate.Equal(tc.expectedDue) {
+				t.Errorf("expected due date %s, got %s", tc.expectedDue, posted.DueDate)
+			}
+			if notified != 1 {
+				t.Errorf("expected only the owner to be notified, got %d notifications", notified)
+			}
+		})
+	}
+}
+
+func TestViolationService_SubmitAppeal(t *testing.T) {
+	userID := uuid.New()
+	propertyID := uuid.New()
+
+	tests := []struct {
+		name          string
+		status        string
+		occupancyType string
+		linked        bool
+		expectedErr   error
+	}{
+		{
+			name:          "owner appeals a fine",
+			status:        constants.ViolationFined,
+			occupancyType: constants.OccupancyOwner,
+			linked:        true,
+		},
+		{
+			name:          "representative appeals a notice",
+			status:        constants.ViolationNoticed,
+			occupancyType: constants.OccupancyRepresentative,
+			linked:        true,
+		},
+		{
+			name:          "tenants cannot appeal",
+			status:        constants.ViolationNoticed,
+			occupancyType: constants.OccupancyTenant,
+			linked:        true,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:          "already appealed",
+			status:        constants.ViolationAppealed,
+			occupancyType: constants.OccupancyOwner,
+			linked:        true,
+			expectedErr:   constants.ErrRecordExists,
+		},
+		{
+			name:          "dismissed violation",
+			status:        constants.ViolationDismissed,
+			occupancyType: constants.OccupancyOwner,
+			linked:        true,
+			expectedErr:   constants.ErrInvalidInput,
+		},
+		{
+			name:          "someone else's property",
+			status:        constants.ViolationFined,
+			occupancyType: constants.OccupancyOwner,
+			expectedErr:   constants.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violation := &model.Violation{ID: uuid.New(), PropertyID: propertyID, Status: tc.status}
+			var submitted *model.ViolationAppeal
+			violationRepo := &MockViolationRepository{
+				GetViolationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Violation, error) {
+					return violation, nil
+				},
+				SubmitViolationAppealFn: func(ctx context.Context, appeal *model.ViolationAppeal, from string) error {
+					submitted = appeal
+					appeal.ID = uuid.New()
+					appeal.Status = constants.AppealPending
+					return nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListUserPropertiesFn: func(ctx context.Context, id uuid.UUID, date time.Time) ([]model.UserProperty, error) {
+					property := model.UserProperty{Property: model.Property{ID: uuid.New()}, OccupancyType: tc.occupancyType}
+					if tc.linked {
+						property.ID = propertyID
+					}
+					return []model.UserProperty{property}, nil
+				},
+			}
+
+			service := NewViolationService(violationRepo, &MockNotificationRepository{}, occupancyRepo, &MockPropertyRepository{}, nil)
+			resp, err := service.SubmitAppeal(context.Background(), userID, violation.ID, &AppealRequest{Reason: "  The dog is not ours  "})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				if submitted != nil {
+					t.Error("expected no appeal to be submitted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != constants.AppealPending || resp.Reason != "The dog is not ours" || resp.SubmittedBy != userID.String() {
+				t.Errorf("unexpected appeal %+v", resp)
+			}
+		})
+	}
+}
+
+func TestViolationService_DecideAppeal(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   bool
+		fines    []model.ViolationFine
+		expected string
+	}{
+		{
+			name:     "accepted appeal dismisses",
+			accept:   true,
+			fines:    []model.ViolationFine{{ID: uuid.New()}},
+			expected: constants.ViolationDismissed,
+		},
+		{
+			name:     "rejected appeal keeps the fine",
+			fines:    []model.ViolationFine{{ID: uuid.New()}},
+			expected: constants.ViolationFined,
+		},
+		{
+			name:     "rejected appeal before any fine",
+			fines:    []model.ViolationFine{},
+			expected: constants.ViolationNoticed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violation := &model.Violation{ID: uuid.New(), ViolationNumber: "VIO-000002", PropertyID: uuid.New(), Status: constants.ViolationAppealed}
+			var movedTo string
+			violationRepo := &MockViolationRepository{
+				GetViolationByIDFn: func(ctx context.Context, id uuid.UUID) (*model.Violation, error) {
+					updated := *violation
+					if movedTo != "" {
+						updated.Status = movedTo
+					}
+					return &updated, nil
+				},
+				ListViolationFinesFn: func(ctx context.Context, violationID uuid.UUID) ([]model.ViolationFine, error) {
+					return tc.fines, nil
+				},
+				DecideViolationAppealFn: func(ctx context.Context, appeal *model.ViolationAppeal, to string) error {
+					movedTo = to
+					return nil
+				},
+			}
+			occupancyRepo := &MockOccupancyRepository{
+				ListOccupantsOnDateFn: func(ctx context.Context, propertyID uuid.UUID, date time.Time) ([]model.PropertyOccupant, error) {
+					return []model.PropertyOccupant{}, nil
+				},
+			}
+
+			service := NewViolationService(violationRepo, &MockNotificationRepository{}, occupancyRepo, &MockPropertyRepository{}, nil)
+			resp, err := service.DecideAppeal(context.Background(), violation.ID, uuid.New(), uuid.New(), &AppealDecisionRequest{Accept: tc.accept})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, resp.Status)
+			}
+		})
+	}
+}
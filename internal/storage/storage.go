@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Storage keeps uploaded files. Keys are slash-separated paths chosen by the
+// service that owns the file, e.g. "tickets/<ticket id>/<photo id>".
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a link that downloads the file as fileName without
+	// further authentication until expiry has passed.
+	SignedURL(ctx context.Context, key, fileName string, expiry time.Duration) (string, error)
+}
+
+// URLVerifier is implemented by stores whose signed URLs point back at this
+// API rather than at the store itself.
+type URLVerifier interface {
+	VerifySignedURL(key, fileName string, expires int64, signature string) error
+}
@@ -0,0 +1,61 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+	"time"
+	// association time zones must load on hosts without zoneinfo
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	"github.com/ivanpaghubasan/hoa-hub-api/internal/model"
+)
+
+type contextKey struct{}
+
+// WithAssociation scopes ctx to an association. Database access made with
+// the returned context only sees that association's rows.
+func WithAssociation(ctx context.Context, association *model.Association) context.Context {
+	return context.WithValue(ctx, contextKey{}, association)
+}
+
+func FromContext(ctx context.Context) (*model.Association, bool) {
+	association, ok := ctx.Value(contextKey{}).(*model.Association)
+	return association, ok && association != nil
+}
+
+func AssociationID(ctx context.Context) (uuid.UUID, bool) {
+	association, ok := FromContext(ctx)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return association.ID, true
+}
+
+// Name returns the display name of the association in ctx.
+func Name(ctx context.Context) string {
+	if association, ok := FromContext(ctx); ok {
+		return association.Name
+	}
+	return ""
+}
+
+var locations sync.Map
+
+// Location returns the time zone of the association in ctx, falling back
+// to UTC. Loaded zones are cached since every request needs one.
+func Location(ctx context.Context) *time.Location {
+	association, ok := FromContext(ctx)
+	if !ok || association.Timezone == "" {
+		return time.UTC
+	}
+	if location, ok := locations.Load(association.Timezone); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(association.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(association.Timezone, location)
+	return location
+}
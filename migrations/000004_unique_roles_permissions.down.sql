@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_user_roles_role_id;
+
+ALTER TABLE permissions DROP CONSTRAINT IF EXISTS permissions_name_key;
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_name_key;
@@ -0,0 +1,4 @@
+ALTER TABLE roles ADD CONSTRAINT roles_name_key UNIQUE (name);
+ALTER TABLE permissions ADD CONSTRAINT permissions_name_key UNIQUE (name);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles (role_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_properties_owner_id;
+DROP INDEX IF EXISTS idx_properties_phase_block_lot;
+
+ALTER TABLE properties DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,4 @@
+ALTER TABLE properties ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX idx_properties_phase_block_lot ON properties (phase, block, lot) WHERE archived_at IS NULL;
+CREATE INDEX idx_properties_owner_id ON properties (owner_id);
@@ -0,0 +1,20 @@
+CREATE TABLE property_occupancies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    occupancy_type VARCHAR(30) NOT NULL CHECK (occupancy_type IN ('owner', 'co_owner', 'tenant', 'representative')),
+    start_date DATE NOT NULL,
+    -- end_date is exclusive: the occupancy covers [start_date, end_date)
+    end_date DATE,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_property_occupancies_property_id ON property_occupancies (property_id, start_date);
+CREATE INDEX idx_property_occupancies_user_id ON property_occupancies (user_id);
+CREATE UNIQUE INDEX idx_property_occupancies_active ON property_occupancies (property_id, user_id, occupancy_type) WHERE end_date IS NULL;
+
+INSERT INTO property_occupancies (property_id, user_id, occupancy_type, start_date)
+SELECT id, owner_id, 'owner', created_at::date FROM properties WHERE owner_id IS NOT NULL;
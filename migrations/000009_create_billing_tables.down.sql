@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS document_sequences;
+DROP TABLE IF EXISTS invoice_line_items;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS billing_runs;
+DROP TABLE IF EXISTS dues_schedules;
+
+ALTER TABLE properties DROP COLUMN IF EXISTS lot_area_sqm;
@@ -0,0 +1,65 @@
+ALTER TABLE properties ADD COLUMN lot_area_sqm NUMERIC(10, 2);
+
+CREATE TABLE dues_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    -- NULL property_type or phase matches every property
+    property_type VARCHAR(100),
+    phase VARCHAR(100),
+    rate_type VARCHAR(20) NOT NULL CHECK (rate_type IN ('flat', 'per_sqm')),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE TABLE billing_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    billing_period DATE NOT NULL,
+    invoice_count INTEGER NOT NULL,
+    total_cents BIGINT NOT NULL,
+    -- NULL when the run was started by the scheduler
+    run_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_number VARCHAR(50) NOT NULL UNIQUE,
+    property_id UUID NOT NULL REFERENCES properties(id),
+    billing_run_id UUID REFERENCES billing_runs(id),
+    billing_period DATE NOT NULL,
+    issue_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'partially_paid', 'paid', 'void')),
+    total_cents BIGINT NOT NULL CHECK (total_cents >= 0),
+    paid_cents BIGINT NOT NULL DEFAULT 0 CHECK (paid_cents >= 0),
+    void_reason TEXT,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_invoices_property_period ON invoices (property_id, billing_period) WHERE status <> 'void';
+CREATE INDEX idx_invoices_status_due_date ON invoices (status, due_date);
+
+CREATE TABLE invoice_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    dues_schedule_id UUID REFERENCES dues_schedules(id),
+    description TEXT NOT NULL,
+    quantity NUMERIC(12, 2) NOT NULL DEFAULT 1,
+    unit_amount_cents BIGINT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_invoice_line_items_invoice_id ON invoice_line_items (invoice_id);
+
+-- gapless counters for invoice and receipt numbers
+CREATE TABLE document_sequences (
+    name VARCHAR(100) PRIMARY KEY,
+    last_value BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS payments_immutable ON payments;
+DROP FUNCTION IF EXISTS protect_payments();
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,53 @@
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    receipt_number VARCHAR(50) NOT NULL UNIQUE,
+    property_id UUID NOT NULL REFERENCES properties(id),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'check', 'bank_transfer', 'e_wallet')),
+    reference_number VARCHAR(100),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    -- the part not yet applied to invoices; this is the property's credit
+    unallocated_cents BIGINT NOT NULL CHECK (unallocated_cents >= 0),
+    paid_at DATE NOT NULL,
+    notes TEXT,
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (unallocated_cents <= amount_cents)
+);
+
+CREATE INDEX idx_payments_property_id ON payments (property_id, paid_at);
+CREATE INDEX idx_payments_unallocated ON payments (property_id) WHERE unallocated_cents > 0;
+-- the same check or transfer must not be recorded twice
+CREATE UNIQUE INDEX idx_payments_method_reference ON payments (method, reference_number) WHERE reference_number IS NOT NULL;
+
+CREATE TABLE payment_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    invoice_id UUID NOT NULL REFERENCES invoices(id),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_payment_allocations_payment_id ON payment_allocations (payment_id);
+CREATE INDEX idx_payment_allocations_invoice_id ON payment_allocations (invoice_id);
+
+-- receipts are official records: only the unallocated balance may change
+CREATE FUNCTION protect_payments() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'payments cannot be deleted';
+    END IF;
+    IF NEW.receipt_number <> OLD.receipt_number
+        OR NEW.property_id <> OLD.property_id
+        OR NEW.method <> OLD.method
+        OR NEW.reference_number IS DISTINCT FROM OLD.reference_number
+        OR NEW.amount_cents <> OLD.amount_cents
+        OR NEW.paid_at <> OLD.paid_at THEN
+        RAISE EXCEPTION 'payment % is immutable', OLD.receipt_number;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER payments_immutable
+    BEFORE UPDATE OR DELETE ON payments
+    FOR EACH ROW EXECUTE FUNCTION protect_payments();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS invoice_penalties;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS penalty_cents;
+
+DROP TABLE IF EXISTS penalty_rules;
@@ -0,0 +1,31 @@
+CREATE TABLE penalty_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    grace_days INTEGER NOT NULL DEFAULT 0 CHECK (grace_days >= 0),
+    fixed_cents BIGINT NOT NULL DEFAULT 0 CHECK (fixed_cents >= 0),
+    -- simple monthly interest on the unpaid dues, in basis points (200 = 2%)
+    monthly_interest_bps INTEGER NOT NULL DEFAULT 0 CHECK (monthly_interest_bps >= 0),
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+ALTER TABLE invoices ADD COLUMN penalty_cents BIGINT NOT NULL DEFAULT 0 CHECK (penalty_cents >= 0);
+
+CREATE TABLE invoice_penalties (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    rule_id UUID NOT NULL REFERENCES penalty_rules(id),
+    penalty_type VARCHAR(20) NOT NULL CHECK (penalty_type IN ('fixed', 'interest')),
+    -- the day the charge accrued; one charge per invoice, rule, type and day
+    period_key DATE NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (status IN ('applied', 'waived')),
+    waive_reason TEXT,
+    waived_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    waived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (invoice_id, rule_id, penalty_type, period_key)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS announcement_reads;
+DROP TABLE IF EXISTS announcements;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_announcements');
+DELETE FROM permissions WHERE name = 'manage_announcements';
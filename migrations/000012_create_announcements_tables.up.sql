@@ -0,0 +1,40 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_announcements', 'Publish and manage announcements')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_announcements'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE announcements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    -- Markdown source, rendered by the clients
+    body TEXT NOT NULL,
+    audience_type VARCHAR(20) NOT NULL CHECK (audience_type IN ('all', 'phase', 'block', 'role')),
+    audience_phase VARCHAR(100),
+    audience_block VARCHAR(100),
+    audience_role VARCHAR(100),
+    pinned BOOLEAN NOT NULL DEFAULT false,
+    publish_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expire_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (expire_at IS NULL OR expire_at > publish_at),
+    CHECK (audience_type <> 'phase' OR audience_phase IS NOT NULL),
+    CHECK (audience_type <> 'block' OR (audience_phase IS NOT NULL AND audience_block IS NOT NULL)),
+    CHECK (audience_type <> 'role' OR audience_role IS NOT NULL)
+);
+
+CREATE INDEX idx_announcements_publish_at ON announcements (publish_at DESC);
+
+CREATE TABLE announcement_reads (
+    announcement_id UUID NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (announcement_id, user_id)
+);
+
+CREATE INDEX idx_announcement_reads_user_id ON announcement_reads (user_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS amenity_reservations;
+DROP TABLE IF EXISTS amenities;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_amenities');
+DELETE FROM permissions WHERE name = 'manage_amenities';
@@ -0,0 +1,58 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+INSERT INTO permissions (name, description) VALUES
+('manage_amenities', 'Manage amenities and approve reservations')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_amenities'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE amenities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+    -- reservations start and end on multiples of this from opens_at
+    slot_minutes INTEGER NOT NULL CHECK (slot_minutes > 0),
+    -- charged per slot
+    fee_cents BIGINT NOT NULL DEFAULT 0 CHECK (fee_cents >= 0),
+    requires_approval BOOLEAN NOT NULL DEFAULT false,
+    -- members may cancel until this many hours before the start
+    cancellation_hours INTEGER NOT NULL DEFAULT 0 CHECK (cancellation_hours >= 0),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (closes_at > opens_at)
+);
+
+CREATE TABLE amenity_reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    amenity_id UUID NOT NULL REFERENCES amenities(id),
+    property_id UUID NOT NULL REFERENCES properties(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    during TSTZRANGE GENERATED ALWAYS AS (tstzrange(start_at, end_at, '[)')) STORED,
+    guest_count INTEGER NOT NULL CHECK (guest_count > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    fee_cents BIGINT NOT NULL DEFAULT 0 CHECK (fee_cents >= 0),
+    notes TEXT,
+    decision_reason TEXT,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    cancel_reason TEXT,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (end_at > start_at),
+    -- pending requests hold their slot until they are rejected or cancelled
+    CONSTRAINT amenity_reservations_no_overlap EXCLUDE USING gist (amenity_id WITH =, during WITH &&)
+        WHERE (status IN ('pending', 'approved'))
+);
+
+CREATE INDEX idx_amenity_reservations_user_id ON amenity_reservations (user_id, start_at);
+CREATE INDEX idx_amenity_reservations_status ON amenity_reservations (status, start_at);
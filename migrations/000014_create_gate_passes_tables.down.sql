@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS gate_entries;
+DROP TABLE IF EXISTS gate_passes;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'verify_gate_passes');
+DELETE FROM permissions WHERE name = 'verify_gate_passes';
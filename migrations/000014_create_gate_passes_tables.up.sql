@@ -0,0 +1,47 @@
+INSERT INTO permissions (name, description) VALUES
+('verify_gate_passes', 'Verify visitor gate passes and log gate entries')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'verify_gate_passes'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE gate_passes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    property_id UUID NOT NULL REFERENCES properties(id),
+    host_user_id UUID NOT NULL REFERENCES users(id),
+    visitor_name VARCHAR(255) NOT NULL,
+    visitor_type VARCHAR(20) NOT NULL CHECK (visitor_type IN ('guest', 'delivery', 'service')),
+    vehicle_plate VARCHAR(20),
+    purpose TEXT,
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- NULL allows unlimited entries within the validity window
+    max_uses INTEGER CHECK (max_uses > 0),
+    use_count INTEGER NOT NULL DEFAULT 0 CHECK (use_count >= 0),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoke_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (valid_until > valid_from),
+    CHECK (max_uses IS NULL OR use_count <= max_uses)
+);
+
+CREATE INDEX idx_gate_passes_host_user_id ON gate_passes (host_user_id, valid_from DESC);
+CREATE INDEX idx_gate_passes_validity ON gate_passes (valid_from, valid_until);
+
+CREATE TABLE gate_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    gate_pass_id UUID NOT NULL REFERENCES gate_passes(id),
+    entered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    entry_guard_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    exited_at TIMESTAMP WITH TIME ZONE,
+    exit_guard_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    notes TEXT,
+    CHECK (exited_at IS NULL OR exited_at >= entered_at)
+);
+
+CREATE INDEX idx_gate_entries_gate_pass_id ON gate_entries (gate_pass_id, entered_at);
+CREATE INDEX idx_gate_entries_entered_at ON gate_entries (entered_at);
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_gate_entries_property_id;
+DROP INDEX IF EXISTS idx_gate_entries_vehicle_plate;
+
+DELETE FROM gate_entries WHERE entry_type = 'walk_in';
+
+ALTER TABLE gate_entries
+    DROP CONSTRAINT IF EXISTS gate_entries_pass_check,
+    DROP COLUMN IF EXISTS purpose,
+    DROP COLUMN IF EXISTS visited_user_id,
+    DROP COLUMN IF EXISTS property_id,
+    DROP COLUMN IF EXISTS vehicle_plate,
+    DROP COLUMN IF EXISTS visitor_name,
+    DROP COLUMN IF EXISTS entry_type,
+    ALTER COLUMN gate_pass_id SET NOT NULL;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name IN ('log_gate_entries', 'view_gate_logbook'))
+   OR role_id IN (SELECT id FROM roles WHERE name = 'guard');
+DELETE FROM user_roles WHERE role_id IN (SELECT id FROM roles WHERE name = 'guard');
+DELETE FROM permissions WHERE name IN ('log_gate_entries', 'view_gate_logbook');
+DELETE FROM roles WHERE name = 'guard';
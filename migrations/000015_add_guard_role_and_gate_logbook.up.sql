@@ -0,0 +1,44 @@
+INSERT INTO roles (name, description) VALUES
+('guard', 'Security guard at the subdivision gate')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+('log_gate_entries', 'Log walk-in visitors and visitor exits'),
+('view_gate_logbook', 'Search the gate logbook')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name IN ('log_gate_entries', 'view_gate_logbook')
+ON CONFLICT DO NOTHING;
+
+-- guards only get the gate permissions
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'guard' AND p.name IN ('verify_gate_passes', 'log_gate_entries')
+ON CONFLICT DO NOTHING;
+
+-- gate_entries becomes the logbook for both pre-registered and walk-in visitors
+ALTER TABLE gate_entries
+    ALTER COLUMN gate_pass_id DROP NOT NULL,
+    ADD COLUMN entry_type VARCHAR(20) NOT NULL DEFAULT 'pass' CHECK (entry_type IN ('pass', 'walk_in')),
+    ADD COLUMN visitor_name VARCHAR(255),
+    ADD COLUMN vehicle_plate VARCHAR(20),
+    ADD COLUMN property_id UUID REFERENCES properties(id),
+    ADD COLUMN visited_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN purpose TEXT;
+
+UPDATE gate_entries e
+SET visitor_name = p.visitor_name, vehicle_plate = p.vehicle_plate, property_id = p.property_id,
+    visited_user_id = p.host_user_id, purpose = p.purpose
+FROM gate_passes p
+WHERE p.id = e.gate_pass_id;
+
+ALTER TABLE gate_entries
+    ALTER COLUMN entry_type DROP DEFAULT,
+    ALTER COLUMN visitor_name SET NOT NULL,
+    ALTER COLUMN property_id SET NOT NULL,
+    ADD CONSTRAINT gate_entries_pass_check CHECK ((entry_type = 'pass') = (gate_pass_id IS NOT NULL));
+
+CREATE INDEX idx_gate_entries_vehicle_plate ON gate_entries (vehicle_plate, entered_at);
+CREATE INDEX idx_gate_entries_property_id ON gate_entries (property_id, entered_at);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS vehicle_stickers;
+DROP TABLE IF EXISTS vehicles;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_vehicles');
+DELETE FROM permissions WHERE name = 'manage_vehicles';
@@ -0,0 +1,44 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_vehicles', 'Manage the vehicle registry and issue car stickers')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_vehicles'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE vehicles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    property_id UUID NOT NULL REFERENCES properties(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    -- stored upper-cased without spaces or dashes
+    plate_number VARCHAR(20) NOT NULL,
+    make VARCHAR(100) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    color VARCHAR(50) NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_vehicles_plate_number ON vehicles (plate_number) WHERE archived_at IS NULL;
+CREATE INDEX idx_vehicles_property_id ON vehicles (property_id);
+CREATE INDEX idx_vehicles_user_id ON vehicles (user_id);
+
+CREATE TABLE vehicle_stickers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    sticker_year INTEGER NOT NULL CHECK (sticker_year BETWEEN 2000 AND 2100),
+    sticker_number VARCHAR(20) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'issued' CHECK (status IN ('issued', 'lost', 'revoked')),
+    status_reason TEXT,
+    issued_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    status_changed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- a lost sticker can be replaced, but a vehicle holds one valid sticker per year
+CREATE UNIQUE INDEX idx_vehicle_stickers_issued ON vehicle_stickers (vehicle_id, sticker_year) WHERE status = 'issued';
+CREATE INDEX idx_vehicle_stickers_year ON vehicle_stickers (sticker_year, status);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS ticket_status_changes;
+DROP TABLE IF EXISTS ticket_comments;
+DROP TABLE IF EXISTS ticket_photos;
+DROP TABLE IF EXISTS tickets;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_tickets');
+DELETE FROM permissions WHERE name = 'manage_tickets';
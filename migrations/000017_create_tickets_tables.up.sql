@@ -0,0 +1,83 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_tickets', 'Triage, assign and resolve maintenance tickets')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_tickets'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE tickets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_number VARCHAR(20) NOT NULL UNIQUE,
+    reporter_id UUID NOT NULL REFERENCES users(id),
+    category VARCHAR(30) NOT NULL CHECK (category IN ('streetlight', 'drainage', 'garbage', 'road', 'water', 'electrical', 'landscaping', 'security', 'other')),
+    priority VARCHAR(20) NOT NULL DEFAULT 'normal' CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL,
+    -- a ticket is either about a property or about a common area
+    property_id UUID REFERENCES properties(id),
+    common_area VARCHAR(200),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'triaged', 'in_progress', 'resolved', 'closed', 'reopened')),
+    assigned_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_vendor VARCHAR(200),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK ((property_id IS NULL) <> (common_area IS NULL))
+);
+
+CREATE INDEX idx_tickets_status ON tickets (status, created_at);
+CREATE INDEX idx_tickets_reporter_id ON tickets (reporter_id);
+CREATE INDEX idx_tickets_assigned_user_id ON tickets (assigned_user_id);
+
+CREATE TABLE ticket_photos (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ticket_photos_ticket_id ON ticket_photos (ticket_id);
+
+CREATE TABLE ticket_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ticket_comments_ticket_id ON ticket_comments (ticket_id, created_at);
+
+CREATE TABLE ticket_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ticket_status_changes_ticket_id ON ticket_status_changes (ticket_id, changed_at);
+
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    -- what the notification is about, e.g. ('ticket', <ticket id>)
+    reference_type VARCHAR(50),
+    reference_id UUID,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS violation_events;
+DROP TABLE IF EXISTS violation_appeals;
+DROP TABLE IF EXISTS violation_fines;
+DROP TABLE IF EXISTS violation_notices;
+DROP TABLE IF EXISTS violation_attachments;
+DROP TABLE IF EXISTS violations;
+
+-- fine invoices would collide with the restored one-per-period index, so
+-- they are voided rather than deleted to keep their payment history
+UPDATE invoices SET status = 'void', void_reason = 'violation fines removed', voided_at = now(), updated_at = now()
+WHERE kind = 'fine' AND status <> 'void';
+
+DROP INDEX IF EXISTS idx_invoices_property_period;
+CREATE UNIQUE INDEX idx_invoices_property_period ON invoices (property_id, billing_period) WHERE status <> 'void';
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS kind;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_violations');
+DELETE FROM permissions WHERE name = 'manage_violations';
@@ -0,0 +1,93 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_violations', 'Record bylaw violations, issue notices and fines, and decide appeals')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_violations'
+ON CONFLICT DO NOTHING;
+
+-- fines are posted as invoices next to the monthly dues, so only dues
+-- invoices are limited to one per property and period
+ALTER TABLE invoices ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'dues' CHECK (kind IN ('dues', 'fine'));
+
+DROP INDEX IF EXISTS idx_invoices_property_period;
+CREATE UNIQUE INDEX idx_invoices_property_period ON invoices (property_id, billing_period) WHERE status <> 'void' AND kind = 'dues';
+
+CREATE TABLE violations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_number VARCHAR(20) NOT NULL UNIQUE,
+    property_id UUID NOT NULL REFERENCES properties(id),
+    category VARCHAR(30) NOT NULL CHECK (category IN ('construction', 'noise', 'pets', 'parking', 'garbage', 'other')),
+    description TEXT NOT NULL,
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'noticed', 'fined', 'appealed', 'resolved', 'dismissed')),
+    reported_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_violations_property_id ON violations (property_id, observed_at);
+CREATE INDEX idx_violations_status ON violations (status);
+
+CREATE TABLE violation_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_id UUID NOT NULL REFERENCES violations(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_violation_attachments_violation_id ON violation_attachments (violation_id);
+
+CREATE TABLE violation_notices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_id UUID NOT NULL REFERENCES violations(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    cure_deadline DATE NOT NULL,
+    issued_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_violation_notices_violation_id ON violation_notices (violation_id, issued_at);
+
+CREATE TABLE violation_fines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_id UUID NOT NULL REFERENCES violations(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL UNIQUE REFERENCES invoices(id),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    issued_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_violation_fines_violation_id ON violation_fines (violation_id);
+
+CREATE TABLE violation_appeals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_id UUID NOT NULL REFERENCES violations(id) ON DELETE CASCADE,
+    submitted_by UUID NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    decision_note TEXT,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_violation_appeals_pending ON violation_appeals (violation_id) WHERE status = 'pending';
+
+-- every action taken on a violation, in order, for the property's history
+CREATE TABLE violation_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    violation_id UUID NOT NULL REFERENCES violations(id) ON DELETE CASCADE,
+    event_type VARCHAR(30) NOT NULL,
+    note TEXT,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_violation_events_violation_id ON violation_events (violation_id, created_at);
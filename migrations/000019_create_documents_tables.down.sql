@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS document_versions;
+DROP TABLE IF EXISTS documents;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_documents')
+   OR role_id IN (SELECT id FROM roles WHERE name = 'board');
+DELETE FROM user_roles WHERE role_id IN (SELECT id FROM roles WHERE name = 'board');
+DELETE FROM permissions WHERE name = 'manage_documents';
+DELETE FROM roles WHERE name = 'board';
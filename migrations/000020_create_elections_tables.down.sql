@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS election_votes;
+DROP TABLE IF EXISTS election_vote_queue;
+DROP TABLE IF EXISTS election_ballots;
+DROP TABLE IF EXISTS election_proxies;
+DROP TABLE IF EXISTS election_candidates;
+DROP TABLE IF EXISTS election_positions;
+DROP TABLE IF EXISTS elections;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_elections');
+DELETE FROM permissions WHERE name = 'manage_elections';
@@ -0,0 +1,105 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_elections', 'Set up board elections, review proxies and publish results')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name IN ('admin', 'board') AND p.name = 'manage_elections'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE elections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    voting_starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    voting_ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    quorum_percent INTEGER NOT NULL CHECK (quorum_percent BETWEEN 1 AND 100),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'cancelled')),
+    -- frozen when the results are published so the quorum no longer drifts
+    -- with later payments and ownership changes
+    eligible_properties INTEGER,
+    results_published_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (voting_ends_at > voting_starts_at)
+);
+
+CREATE INDEX idx_elections_voting_starts_at ON elections (voting_starts_at);
+
+CREATE TABLE election_positions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    seats INTEGER NOT NULL DEFAULT 1 CHECK (seats > 0),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (election_id, title)
+);
+
+CREATE TABLE election_candidates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    position_id UUID NOT NULL REFERENCES election_positions(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    bio TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_election_candidates_position_id ON election_candidates (position_id);
+
+CREATE TABLE election_proxies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id),
+    granted_by UUID NOT NULL REFERENCES users(id),
+    proxy_user_id UUID NOT NULL REFERENCES users(id),
+    storage_key TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'revoked')),
+    review_note TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_election_proxies_active ON election_proxies (election_id, property_id) WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_election_proxies_proxy_user_id ON election_proxies (proxy_user_id);
+
+-- who voted for which property; the choices themselves go through
+-- election_vote_queue into election_votes, with no link back to the ballot
+CREATE TABLE election_ballots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id),
+    cast_by UUID NOT NULL REFERENCES users(id),
+    proxy_id UUID REFERENCES election_proxies(id),
+    cast_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (election_id, property_id)
+);
+
+-- A ballot's choices are queued in the ballot's transaction, so until they
+-- are moved the queue row shares the ballot's xmin. The application moves
+-- them in shuffled batches, and reshuffles everything once voting closes.
+CREATE TABLE election_vote_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    candidate_ids UUID[] NOT NULL
+);
+
+CREATE INDEX idx_election_vote_queue_election_id ON election_vote_queue (election_id);
+
+-- secret ballot: no voter, ballot or timestamp column. Votes are written in
+-- shuffled batches by a transaction of their own, so neither xmin nor their
+-- order on disk ties them to the ballot that cast them.
+CREATE TABLE election_votes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    position_id UUID NOT NULL REFERENCES election_positions(id) ON DELETE CASCADE,
+    candidate_id UUID NOT NULL REFERENCES election_candidates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_election_votes_election_id ON election_votes (election_id, candidate_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_vote_queue;
+DROP TABLE IF EXISTS poll_ballots;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_polls');
+DELETE FROM permissions WHERE name = 'manage_polls';
@@ -0,0 +1,73 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_polls', 'Create polls and resolutions and close them')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name IN ('admin', 'board') AND p.name = 'manage_polls'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE polls (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('yes_no', 'multiple_choice')),
+    audience VARCHAR(20) NOT NULL CHECK (audience IN ('members', 'owners')),
+    threshold VARCHAR(20) NOT NULL CHECK (threshold IN ('simple_majority', 'two_thirds')),
+    quorum_percent INTEGER NOT NULL CHECK (quorum_percent BETWEEN 1 AND 100),
+    secret BOOLEAN NOT NULL DEFAULT false,
+    opens_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closes_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'closed', 'cancelled')),
+    -- frozen when the poll is closed so the quorum no longer drifts with
+    -- later membership and ownership changes
+    eligible_voters INTEGER,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (closes_at > opens_at)
+);
+
+CREATE INDEX idx_polls_opens_at ON polls (opens_at);
+
+CREATE TABLE poll_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    label VARCHAR(255) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (poll_id, label)
+);
+
+-- poll_ballots is the record of who voted; one per member per poll
+CREATE TABLE poll_ballots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    cast_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (poll_id, user_id)
+);
+
+-- Secret choices are queued in the ballot's transaction, so until they are
+-- moved the queue row shares the ballot's xmin. The application moves them
+-- in shuffled batches, and reshuffles everything once the poll closes.
+CREATE TABLE poll_vote_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_id UUID REFERENCES poll_options(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_poll_vote_queue_poll_id ON poll_vote_queue (poll_id);
+
+-- poll_votes holds the choices. A null option is an abstention. The ballot
+-- is only linked for open polls. Secret votes arrive from the queue in
+-- shuffled batches written by a transaction of their own, so neither xmin
+-- nor their order on disk ties them to a ballot.
+CREATE TABLE poll_votes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_id UUID REFERENCES poll_options(id) ON DELETE CASCADE,
+    ballot_id UUID UNIQUE REFERENCES poll_ballots(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_poll_votes_poll_id ON poll_votes (poll_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS calendar_tokens;
+DROP TABLE IF EXISTS event_rsvps;
+DROP TABLE IF EXISTS events;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'manage_events');
+DELETE FROM permissions WHERE name = 'manage_events';
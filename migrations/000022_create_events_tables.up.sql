@@ -0,0 +1,55 @@
+INSERT INTO permissions (name, description) VALUES
+('manage_events', 'Create and cancel community events and see RSVPs')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'manage_events'
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    category VARCHAR(30) NOT NULL DEFAULT 'other'
+        CHECK (category IN ('general_assembly', 'meeting', 'social', 'cleanup', 'other')),
+    location VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- total headcount allowed across RSVPs; null means no limit
+    capacity INTEGER CHECK (capacity > 0),
+    rsvp_deadline TIMESTAMP WITH TIME ZONE,
+    cancel_reason TEXT,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (ends_at > starts_at),
+    CHECK (rsvp_deadline IS NULL OR rsvp_deadline <= starts_at)
+);
+
+CREATE INDEX idx_events_starts_at ON events (starts_at);
+
+CREATE TABLE event_rsvps (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('going', 'not_going')),
+    headcount INTEGER NOT NULL CHECK (headcount >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (event_id, user_id),
+    CHECK ((status = 'going') = (headcount > 0))
+);
+
+-- calendar feed tokens are stored hashed; the plain token only ever
+-- appears in the feed URL handed to the user
+CREATE TABLE calendar_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_calendar_tokens_active_user ON calendar_tokens (user_id) WHERE revoked_at IS NULL;
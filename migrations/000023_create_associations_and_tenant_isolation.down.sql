@@ -0,0 +1,48 @@
+ALTER TABLE violations DROP CONSTRAINT violations_violation_number_key, ADD CONSTRAINT violations_violation_number_key UNIQUE (violation_number);
+ALTER TABLE tickets DROP CONSTRAINT tickets_ticket_number_key, ADD CONSTRAINT tickets_ticket_number_key UNIQUE (ticket_number);
+ALTER TABLE vehicle_stickers DROP CONSTRAINT vehicle_stickers_sticker_number_key, ADD CONSTRAINT vehicle_stickers_sticker_number_key UNIQUE (sticker_number);
+
+DROP INDEX idx_vehicles_plate_number;
+CREATE UNIQUE INDEX idx_vehicles_plate_number ON vehicles (plate_number) WHERE archived_at IS NULL;
+
+ALTER TABLE amenities DROP CONSTRAINT amenities_name_key, ADD CONSTRAINT amenities_name_key UNIQUE (name);
+
+DROP INDEX idx_payments_method_reference;
+CREATE UNIQUE INDEX idx_payments_method_reference ON payments (method, reference_number) WHERE reference_number IS NOT NULL;
+
+ALTER TABLE payments DROP CONSTRAINT payments_receipt_number_key, ADD CONSTRAINT payments_receipt_number_key UNIQUE (receipt_number);
+ALTER TABLE invoices DROP CONSTRAINT invoices_invoice_number_key, ADD CONSTRAINT invoices_invoice_number_key UNIQUE (invoice_number);
+ALTER TABLE document_sequences DROP CONSTRAINT document_sequences_pkey, ADD PRIMARY KEY (name);
+
+DROP INDEX idx_properties_phase_block_lot;
+CREATE UNIQUE INDEX idx_properties_phase_block_lot ON properties (phase, block, lot) WHERE archived_at IS NULL;
+
+ALTER TABLE users DROP CONSTRAINT users_mobile_number_key, ADD CONSTRAINT users_mobile_number_key UNIQUE (mobile_number);
+ALTER TABLE users DROP CONSTRAINT users_email_key, ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DO $$
+DECLARE
+    t REGCLASS;
+BEGIN
+    FOR t IN SELECT DISTINCT c.oid::REGCLASS FROM pg_class c
+        JOIN pg_attribute a ON a.attrelid = c.oid
+        WHERE c.relkind = 'r' AND c.relnamespace = 'public'::REGNAMESPACE
+            AND a.attname = 'association_id' AND NOT a.attisdropped
+    LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %s', t);
+        EXECUTE format('ALTER TABLE %s DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %s DROP COLUMN association_id', t);
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS enable_tenant_isolation(REGCLASS);
+DROP FUNCTION IF EXISTS current_association_id();
+
+-- roles are shared by every database in the cluster, so hoa_hub_tenant
+-- itself is left in place
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM hoa_hub_tenant;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM hoa_hub_tenant;
+REVOKE USAGE ON SCHEMA public FROM hoa_hub_tenant;
+
+DROP TABLE IF EXISTS associations;
@@ -0,0 +1,91 @@
+CREATE TABLE associations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- used in subdomains, the X-Association header and calendar feed URLs
+    slug VARCHAR(63) NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9]([a-z0-9-]*[a-z0-9])?$'),
+    name VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Manila',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- existing data becomes the first association; rename it with the
+-- association command
+INSERT INTO associations (slug, name) VALUES ('default', 'Homeowners Association');
+
+-- The application sets app.association_id per transaction. Unset means no
+-- association, which matches no rows and fails inserts on NOT NULL.
+CREATE FUNCTION current_association_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.association_id', true), '')::UUID
+$$ LANGUAGE sql STABLE;
+
+-- The application runs its queries as this role, so row-level security
+-- applies even when it connects as the table owner or a superuser.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'hoa_hub_tenant') THEN
+        CREATE ROLE hoa_hub_tenant NOLOGIN;
+    END IF;
+END
+$$;
+GRANT hoa_hub_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO hoa_hub_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO hoa_hub_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO hoa_hub_tenant;
+REVOKE ALL ON schema_migrations FROM hoa_hub_tenant;
+
+-- enable_tenant_isolation scopes a table to the current association: new
+-- rows are stamped with it and other associations' rows are invisible.
+-- Every tenant table created after this migration must call it.
+CREATE FUNCTION enable_tenant_isolation(tbl REGCLASS) RETURNS void AS $$
+BEGIN
+    EXECUTE format('ALTER TABLE %s ADD COLUMN association_id UUID NOT NULL DEFAULT current_association_id() REFERENCES associations(id)', tbl);
+    EXECUTE format('CREATE INDEX ON %s (association_id)', tbl);
+    EXECUTE format('ALTER TABLE %s ENABLE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('CREATE POLICY tenant_isolation ON %s USING (association_id = current_association_id()) WITH CHECK (association_id = current_association_id())', tbl);
+END;
+$$ LANGUAGE plpgsql;
+
+-- existing rows are stamped with the default association
+SELECT set_config('app.association_id', id::TEXT, false) FROM associations WHERE slug = 'default';
+
+SELECT enable_tenant_isolation(t) FROM unnest(ARRAY[
+    'users', 'user_roles', 'refresh_tokens', 'revoked_sessions',
+    'properties', 'property_occupancies',
+    'dues_schedules', 'billing_runs', 'invoices', 'invoice_line_items', 'document_sequences',
+    'payments', 'payment_allocations', 'penalty_rules', 'invoice_penalties',
+    'announcements', 'announcement_reads',
+    'amenities', 'amenity_reservations',
+    'gate_passes', 'gate_entries',
+    'vehicles', 'vehicle_stickers',
+    'tickets', 'ticket_photos', 'ticket_comments', 'ticket_status_changes', 'notifications',
+    'violations', 'violation_attachments', 'violation_notices', 'violation_fines', 'violation_appeals', 'violation_events',
+    'documents', 'document_versions',
+    'elections', 'election_positions', 'election_candidates', 'election_proxies', 'election_ballots', 'election_vote_queue', 'election_votes',
+    'polls', 'poll_options', 'poll_ballots', 'poll_vote_queue', 'poll_votes',
+    'events', 'event_rsvps', 'calendar_tokens'
+]::REGCLASS[]) AS t;
+
+SELECT set_config('app.association_id', '', false);
+
+-- natural keys are unique per association, not across the deployment
+ALTER TABLE users DROP CONSTRAINT users_email_key, ADD CONSTRAINT users_email_key UNIQUE (association_id, email);
+ALTER TABLE users DROP CONSTRAINT users_mobile_number_key, ADD CONSTRAINT users_mobile_number_key UNIQUE (association_id, mobile_number);
+
+DROP INDEX idx_properties_phase_block_lot;
+CREATE UNIQUE INDEX idx_properties_phase_block_lot ON properties (association_id, phase, block, lot) WHERE archived_at IS NULL;
+
+ALTER TABLE document_sequences DROP CONSTRAINT document_sequences_pkey, ADD PRIMARY KEY (association_id, name);
+ALTER TABLE invoices DROP CONSTRAINT invoices_invoice_number_key, ADD CONSTRAINT invoices_invoice_number_key UNIQUE (association_id, invoice_number);
+ALTER TABLE payments DROP CONSTRAINT payments_receipt_number_key, ADD CONSTRAINT payments_receipt_number_key UNIQUE (association_id, receipt_number);
+
+DROP INDEX idx_payments_method_reference;
+CREATE UNIQUE INDEX idx_payments_method_reference ON payments (association_id, method, reference_number) WHERE reference_number IS NOT NULL;
+
+ALTER TABLE amenities DROP CONSTRAINT amenities_name_key, ADD CONSTRAINT amenities_name_key UNIQUE (association_id, name);
+
+DROP INDEX idx_vehicles_plate_number;
+CREATE UNIQUE INDEX idx_vehicles_plate_number ON vehicles (association_id, plate_number) WHERE archived_at IS NULL;
+
+ALTER TABLE vehicle_stickers DROP CONSTRAINT vehicle_stickers_sticker_number_key, ADD CONSTRAINT vehicle_stickers_sticker_number_key UNIQUE (association_id, sticker_number);
+ALTER TABLE tickets DROP CONSTRAINT tickets_ticket_number_key, ADD CONSTRAINT tickets_ticket_number_key UNIQUE (association_id, ticket_number);
+ALTER TABLE violations DROP CONSTRAINT violations_violation_number_key, ADD CONSTRAINT violations_violation_number_key UNIQUE (association_id, violation_number);
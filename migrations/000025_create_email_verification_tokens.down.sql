@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_pending_verification;
+DROP TABLE IF EXISTS email_verification_tokens;
@@ -0,0 +1,17 @@
+CREATE TABLE email_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- sha256 of the emailed token; the token itself is never stored
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- set when the token is redeemed or superseded by a resend
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_email_verification_tokens_user ON email_verification_tokens (user_id, created_at DESC);
+
+SELECT enable_tenant_isolation('email_verification_tokens');
+
+-- finds registrations that were never confirmed so they can be expired
+CREATE INDEX idx_users_pending_verification ON users (created_at) WHERE status = 'pending_verification';
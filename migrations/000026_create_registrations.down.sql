@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS registrations;
+
+-- accounts held for review are released rather than locked out
+UPDATE users SET status = 'active' WHERE status IN ('pending_approval', 'rejected');
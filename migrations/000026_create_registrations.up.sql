@@ -0,0 +1,27 @@
+-- registrations track the admin review of each new account and, when the
+-- registrant claimed a property, the proof of ownership or tenancy
+CREATE TABLE registrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    property_id UUID REFERENCES properties(id),
+    proof_type VARCHAR(20) CHECK (proof_type IN ('title', 'lease')),
+    proof_key TEXT,
+    proof_file_name VARCHAR(255),
+    proof_content_type VARCHAR(100),
+    proof_size_bytes BIGINT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    rejection_reason TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    -- a claim names the property and carries its proof, or neither
+    CHECK ((property_id IS NULL) = (proof_key IS NULL)),
+    CHECK ((property_id IS NULL) = (proof_type IS NULL)),
+    CHECK (status <> 'rejected' OR rejection_reason IS NOT NULL)
+);
+
+CREATE INDEX idx_registrations_status ON registrations (status, created_at);
+
+SELECT enable_tenant_isolation('registrations');
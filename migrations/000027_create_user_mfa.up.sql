@@ -0,0 +1,27 @@
+-- one TOTP enrollment per user; enabled_at stays NULL until the first code
+-- from the authenticator app is confirmed
+CREATE TABLE user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- base32 TOTP secret; it has to be readable to check codes
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    -- the last accepted time step, so a code cannot be replayed
+    last_used_step BIGINT,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES user_mfa(user_id) ON DELETE CASCADE,
+    -- sha256 of the normalized code; the code itself is shown once
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (user_id, code_hash)
+);
+
+SELECT enable_tenant_isolation('user_mfa');
+SELECT enable_tenant_isolation('mfa_recovery_codes');
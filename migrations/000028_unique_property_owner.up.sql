@@ -0,0 +1,3 @@
+-- a property has at most one primary owner at a time; ownership changes go
+-- through a transfer, which ends the current owner before adding the next
+CREATE UNIQUE INDEX idx_property_occupancies_owner ON property_occupancies (property_id) WHERE occupancy_type = 'owner' AND end_date IS NULL;